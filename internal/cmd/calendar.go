@@ -11,14 +11,21 @@ import (
 )
 
 type CalendarCmd struct {
-	Calendars       CalendarCalendarsCmd       `cmd:"" name:"calendars" help:"List calendars"`
-	ACL             CalendarAclCmd             `cmd:"" name:"acl" help:"List calendar ACL"`
+	Calendars       CalendarCalendarsCmd       `cmd:"" name:"calendars" help:"List and manage calendars"`
+	ACL             CalendarAclCmd             `cmd:"" name:"acl" help:"List and manage calendar ACL rules"`
 	Events          CalendarEventsCmd          `cmd:"" name:"events" aliases:"list" help:"List events from a calendar or all calendars"`
 	Event           CalendarEventCmd           `cmd:"" name:"event" aliases:"get" help:"Get event"`
 	Create          CalendarCreateCmd          `cmd:"" name:"create" help:"Create an event"`
+	QuickAdd        CalendarQuickAddCmd        `cmd:"" name:"quick-add" help:"Create an event from plain text (\"Lunch with Sam tomorrow 12:30 at Cafe\")"`
 	Update          CalendarUpdateCmd          `cmd:"" name:"update" help:"Update an event"`
 	Delete          CalendarDeleteCmd          `cmd:"" name:"delete" help:"Delete an event"`
 	FreeBusy        CalendarFreeBusyCmd        `cmd:"" name:"freebusy" help:"Get free/busy"`
+	FindTime        CalendarFindTimeCmd        `cmd:"" name:"find-time" help:"Find meeting slots where attendees are free"`
+	Rooms           CalendarRoomsCmd           `cmd:"" name:"rooms" help:"List, check and book Workspace meeting rooms"`
+	Export          CalendarExportCmd          `cmd:"" name:"export" help:"Export events as an iCalendar (.ics) feed"`
+	Import          CalendarImportCmd          `cmd:"" name:"import" help:"Import events from an iCalendar (.ics) file"`
+	Bulk            CalendarBulkCmd            `cmd:"" name:"bulk" help:"Cancel, move, shift or re-invite many events selected by query and time range"`
+	Watch           CalendarWatchCmd           `cmd:"" name:"watch" help:"Forward event changes to a webhook (events.watch push or polling)"`
 	Respond         CalendarRespondCmd         `cmd:"" name:"respond" help:"Respond to an event invitation"`
 	ProposeTime     CalendarProposeTimeCmd     `cmd:"" name:"propose-time" help:"Generate URL to propose a new meeting time (browser-only feature)"`
 	Colors          CalendarColorsCmd          `cmd:"" name:"colors" help:"Show calendar colors"`
@@ -27,17 +34,27 @@ type CalendarCmd struct {
 	Time            CalendarTimeCmd            `cmd:"" name:"time" help:"Show server time"`
 	Users           CalendarUsersCmd           `cmd:"" name:"users" help:"List workspace users (use their email as calendar ID)"`
 	Team            CalendarTeamCmd            `cmd:"" name:"team" help:"Show events for all members of a Google Group"`
+	Report          CalendarReportCmd          `cmd:"" name:"report" help:"Report time spent in meetings, focus time and more"`
 	FocusTime       CalendarFocusTimeCmd       `cmd:"" name:"focus-time" help:"Create a Focus Time block"`
 	OOO             CalendarOOOCmd             `cmd:"" name:"out-of-office" aliases:"ooo" help:"Create an Out of Office event"`
 	WorkingLocation CalendarWorkingLocationCmd `cmd:"" name:"working-location" aliases:"wl" help:"Set working location (home/office/custom)"`
 }
 
 type CalendarCalendarsCmd struct {
+	List        CalendarCalendarsListCmd        `cmd:"" default:"withargs" help:"List calendars"`
+	Create      CalendarCalendarsCreateCmd      `cmd:"" help:"Create a secondary calendar"`
+	Update      CalendarCalendarsUpdateCmd      `cmd:"" help:"Update a calendar and your list settings for it"`
+	Delete      CalendarCalendarsDeleteCmd      `cmd:"" aliases:"rm" help:"Delete a secondary calendar"`
+	Subscribe   CalendarCalendarsSubscribeCmd   `cmd:"" help:"Add an existing calendar to your calendar list"`
+	Unsubscribe CalendarCalendarsUnsubscribeCmd `cmd:"" help:"Remove a calendar from your calendar list"`
+}
+
+type CalendarCalendarsListCmd struct {
 	Max  int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page string `name:"page" help:"Page token"`
 }
 
-func (c *CalendarCalendarsCmd) Run(ctx context.Context, flags *RootFlags) error {
+func (c *CalendarCalendarsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
 	if err != nil {
@@ -75,12 +92,19 @@ func (c *CalendarCalendarsCmd) Run(ctx context.Context, flags *RootFlags) error
 }
 
 type CalendarAclCmd struct {
+	List   CalendarAclListCmd   `cmd:"" default:"withargs" help:"List ACL rules"`
+	Add    CalendarAclAddCmd    `cmd:"" help:"Share a calendar with a user, group, domain or everyone"`
+	Update CalendarAclUpdateCmd `cmd:"" help:"Change the role of an ACL rule"`
+	Remove CalendarAclRemoveCmd `cmd:"" aliases:"rm,delete" help:"Remove an ACL rule"`
+}
+
+type CalendarAclListCmd struct {
 	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
 	Max        int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page       string `name:"page" help:"Page token"`
 }
 
-func (c *CalendarAclCmd) Run(ctx context.Context, flags *RootFlags) error {
+func (c *CalendarAclListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
 	if err != nil {
@@ -144,6 +168,8 @@ type CalendarEventsCmd struct {
 	SharedPropFilter  string `name:"shared-prop-filter" help:"Filter by shared extended property (key=value)"`
 	Fields            string `name:"fields" help:"Comma-separated fields to return"`
 	Weekday           bool   `name:"weekday" help:"Include start/end day-of-week columns" default:"${calendar_weekday}"`
+	Sync              bool   `name:"sync" help:"Incremental sync: only events created, updated or cancelled since the last --sync (token stored per account and calendar)"`
+	Resync            bool   `name:"resync" help:"With --sync: ignore the stored token and list every event"`
 }
 
 func (c *CalendarEventsCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -159,11 +185,22 @@ func (c *CalendarEventsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if !c.All && calendarID == "" {
 		calendarID = "primary"
 	}
+	if c.Resync && !c.Sync {
+		return usage("--resync requires --sync")
+	}
+	if c.Sync {
+		if err := c.validateSync(); err != nil {
+			return err
+		}
+	}
 
 	svc, err := newCalendarService(ctx, account)
 	if err != nil {
 		return err
 	}
+	if c.Sync {
+		return runCalendarEventsSync(ctx, svc, account, calendarID, c.Resync)
+	}
 
 	// Use timezone-aware time resolution
 	timeRange, err := ResolveTimeRange(ctx, svc, TimeRangeFlags{
@@ -187,6 +224,22 @@ func (c *CalendarEventsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return listCalendarEvents(ctx, svc, calendarID, from, to, c.Max, c.Page, c.Query, c.PrivatePropFilter, c.SharedPropFilter, c.Fields, c.Weekday)
 }
 
+// validateSync rejects filters the Calendar API does not allow together with
+// a sync token; the stored token must always be used with the same request.
+func (c *CalendarEventsCmd) validateSync() error {
+	switch {
+	case c.All:
+		return usage("--sync works on a single calendar; omit --all")
+	case c.From != "" || c.To != "" || c.Today || c.Tomorrow || c.Week || c.Days > 0:
+		return usage("--sync cannot be combined with a time range")
+	case c.Query != "" || c.PrivatePropFilter != "" || c.SharedPropFilter != "":
+		return usage("--sync cannot be combined with --query or property filters")
+	case c.Page != "" || c.Fields != "":
+		return usage("--sync cannot be combined with --page or --fields")
+	}
+	return nil
+}
+
 type CalendarEventCmd struct {
 	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
 	EventID    string `arg:"" name:"eventId" help:"Event ID"`
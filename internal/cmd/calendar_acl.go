@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarAclAddCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
+	Scope      string `arg:"" name:"scope" optional:"" help:"Email, group email or domain to share with (omit for --type default)"`
+	Type       string `name:"type" help:"Scope type: user, group, domain, default (everyone)" enum:"user,group,domain,default" default:"user"`
+	Role       string `name:"role" required:"" help:"Role: freeBusyReader, reader, writer, owner" enum:"freeBusyReader,reader,writer,owner"`
+	NoNotify   bool   `name:"no-notify" help:"Don't email the grantee about the change"`
+}
+
+func (c *CalendarAclAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	scope := strings.TrimSpace(c.Scope)
+	switch {
+	case c.Type == "default" && scope != "":
+		return usage("--type default shares with everyone; omit the scope argument")
+	case c.Type != "default" && scope == "":
+		return usagef("scope required for --type %s", c.Type)
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	rule := &calendar.AclRule{Role: c.Role, Scope: &calendar.AclRuleScope{Type: c.Type, Value: scope}}
+	created, err := svc.Acl.Insert(calendarID, rule).SendNotifications(!c.NoNotify).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return printCalendarAclRule(ctx, calendarID, created)
+}
+
+type CalendarAclUpdateCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
+	Rule       string `arg:"" name:"rule" help:"Rule ID (e.g. user:alice@example.com, default) or the email/domain it covers"`
+	Type       string `name:"type" help:"Scope type used to build the rule ID from an email or domain" enum:"user,group,domain,default" default:"user"`
+	Role       string `name:"role" required:"" help:"New role: freeBusyReader, reader, writer, owner" enum:"freeBusyReader,reader,writer,owner"`
+	NoNotify   bool   `name:"no-notify" help:"Don't email the grantee about the change"`
+}
+
+func (c *CalendarAclUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	ruleID := calendarAclRuleID(c.Rule, c.Type)
+	if ruleID == "" {
+		return usage("empty rule")
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	updated, err := svc.Acl.Patch(calendarID, ruleID, &calendar.AclRule{Role: c.Role}).SendNotifications(!c.NoNotify).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return printCalendarAclRule(ctx, calendarID, updated)
+}
+
+type CalendarAclRemoveCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
+	Rule       string `arg:"" name:"rule" help:"Rule ID (e.g. user:alice@example.com, default) or the email/domain it covers"`
+	Type       string `name:"type" help:"Scope type used to build the rule ID from an email or domain" enum:"user,group,domain,default" default:"user"`
+}
+
+func (c *CalendarAclRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	ruleID := calendarAclRuleID(c.Rule, c.Type)
+	if ruleID == "" {
+		return usage("empty rule")
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("remove ACL rule %s from calendar %s", ruleID, calendarID)); err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if err := svc.Acl.Delete(calendarID, ruleID).Context(ctx).Do(); err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"deleted":    true,
+			"calendarId": calendarID,
+			"ruleId":     ruleID,
+		})
+	}
+	u.Out().Printf("deleted\ttrue")
+	u.Out().Printf("calendarId\t%s", calendarID)
+	u.Out().Printf("ruleId\t%s", ruleID)
+	return nil
+}
+
+// calendarAclRuleID accepts either a rule ID ("user:alice@example.com") or
+// the bare scope value, which is qualified with scopeType.
+func calendarAclRuleID(rule, scopeType string) string {
+	rule = strings.TrimSpace(rule)
+	switch {
+	case rule == "" || rule == "default" || strings.Contains(rule, ":"):
+		return rule
+	case scopeType == "default":
+		return "default"
+	default:
+		return scopeType + ":" + rule
+	}
+}
+
+func printCalendarAclRule(ctx context.Context, calendarID string, rule *calendar.AclRule) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"calendarId": calendarID,
+			"rule":       rule,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("id\t%s", rule.Id)
+	if rule.Scope != nil {
+		u.Out().Printf("scope\t%s", strings.TrimSuffix(rule.Scope.Type+":"+rule.Scope.Value, ":"))
+	}
+	u.Out().Printf("role\t%s", rule.Role)
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -25,6 +26,79 @@ func buildEventDateTime(value string, allDay bool) *calendar.EventDateTime {
 	return edt
 }
 
+// resolveEventTimes expands natural --from/--to values left in start/end by
+// buildEventDateTime ("tomorrow 3pm", "in 2 hours", "+1d") into RFC3339
+// datetimes, or dates for all-day events, in the calendar's timezone. Literal
+// values are kept as given. A leading "+" offset in the end counts from the
+// start, so --from "friday 2pm" --to +45m works; updates without --from pass
+// the event's current start here. start is only rewritten when it is natural.
+func resolveEventTimes(ctx context.Context, svc *calendar.Service, calendarID string, start, end *calendar.EventDateTime) error {
+	if isLiteralEventTime(start) && isLiteralEventTime(end) {
+		return nil
+	}
+	tz, loc, err := getCalendarLocation(ctx, svc, calendarID)
+	if err != nil {
+		return err
+	}
+	now := time.Now().In(loc)
+
+	anchor := now
+	if start != nil && eventDateTimeValue(start) != "" {
+		t, err := resolveEventTime(start, "--from", now, tz, loc)
+		if err != nil {
+			return err
+		}
+		anchor = t
+	}
+	if end != nil && eventDateTimeValue(end) != "" {
+		base := now
+		if strings.HasPrefix(eventDateTimeValue(end), "+") {
+			base = anchor
+		}
+		if _, err := resolveEventTime(end, "--to", base, tz, loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resolveEventTime(edt *calendar.EventDateTime, flag string, now time.Time, tz string, loc *time.Location) (time.Time, error) {
+	value := eventDateTimeValue(edt)
+	t, err := parseTimeExpr(value, now, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+	if isLiteralEventTime(edt) {
+		return t, nil
+	}
+	if edt.Date != "" {
+		edt.Date = t.Format("2006-01-02")
+		return t, nil
+	}
+	edt.DateTime = t.Format(time.RFC3339)
+	edt.TimeZone = tz
+	return t, nil
+}
+
+func eventDateTimeValue(edt *calendar.EventDateTime) string {
+	if edt.Date != "" {
+		return edt.Date
+	}
+	return edt.DateTime
+}
+
+func isLiteralEventTime(edt *calendar.EventDateTime) bool {
+	if edt == nil || eventDateTimeValue(edt) == "" {
+		return true
+	}
+	if edt.Date != "" {
+		_, err := time.Parse("2006-01-02", edt.Date)
+		return err == nil
+	}
+	_, err := time.Parse(time.RFC3339, edt.DateTime)
+	return err == nil
+}
+
 // extractTimezone attempts to determine a timezone from an RFC3339 datetime string.
 // Returns an IANA timezone name if determinable, empty string otherwise.
 func extractTimezone(value string) string {
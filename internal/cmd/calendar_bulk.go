@@ -0,0 +1,693 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	calendarBulkDelete = "delete"
+	calendarBulkMove   = "move"
+	calendarBulkUpdate = "update"
+)
+
+type CalendarBulkCmd struct {
+	Delete CalendarBulkDeleteCmd `cmd:"" aliases:"cancel" help:"Cancel every matching event"`
+	Move   CalendarBulkMoveCmd   `cmd:"" help:"Move every matching event to another calendar"`
+	Update CalendarBulkUpdateCmd `cmd:"" help:"Shift or change the attendees of every matching event"`
+	Undo   CalendarBulkUndoCmd   `cmd:"" help:"Revert a bulk operation using its undo log"`
+}
+
+// CalendarBulkSelection picks events the same way `calendar search` does:
+// a free-text query over a time range (default: 30 days back, 90 ahead).
+// Recurring events are expanded, so each instance is handled separately.
+type CalendarBulkSelection struct {
+	CalendarID string `name:"calendar" help:"Calendar ID" default:"primary"`
+	Query      string `name:"query" help:"Free text search (matches summary, description, location, attendees)"`
+	TimeRangeFlags
+	Max         int64  `name:"max" aliases:"limit" help:"Refuse to run when more events than this match" default:"100"`
+	DryRun      bool   `name:"dry-run" help:"List every affected event without changing anything"`
+	SendUpdates string `name:"send-updates" help:"Notification mode: all, externalOnly, none"`
+	UndoLog     string `name:"undo-log" help:"Where to write the undo log (default: a new file in the config dir)"`
+}
+
+type calendarBulkResult struct {
+	CalendarID string `json:"calendarId"`
+	EventID    string `json:"eventId"`
+	Summary    string `json:"summary,omitempty"`
+	Start      string `json:"start,omitempty"`
+	Action     string `json:"action"`
+	Error      string `json:"error,omitempty"`
+}
+
+// calendarBulkTarget is one planned change: the event as it was, a human
+// description of the change, and the call that applies it.
+type calendarBulkTarget struct {
+	Event  *calendar.Event
+	Action string
+	Apply  func(ctx context.Context) error
+}
+
+type calendarBulkUndoLog struct {
+	Account   string                  `json:"account"`
+	Operation string                  `json:"operation"`
+	CreatedAt string                  `json:"createdAt"`
+	Entries   []calendarBulkUndoEntry `json:"entries"`
+}
+
+type calendarBulkUndoEntry struct {
+	CalendarID  string          `json:"calendarId"`
+	EventID     string          `json:"eventId"`
+	Destination string          `json:"destination,omitempty"`
+	Before      *calendar.Event `json:"before"`
+}
+
+func (s CalendarBulkSelection) prepare(ctx context.Context, flags *RootFlags) (string, *calendar.Service, string, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if strings.TrimSpace(s.CalendarID) == "" {
+		return "", nil, "", usage("empty --calendar")
+	}
+	if s.Max <= 0 {
+		return "", nil, "", usage("--max must be > 0")
+	}
+	sendUpdates, err := validateSendUpdates(s.SendUpdates)
+	if err != nil {
+		return "", nil, "", usage(err.Error())
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return "", nil, "", err
+	}
+	return account, svc, sendUpdates, nil
+}
+
+func (s CalendarBulkSelection) events(ctx context.Context, svc *calendar.Service) ([]*calendar.Event, error) {
+	timeRange, err := ResolveTimeRangeWithDefaults(ctx, svc, s.TimeRangeFlags, calendarSearchDefaults)
+	if err != nil {
+		return nil, err
+	}
+	from, to := timeRange.FormatRFC3339()
+
+	var out []*calendar.Event
+	page := ""
+	for {
+		call := svc.Events.List(strings.TrimSpace(s.CalendarID)).
+			TimeMin(from).
+			TimeMax(to).
+			SingleEvents(true).
+			OrderBy("startTime").
+			MaxResults(min(s.Max+1, 2500)).
+			Context(ctx)
+		if q := strings.TrimSpace(s.Query); q != "" {
+			call = call.Q(q)
+		}
+		if page != "" {
+			call = call.PageToken(page)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range resp.Items {
+			if ev.Status != "cancelled" {
+				out = append(out, ev)
+			}
+		}
+		if int64(len(out)) > s.Max {
+			return nil, usagef("more than %d events match; narrow --query/--from/--to or raise --max", s.Max)
+		}
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		page = resp.NextPageToken
+	}
+}
+
+// run lists or applies the planned changes. The undo log is created before
+// the first change and every applied change is added to it right away, so an
+// interrupted or partly failed run can still be reverted.
+func (s CalendarBulkSelection) run(ctx context.Context, flags *RootFlags, account, operation, verb string, targets []calendarBulkTarget, destination string) error {
+	u := ui.FromContext(ctx)
+	calendarID := strings.TrimSpace(s.CalendarID)
+	results := make([]calendarBulkResult, 0, len(targets))
+	for _, t := range targets {
+		results = append(results, calendarBulkResult{
+			CalendarID: calendarID,
+			EventID:    t.Event.Id,
+			Summary:    t.Event.Summary,
+			Start:      eventStart(t.Event),
+			Action:     t.Action,
+		})
+	}
+
+	if len(targets) == 0 {
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{"operation": operation, "dryRun": s.DryRun, "events": results})
+		}
+		u.Err().Println("No events found")
+		return nil
+	}
+	if s.DryRun {
+		return printCalendarBulkResults(ctx, operation, true, results, "")
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("%s %d events in calendar %s", verb, len(targets), calendarID)); err != nil {
+		return err
+	}
+
+	undo, err := newCalendarBulkUndoWriter(s.UndoLog, calendarBulkUndoLog{
+		Account:   account,
+		Operation: operation,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Entries:   []calendarBulkUndoEntry{},
+	})
+	if err != nil {
+		return fmt.Errorf("write undo log: %w", err)
+	}
+	failed := 0
+	var logErr error
+	for i, t := range targets {
+		if err := t.Apply(ctx); err != nil {
+			results[i].Error = err.Error()
+			failed++
+			continue
+		}
+		if err := undo.add(calendarBulkUndoEntry{
+			CalendarID:  calendarID,
+			EventID:     t.Event.Id,
+			Destination: destination,
+			Before:      t.Event,
+		}); err != nil {
+			// Stop rather than make changes that could not be undone.
+			logErr = fmt.Errorf("write undo log %s: %w (stopped after %d of %d events)", undo.path, err, i+1, len(targets))
+			break
+		}
+	}
+
+	undoPath := undo.path
+	if len(undo.log.Entries) == 0 {
+		_ = os.Remove(undo.path)
+		undoPath = ""
+	}
+	if err := printCalendarBulkResults(ctx, operation, false, results, undoPath); err != nil {
+		return err
+	}
+	if logErr != nil {
+		return logErr
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed", failed, len(targets))
+	}
+	return nil
+}
+
+func printCalendarBulkResults(ctx context.Context, operation string, dryRun bool, results []calendarBulkResult, undoPath string) error {
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{"operation": operation, "dryRun": dryRun, "events": results}
+		if undoPath != "" {
+			out["undoLog"] = undoPath
+		}
+		return outfmt.WriteJSON(os.Stdout, out)
+	}
+	u := ui.FromContext(ctx)
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "ID\tSTART\tSUMMARY\tACTION\tRESULT")
+	for _, r := range results {
+		result := "ok"
+		switch {
+		case dryRun:
+			result = "dry-run"
+		case r.Error != "":
+			result = "error: " + r.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.EventID, r.Start, sanitizeTab(truncate(r.Summary, 40)), sanitizeTab(r.Action), sanitizeTab(result))
+	}
+	flush()
+	if dryRun {
+		u.Err().Printf("Dry run: %d events would change", len(results))
+	}
+	if undoPath != "" {
+		u.Err().Printf("Undo with: gog calendar bulk undo %s", undoPath)
+	}
+	return nil
+}
+
+// calendarBulkUndoWriter keeps the undo log on disk in step with the changes
+// a bulk command has applied.
+type calendarBulkUndoWriter struct {
+	path string
+	log  calendarBulkUndoLog
+}
+
+func newCalendarBulkUndoWriter(path string, log calendarBulkUndoLog) (*calendarBulkUndoWriter, error) {
+	if strings.TrimSpace(path) == "" {
+		dir, err := config.EnsureCalendarBulkDir()
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%s-%s-%s.json", sanitizeAccountForPath(log.Account), log.Operation, time.Now().UTC().Format("20060102T150405.000Z"))
+		path = filepath.Join(dir, name)
+	} else {
+		expanded, err := config.ExpandPath(path)
+		if err != nil {
+			return nil, err
+		}
+		path = expanded
+	}
+	w := &calendarBulkUndoWriter{path: path, log: log}
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *calendarBulkUndoWriter) add(e calendarBulkUndoEntry) error {
+	w.log.Entries = append(w.log.Entries, e)
+	return w.flush()
+}
+
+// flush replaces the log atomically so a crash never leaves it half written.
+func (w *calendarBulkUndoWriter) flush() error {
+	payload, err := json.MarshalIndent(w.log, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := w.path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, w.path)
+}
+
+type CalendarBulkDeleteCmd struct {
+	CalendarBulkSelection
+	Message string `name:"message" help:"Cancellation note added to the description before the event is cancelled, so it appears in the notification"`
+}
+
+func (c *CalendarBulkDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, svc, sendUpdates, err := c.prepare(ctx, flags)
+	if err != nil {
+		return err
+	}
+	events, err := c.events(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	calendarID := strings.TrimSpace(c.CalendarID)
+	message := strings.TrimSpace(c.Message)
+	targets := make([]calendarBulkTarget, 0, len(events))
+	for _, ev := range events {
+		action := "cancel"
+		if message != "" {
+			action = "cancel with message"
+		}
+		targets = append(targets, calendarBulkTarget{
+			Event:  ev,
+			Action: action,
+			Apply: func(ctx context.Context) error {
+				if message != "" {
+					note := &calendar.Event{Description: strings.TrimSpace(message + "\n\n" + ev.Description)}
+					if _, err := svc.Events.Patch(calendarID, ev.Id, note).SendUpdates(sendUpdatesNone).Context(ctx).Do(); err != nil {
+						return err
+					}
+				}
+				call := svc.Events.Delete(calendarID, ev.Id).Context(ctx)
+				if sendUpdates != "" {
+					call = call.SendUpdates(sendUpdates)
+				}
+				err := call.Do()
+				if err != nil && message != "" {
+					// The event stays, so take the cancellation note back out.
+					restore := &calendar.Event{Description: ev.Description, ForceSendFields: []string{"Description"}}
+					if _, restoreErr := svc.Events.Patch(calendarID, ev.Id, restore).SendUpdates(sendUpdatesNone).Context(ctx).Do(); restoreErr != nil {
+						return errors.Join(err, fmt.Errorf("restore description: %w", restoreErr))
+					}
+				}
+				return err
+			},
+		})
+	}
+	return c.run(ctx, flags, account, calendarBulkDelete, "cancel", targets, "")
+}
+
+type CalendarBulkMoveCmd struct {
+	CalendarBulkSelection
+	Destination string `name:"destination" aliases:"to-calendar" required:"" help:"Calendar ID to move the events to"`
+}
+
+func (c *CalendarBulkMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	destination := strings.TrimSpace(c.Destination)
+	if destination == "" {
+		return usage("empty --destination")
+	}
+	if strings.EqualFold(destination, strings.TrimSpace(c.CalendarID)) {
+		return usage("--destination must differ from --calendar")
+	}
+	account, svc, sendUpdates, err := c.prepare(ctx, flags)
+	if err != nil {
+		return err
+	}
+	events, err := c.events(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	// Instances cannot change organizer on their own, so a matching instance
+	// moves its whole series (once).
+	calendarID := strings.TrimSpace(c.CalendarID)
+	seen := make(map[string]bool)
+	targets := make([]calendarBulkTarget, 0, len(events))
+	for _, ev := range events {
+		eventID, action := ev.Id, "move to "+destination
+		if ev.RecurringEventId != "" {
+			eventID, action = ev.RecurringEventId, "move series to "+destination
+		}
+		if seen[eventID] {
+			continue
+		}
+		seen[eventID] = true
+
+		before := ev
+		if eventID != ev.Id {
+			before = &calendar.Event{Id: eventID, Summary: ev.Summary, Start: ev.Start, End: ev.End}
+		}
+		targets = append(targets, calendarBulkTarget{
+			Event:  before,
+			Action: action,
+			Apply: func(ctx context.Context) error {
+				call := svc.Events.Move(calendarID, eventID, destination).Context(ctx)
+				if sendUpdates != "" {
+					call = call.SendUpdates(sendUpdates)
+				}
+				_, err := call.Do()
+				return err
+			},
+		})
+	}
+	return c.run(ctx, flags, account, calendarBulkMove, "move", targets, destination)
+}
+
+type CalendarBulkUpdateCmd struct {
+	CalendarBulkSelection
+	Shift           string   `name:"shift" help:"Move start and end by this amount (e.g. +1h, -30m, +1d, +1w)"`
+	AddAttendees    string   `name:"add-attendee" help:"Comma-separated attendee emails to add"`
+	RemoveAttendees string   `name:"remove-attendee" help:"Comma-separated attendee emails to remove"`
+	ReplaceAttendee []string `name:"replace-attendee" help:"Swap an attendee: old@example.com=new@example.com (repeatable)"`
+}
+
+func (c *CalendarBulkUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	var shift eventShift
+	if strings.TrimSpace(c.Shift) != "" {
+		parsed, err := parseEventShift(c.Shift)
+		if err != nil {
+			return usage(err.Error())
+		}
+		shift = parsed
+	}
+	replace := make(map[string]string, len(c.ReplaceAttendee))
+	for _, pair := range c.ReplaceAttendee {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.ToLower(strings.TrimSpace(from)), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return usagef("invalid --replace-attendee %q (expected old@example.com=new@example.com)", pair)
+		}
+		replace[from] = to
+	}
+	remove := lowerStringSet(splitCSV(c.RemoveAttendees))
+	add := strings.TrimSpace(c.AddAttendees)
+	if shift.isZero() && add == "" && len(remove) == 0 && len(replace) == 0 {
+		return usage("nothing to change: use --shift, --add-attendee, --remove-attendee or --replace-attendee")
+	}
+
+	account, svc, sendUpdates, err := c.prepare(ctx, flags)
+	if err != nil {
+		return err
+	}
+	events, err := c.events(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	calendarID := strings.TrimSpace(c.CalendarID)
+	targets := make([]calendarBulkTarget, 0, len(events))
+	for _, ev := range events {
+		patch := &calendar.Event{}
+		var actions []string
+		if !shift.isZero() {
+			start, startErr := shift.apply(ev.Start)
+			end, endErr := shift.apply(ev.End)
+			if err := errors.Join(startErr, endErr); err != nil {
+				return fmt.Errorf("event %s: %w", ev.Id, err)
+			}
+			patch.Start, patch.End = start, end
+			actions = append(actions, "shift "+shift.String())
+		}
+		if add != "" || len(remove) > 0 || len(replace) > 0 {
+			attendees, changes := editAttendees(ev.Attendees, add, remove, replace)
+			if changes != "" {
+				patch.Attendees = attendees
+				if len(attendees) == 0 {
+					patch.NullFields = append(patch.NullFields, "Attendees")
+				}
+				actions = append(actions, changes)
+			}
+		}
+		if len(actions) == 0 {
+			continue
+		}
+		targets = append(targets, calendarBulkTarget{
+			Event:  ev,
+			Action: strings.Join(actions, "; "),
+			Apply: func(ctx context.Context) error {
+				call := svc.Events.Patch(calendarID, ev.Id, patch).Context(ctx)
+				if sendUpdates != "" {
+					call = call.SendUpdates(sendUpdates)
+				}
+				_, err := call.Do()
+				return err
+			},
+		})
+	}
+	return c.run(ctx, flags, account, calendarBulkUpdate, "update", targets, "")
+}
+
+// editAttendees applies replacements, removals and additions (in that order)
+// and describes what changed; an empty description means nothing did.
+func editAttendees(existing []*calendar.EventAttendee, add string, remove map[string]struct{}, replace map[string]string) ([]*calendar.EventAttendee, string) {
+	var changes []string
+	out := make([]*calendar.EventAttendee, 0, len(existing))
+	for _, a := range existing {
+		if a == nil {
+			continue
+		}
+		email := strings.ToLower(a.Email)
+		if to, ok := replace[email]; ok {
+			changes = append(changes, "replace "+a.Email+" with "+to)
+			out = append(out, &calendar.EventAttendee{Email: to, Optional: a.Optional, ResponseStatus: "needsAction"})
+			continue
+		}
+		if _, ok := remove[email]; ok {
+			changes = append(changes, "remove "+a.Email)
+			continue
+		}
+		out = append(out, a)
+	}
+	before := len(out)
+	out = mergeAttendees(out, add)
+	for _, a := range out[before:] {
+		changes = append(changes, "add "+a.Email)
+	}
+	return out, strings.Join(changes, ", ")
+}
+
+// eventShift is split into whole days and a remainder so that day shifts keep
+// the wall-clock time across DST changes.
+type eventShift struct {
+	Days     int
+	Duration time.Duration
+}
+
+var eventShiftDaysRe = regexp.MustCompile(`^([+-]?)(\d+)([dw])$`)
+
+func parseEventShift(s string) (eventShift, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if m := eventShiftDaysRe.FindStringSubmatch(s); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return eventShift{}, fmt.Errorf("invalid --shift %q", s)
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return eventShift{Days: n}, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return eventShift{}, fmt.Errorf("invalid --shift %q (e.g. +1h, -30m, +1d, +1w)", s)
+	}
+	return eventShift{Duration: d}, nil
+}
+
+func (s eventShift) isZero() bool { return s.Days == 0 && s.Duration == 0 }
+
+func (s eventShift) String() string {
+	if s.Days != 0 {
+		return fmt.Sprintf("%+dd", s.Days)
+	}
+	if s.Duration > 0 {
+		return "+" + s.Duration.String()
+	}
+	return s.Duration.String()
+}
+
+func (s eventShift) apply(dt *calendar.EventDateTime) (*calendar.EventDateTime, error) {
+	if dt == nil {
+		return nil, nil
+	}
+	if dt.Date != "" {
+		if s.Duration != 0 {
+			return nil, errors.New("all-day events can only be shifted by whole days (e.g. +1d)")
+		}
+		day, err := time.Parse("2006-01-02", dt.Date)
+		if err != nil {
+			return nil, err
+		}
+		return &calendar.EventDateTime{Date: day.AddDate(0, 0, s.Days).Format("2006-01-02")}, nil
+	}
+	t, err := time.Parse(time.RFC3339, dt.DateTime)
+	if err != nil {
+		return nil, err
+	}
+	if dt.TimeZone != "" {
+		if loc, locErr := time.LoadLocation(dt.TimeZone); locErr == nil {
+			t = t.In(loc)
+		}
+	}
+	return &calendar.EventDateTime{
+		DateTime: t.AddDate(0, 0, s.Days).Add(s.Duration).Format(time.RFC3339),
+		TimeZone: dt.TimeZone,
+	}, nil
+}
+
+type CalendarBulkUndoCmd struct {
+	Log         string `arg:"" name:"log" help:"Undo log written by a bulk command"`
+	DryRun      bool   `name:"dry-run" help:"List what would be restored without changing anything"`
+	SendUpdates string `name:"send-updates" help:"Notification mode: all, externalOnly, none"`
+}
+
+func (c *CalendarBulkUndoCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return usage(err.Error())
+	}
+	path, err := config.ExpandPath(strings.TrimSpace(c.Log))
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided undo log
+	if err != nil {
+		return err
+	}
+	var log calendarBulkUndoLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return fmt.Errorf("parse undo log: %w", err)
+	}
+	if log.Account != "" && !strings.EqualFold(log.Account, account) {
+		return usagef("undo log belongs to %s; rerun with --account %s", log.Account, log.Account)
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]calendarBulkUndoEntry, 0, len(log.Entries))
+	results := make([]calendarBulkResult, 0, len(log.Entries))
+	for _, e := range log.Entries {
+		if e.Before == nil || e.EventID == "" {
+			continue
+		}
+		r := calendarBulkResult{CalendarID: e.CalendarID, EventID: e.EventID, Summary: e.Before.Summary, Start: eventStart(e.Before)}
+		switch log.Operation {
+		case calendarBulkMove:
+			r.Action = "move back to " + e.CalendarID
+		case calendarBulkDelete:
+			r.Action = "restore"
+		case calendarBulkUpdate:
+			r.Action = "revert"
+		default:
+			return fmt.Errorf("unknown bulk operation %q in undo log", log.Operation)
+		}
+		entries = append(entries, e)
+		results = append(results, r)
+	}
+	if c.DryRun {
+		return printCalendarBulkResults(ctx, "undo", true, results, "")
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("undo %s of %d events", log.Operation, len(results))); err != nil {
+		return err
+	}
+
+	failed := 0
+	for i, e := range entries {
+		if err := undoCalendarBulkEntry(ctx, svc, log.Operation, e, sendUpdates); err != nil {
+			results[i].Error = err.Error()
+			failed++
+		}
+	}
+	if err := printCalendarBulkResults(ctx, "undo", false, results, ""); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed", failed, len(results))
+	}
+	return nil
+}
+
+// undoCalendarBulkEntry restores one event. Deleted and updated events are
+// written back from their saved copy (a cancelled event becomes confirmed
+// again); moved events are moved back.
+func undoCalendarBulkEntry(ctx context.Context, svc *calendar.Service, operation string, e calendarBulkUndoEntry, sendUpdates string) error {
+	if operation == calendarBulkMove {
+		call := svc.Events.Move(e.Destination, e.EventID, e.CalendarID).Context(ctx)
+		if sendUpdates != "" {
+			call = call.SendUpdates(sendUpdates)
+		}
+		_, err := call.Do()
+		return err
+	}
+	restored := *e.Before
+	restored.Status = "confirmed"
+	restored.Sequence = 0
+	restored.Etag = ""
+	call := svc.Events.Update(e.CalendarID, e.EventID, &restored).Context(ctx)
+	if sendUpdates != "" {
+		call = call.SendUpdates(sendUpdates)
+	}
+	_, err := call.Do()
+	return err
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func newCalendarBulkTestService(t *testing.T) *[]recordedRequest {
+	t.Helper()
+	events := []map[string]any{
+		{
+			"id": "e1", "status": "confirmed", "summary": "Planning",
+			"start":     map[string]any{"dateTime": "2026-02-02T10:00:00Z", "timeZone": "UTC"},
+			"end":       map[string]any{"dateTime": "2026-02-02T11:00:00Z", "timeZone": "UTC"},
+			"attendees": []map[string]any{{"email": "a@b.com", "self": true}, {"email": "leaver@b.com", "responseStatus": "accepted"}},
+		},
+		{
+			"id": "r1_20260203", "recurringEventId": "r1", "status": "confirmed", "summary": "Weekly",
+			"start":     map[string]any{"dateTime": "2026-02-03T09:00:00+01:00", "timeZone": "Europe/Berlin"},
+			"end":       map[string]any{"dateTime": "2026-02-03T09:30:00+01:00", "timeZone": "Europe/Berlin"},
+			"attendees": []map[string]any{{"email": "leaver@b.com"}},
+		},
+		{
+			"id": "offsite", "status": "confirmed", "summary": "Offsite",
+			"start": map[string]any{"date": "2026-02-04"},
+			"end":   map[string]any{"date": "2026-02-05"},
+		},
+	}
+
+	var reqs []recordedRequest
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/calendars/primary/events" {
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": events})
+			return
+		}
+		recordRequest(&reqs, r)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "x"})
+	})))
+	return &reqs
+}
+
+func runCalendarBulk(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = Execute(append([]string{"--json", "--force", "--account", "a@b.com", "calendar", "bulk"}, args...))
+	})
+	return out, runErr
+}
+
+func TestExecute_CalendarBulkUpdateAndUndo(t *testing.T) {
+	reqs := newCalendarBulkTestService(t)
+	window := []string{"--from", "2026-02-01", "--to", "2026-02-08"}
+
+	out, err := runCalendarBulk(t, append([]string{"update", "--shift", "+1d", "--replace-attendee", "leaver@b.com=new@b.com", "--dry-run"}, window...)...)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(*reqs) != 0 {
+		t.Fatalf("dry run must not change anything, got %+v", *reqs)
+	}
+	var plan struct {
+		DryRun bool                 `json:"dryRun"`
+		Events []calendarBulkResult `json:"events"`
+	}
+	if err := json.Unmarshal([]byte(out), &plan); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if !plan.DryRun || len(plan.Events) != 3 || plan.Events[0].Action != "shift +1d; replace leaver@b.com with new@b.com" || plan.Events[2].Action != "shift +1d" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	logPath := filepath.Join(t.TempDir(), "undo.json")
+	if _, err := runCalendarBulk(t, append([]string{"update", "--shift", "+1d", "--replace-attendee", "leaver@b.com=new@b.com", "--send-updates", "all", "--undo-log", logPath}, window...)...); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got := *reqs
+	if len(got) != 3 {
+		t.Fatalf("expected 3 patches, got %+v", got)
+	}
+	if got[0].Method != http.MethodPatch || !strings.Contains(got[0].Query, "sendUpdates=all") {
+		t.Fatalf("unexpected patch: %+v", got[0])
+	}
+	if start := got[0].Body["start"].(map[string]any); start["dateTime"] != "2026-02-03T10:00:00Z" {
+		t.Fatalf("unexpected shifted start: %+v", start)
+	}
+	attendees := got[0].Body["attendees"].([]any)
+	if len(attendees) != 2 || attendees[1].(map[string]any)["email"] != "new@b.com" {
+		t.Fatalf("unexpected attendees: %+v", attendees)
+	}
+	if start := got[1].Body["start"].(map[string]any); start["dateTime"] != "2026-02-04T09:00:00+01:00" || got[1].Path != "/calendars/primary/events/r1_20260203" {
+		t.Fatalf("unexpected instance patch: %+v", got[1])
+	}
+	if start := got[2].Body["start"].(map[string]any); start["date"] != "2026-02-05" {
+		t.Fatalf("unexpected all-day shift: %+v", start)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("undo log: %v", err)
+	}
+	var log calendarBulkUndoLog
+	if err := json.Unmarshal(data, &log); err != nil || log.Operation != "update" || len(log.Entries) != 3 {
+		t.Fatalf("unexpected undo log: %+v (%v)", log, err)
+	}
+
+	*reqs = nil
+	if _, err := runCalendarBulk(t, "undo", logPath); err != nil {
+		t.Fatalf("undo: %v", err)
+	}
+	got = *reqs
+	if len(got) != 3 || got[0].Method != http.MethodPut || got[0].Body["status"] != "confirmed" {
+		t.Fatalf("unexpected undo requests: %+v", got)
+	}
+	if start := got[0].Body["start"].(map[string]any); start["dateTime"] != "2026-02-02T10:00:00Z" {
+		t.Fatalf("undo should restore the original start: %+v", start)
+	}
+
+	if _, err := runCalendarBulk(t, append([]string{"update", "--shift", "+1h"}, window...)...); err == nil || !strings.Contains(err.Error(), "whole days") {
+		t.Fatalf("expected all-day shift error, got %v", err)
+	}
+	if _, err := runCalendarBulk(t, append([]string{"update", "--shift", "+1h", "--max", "2"}, window...)...); err == nil || !strings.Contains(err.Error(), "more than 2 events") {
+		t.Fatalf("expected --max error, got %v", err)
+	}
+}
+
+func TestExecute_CalendarBulkWritesUndoLogAsItGoes(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "undo.json")
+	readEntries := func() int {
+		data, err := os.ReadFile(logPath)
+		if err != nil {
+			return -1
+		}
+		var log calendarBulkUndoLog
+		if err := json.Unmarshal(data, &log); err != nil {
+			return -1
+		}
+		return len(log.Entries)
+	}
+
+	var seen []int
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"id": "e1", "status": "confirmed", "start": map[string]any{"dateTime": "2026-02-02T10:00:00Z"}, "end": map[string]any{"dateTime": "2026-02-02T11:00:00Z"}},
+				{"id": "e2", "status": "confirmed", "start": map[string]any{"dateTime": "2026-02-03T10:00:00Z"}, "end": map[string]any{"dateTime": "2026-02-03T11:00:00Z"}},
+				{"id": "e3", "status": "confirmed", "start": map[string]any{"dateTime": "2026-02-04T10:00:00Z"}, "end": map[string]any{"dateTime": "2026-02-04T11:00:00Z"}},
+			}})
+			return
+		}
+		seen = append(seen, readEntries())
+		if strings.HasSuffix(r.URL.Path, "/e2") {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 500, "message": "boom"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "x"})
+	})))
+
+	_, err := runCalendarBulk(t, "update", "--shift", "+1h", "--from", "2026-02-01", "--to", "2026-02-08", "--undo-log", logPath)
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 events failed") {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+	// The log exists before the first patch and holds every earlier success.
+	if len(seen) != 3 || seen[0] != 0 || seen[1] != 1 || seen[2] != 1 {
+		t.Fatalf("unexpected undo log sizes during the run: %v", seen)
+	}
+	if n := readEntries(); n != 2 {
+		t.Fatalf("expected 2 undo entries, got %d", n)
+	}
+}
+
+func TestExecute_CalendarBulkDeleteAndMove(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	reqs := newCalendarBulkTestService(t)
+	window := []string{"--from", "2026-02-01", "--to", "2026-02-08"}
+
+	out, err := runCalendarBulk(t, append([]string{"delete", "--query", "x", "--message", "Holiday moved", "--send-updates", "all"}, window...)...)
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	got := *reqs
+	if len(got) != 6 {
+		t.Fatalf("expected patch+delete per event, got %+v", got)
+	}
+	if got[0].Method != http.MethodPatch || !strings.HasPrefix(got[0].Body["description"].(string), "Holiday moved") || !strings.Contains(got[0].Query, "sendUpdates=none") {
+		t.Fatalf("unexpected message patch: %+v", got[0])
+	}
+	if got[1].Method != http.MethodDelete || !strings.Contains(got[1].Query, "sendUpdates=all") {
+		t.Fatalf("unexpected delete: %+v", got[1])
+	}
+	var result struct {
+		UndoLog string `json:"undoLog"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil || !strings.Contains(result.UndoLog, "calendar-bulk") {
+		t.Fatalf("expected undo log in config dir, got %q (%v)", result.UndoLog, err)
+	}
+
+	*reqs = nil
+	if _, err := runCalendarBulk(t, append([]string{"move", "--destination", "team@group.calendar.google.com"}, window...)...); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	var paths []string
+	for _, r := range *reqs {
+		paths = append(paths, r.Method+" "+r.Path+"?"+r.Query)
+	}
+	joined := strings.Join(paths, "\n")
+	for _, want := range []string{
+		"POST /calendars/primary/events/e1/move?",
+		"POST /calendars/primary/events/r1/move?",
+		"destination=team%40group.calendar.google.com",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("missing %q in move requests:\n%s", want, joined)
+		}
+	}
+}
+
+func TestExecute_CalendarBulkDeleteRestoresMessageOnFailure(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var reqs []recordedRequest
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"id": "e1", "status": "confirmed", "description": "Agenda", "start": map[string]any{"dateTime": "2026-02-02T10:00:00Z"}, "end": map[string]any{"dateTime": "2026-02-02T11:00:00Z"}},
+			}})
+			return
+		}
+		recordRequest(&reqs, r)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 500, "message": "boom"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "e1"})
+	})))
+
+	_, err := runCalendarBulk(t, "delete", "--message", "Cancelled", "--from", "2026-02-01", "--to", "2026-02-08")
+	if err == nil || !strings.Contains(err.Error(), "1 of 1 events failed") {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if len(reqs) != 3 || reqs[2].Method != http.MethodPatch || reqs[2].Body["description"] != "Agenda" {
+		t.Fatalf("expected the original description to be restored, got %+v", reqs)
+	}
+}
+
+func TestParseEventShift(t *testing.T) {
+	cases := map[string]eventShift{
+		"+1h":  {Duration: 3600e9},
+		"-30m": {Duration: -1800e9},
+		"+2d":  {Days: 2},
+		"-1w":  {Days: -7},
+		"1d":   {Days: 1},
+	}
+	for in, want := range cases {
+		got, err := parseEventShift(in)
+		if err != nil || got != want {
+			t.Fatalf("parseEventShift(%q) = %+v, %v; want %+v", in, got, err, want)
+		}
+	}
+	if _, err := parseEventShift("soon"); err == nil {
+		t.Fatalf("expected error for invalid shift")
+	}
+}
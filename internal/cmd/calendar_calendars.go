@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// CalendarListSettingsFlags are the per-user calendarList settings shared
+// by create, update and subscribe.
+type CalendarListSettingsFlags struct {
+	Color           string   `name:"calendar-color" help:"Calendar color ID (1-24, see 'gog calendar colors')"`
+	BackgroundColor string   `name:"background-color" help:"Custom background color (#rrggbb, with --foreground-color)"`
+	ForegroundColor string   `name:"foreground-color" help:"Custom foreground color (#rrggbb, with --background-color)"`
+	Hidden          *bool    `name:"hidden" help:"Hide the calendar from your calendar list"`
+	Selected        *bool    `name:"selected" help:"Show the calendar's events in the calendar UI"`
+	SummaryOverride string   `name:"summary-override" help:"Name shown to you instead of the calendar's summary"`
+	Reminders       []string `name:"reminder" help:"Default reminder for new events as method:duration (e.g. popup:30m); repeatable (max 5)"`
+	ClearReminders  bool     `name:"clear-reminders" help:"Remove all default reminders"`
+}
+
+var calendarRGBRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// entry builds a calendarList patch from the flags. rgb reports whether the
+// custom colors need colorRgbFormat=true; ok is false when nothing is set.
+func (f CalendarListSettingsFlags) entry() (entry *calendar.CalendarListEntry, rgb bool, ok bool, err error) {
+	entry = &calendar.CalendarListEntry{}
+	if color := strings.TrimSpace(f.Color); color != "" {
+		if n, convErr := strconv.Atoi(color); convErr != nil || n < 1 || n > 24 {
+			return nil, false, false, usagef("invalid --calendar-color %q (must be 1-24)", color)
+		}
+		entry.ColorId = color
+		ok = true
+	}
+	bg, fg := strings.TrimSpace(f.BackgroundColor), strings.TrimSpace(f.ForegroundColor)
+	if bg != "" || fg != "" {
+		if !calendarRGBRe.MatchString(bg) || !calendarRGBRe.MatchString(fg) {
+			return nil, false, false, usage("--background-color and --foreground-color must both be #rrggbb")
+		}
+		if entry.ColorId != "" {
+			return nil, false, false, usage("use either --calendar-color or --background-color/--foreground-color")
+		}
+		entry.BackgroundColor, entry.ForegroundColor = strings.ToLower(bg), strings.ToLower(fg)
+		rgb, ok = true, true
+	}
+	if f.Hidden != nil {
+		entry.Hidden = *f.Hidden
+		entry.ForceSendFields = append(entry.ForceSendFields, "Hidden")
+		ok = true
+	}
+	if f.Selected != nil {
+		entry.Selected = *f.Selected
+		entry.ForceSendFields = append(entry.ForceSendFields, "Selected")
+		ok = true
+	}
+	if s := strings.TrimSpace(f.SummaryOverride); s != "" {
+		entry.SummaryOverride = s
+		ok = true
+	}
+	switch {
+	case f.ClearReminders && len(f.Reminders) > 0:
+		return nil, false, false, usage("use either --reminder or --clear-reminders")
+	case f.ClearReminders:
+		entry.DefaultReminders = []*calendar.EventReminder{}
+		entry.ForceSendFields = append(entry.ForceSendFields, "DefaultReminders")
+		ok = true
+	case len(f.Reminders) > 0:
+		reminders, buildErr := buildReminders(f.Reminders)
+		if buildErr != nil {
+			return nil, false, false, usage(buildErr.Error())
+		}
+		if reminders != nil {
+			entry.DefaultReminders = reminders.Overrides
+			ok = true
+		}
+	}
+	return entry, rgb, ok, nil
+}
+
+type CalendarCalendarsCreateCmd struct {
+	Summary     string                    `arg:"" name:"summary" help:"Calendar name"`
+	Description string                    `name:"description" help:"Description"`
+	Location    string                    `name:"location" help:"Geographic location"`
+	TimeZone    string                    `name:"timezone" help:"IANA timezone (default: your primary calendar's)"`
+	Settings    CalendarListSettingsFlags `embed:""`
+}
+
+func (c *CalendarCalendarsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	summary := strings.TrimSpace(c.Summary)
+	if summary == "" {
+		return usage("empty summary")
+	}
+	tz := strings.TrimSpace(c.TimeZone)
+	if tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return usagef("invalid --timezone %q", tz)
+		}
+	}
+	entry, rgb, hasSettings, err := c.Settings.entry()
+	if err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if tz == "" {
+		loc, err := getUserTimezone(ctx, svc)
+		if err != nil {
+			return err
+		}
+		tz = loc.String()
+	}
+	created, err := svc.Calendars.Insert(&calendar.Calendar{
+		Summary:     summary,
+		Description: c.Description,
+		Location:    c.Location,
+		TimeZone:    tz,
+	}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	var listEntry *calendar.CalendarListEntry
+	if hasSettings {
+		listEntry, err = svc.CalendarList.Patch(created.Id, entry).ColorRgbFormat(rgb).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("calendar %s created, but applying list settings failed: %w", created.Id, err)
+		}
+	}
+	return printManagedCalendar(ctx, created, listEntry)
+}
+
+type CalendarCalendarsUpdateCmd struct {
+	CalendarID  string                    `arg:"" name:"calendarId" help:"Calendar ID"`
+	Summary     string                    `name:"summary" help:"New calendar name"`
+	Description string                    `name:"description" help:"New description (empty to clear)"`
+	Location    string                    `name:"location" help:"New location (empty to clear)"`
+	TimeZone    string                    `name:"timezone" help:"New IANA timezone"`
+	Settings    CalendarListSettingsFlags `embed:""`
+}
+
+func (c *CalendarCalendarsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+
+	patch := &calendar.Calendar{}
+	changed := false
+	if flagProvided(kctx, "summary") {
+		if strings.TrimSpace(c.Summary) == "" {
+			return usage("--summary cannot be empty")
+		}
+		patch.Summary = strings.TrimSpace(c.Summary)
+		changed = true
+	}
+	if flagProvided(kctx, "description") {
+		patch.Description = c.Description
+		patch.ForceSendFields = append(patch.ForceSendFields, "Description")
+		changed = true
+	}
+	if flagProvided(kctx, "location") {
+		patch.Location = c.Location
+		patch.ForceSendFields = append(patch.ForceSendFields, "Location")
+		changed = true
+	}
+	if flagProvided(kctx, "timezone") {
+		if _, err := time.LoadLocation(strings.TrimSpace(c.TimeZone)); err != nil || strings.TrimSpace(c.TimeZone) == "" {
+			return usagef("invalid --timezone %q", c.TimeZone)
+		}
+		patch.TimeZone = strings.TrimSpace(c.TimeZone)
+		changed = true
+	}
+	entry, rgb, hasSettings, err := c.Settings.entry()
+	if err != nil {
+		return err
+	}
+	if !changed && !hasSettings {
+		return usage("no updates provided")
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	var cal *calendar.Calendar
+	if changed {
+		if cal, err = svc.Calendars.Patch(calendarID, patch).Context(ctx).Do(); err != nil {
+			return err
+		}
+	} else if cal, err = svc.Calendars.Get(calendarID).Context(ctx).Do(); err != nil {
+		return err
+	}
+	var listEntry *calendar.CalendarListEntry
+	if hasSettings {
+		if listEntry, err = svc.CalendarList.Patch(calendarID, entry).ColorRgbFormat(rgb).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+	return printManagedCalendar(ctx, cal, listEntry)
+}
+
+type CalendarCalendarsDeleteCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Secondary calendar ID"`
+}
+
+func (c *CalendarCalendarsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	if calendarID == "primary" || strings.EqualFold(calendarID, account) {
+		return usage("the primary calendar cannot be deleted")
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete calendar %s and all its events", calendarID)); err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if err := svc.Calendars.Delete(calendarID).Context(ctx).Do(); err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"deleted":    true,
+			"calendarId": calendarID,
+		})
+	}
+	u.Out().Printf("deleted\ttrue")
+	u.Out().Printf("calendarId\t%s", calendarID)
+	return nil
+}
+
+type CalendarCalendarsSubscribeCmd struct {
+	CalendarID string                    `arg:"" name:"calendarId" help:"Calendar ID to add (e.g. a colleague's email or a shared calendar ID)"`
+	Settings   CalendarListSettingsFlags `embed:""`
+}
+
+func (c *CalendarCalendarsSubscribeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	entry, rgb, _, err := c.Settings.entry()
+	if err != nil {
+		return err
+	}
+	entry.Id = calendarID
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	added, err := svc.CalendarList.Insert(entry).ColorRgbFormat(rgb).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"calendarListEntry": added})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("id\t%s", added.Id)
+	u.Out().Printf("summary\t%s", added.Summary)
+	u.Out().Printf("role\t%s", added.AccessRole)
+	return nil
+}
+
+type CalendarCalendarsUnsubscribeCmd struct {
+	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID to remove from your list"`
+}
+
+func (c *CalendarCalendarsUnsubscribeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty calendarId")
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if err := svc.CalendarList.Delete(calendarID).Context(ctx).Do(); err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"unsubscribed": true,
+			"calendarId":   calendarID,
+		})
+	}
+	u.Out().Printf("unsubscribed\ttrue")
+	u.Out().Printf("calendarId\t%s", calendarID)
+	return nil
+}
+
+func printManagedCalendar(ctx context.Context, cal *calendar.Calendar, entry *calendar.CalendarListEntry) error {
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{"calendar": cal}
+		if entry != nil {
+			out["calendarListEntry"] = entry
+		}
+		return outfmt.WriteJSON(os.Stdout, out)
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("id\t%s", cal.Id)
+	u.Out().Printf("summary\t%s", cal.Summary)
+	u.Out().Printf("timezone\t%s", cal.TimeZone)
+	if entry != nil {
+		if entry.BackgroundColor != "" {
+			u.Out().Printf("color\t%s", entry.BackgroundColor)
+		}
+		u.Out().Printf("hidden\t%t", entry.Hidden)
+		u.Out().Printf("selected\t%t", entry.Selected)
+	}
+	return nil
+}
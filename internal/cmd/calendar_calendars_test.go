@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func newCalendarManageTestService(t *testing.T) *[]recordedRequest {
+	t.Helper()
+	var reqs []recordedRequest
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordRequest(&reqs, r)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(r.URL.Path, "/calendars/cal1/acl"):
+			rule := map[string]any{"id": "user:bob@example.com", "role": "reader", "scope": map[string]any{"type": "user", "value": "bob@example.com"}}
+			if role, ok := req.Body["role"]; ok {
+				rule["role"] = role
+			}
+			_ = json.NewEncoder(w).Encode(rule)
+		case r.URL.Path == "/calendars" || strings.HasPrefix(r.URL.Path, "/calendars/"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "new@group.calendar.google.com", "summary": "Project X", "timeZone": "UTC"})
+		case strings.HasPrefix(r.URL.Path, "/users/me/calendarList"):
+			entry := map[string]any{"id": "new@group.calendar.google.com", "summary": "Project X", "accessRole": "owner"}
+			for k, v := range req.Body {
+				entry[k] = v
+			}
+			_ = json.NewEncoder(w).Encode(entry)
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+	return &reqs
+}
+
+func TestExecute_CalendarAclManage(t *testing.T) {
+	reqs := newCalendarManageTestService(t)
+	run := func(args ...string) string {
+		t.Helper()
+		return captureStdout(t, func() {
+			if err := Execute(append([]string{"--json", "--force", "--account", "a@b.com", "calendar", "acl"}, args...)); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+		})
+	}
+
+	run("add", "cal1", "bob@example.com", "--role", "reader", "--no-notify")
+	run("add", "cal1", "--type", "domain", "example.com", "--role", "freeBusyReader")
+	out := run("update", "cal1", "bob@example.com", "--role", "writer")
+	if !strings.Contains(out, `"role": "writer"`) {
+		t.Fatalf("unexpected update output: %s", out)
+	}
+	run("remove", "cal1", "default")
+
+	got := *reqs
+	if len(got) != 4 {
+		t.Fatalf("expected 4 requests, got %+v", got)
+	}
+	if got[0].Method != http.MethodPost || got[0].Query != "alt=json&prettyPrint=false&sendNotifications=false" ||
+		got[0].Body["scope"].(map[string]any)["value"] != "bob@example.com" {
+		t.Fatalf("unexpected add: %+v", got[0])
+	}
+	if scope := got[1].Body["scope"].(map[string]any); scope["type"] != "domain" || got[1].Body["role"] != "freeBusyReader" {
+		t.Fatalf("unexpected domain add: %+v", got[1])
+	}
+	if got[2].Method != http.MethodPatch || got[2].Path != "/calendars/cal1/acl/user:bob@example.com" {
+		t.Fatalf("unexpected update: %+v", got[2])
+	}
+	if got[3].Method != http.MethodDelete || got[3].Path != "/calendars/cal1/acl/default" {
+		t.Fatalf("unexpected remove: %+v", got[3])
+	}
+
+	if err := Execute([]string{"--account", "a@b.com", "calendar", "acl", "add", "cal1", "--type", "default", "x", "--role", "reader"}); err == nil {
+		t.Fatalf("expected usage error for --type default with a scope")
+	}
+}
+
+func TestExecute_CalendarCalendarsManage(t *testing.T) {
+	reqs := newCalendarManageTestService(t)
+	run := func(args ...string) {
+		t.Helper()
+		_ = captureStdout(t, func() {
+			if err := Execute(append([]string{"--json", "--force", "--account", "a@b.com", "calendar", "calendars"}, args...)); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+		})
+	}
+
+	run("create", "Project X", "--description", "Launch", "--calendar-color", "7", "--hidden", "--reminder", "popup:15m")
+	run("update", "new@group.calendar.google.com", "--description", "", "--background-color", "#AABBCC", "--foreground-color", "#000000", "--selected=false")
+	run("subscribe", "team@example.com", "--summary-override", "Team")
+	run("unsubscribe", "team@example.com")
+	run("delete", "new@group.calendar.google.com")
+
+	var summary []string
+	for _, r := range *reqs {
+		if strings.Contains(r.Path, "calendarList/primary") {
+			continue
+		}
+		summary = append(summary, r.Method+" "+r.Path)
+	}
+	want := []string{
+		"POST /calendars",
+		"PATCH /users/me/calendarList/new@group.calendar.google.com",
+		"PATCH /calendars/new@group.calendar.google.com",
+		"PATCH /users/me/calendarList/new@group.calendar.google.com",
+		"POST /users/me/calendarList",
+		"DELETE /users/me/calendarList/team@example.com",
+		"DELETE /calendars/new@group.calendar.google.com",
+	}
+	if strings.Join(summary, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected requests:\n%s", strings.Join(summary, "\n"))
+	}
+
+	byIndex := func(i int) recordedRequest {
+		n := -1
+		for _, r := range *reqs {
+			if strings.Contains(r.Path, "calendarList/primary") {
+				continue
+			}
+			if n++; n == i {
+				return r
+			}
+		}
+		return recordedRequest{}
+	}
+	if created := byIndex(0); created.Body["timeZone"] != "UTC" || created.Body["description"] != "Launch" {
+		t.Fatalf("unexpected create body: %+v", created.Body)
+	}
+	settings := byIndex(1)
+	if settings.Body["colorId"] != "7" || settings.Body["hidden"] != true {
+		t.Fatalf("unexpected list settings: %+v", settings.Body)
+	}
+	if reminders, _ := settings.Body["defaultReminders"].([]any); len(reminders) != 1 {
+		t.Fatalf("unexpected reminders: %+v", settings.Body)
+	}
+	if update := byIndex(2); update.Body["description"] != "" {
+		t.Fatalf("expected description to be cleared: %+v", update.Body)
+	}
+	if colors := byIndex(3); !strings.Contains(colors.Query, "colorRgbFormat=true") || colors.Body["backgroundColor"] != "#aabbcc" || colors.Body["selected"] != false {
+		t.Fatalf("unexpected color patch: %+v", colors)
+	}
+	if sub := byIndex(4); sub.Body["id"] != "team@example.com" || sub.Body["summaryOverride"] != "Team" {
+		t.Fatalf("unexpected subscribe body: %+v", sub.Body)
+	}
+
+	if err := Execute([]string{"--force", "--account", "a@b.com", "calendar", "calendars", "delete", "primary"}); err == nil {
+		t.Fatalf("expected primary delete to be rejected")
+	}
+}
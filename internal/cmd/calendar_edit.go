@@ -16,8 +16,8 @@ import (
 type CalendarCreateCmd struct {
 	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
 	Summary               string   `name:"summary" help:"Event summary/title"`
-	From                  string   `name:"from" help:"Start time (RFC3339)"`
-	To                    string   `name:"to" help:"End time (RFC3339)"`
+	From                  string   `name:"from" help:"Start time (RFC3339, date, or natural: tomorrow 3pm, next tuesday 10:00, in 2 hours)"`
+	To                    string   `name:"to" help:"End time (RFC3339, date, or natural; +45m counts from --from)"`
 	Description           string   `name:"description" help:"Description"`
 	Location              string   `name:"location" help:"Location"`
 	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails"`
@@ -49,6 +49,8 @@ type CalendarCreateCmd struct {
 	WorkingFloorId        string   `name:"working-floor-id" help:"Working location floor ID"`
 	WorkingDeskId         string   `name:"working-desk-id" help:"Working location desk ID"`
 	WorkingCustomLabel    string   `name:"working-custom-label" help:"Working location custom label"`
+	Room                  string   `name:"room" help:"Meeting room email, or 'auto' to book the smallest free room matching --building/--floor/--capacity/--feature"`
+	RoomFilterFlags
 }
 
 func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -101,6 +103,9 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 	transparency = applyEventTypeTransparencyDefault(transparency, eventType)
+	if err = c.validateRoom(allDay); err != nil {
+		return err
+	}
 
 	svc, err := newCalendarService(ctx, account)
 	if err != nil {
@@ -123,6 +128,9 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		Attachments:        buildAttachments(c.Attachments),
 		ExtendedProperties: buildExtendedProperties(c.PrivateProps, c.SharedProps),
 	}
+	if err = resolveEventTimes(ctx, svc, calendarID, event.Start, event.End); err != nil {
+		return err
+	}
 	if c.GuestsCanInviteOthers != nil {
 		event.GuestsCanInviteOthers = c.GuestsCanInviteOthers
 	}
@@ -142,6 +150,15 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err = c.applyCreateEventType(event, eventType); err != nil {
 		return err
 	}
+	if strings.TrimSpace(c.Room) != "" {
+		room, roomErr := resolveRoom(ctx, svc, account, c.Room, c.RoomFilterFlags, func() (findTimeInterval, error) {
+			return eventWindow(event)
+		})
+		if roomErr != nil {
+			return roomErr
+		}
+		addRoomAttendee(event, room)
+	}
 
 	call := svc.Events.Insert(calendarID, event)
 	if sendUpdates != "" {
@@ -165,6 +182,20 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (c *CalendarCreateCmd) validateRoom(allDay bool) error {
+	room := strings.TrimSpace(c.Room)
+	if room == "" {
+		if c.RoomFilterFlags.active() {
+			return usage("--building, --floor, --capacity and --feature require --room auto")
+		}
+		return nil
+	}
+	if allDay && strings.EqualFold(room, roomAuto) {
+		return usage("--room auto cannot be used with all-day events")
+	}
+	return validateRoomFlags(room, c.RoomFilterFlags)
+}
+
 func (c *CalendarCreateCmd) resolveCreateEventType() (string, error) {
 	focusFlags := strings.TrimSpace(c.FocusAutoDecline) != "" ||
 		strings.TrimSpace(c.FocusDeclineMessage) != "" ||
@@ -298,8 +329,8 @@ type CalendarUpdateCmd struct {
 	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
 	EventID               string   `arg:"" name:"eventId" help:"Event ID"`
 	Summary               string   `name:"summary" help:"New summary/title (set empty to clear)"`
-	From                  string   `name:"from" help:"New start time (RFC3339; set empty to clear)"`
-	To                    string   `name:"to" help:"New end time (RFC3339; set empty to clear)"`
+	From                  string   `name:"from" help:"New start time (RFC3339, date, or natural: tomorrow 3pm, in 2 hours; set empty to clear)"`
+	To                    string   `name:"to" help:"New end time (RFC3339, date, or natural; +45m counts from --from, or from the current start; set empty to clear)"`
 	Description           string   `name:"description" help:"New description (set empty to clear)"`
 	Location              string   `name:"location" help:"New location (set empty to clear)"`
 	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails (replaces all; set empty to clear)"`
@@ -395,13 +426,39 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *
 		return err
 	}
 
+	var existing *calendar.Event
+	fetchExisting := func() (*calendar.Event, error) {
+		if existing != nil {
+			return existing, nil
+		}
+		ev, getErr := svc.Events.Get(calendarID, eventID).Context(ctx).Do()
+		if getErr != nil {
+			return nil, fmt.Errorf("failed to fetch current event: %w", getErr)
+		}
+		existing = ev
+		return ev, nil
+	}
+
+	// A "+45m" --to without --from counts from the event's current start.
+	start := patch.Start
+	if start == nil && patch.End != nil && strings.HasPrefix(eventDateTimeValue(patch.End), "+") {
+		ev, fetchErr := fetchExisting()
+		if fetchErr != nil {
+			return fetchErr
+		}
+		start = ev.Start
+	}
+	if err = resolveEventTimes(ctx, svc, calendarID, start, patch.End); err != nil {
+		return err
+	}
+
 	// For --add-attendee, fetch current event to preserve existing attendees with metadata.
 	if wantsAddAttendee {
-		existing, getErr := svc.Events.Get(calendarID, eventID).Context(ctx).Do()
-		if getErr != nil {
-			return fmt.Errorf("failed to fetch current event: %w", getErr)
+		ev, fetchErr := fetchExisting()
+		if fetchErr != nil {
+			return fetchErr
 		}
-		patch.Attendees = mergeAttendees(existing.Attendees, c.AddAttendee)
+		patch.Attendees = mergeAttendees(ev.Attendees, c.AddAttendee)
 		changed = true
 	}
 
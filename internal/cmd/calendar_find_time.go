@@ -0,0 +1,563 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// freeBusyMaxItems is the Calendar API's limit on calendars per
+// freebusy query.
+const freeBusyMaxItems = 50
+
+// findTimeBackToBack is the gap to a neighbouring meeting below which a
+// slot counts as back-to-back and ranks lower.
+const findTimeBackToBack = 15 * time.Minute
+
+type CalendarFindTimeCmd struct {
+	Attendees    string   `name:"attendees" required:"" help:"Comma-separated attendee emails; Google Groups are expanded to their members"`
+	Duration     string   `name:"duration" help:"Meeting length (e.g. 30m, 45m, 1h30m)" default:"30m"`
+	Within       string   `name:"within" help:"Search window: today, tomorrow, this week, next week, 'next N days', a day, or FROM..TO" default:"next 7 days"`
+	WorkingHours string   `name:"working-hours" help:"Working hours (HH:MM-HH:MM) applied in each attendee's timezone" default:"09:00-17:00"`
+	Days         []string `name:"days" help:"Weekdays to consider" default:"mon,tue,wed,thu,fri"`
+	AttendeeTZ   []string `name:"attendee-tz" help:"Override an attendee's timezone (email=Area/City, repeatable)"`
+	Step         string   `name:"step" help:"Granularity of candidate start times" default:"15m"`
+	MinAvailable int      `name:"min-available" help:"Also propose slots where at least N attendees are free (default: everyone)"`
+	Max          int      `name:"max" help:"Max slots to show" default:"10"`
+	Book         bool     `name:"book" help:"Create the event in the best slot where everyone is free"`
+	CalendarID   string   `name:"calendar" help:"Calendar to book into" default:"primary"`
+	Summary      string   `name:"summary" help:"Event title when booking"`
+	Description  string   `name:"description" help:"Event description when booking"`
+	Location     string   `name:"location" help:"Event location when booking"`
+	WithMeet     bool     `name:"with-meet" help:"Add a Google Meet link when booking"`
+	SendUpdates  string   `name:"send-updates" help:"Notification mode when booking: all, externalOnly, none (default: all)"`
+}
+
+type findTimeInterval struct {
+	Start time.Time
+	End   time.Time
+}
+
+type findTimeAttendee struct {
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+	Group    string `json:"group,omitempty"`
+	Error    string `json:"error,omitempty"`
+	loc      *time.Location
+	busy     []findTimeInterval
+}
+
+type findTimeSlot struct {
+	Start       string   `json:"start"`
+	End         string   `json:"end"`
+	Available   int      `json:"available"`
+	Total       int      `json:"total"`
+	Unavailable []string `json:"unavailable,omitempty"`
+	Unknown     []string `json:"unknown,omitempty"`
+	BackToBack  bool     `json:"backToBack,omitempty"`
+	start       time.Time
+	end         time.Time
+}
+
+type findTimeOptions struct {
+	Window       findTimeInterval
+	Duration     time.Duration
+	Step         time.Duration
+	WorkStart    time.Duration
+	WorkEnd      time.Duration
+	Days         map[time.Weekday]bool
+	MinAvailable int
+	Max          int
+}
+
+func (c *CalendarFindTimeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	invitees := splitCSV(strings.ToLower(c.Attendees))
+	if len(invitees) == 0 {
+		return usage("no attendees provided")
+	}
+	opts, err := c.options()
+	if err != nil {
+		return err
+	}
+	overrides, err := parseFindTimeTZOverrides(c.AttendeeTZ)
+	if err != nil {
+		return err
+	}
+	if c.Book && strings.TrimSpace(c.Summary) == "" {
+		return usage("--book requires --summary")
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	loc, err := getUserTimezone(ctx, svc)
+	if err != nil {
+		return err
+	}
+	window, err := resolveFindTimeWindow(c.Within, time.Now().In(loc), loc)
+	if err != nil {
+		return err
+	}
+	opts.Window = window
+
+	attendees, err := collectFindTimeAttendees(ctx, svc, account, invitees, window, loc, overrides, flags)
+	if err != nil {
+		return err
+	}
+	for _, a := range attendees {
+		if a.Error != "" {
+			u.Err().Printf("Warning: %s: %s (treated as free)", a.Email, a.Error)
+		}
+	}
+	slots := rankFindTimeSlots(attendees, opts)
+
+	if c.Book {
+		if len(slots) == 0 || slots[0].Available < slots[0].Total {
+			return fmt.Errorf("no slot where all %d attendees are free in %s", len(attendees), formatFindTimeWindow(window, loc))
+		}
+		best := slots[0]
+		u.Err().Printf("Booking %s - %s", best.start.In(loc).Format("Mon Jan 2 15:04"), best.end.In(loc).Format("15:04 MST"))
+		var guests []string
+		for _, email := range invitees {
+			if !strings.EqualFold(email, account) {
+				guests = append(guests, email)
+			}
+		}
+		create := &CalendarCreateCmd{
+			CalendarID:  c.CalendarID,
+			Summary:     c.Summary,
+			From:        best.Start,
+			To:          best.End,
+			Description: c.Description,
+			Location:    c.Location,
+			Attendees:   strings.Join(guests, ","),
+			WithMeet:    c.WithMeet,
+			SendUpdates: c.SendUpdates,
+		}
+		return create.Run(ctx, flags)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"timeMin":   window.Start.Format(time.RFC3339),
+			"timeMax":   window.End.Format(time.RFC3339),
+			"timezone":  loc.String(),
+			"duration":  opts.Duration.String(),
+			"attendees": attendees,
+			"slots":     slots,
+		})
+	}
+	if len(slots) == 0 {
+		u.Err().Printf("No free %s slot for %d attendees in %s", opts.Duration, len(attendees), formatFindTimeWindow(window, loc))
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "RANK\tSTART\tEND\tAVAILABLE\tNOTES")
+	for i, s := range slots {
+		var notes []string
+		if len(s.Unavailable) > 0 {
+			notes = append(notes, "busy: "+strings.Join(s.Unavailable, ", "))
+		}
+		if s.BackToBack {
+			notes = append(notes, "back-to-back")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d/%d\t%s\n", i+1,
+			s.start.In(loc).Format("Mon Jan 02 15:04"),
+			s.end.In(loc).Format("15:04"),
+			s.Available, s.Total,
+			sanitizeTab(orDash(strings.Join(notes, "; "))),
+		)
+	}
+	return nil
+}
+
+func (c *CalendarFindTimeCmd) options() (findTimeOptions, error) {
+	opts := findTimeOptions{Days: map[time.Weekday]bool{}, MinAvailable: c.MinAvailable, Max: c.Max}
+	var err error
+	if opts.Duration, err = time.ParseDuration(strings.TrimSpace(c.Duration)); err != nil || opts.Duration <= 0 {
+		return opts, usagef("invalid --duration %q (e.g. 30m, 1h)", c.Duration)
+	}
+	if opts.Step, err = time.ParseDuration(strings.TrimSpace(c.Step)); err != nil || opts.Step <= 0 {
+		return opts, usagef("invalid --step %q (e.g. 15m)", c.Step)
+	}
+	if opts.WorkStart, opts.WorkEnd, err = parseWorkingHours(c.WorkingHours); err != nil {
+		return opts, err
+	}
+	for _, d := range c.Days {
+		wd, ok := parseWeekStart(d)
+		if !ok {
+			return opts, usagef("invalid --days value %q (use mon, tue, ...)", d)
+		}
+		opts.Days[wd] = true
+	}
+	if opts.MinAvailable < 0 {
+		return opts, usage("--min-available must be >= 0")
+	}
+	return opts, nil
+}
+
+// parseWorkingHours parses "HH:MM-HH:MM" into offsets from midnight.
+func parseWorkingHours(s string) (time.Duration, time.Duration, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	parse := func(v string) (time.Duration, bool) {
+		t, err := time.Parse("15:04", strings.TrimSpace(v))
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+	}
+	start, okStart := parse(from)
+	end, okEnd := parse(to)
+	if !ok || !okStart || !okEnd || end <= start {
+		return 0, 0, usagef("invalid --working-hours %q (expected HH:MM-HH:MM)", s)
+	}
+	return start, end, nil
+}
+
+func parseFindTimeTZOverrides(values []string) (map[string]*time.Location, error) {
+	out := map[string]*time.Location{}
+	for _, v := range values {
+		email, zone, ok := strings.Cut(v, "=")
+		if !ok {
+			return nil, usagef("invalid --attendee-tz %q (expected email=Area/City)", v)
+		}
+		loc, err := time.LoadLocation(strings.TrimSpace(zone))
+		if err != nil {
+			return nil, usagef("invalid timezone in --attendee-tz %q: %v", v, err)
+		}
+		out[strings.ToLower(strings.TrimSpace(email))] = loc
+	}
+	return out, nil
+}
+
+var findTimeNextDaysRe = regexp.MustCompile(`^(?:next\s+)?(\d+)\s*(?:d|days?)$`)
+
+// resolveFindTimeWindow turns --within into an absolute window that never
+// starts in the past.
+func resolveFindTimeWindow(expr string, now time.Time, loc *time.Location) (findTimeInterval, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	var w findTimeInterval
+	switch {
+	case expr == "" || expr == "week":
+		w = findTimeInterval{now, endOfDay(now.AddDate(0, 0, 6))}
+	case expr == "today":
+		w = findTimeInterval{now, endOfDay(now)}
+	case expr == "this week":
+		w = findTimeInterval{now, endOfWeek(now, time.Monday)}
+	case expr == "next week":
+		next := now.AddDate(0, 0, 7)
+		w = findTimeInterval{startOfWeek(next, time.Monday), endOfWeek(next, time.Monday)}
+	case findTimeNextDaysRe.MatchString(expr):
+		n, _ := strconv.Atoi(findTimeNextDaysRe.FindStringSubmatch(expr)[1])
+		if n < 1 {
+			return w, usagef("invalid --within %q", expr)
+		}
+		w = findTimeInterval{now, endOfDay(now.AddDate(0, 0, n-1))}
+	case strings.Contains(expr, ".."):
+		fromExpr, toExpr, _ := strings.Cut(expr, "..")
+		from, err := parseTimeExpr(fromExpr, now, loc)
+		if err != nil {
+			return w, fmt.Errorf("invalid --within start: %w", err)
+		}
+		to, err := parseTimeExpr(toExpr, now, loc)
+		if err != nil {
+			return w, fmt.Errorf("invalid --within end: %w", err)
+		}
+		if to.Equal(startOfDay(to)) {
+			// A bare date includes that whole day.
+			to = endOfDay(to)
+		}
+		w = findTimeInterval{from, to}
+	default:
+		day, err := parseTimeExpr(expr, now, loc)
+		if err != nil {
+			return w, fmt.Errorf("invalid --within: %w", err)
+		}
+		w = findTimeInterval{startOfDay(day), endOfDay(day)}
+	}
+	if w.Start.Before(now) {
+		w.Start = now
+	}
+	if !w.End.After(w.Start) {
+		return w, usagef("--within %q is in the past", expr)
+	}
+	return w, nil
+}
+
+func formatFindTimeWindow(w findTimeInterval, loc *time.Location) string {
+	return (&TimeRange{From: w.Start, To: w.End, Location: loc}).FormatHuman()
+}
+
+// collectFindTimeAttendees queries free/busy for the organizer and every
+// invitee. Entries the API reports as groups are expanded to their members
+// (via Cloud Identity, falling back to the API's own expansion), and each
+// person's timezone is read from their calendar.
+func collectFindTimeAttendees(ctx context.Context, svc *calendar.Service, account string, invitees []string, window findTimeInterval, loc *time.Location, overrides map[string]*time.Location, flags *RootFlags) ([]*findTimeAttendee, error) {
+	ids := append([]string{strings.ToLower(account)}, invitees...)
+	resp, err := queryFreeBusy(ctx, svc, ids, window)
+	if err != nil {
+		return nil, err
+	}
+
+	people := []string{}
+	groupOf := map[string]string{}
+	seen := map[string]bool{}
+	add := func(email, group string) {
+		if email == "" || seen[email] {
+			return
+		}
+		seen[email] = true
+		people = append(people, email)
+		if group != "" {
+			groupOf[email] = group
+		}
+	}
+	for _, id := range ids {
+		g, isGroup := resp.groups[id]
+		if !isGroup {
+			add(id, "")
+			continue
+		}
+		members, expandErr := expandFindTimeGroup(ctx, account, id)
+		if expandErr != nil {
+			members = g
+		}
+		for _, m := range members {
+			add(strings.ToLower(m), id)
+		}
+	}
+
+	var missing []string
+	for _, p := range people {
+		if _, ok := resp.busy[p]; !ok {
+			if _, ok := resp.errs[p]; !ok {
+				missing = append(missing, p)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		more, err := queryFreeBusy(ctx, svc, missing, window)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range more.busy {
+			resp.busy[k] = v
+		}
+		for k, v := range more.errs {
+			resp.errs[k] = v
+		}
+	}
+
+	attendees := make([]*findTimeAttendee, len(people))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, 10)
+	for i, email := range people {
+		a := &findTimeAttendee{Email: email, Group: groupOf[email], Error: resp.errs[email], busy: resp.busy[email]}
+		attendees[i] = a
+		if o, ok := overrides[email]; ok {
+			a.loc = o
+			continue
+		}
+		if i == 0 {
+			a.loc = loc
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			a.loc = loc
+			cal, err := svc.Calendars.Get(a.Email).Context(ctx).Do()
+			if err != nil || cal.TimeZone == "" {
+				return
+			}
+			if l, err := time.LoadLocation(cal.TimeZone); err == nil {
+				a.loc = l
+			}
+		}()
+	}
+	wg.Wait()
+	for _, a := range attendees {
+		a.Timezone = a.loc.String()
+	}
+	return attendees, nil
+}
+
+func expandFindTimeGroup(ctx context.Context, account, group string) ([]string, error) {
+	cloudSvc, err := newCloudIdentityService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return collectGroupMemberEmails(ctx, cloudSvc, group)
+}
+
+type findTimeFreeBusy struct {
+	busy   map[string][]findTimeInterval
+	errs   map[string]string
+	groups map[string][]string
+}
+
+func queryFreeBusy(ctx context.Context, svc *calendar.Service, ids []string, window findTimeInterval) (*findTimeFreeBusy, error) {
+	out := &findTimeFreeBusy{busy: map[string][]findTimeInterval{}, errs: map[string]string{}, groups: map[string][]string{}}
+	for start := 0; start < len(ids); start += freeBusyMaxItems {
+		end := min(start+freeBusyMaxItems, len(ids))
+		req := &calendar.FreeBusyRequest{
+			TimeMin: window.Start.Format(time.RFC3339),
+			TimeMax: window.End.Format(time.RFC3339),
+		}
+		for _, id := range ids[start:end] {
+			req.Items = append(req.Items, &calendar.FreeBusyRequestItem{Id: id})
+		}
+		resp, err := svc.Freebusy.Query(req).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("freebusy query: %w", err)
+		}
+		for id, g := range resp.Groups {
+			out.groups[strings.ToLower(id)] = g.Calendars
+		}
+		for id, cal := range resp.Calendars {
+			id = strings.ToLower(id)
+			if _, isGroup := out.groups[id]; isGroup {
+				continue
+			}
+			if len(cal.Errors) > 0 {
+				out.errs[id] = cal.Errors[0].Reason
+				continue
+			}
+			var busy []findTimeInterval
+			for _, b := range cal.Busy {
+				s, errS := time.Parse(time.RFC3339, b.Start)
+				e, errE := time.Parse(time.RFC3339, b.End)
+				if errS == nil && errE == nil {
+					busy = append(busy, findTimeInterval{s, e})
+				}
+			}
+			out.busy[id] = busy
+		}
+	}
+	return out, nil
+}
+
+// rankFindTimeSlots proposes non-overlapping slots ranked by how many
+// attendees can make it, then by avoiding back-to-back meetings, then by
+// earliest start. An attendee is available when the slot falls inside
+// their working hours (in their timezone) and overlaps none of their busy
+// blocks; attendees whose free/busy is unknown count as available.
+func rankFindTimeSlots(attendees []*findTimeAttendee, opts findTimeOptions) []findTimeSlot {
+	total := len(attendees)
+	need := total
+	if opts.MinAvailable > 0 && opts.MinAvailable < total {
+		need = opts.MinAvailable
+	}
+
+	start := opts.Window.Start.Truncate(opts.Step)
+	if start.Before(opts.Window.Start) {
+		start = start.Add(opts.Step)
+	}
+	var candidates []findTimeSlot
+	for s := start; !s.Add(opts.Duration).After(opts.Window.End); s = s.Add(opts.Step) {
+		e := s.Add(opts.Duration)
+		slot := findTimeSlot{start: s, end: e, Total: total}
+		for _, a := range attendees {
+			switch {
+			case !withinWorkingHours(s, e, a.loc, opts):
+				slot.Unavailable = append(slot.Unavailable, a.Email)
+			case a.Error != "":
+				slot.Unknown = append(slot.Unknown, a.Email)
+				slot.Available++
+			default:
+				free, gap := findTimeFreeGap(a.busy, s, e)
+				if !free {
+					slot.Unavailable = append(slot.Unavailable, a.Email)
+					continue
+				}
+				slot.Available++
+				if gap < findTimeBackToBack {
+					slot.BackToBack = true
+				}
+			}
+		}
+		if slot.Available >= need && slot.Available > 0 {
+			candidates = append(candidates, slot)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Available != b.Available {
+			return a.Available > b.Available
+		}
+		if a.BackToBack != b.BackToBack {
+			return !a.BackToBack
+		}
+		return a.start.Before(b.start)
+	})
+
+	var out []findTimeSlot
+	for _, c := range candidates {
+		if opts.Max > 0 && len(out) >= opts.Max {
+			break
+		}
+		overlaps := false
+		for _, o := range out {
+			if c.start.Before(o.end) && o.start.Before(c.end) {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		c.Start = c.start.Format(time.RFC3339)
+		c.End = c.end.Format(time.RFC3339)
+		out = append(out, c)
+	}
+	return out
+}
+
+func withinWorkingHours(s, e time.Time, loc *time.Location, opts findTimeOptions) bool {
+	local := s.In(loc)
+	if len(opts.Days) > 0 && !opts.Days[local.Weekday()] {
+		return false
+	}
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return !s.Before(midnight.Add(opts.WorkStart)) && !e.After(midnight.Add(opts.WorkEnd))
+}
+
+// findTimeFreeGap reports whether [s, e) is clear of busy blocks and the
+// smallest gap to an adjacent block (capped at one hour).
+func findTimeFreeGap(busy []findTimeInterval, s, e time.Time) (bool, time.Duration) {
+	gap := time.Hour
+	for _, b := range busy {
+		if b.Start.Before(e) && s.Before(b.End) {
+			return false, 0
+		}
+		if !b.End.After(s) {
+			gap = min(gap, s.Sub(b.End))
+		}
+		if !b.Start.Before(e) {
+			gap = min(gap, b.Start.Sub(e))
+		}
+	}
+	return true, gap
+}
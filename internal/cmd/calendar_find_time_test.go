@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/cloudidentity/v1"
+)
+
+func newFindTimeTestServices(t *testing.T, inserted *map[string]any) *int {
+	t.Helper()
+	stubGoogleService(t, &newCloudIdentityService, cloudidentity.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "groups:lookup"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "groups/abc123"})
+		case strings.Contains(r.URL.Path, "groups/abc123/memberships"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"memberships": []map[string]any{
+				{"preferredMemberKey": map[string]any{"id": "alice@example.com"}, "type": "USER"},
+				{"preferredMemberKey": map[string]any{"id": "bob@example.com"}, "type": "USER"},
+				{"preferredMemberKey": map[string]any{"id": "carol@example.com"}, "type": "USER"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	busy := map[string][]map[string]string{
+		"a@b.com":           {{"start": "2030-01-07T09:00:00Z", "end": "2030-01-07T10:00:00Z"}},
+		"alice@example.com": {{"start": "2030-01-07T10:00:00Z", "end": "2030-01-07T11:00:00Z"}},
+		"bob@example.com":   {},
+		"carol@example.com": {},
+	}
+	queries := 0
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/freeBusy" && r.Method == http.MethodPost:
+			queries++
+			var req calendar.FreeBusyRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			cals := map[string]any{}
+			groups := map[string]any{}
+			for _, item := range req.Items {
+				if item.Id == "eng@example.com" {
+					// The API's own expansion misses carol.
+					groups[item.Id] = map[string]any{"calendars": []string{"alice@example.com", "bob@example.com"}}
+					cals["alice@example.com"] = map[string]any{"busy": busy["alice@example.com"]}
+					continue
+				}
+				cals[item.Id] = map[string]any{"busy": busy[item.Id]}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"calendars": cals, "groups": groups})
+		case r.URL.Path == "/calendars/bob@example.com" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "bob@example.com", "timeZone": "Europe/Berlin"})
+		case r.URL.Path == "/calendars/primary/events" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, inserted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "ev1", "summary": "Sync"})
+		default:
+			http.NotFound(w, r)
+		}
+	})))
+	return &queries
+}
+
+func TestExecute_CalendarFindTime(t *testing.T) {
+	var inserted map[string]any
+	queries := newFindTimeTestServices(t, &inserted)
+	args := []string{
+		"--account", "a@b.com", "calendar", "find-time",
+		"--attendees", "bob@example.com,eng@example.com",
+		"--duration", "1h", "--step", "30m",
+		"--within", "2030-01-07..2030-01-07",
+		"--max", "3",
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute(append([]string{"--json"}, args...)); err != nil {
+			t.Fatalf("find-time: %v", err)
+		}
+	})
+	var parsed struct {
+		Attendees []findTimeAttendee `json:"attendees"`
+		Slots     []findTimeSlot     `json:"slots"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if *queries != 2 {
+		t.Fatalf("expected a follow-up freebusy query for carol, got %d queries", *queries)
+	}
+	tz := map[string]string{}
+	for _, a := range parsed.Attendees {
+		tz[a.Email] = a.Timezone
+	}
+	if len(parsed.Attendees) != 4 || tz["bob@example.com"] != "Europe/Berlin" || tz["carol@example.com"] != "UTC" {
+		t.Fatalf("unexpected attendees: %+v", parsed.Attendees)
+	}
+	// Bob's working day ends at 16:00 UTC, the organizer is busy 9-10 and
+	// alice 10-11, so 11:00 would be back-to-back for alice.
+	var starts []string
+	for _, s := range parsed.Slots {
+		if s.Available != 4 || s.Total != 4 || s.BackToBack {
+			t.Fatalf("unexpected slot: %+v", s)
+		}
+		starts = append(starts, s.Start)
+	}
+	if strings.Join(starts, ",") != "2030-01-07T11:30:00Z,2030-01-07T12:30:00Z,2030-01-07T13:30:00Z" {
+		t.Fatalf("unexpected slots: %v", starts)
+	}
+
+	_ = captureStderr(t, func() {
+		_ = captureStdout(t, func() {
+			if err := Execute(append(args, "--book", "--summary", "Sync")); err != nil {
+				t.Fatalf("book: %v", err)
+			}
+		})
+	})
+	start, _ := inserted["start"].(map[string]any)
+	if start["dateTime"] != "2030-01-07T11:30:00Z" || inserted["summary"] != "Sync" {
+		t.Fatalf("unexpected inserted event: %v", inserted)
+	}
+	var guests []string
+	attendees, _ := inserted["attendees"].([]any)
+	for _, a := range attendees {
+		guests = append(guests, a.(map[string]any)["email"].(string))
+	}
+	if strings.Join(guests, ",") != "bob@example.com,eng@example.com" {
+		t.Fatalf("unexpected guests: %v", guests)
+	}
+}
+
+func TestResolveFindTimeWindow(t *testing.T) {
+	now := time.Date(2026, 1, 7, 13, 0, 0, 0, time.UTC) // Wednesday
+	for expr, want := range map[string][2]string{
+		"today":                  {"2026-01-07T13:00:00Z", "2026-01-07T23:59:59Z"},
+		"next week":              {"2026-01-12T00:00:00Z", "2026-01-18T23:59:59Z"},
+		"next 3 days":            {"2026-01-07T13:00:00Z", "2026-01-09T23:59:59Z"},
+		"tomorrow":               {"2026-01-08T00:00:00Z", "2026-01-08T23:59:59Z"},
+		"2026-01-05..2026-01-08": {"2026-01-07T13:00:00Z", "2026-01-08T23:59:59Z"},
+	} {
+		w, err := resolveFindTimeWindow(expr, now, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: %v", expr, err)
+		}
+		if got := [2]string{w.Start.Format(time.RFC3339), w.End.Format(time.RFC3339)}; got != want {
+			t.Fatalf("%s: got %v, want %v", expr, got, want)
+		}
+	}
+	if _, err := resolveFindTimeWindow("2025-01-01", now, time.UTC); err == nil {
+		t.Fatalf("expected error for a window in the past")
+	}
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Minimal RFC 5545 (iCalendar) reading and writing: content lines with
+// folding, parameters and text escaping, components, and the date/time and
+// duration value types needed to move events between Google Calendar and
+// .ics files.
+
+const (
+	icsDateLayout     = "20060102"
+	icsLocalLayout    = "20060102T150405"
+	icsUTCLayout      = "20060102T150405Z"
+	icsMaxLineOctets  = 75
+	icsCalendarProdID = "-//gogcli//Google Calendar export//EN"
+)
+
+type icsProp struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+func (p icsProp) param(name string) string {
+	return p.Params[strings.ToUpper(name)]
+}
+
+// line renders the property back into a content line (unfolded).
+func (p icsProp) line() string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+	keys := make([]string, 0, len(p.Params))
+	for k := range p.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(";" + k + "=" + quoteICSParam(p.Params[k]))
+	}
+	b.WriteString(":" + p.Value)
+	return b.String()
+}
+
+type icsComponent struct {
+	Name     string
+	Props    []icsProp
+	Children []*icsComponent
+}
+
+func (c *icsComponent) get(name string) (icsProp, bool) {
+	for _, p := range c.Props {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return icsProp{}, false
+}
+
+func (c *icsComponent) value(name string) string {
+	p, _ := c.get(name)
+	return p.Value
+}
+
+func (c *icsComponent) all(name string) []icsProp {
+	var out []icsProp
+	for _, p := range c.Props {
+		if p.Name == name {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (c *icsComponent) children(name string) []*icsComponent {
+	var out []*icsComponent
+	for _, ch := range c.Children {
+		if ch.Name == name {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+// parseICS reads an iCalendar stream and returns a root component whose
+// children are the top-level components (normally one VCALENDAR).
+func parseICS(r io.Reader) (*icsComponent, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ics: %w", err)
+	}
+
+	root := &icsComponent{}
+	stack := []*icsComponent{root}
+	for i, line := range lines {
+		p, err := parseICSLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("ics line %d: %w", i+1, err)
+		}
+		cur := stack[len(stack)-1]
+		switch p.Name {
+		case "BEGIN":
+			child := &icsComponent{Name: strings.ToUpper(p.Value)}
+			cur.Children = append(cur.Children, child)
+			stack = append(stack, child)
+		case "END":
+			if len(stack) == 1 || cur.Name != strings.ToUpper(p.Value) {
+				return nil, fmt.Errorf("ics line %d: unexpected END:%s", i+1, p.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			cur.Props = append(cur.Props, p)
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("ics: missing END:%s", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+func parseICSLine(line string) (icsProp, error) {
+	p := icsProp{Params: map[string]string{}}
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return p, fmt.Errorf("malformed content line %q", truncate(line, 40))
+	}
+	p.Name = strings.ToUpper(line[:end])
+	rest := line[end:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			return p, fmt.Errorf("malformed parameter in %q", truncate(line, 40))
+		}
+		key := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+		var val strings.Builder
+		inQuotes := false
+		i := 0
+		for ; i < len(rest); i++ {
+			ch := rest[i]
+			if ch == '"' {
+				inQuotes = !inQuotes
+				continue
+			}
+			if !inQuotes && (ch == ';' || ch == ':') {
+				break
+			}
+			val.WriteByte(ch)
+		}
+		p.Params[key] = val.String()
+		rest = rest[i:]
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return p, fmt.Errorf("missing value in %q", truncate(line, 40))
+	}
+	p.Value = rest[1:]
+	return p, nil
+}
+
+// icsWriter writes folded CRLF-terminated content lines.
+type icsWriter struct {
+	b strings.Builder
+}
+
+func (w *icsWriter) line(s string) {
+	limit := icsMaxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.b.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = icsMaxLineOctets - 1
+	}
+	w.b.WriteString(s + "\r\n")
+}
+
+func (w *icsWriter) prop(name, value string) {
+	if value != "" {
+		w.line(name + ":" + value)
+	}
+}
+
+func (w *icsWriter) text(name, value string) {
+	if value != "" {
+		w.line(name + ":" + escapeICSText(value))
+	}
+}
+
+func (w *icsWriter) String() string {
+	return w.b.String()
+}
+
+func escapeICSText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+func unescapeICSText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func quoteICSParam(v string) string {
+	v = strings.ReplaceAll(v, `"`, "")
+	if strings.ContainsAny(v, ":;,") {
+		return `"` + v + `"`
+	}
+	return v
+}
+
+var icsDurationRe = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseICSDuration parses an RFC 5545 DURATION value such as PT1H30M,
+// P1D or -PT15M.
+func parseICSDuration(s string) (time.Duration, error) {
+	m := icsDurationRe.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, _ := strconv.Atoi(m[i+2])
+		d += time.Duration(n) * unit
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+func formatICSDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	if d%(24*time.Hour) == 0 && d > 0 {
+		return fmt.Sprintf("%sP%dD", sign, d/(24*time.Hour))
+	}
+	out := sign + "PT"
+	if h := d / time.Hour; h > 0 {
+		out += fmt.Sprintf("%dH", h)
+	}
+	if m := (d % time.Hour) / time.Minute; m > 0 || d < time.Hour {
+		out += fmt.Sprintf("%dM", m)
+	}
+	return out
+}
+
+// writeICSTimezone emits a VTIMEZONE for tzid with one observance per
+// offset change between from and to, taken from the Go zone database.
+func writeICSTimezone(w *icsWriter, tzid string, from, to time.Time) {
+	loc, err := time.LoadLocation(tzid)
+	if err != nil {
+		return
+	}
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + tzid)
+
+	observance := func(at time.Time, prevOffset int) {
+		local := at.In(loc)
+		name, offset := local.Zone()
+		kind := "STANDARD"
+		if local.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		w.line("BEGIN:" + kind)
+		w.line("DTSTART:" + at.In(time.FixedZone("", prevOffset)).Format(icsLocalLayout))
+		w.line("TZOFFSETFROM:" + formatICSOffset(prevOffset))
+		w.line("TZOFFSETTO:" + formatICSOffset(offset))
+		if name != "" && !strings.HasPrefix(name, "+") && !strings.HasPrefix(name, "-") {
+			w.line("TZNAME:" + name)
+		}
+		w.line("END:" + kind)
+	}
+
+	start, _ := from.In(loc).ZoneBounds()
+	if start.IsZero() {
+		_, offset := from.In(loc).Zone()
+		observance(time.Date(1970, 1, 1, 0, 0, 0, 0, time.FixedZone("", offset)), offset)
+	} else {
+		_, prev := start.Add(-time.Second).In(loc).Zone()
+		observance(start, prev)
+	}
+	for t := from.In(loc); t.Before(to); {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || end.After(to) {
+			break
+		}
+		_, prev := t.Zone()
+		observance(end, prev)
+		t = end.In(loc)
+	}
+	w.line("END:VTIMEZONE")
+}
+
+func formatICSOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
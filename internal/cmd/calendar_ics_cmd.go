@@ -0,0 +1,782 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarExportCmd struct {
+	CalendarID string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	From       string `name:"from" help:"Start time (RFC3339, date, or relative: today, tomorrow, monday); default: all events"`
+	To         string `name:"to" help:"End time (RFC3339, date, or relative)"`
+	Today      bool   `name:"today" help:"Today only (timezone-aware)"`
+	Week       bool   `name:"week" help:"This week (uses --week-start, default Mon)"`
+	Days       int    `name:"days" help:"Next N days (timezone-aware)" default:"0"`
+	WeekStart  string `name:"week-start" help:"Week start day for --week (sun, mon, ...)" default:""`
+	Query      string `name:"query" help:"Free text search"`
+	Format     string `name:"format" help:"Export format" enum:"ics" default:"ics"`
+	Out        string `name:"out" help:"Write to this file instead of stdout"`
+}
+
+func (c *CalendarExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Events.List(calendarID).SingleEvents(false).ShowDeleted(true).MaxResults(2500)
+	if c.From != "" || c.To != "" || c.Today || c.Week || c.Days > 0 {
+		tr, err := ResolveTimeRange(ctx, svc, TimeRangeFlags{
+			From:      c.From,
+			To:        c.To,
+			Today:     c.Today,
+			Week:      c.Week,
+			Days:      c.Days,
+			WeekStart: c.WeekStart,
+		})
+		if err != nil {
+			return err
+		}
+		from, to := tr.FormatRFC3339()
+		call = call.TimeMin(from).TimeMax(to)
+	}
+	if q := strings.TrimSpace(c.Query); q != "" {
+		call = call.Q(q)
+	}
+
+	var events []*calendar.Event
+	var calName, calTZ string
+	for page := ""; ; {
+		resp, err := call.PageToken(page).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		calName, calTZ = resp.Summary, resp.TimeZone
+		events = append(events, resp.Items...)
+		if resp.NextPageToken == "" {
+			break
+		}
+		page = resp.NextPageToken
+	}
+
+	data, count := buildICSCalendar(events, calName, calTZ, time.Now())
+
+	if strings.TrimSpace(c.Out) == "" || c.Out == "-" {
+		_, err = io.WriteString(os.Stdout, data)
+		return err
+	}
+	path, err := config.ExpandPath(c.Out)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"path": path, "events": count})
+	}
+	u.Out().Printf("path\t%s", path)
+	u.Out().Printf("events\t%d", count)
+	return nil
+}
+
+// buildICSCalendar renders events (as listed with singleEvents=false and
+// showDeleted=true) as a VCALENDAR. Recurring masters keep their RRULE /
+// EXDATE lines, cancelled instances become EXDATEs and modified instances
+// are written with a RECURRENCE-ID. It returns the feed and the number of
+// VEVENTs written.
+func buildICSCalendar(events []*calendar.Event, name, calTZ string, now time.Time) (string, int) {
+	masters := map[string]*calendar.Event{}
+	for _, e := range events {
+		if e.RecurringEventId == "" && len(e.Recurrence) > 0 && e.Status != "cancelled" {
+			masters[e.Id] = e
+		}
+	}
+	exdates := map[string][]*calendar.EventDateTime{}
+	var primary, exceptions []*calendar.Event
+	for _, e := range events {
+		master := masters[e.RecurringEventId]
+		switch {
+		case e.Status == "cancelled" && master != nil && e.OriginalStartTime != nil:
+			exdates[master.Id] = append(exdates[master.Id], e.OriginalStartTime)
+		case e.Status == "cancelled":
+		case master != nil:
+			exceptions = append(exceptions, e)
+		default:
+			primary = append(primary, e)
+		}
+	}
+
+	tzids := map[string]bool{}
+	var body icsWriter
+	var first, last time.Time
+	for _, e := range append(primary, exceptions...) {
+		master := masters[e.RecurringEventId]
+		tz := calTZ
+		if master != nil && master.Start != nil && master.Start.TimeZone != "" {
+			tz = master.Start.TimeZone
+		}
+		writeICSEvent(&body, e, master, exdates[e.Id], tz, now, tzids)
+		if t, ok := eventDateTimeInstant(e.Start); ok {
+			if first.IsZero() || t.Before(first) {
+				first = t
+			}
+			if t.After(last) {
+				last = t
+			}
+		}
+	}
+	if first.IsZero() {
+		first, last = now, now
+	}
+	// Recurring series outlive their first instance; cover a decade of
+	// offset changes so clients don't fall back to the last known offset.
+	until := last.AddDate(1, 0, 0)
+	if len(masters) > 0 {
+		until = last.AddDate(10, 0, 0)
+	}
+
+	var w icsWriter
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icsCalendarProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	w.text("X-WR-CALNAME", name)
+	w.prop("X-WR-TIMEZONE", calTZ)
+	ids := make([]string, 0, len(tzids))
+	for id := range tzids {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		writeICSTimezone(&w, id, first.AddDate(-1, 0, 0), until)
+	}
+	w.b.WriteString(body.String())
+	w.line("END:VCALENDAR")
+	return w.String(), len(primary) + len(exceptions)
+}
+
+func writeICSEvent(w *icsWriter, e *calendar.Event, master *calendar.Event, exdates []*calendar.EventDateTime, tz string, now time.Time, tzids map[string]bool) {
+	w.line("BEGIN:VEVENT")
+	uid := e.ICalUID
+	if uid == "" {
+		uid = e.Id + "@google.com"
+	}
+	w.text("UID", uid)
+	stamp := now.UTC()
+	if t, err := time.Parse(time.RFC3339, e.Updated); err == nil {
+		stamp = t.UTC()
+	}
+	w.prop("DTSTAMP", stamp.Format(icsUTCLayout))
+	if t, err := time.Parse(time.RFC3339, e.Created); err == nil {
+		w.prop("CREATED", t.UTC().Format(icsUTCLayout))
+	}
+	if t, err := time.Parse(time.RFC3339, e.Updated); err == nil {
+		w.prop("LAST-MODIFIED", t.UTC().Format(icsUTCLayout))
+	}
+	w.line(icsDateTimeLine("DTSTART", e.Start, tz, tzids))
+	if e.End != nil {
+		w.line(icsDateTimeLine("DTEND", e.End, tz, tzids))
+	}
+	if master != nil && e.OriginalStartTime != nil {
+		w.line(icsDateTimeLine("RECURRENCE-ID", e.OriginalStartTime, tz, tzids))
+	}
+	for _, rule := range e.Recurrence {
+		rule = strings.TrimSpace(rule)
+		if p, err := parseICSLine(rule); err == nil {
+			if id := p.param("TZID"); id != "" {
+				tzids[id] = true
+			}
+		}
+		w.line(rule)
+	}
+	for _, ex := range exdates {
+		w.line(icsDateTimeLine("EXDATE", ex, tz, tzids))
+	}
+	w.text("SUMMARY", e.Summary)
+	w.text("DESCRIPTION", e.Description)
+	w.text("LOCATION", e.Location)
+	if status := strings.ToUpper(e.Status); status != "" {
+		w.prop("STATUS", status)
+	}
+	if e.Transparency == "transparent" {
+		w.prop("TRANSP", "TRANSPARENT")
+	} else {
+		w.prop("TRANSP", "OPAQUE")
+	}
+	switch e.Visibility {
+	case "public", "private", "confidential":
+		w.prop("CLASS", strings.ToUpper(e.Visibility))
+	}
+	if e.Sequence > 0 {
+		w.prop("SEQUENCE", strconv.FormatInt(e.Sequence, 10))
+	}
+	w.prop("URL", e.HtmlLink)
+	w.prop("X-GOOGLE-CONFERENCE", e.HangoutLink)
+	if o := e.Organizer; o != nil && o.Email != "" {
+		w.line("ORGANIZER" + icsCNParam(o.DisplayName) + ":mailto:" + o.Email)
+	}
+	for _, a := range e.Attendees {
+		if a == nil || a.Email == "" {
+			continue
+		}
+		params := icsCNParam(a.DisplayName)
+		if a.Resource {
+			params += ";CUTYPE=RESOURCE"
+		}
+		role := "REQ-PARTICIPANT"
+		if a.Optional {
+			role = "OPT-PARTICIPANT"
+		}
+		params += ";ROLE=" + role + ";PARTSTAT=" + icsPartStat(a.ResponseStatus)
+		w.line("ATTENDEE" + params + ":mailto:" + a.Email)
+	}
+	if ep := e.ExtendedProperties; ep != nil {
+		for _, k := range sortedKeys(ep.Private) {
+			w.text("X-GOG-PRIVATE-PROP", k+"="+ep.Private[k])
+		}
+		for _, k := range sortedKeys(ep.Shared) {
+			w.text("X-GOG-SHARED-PROP", k+"="+ep.Shared[k])
+		}
+	}
+	if e.Reminders != nil {
+		for _, r := range e.Reminders.Overrides {
+			action := "DISPLAY"
+			if r.Method == "email" {
+				action = "EMAIL"
+			}
+			w.line("BEGIN:VALARM")
+			w.prop("ACTION", action)
+			w.prop("TRIGGER", formatICSDuration(-time.Duration(r.Minutes)*time.Minute))
+			w.text("DESCRIPTION", orDash(e.Summary))
+			if action == "EMAIL" {
+				w.text("SUMMARY", orDash(e.Summary))
+			}
+			w.line("END:VALARM")
+		}
+	}
+	w.line("END:VEVENT")
+}
+
+// icsDateTimeLine formats a Google date/time as an iCalendar property:
+// VALUE=DATE for all-day values, TZID-qualified local time when a zone is
+// known, and UTC otherwise.
+func icsDateTimeLine(name string, edt *calendar.EventDateTime, tz string, tzids map[string]bool) string {
+	if edt.Date != "" {
+		d, err := time.Parse("2006-01-02", edt.Date)
+		if err != nil {
+			return name + ";VALUE=DATE:" + strings.ReplaceAll(edt.Date, "-", "")
+		}
+		return name + ";VALUE=DATE:" + d.Format(icsDateLayout)
+	}
+	t, err := time.Parse(time.RFC3339, edt.DateTime)
+	if err != nil {
+		return name + ":" + edt.DateTime
+	}
+	if edt.TimeZone != "" {
+		tz = edt.TimeZone
+	}
+	if tz != "" && tz != "UTC" && tz != "Etc/UTC" {
+		if loc, err := time.LoadLocation(tz); err == nil {
+			tzids[tz] = true
+			return name + ";TZID=" + quoteICSParam(tz) + ":" + t.In(loc).Format(icsLocalLayout)
+		}
+	}
+	return name + ":" + t.UTC().Format(icsUTCLayout)
+}
+
+func icsCNParam(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return ""
+	}
+	return ";CN=" + quoteICSParam(name)
+}
+
+func icsPartStat(status string) string {
+	switch status {
+	case "accepted":
+		return "ACCEPTED"
+	case "declined":
+		return "DECLINED"
+	case "tentative":
+		return "TENTATIVE"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+func eventDateTimeInstant(edt *calendar.EventDateTime) (time.Time, bool) {
+	if edt == nil {
+		return time.Time{}, false
+	}
+	if edt.Date != "" {
+		t, err := time.Parse("2006-01-02", edt.Date)
+		return t, err == nil
+	}
+	t, err := time.Parse(time.RFC3339, edt.DateTime)
+	return t, err == nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type CalendarImportCmd struct {
+	File        string `arg:"" name:"file" help:"iCalendar (.ics) file ('-' for stdin)"`
+	CalendarID  string `name:"calendar" help:"Calendar to import into" default:"primary"`
+	Insert      bool   `name:"insert" help:"Create events with events.insert (you become the organizer) instead of events.import"`
+	SendUpdates string `name:"send-updates" help:"Notification mode with --insert: all, externalOnly, none (default: none)"`
+	Update      bool   `name:"update" help:"Overwrite events whose iCalUID already exists instead of skipping them"`
+	DryRun      bool   `name:"dry-run" help:"Show what would be imported without writing"`
+}
+
+type calendarImportResult struct {
+	Action  string `json:"action"`
+	ICalUID string `json:"iCalUID"`
+	Start   string `json:"start"`
+	Summary string `json:"summary,omitempty"`
+	ID      string `json:"id,omitempty"`
+}
+
+func (c *CalendarImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty --calendar")
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return usage(err.Error())
+	}
+	if sendUpdates != "" && !c.Insert {
+		return usage("--send-updates requires --insert (events.import never notifies attendees)")
+	}
+
+	var r io.Reader = os.Stdin
+	if c.File != "-" {
+		path, err := config.ExpandPath(c.File)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path) //nolint:gosec // user-provided path
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	root, err := parseICS(r)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var vevents []*icsComponent
+	fallbackTZ := ""
+	for _, cal := range root.children("VCALENDAR") {
+		if fallbackTZ == "" {
+			fallbackTZ = cal.value("X-WR-TIMEZONE")
+		}
+		vevents = append(vevents, cal.children("VEVENT")...)
+	}
+	if len(vevents) == 0 {
+		return usage("no VEVENT found in " + c.File)
+	}
+	fallback, fallbackOK := resolveICSTZID(fallbackTZ)
+	if !fallbackOK {
+		if _, fallback, err = getCalendarLocation(ctx, svc, calendarID); err != nil {
+			return err
+		}
+	}
+
+	events := make([]*calendar.Event, 0, len(vevents))
+	for i, ve := range vevents {
+		ev, err := icsEventToGoogle(ve, fallback)
+		if err != nil {
+			return fmt.Errorf("VEVENT %d: %w", i+1, err)
+		}
+		events = append(events, ev)
+	}
+	// Series must exist before their modified instances.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OriginalStartTime == nil && events[j].OriginalStartTime != nil
+	})
+
+	existing := map[string][]*calendar.Event{}
+	results := make([]calendarImportResult, 0, len(events))
+	for _, ev := range events {
+		res := calendarImportResult{ICalUID: ev.ICalUID, Start: eventStart(ev), Summary: ev.Summary}
+		known, ok := existing[ev.ICalUID]
+		if !ok {
+			resp, err := svc.Events.List(calendarID).ICalUID(ev.ICalUID).ShowDeleted(false).Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("look up %s: %w", ev.ICalUID, err)
+			}
+			known = resp.Items
+			existing[ev.ICalUID] = known
+		}
+		match := matchImportedEvent(known, ev)
+		if ev.OriginalStartTime != nil {
+			for _, k := range known {
+				if k.OriginalStartTime == nil && k.RecurringEventId == "" {
+					ev.RecurringEventId = k.Id
+				}
+			}
+		}
+
+		switch {
+		case match != nil && !c.Update:
+			res.Action, res.ID = "skipped", match.Id
+		case c.DryRun && match != nil:
+			res.Action, res.ID = "would update", match.Id
+		case c.DryRun:
+			res.Action = "would import"
+		case match != nil:
+			updated, err := svc.Events.Update(calendarID, match.Id, ev).Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("update %s: %w (%s)", ev.ICalUID, err, importProgress(results))
+			}
+			res.Action, res.ID = "updated", updated.Id
+		case c.Insert && ev.OriginalStartTime != nil:
+			// events.insert cannot create a modified instance; patch the
+			// occurrence the series already generated instead.
+			inst, err := findSeriesInstance(ctx, svc, calendarID, ev)
+			if err != nil {
+				return fmt.Errorf("import %s: %w (%s)", ev.ICalUID, err, importProgress(results))
+			}
+			ev.Organizer = nil
+			call := svc.Events.Update(calendarID, inst.Id, ev)
+			if sendUpdates != "" {
+				call = call.SendUpdates(sendUpdates)
+			}
+			updated, err := call.Context(ctx).Do()
+			if err != nil {
+				return fmt.Errorf("import %s: %w (%s)", ev.ICalUID, err, importProgress(results))
+			}
+			res.Action, res.ID = "imported", updated.Id
+			existing[ev.ICalUID] = append(existing[ev.ICalUID], updated)
+		default:
+			var created *calendar.Event
+			if c.Insert {
+				ev.Organizer = nil
+				call := svc.Events.Insert(calendarID, ev)
+				if sendUpdates != "" {
+					call = call.SendUpdates(sendUpdates)
+				}
+				created, err = call.Context(ctx).Do()
+			} else {
+				created, err = svc.Events.Import(calendarID, ev).Context(ctx).Do()
+			}
+			if err != nil {
+				return fmt.Errorf("import %s: %w (%s)", ev.ICalUID, err, importProgress(results))
+			}
+			res.Action, res.ID = "imported", created.Id
+			existing[ev.ICalUID] = append(existing[ev.ICalUID], created)
+		}
+		results = append(results, res)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"calendarId": calendarID,
+			"dryRun":     c.DryRun,
+			"results":    results,
+		})
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ACTION\tSTART\tSUMMARY\tICAL_UID\tID")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Action, r.Start, sanitizeTab(truncate(r.Summary, 50)), r.ICalUID, orDash(r.ID))
+	}
+	return nil
+}
+
+func importProgress(results []calendarImportResult) string {
+	done := 0
+	for _, r := range results {
+		if r.Action != "skipped" {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d events written before the failure", done)
+}
+
+// matchImportedEvent finds the existing event an import would duplicate:
+// the series/single event itself, or the same modified instance.
+// findSeriesInstance returns the occurrence of ev's series that starts at
+// ev.OriginalStartTime.
+func findSeriesInstance(ctx context.Context, svc *calendar.Service, calendarID string, ev *calendar.Event) (*calendar.Event, error) {
+	if ev.RecurringEventId == "" {
+		return nil, fmt.Errorf("modified instance %s has no recurring event on the calendar", eventDateTimeValue(ev.OriginalStartTime))
+	}
+	want, ok := eventDateTimeInstant(ev.OriginalStartTime)
+	if !ok {
+		return nil, fmt.Errorf("invalid RECURRENCE-ID %q", eventDateTimeValue(ev.OriginalStartTime))
+	}
+	resp, err := svc.Events.Instances(calendarID, ev.RecurringEventId).
+		OriginalStart(eventDateTimeValue(ev.OriginalStartTime)).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("look up instance %s: %w", eventDateTimeValue(ev.OriginalStartTime), err)
+	}
+	for _, it := range resp.Items {
+		if got, ok := eventDateTimeInstant(it.OriginalStartTime); ok && got.Equal(want) {
+			return it, nil
+		}
+	}
+	return nil, fmt.Errorf("recurring event %s has no instance at %s", ev.RecurringEventId, eventDateTimeValue(ev.OriginalStartTime))
+}
+
+func matchImportedEvent(known []*calendar.Event, ev *calendar.Event) *calendar.Event {
+	want, _ := eventDateTimeInstant(ev.OriginalStartTime)
+	for _, k := range known {
+		if ev.OriginalStartTime == nil {
+			if k.OriginalStartTime == nil {
+				return k
+			}
+			continue
+		}
+		if got, ok := eventDateTimeInstant(k.OriginalStartTime); ok && got.Equal(want) {
+			return k
+		}
+	}
+	return nil
+}
+
+// icsEventToGoogle maps a VEVENT onto a Calendar API event. Floating times
+// and unknown TZIDs are interpreted in fallback.
+func icsEventToGoogle(ve *icsComponent, fallback *time.Location) (*calendar.Event, error) {
+	dtstart, ok := ve.get("DTSTART")
+	if !ok {
+		return nil, fmt.Errorf("missing DTSTART")
+	}
+	start, startTime, err := icsDateTimeValue(dtstart, fallback)
+	if err != nil {
+		return nil, fmt.Errorf("DTSTART: %w", err)
+	}
+
+	ev := &calendar.Event{
+		ICalUID:     unescapeICSText(ve.value("UID")),
+		Summary:     unescapeICSText(ve.value("SUMMARY")),
+		Description: unescapeICSText(ve.value("DESCRIPTION")),
+		Location:    unescapeICSText(ve.value("LOCATION")),
+		Start:       start,
+	}
+	if ev.ICalUID == "" {
+		// A stable UID keeps re-imports of the same file idempotent.
+		sum := sha256.Sum256([]byte(dtstart.Value + "\x00" + ev.Summary))
+		ev.ICalUID = hex.EncodeToString(sum[:12]) + "@gogcli"
+	}
+
+	switch {
+	case hasICSProp(ve, "DTEND"):
+		p, _ := ve.get("DTEND")
+		if ev.End, _, err = icsDateTimeValue(p, fallback); err != nil {
+			return nil, fmt.Errorf("DTEND: %w", err)
+		}
+	case hasICSProp(ve, "DURATION"):
+		d, err := parseICSDuration(ve.value("DURATION"))
+		if err != nil {
+			return nil, err
+		}
+		ev.End = shiftEventDateTime(start, startTime, d)
+	case start.Date != "":
+		ev.End = shiftEventDateTime(start, startTime, 24*time.Hour)
+	default:
+		ev.End = shiftEventDateTime(start, startTime, 0)
+	}
+
+	if p, ok := ve.get("RECURRENCE-ID"); ok {
+		if ev.OriginalStartTime, _, err = icsDateTimeValue(p, fallback); err != nil {
+			return nil, fmt.Errorf("RECURRENCE-ID: %w", err)
+		}
+	}
+	for _, p := range ve.Props {
+		switch p.Name {
+		case "RRULE", "EXRULE", "RDATE", "EXDATE":
+			ev.Recurrence = append(ev.Recurrence, p.line())
+		}
+	}
+
+	switch s := strings.ToLower(ve.value("STATUS")); s {
+	case "confirmed", "tentative", "cancelled":
+		ev.Status = s
+	}
+	if strings.EqualFold(ve.value("TRANSP"), "TRANSPARENT") {
+		ev.Transparency = "transparent"
+	}
+	switch v := strings.ToLower(ve.value("CLASS")); v {
+	case "public", "private", "confidential":
+		ev.Visibility = v
+	}
+	if n, err := strconv.ParseInt(ve.value("SEQUENCE"), 10, 64); err == nil {
+		ev.Sequence = n
+	}
+
+	if p, ok := ve.get("ORGANIZER"); ok {
+		if email := icsMailto(p.Value); email != "" {
+			ev.Organizer = &calendar.EventOrganizer{Email: email, DisplayName: p.param("CN")}
+		}
+	}
+	for _, p := range ve.all("ATTENDEE") {
+		email := icsMailto(p.Value)
+		if email == "" {
+			continue
+		}
+		a := &calendar.EventAttendee{
+			Email:          email,
+			DisplayName:    p.param("CN"),
+			Optional:       strings.EqualFold(p.param("ROLE"), "OPT-PARTICIPANT"),
+			Resource:       strings.EqualFold(p.param("CUTYPE"), "RESOURCE") || strings.EqualFold(p.param("CUTYPE"), "ROOM"),
+			ResponseStatus: "needsAction",
+		}
+		switch strings.ToUpper(p.param("PARTSTAT")) {
+		case "ACCEPTED":
+			a.ResponseStatus = "accepted"
+		case "DECLINED":
+			a.ResponseStatus = "declined"
+		case "TENTATIVE":
+			a.ResponseStatus = "tentative"
+		}
+		ev.Attendees = append(ev.Attendees, a)
+	}
+
+	var private, shared []string
+	for _, p := range ve.all("X-GOG-PRIVATE-PROP") {
+		private = append(private, unescapeICSText(p.Value))
+	}
+	for _, p := range ve.all("X-GOG-SHARED-PROP") {
+		shared = append(shared, unescapeICSText(p.Value))
+	}
+	ev.ExtendedProperties = buildExtendedProperties(private, shared)
+
+	for _, alarm := range ve.children("VALARM") {
+		d, err := parseICSDuration(alarm.value("TRIGGER"))
+		if err != nil || d > 0 {
+			continue
+		}
+		method := "popup"
+		if strings.EqualFold(alarm.value("ACTION"), "EMAIL") {
+			method = "email"
+		}
+		if ev.Reminders == nil {
+			ev.Reminders = &calendar.EventReminders{ForceSendFields: []string{"UseDefault"}}
+		}
+		ev.Reminders.Overrides = append(ev.Reminders.Overrides, &calendar.EventReminder{Method: method, Minutes: int64(-d / time.Minute)})
+	}
+	return ev, nil
+}
+
+func hasICSProp(c *icsComponent, name string) bool {
+	_, ok := c.get(name)
+	return ok
+}
+
+func icsMailto(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 7 && strings.EqualFold(v[:7], "mailto:") {
+		v = v[7:]
+	}
+	if !strings.Contains(v, "@") {
+		return ""
+	}
+	return v
+}
+
+// icsDateTimeValue converts a DATE or DATE-TIME property. Google needs an
+// IANA zone on recurring events, so local times always carry one.
+func icsDateTimeValue(p icsProp, fallback *time.Location) (*calendar.EventDateTime, time.Time, error) {
+	v := strings.TrimSpace(p.Value)
+	if i := strings.Index(v, ","); i >= 0 {
+		v = v[:i]
+	}
+	if strings.EqualFold(p.param("VALUE"), "DATE") || len(v) == len(icsDateLayout) {
+		d, err := time.Parse(icsDateLayout, v)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid date %q", v)
+		}
+		return &calendar.EventDateTime{Date: d.Format("2006-01-02")}, d, nil
+	}
+	if strings.HasSuffix(v, "Z") {
+		t, err := time.Parse(icsUTCLayout, v)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("invalid date-time %q", v)
+		}
+		return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339), TimeZone: "UTC"}, t, nil
+	}
+	loc := fallback
+	if l, ok := resolveICSTZID(p.param("TZID")); ok {
+		loc = l
+	}
+	t, err := time.ParseInLocation(icsLocalLayout, v, loc)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid date-time %q", v)
+	}
+	edt := &calendar.EventDateTime{DateTime: t.Format(time.RFC3339)}
+	if name := loc.String(); name != "Local" {
+		edt.TimeZone = name
+	}
+	return edt, t, nil
+}
+
+// resolveICSTZID maps a TZID to a Go location, tolerating vendor prefixes
+// such as "/mozilla.org/20050126_1/Europe/Berlin".
+func resolveICSTZID(tzid string) (*time.Location, bool) {
+	tzid = strings.Trim(strings.TrimSpace(tzid), "/")
+	for tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			return loc, true
+		}
+		_, rest, ok := strings.Cut(tzid, "/")
+		if !ok {
+			break
+		}
+		tzid = rest
+	}
+	return nil, false
+}
+
+func shiftEventDateTime(start *calendar.EventDateTime, t time.Time, d time.Duration) *calendar.EventDateTime {
+	if start.Date != "" {
+		return &calendar.EventDateTime{Date: t.AddDate(0, 0, int(d/(24*time.Hour))).Format("2006-01-02")}
+	}
+	return &calendar.EventDateTime{DateTime: t.Add(d).Format(time.RFC3339), TimeZone: start.TimeZone}
+}
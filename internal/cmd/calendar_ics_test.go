@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func newICSTestService(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(handler))
+}
+
+func TestExecute_CalendarExportImportICS(t *testing.T) {
+	berlin := func(dt string) map[string]any { return map[string]any{"dateTime": dt, "timeZone": "Europe/Berlin"} }
+	description := strings.Repeat("Agenda: roadmap, hiring; ", 6) + "\nBring notes ✓"
+	exported := []map[string]any{
+		{
+			"id": "m1", "iCalUID": "m1@google.com", "status": "confirmed", "summary": "Weekly, sync",
+			"description": description,
+			"start":       berlin("2026-01-05T09:00:00+01:00"), "end": berlin("2026-01-05T10:00:00+01:00"),
+			"recurrence": []string{"RRULE:FREQ=WEEKLY;BYDAY=MO"},
+			"organizer":  map[string]any{"email": "a@b.com", "displayName": "Ada"},
+			"attendees": []map[string]any{
+				{"email": "a@b.com", "responseStatus": "accepted"},
+				{"email": "c@d.com", "displayName": "Lee, C", "optional": true, "responseStatus": "tentative"},
+			},
+			"reminders":          map[string]any{"useDefault": false, "overrides": []map[string]any{{"method": "popup", "minutes": 10}}},
+			"extendedProperties": map[string]any{"private": map[string]any{"source": "crm"}},
+		},
+		{
+			"id": "m1_20260112T080000Z", "recurringEventId": "m1", "status": "cancelled",
+			"originalStartTime": berlin("2026-01-12T09:00:00+01:00"),
+		},
+		{
+			"id": "m1_20260119T080000Z", "recurringEventId": "m1", "iCalUID": "m1@google.com", "status": "confirmed",
+			"summary": "Weekly sync (moved)", "originalStartTime": berlin("2026-01-19T09:00:00+01:00"),
+			"start": berlin("2026-01-19T11:00:00+01:00"), "end": berlin("2026-01-19T12:00:00+01:00"),
+		},
+		{
+			"id": "d1", "iCalUID": "d1@google.com", "status": "confirmed", "summary": "Offsite",
+			"start": map[string]any{"date": "2026-02-14"}, "end": map[string]any{"date": "2026-02-15"},
+		},
+	}
+
+	newICSTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/calendars/primary/events" || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		if q := r.URL.Query(); q.Get("singleEvents") != "false" || q.Get("showDeleted") != "true" {
+			t.Errorf("unexpected export query: %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"summary": "Work", "timeZone": "Europe/Berlin", "items": exported})
+	})
+
+	path := filepath.Join(t.TempDir(), "work.ics")
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "export", "--out", path}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	data := string(raw)
+	for _, line := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		if len(line) > icsMaxLineOctets {
+			t.Fatalf("line not folded (%d octets): %q", len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(data, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-TIMEZONE:Europe/Berlin\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\n",
+		"UID:m1@google.com\r\n",
+		"DTSTART;TZID=Europe/Berlin:20260105T090000\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO\r\n",
+		"EXDATE;TZID=Europe/Berlin:20260112T090000\r\n",
+		"RECURRENCE-ID;TZID=Europe/Berlin:20260119T090000\r\n",
+		"SUMMARY:Weekly\\, sync\r\n",
+		"ATTENDEE;CN=\"Lee, C\";ROLE=OPT-PARTICIPANT;PARTSTAT=TENTATIVE:mailto:c@d.com\r\n",
+		"TRIGGER:-PT10M\r\n",
+		"X-GOG-PRIVATE-PROP:source=crm\r\n",
+		"DTSTART;VALUE=DATE:20260214\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Fatalf("export missing %q:\n%s", want, unfolded)
+		}
+	}
+	if strings.Count(unfolded, "BEGIN:VEVENT") != 3 {
+		t.Fatalf("expected 3 VEVENTs:\n%s", unfolded)
+	}
+
+	var imported []calendar.Event
+	newICSTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/calendars/primary/events" && r.Method == http.MethodGet:
+			items := []map[string]any{}
+			if r.URL.Query().Get("iCalUID") == "d1@google.com" {
+				items = append(items, map[string]any{"id": "existing-d1", "iCalUID": "d1@google.com"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+		case r.URL.Path == "/calendars/primary/events/import" && r.Method == http.MethodPost:
+			var ev calendar.Event
+			_ = json.NewDecoder(r.Body).Decode(&ev)
+			imported = append(imported, ev)
+			ev.Id = "new" + string(rune('0'+len(imported)))
+			_ = json.NewEncoder(w).Encode(ev)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "import", path}); err != nil {
+			t.Fatalf("import: %v", err)
+		}
+	})
+	var parsed struct {
+		Results []calendarImportResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	var actions []string
+	for _, r := range parsed.Results {
+		actions = append(actions, r.Action+":"+r.ICalUID)
+	}
+	if strings.Join(actions, ",") != "imported:m1@google.com,skipped:d1@google.com,imported:m1@google.com" {
+		t.Fatalf("unexpected results: %v", actions)
+	}
+
+	master, moved := imported[0], imported[1]
+	if master.Summary != "Weekly, sync" || master.Description != description {
+		t.Fatalf("text not round-tripped: %q / %q", master.Summary, master.Description)
+	}
+	if master.Start.DateTime != "2026-01-05T09:00:00+01:00" || master.Start.TimeZone != "Europe/Berlin" {
+		t.Fatalf("unexpected start: %+v", master.Start)
+	}
+	if strings.Join(master.Recurrence, "|") != "RRULE:FREQ=WEEKLY;BYDAY=MO|EXDATE;TZID=Europe/Berlin:20260112T090000" {
+		t.Fatalf("unexpected recurrence: %v", master.Recurrence)
+	}
+	if len(master.Attendees) != 2 || !master.Attendees[1].Optional || master.Attendees[1].DisplayName != "Lee, C" || master.Organizer.Email != "a@b.com" {
+		t.Fatalf("unexpected people: %+v %+v", master.Attendees, master.Organizer)
+	}
+	if master.ExtendedProperties.Private["source"] != "crm" || master.Reminders.Overrides[0].Minutes != 10 {
+		t.Fatalf("unexpected props/reminders: %+v %+v", master.ExtendedProperties, master.Reminders)
+	}
+	if moved.OriginalStartTime.DateTime != "2026-01-19T09:00:00+01:00" || moved.RecurringEventId != "new1" {
+		t.Fatalf("unexpected exception: %+v %q", moved.OriginalStartTime, moved.RecurringEventId)
+	}
+}
+
+func TestExecute_CalendarImportICS_InsertPatchesModifiedInstance(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR", "VERSION:2.0",
+		"BEGIN:VEVENT", "UID:s1@example.com", "SUMMARY:Standup",
+		"DTSTART;TZID=Europe/Berlin:20260105T090000", "DTEND;TZID=Europe/Berlin:20260105T091500",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO", "END:VEVENT",
+		"BEGIN:VEVENT", "UID:s1@example.com", "SUMMARY:Standup (moved)",
+		"RECURRENCE-ID;TZID=Europe/Berlin:20260112T090000",
+		"DTSTART;TZID=Europe/Berlin:20260112T110000", "DTEND;TZID=Europe/Berlin:20260112T111500", "END:VEVENT",
+		"END:VCALENDAR", "",
+	}, "\r\n")
+	path := filepath.Join(t.TempDir(), "standup.ics")
+	if err := os.WriteFile(path, []byte(ics), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var inserted int
+	var patched calendar.Event
+	var instancesQuery, updateQuery string
+	newICSTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/calendars/primary/events" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{}})
+		case r.URL.Path == "/calendars/primary/events" && r.Method == http.MethodPost:
+			var ev calendar.Event
+			_ = json.NewDecoder(r.Body).Decode(&ev)
+			if ev.OriginalStartTime != nil {
+				t.Errorf("modified instance sent to events.insert")
+			}
+			inserted++
+			ev.Id = "series1"
+			_ = json.NewEncoder(w).Encode(ev)
+		case r.URL.Path == "/calendars/primary/events/series1/instances" && r.Method == http.MethodGet:
+			instancesQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{{
+				"id": "series1_20260112T080000Z", "recurringEventId": "series1",
+				"originalStartTime": map[string]any{"dateTime": "2026-01-12T08:00:00Z"},
+			}}})
+		case r.URL.Path == "/calendars/primary/events/series1_20260112T080000Z" && r.Method == http.MethodPut:
+			updateQuery = r.URL.RawQuery
+			_ = json.NewDecoder(r.Body).Decode(&patched)
+			patched.Id = "series1_20260112T080000Z"
+			_ = json.NewEncoder(w).Encode(patched)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "import", path, "--insert", "--send-updates", "all"}); err != nil {
+			t.Fatalf("import: %v", err)
+		}
+	})
+	var parsed struct {
+		Results []calendarImportResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if inserted != 1 || len(parsed.Results) != 2 || parsed.Results[1].ID != "series1_20260112T080000Z" {
+		t.Fatalf("unexpected results (inserted=%d): %+v", inserted, parsed.Results)
+	}
+	if !strings.Contains(instancesQuery, "originalStart=2026-01-12T09%3A00%3A00%2B01%3A00") {
+		t.Fatalf("unexpected instances query: %s", instancesQuery)
+	}
+	if !strings.Contains(updateQuery, "sendUpdates=all") {
+		t.Fatalf("unexpected update query: %s", updateQuery)
+	}
+	if patched.Summary != "Standup (moved)" || patched.Start.DateTime != "2026-01-12T11:00:00+01:00" || patched.RecurringEventId != "series1" {
+		t.Fatalf("unexpected patch: %+v", patched)
+	}
+}
+
+func TestExecute_CalendarImportICS_InsertOrphanInstance(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR", "VERSION:2.0",
+		"BEGIN:VEVENT", "UID:s1@example.com", "SUMMARY:Standup (moved)",
+		"RECURRENCE-ID:20260112T080000Z", "DTSTART:20260112T100000Z", "DTEND:20260112T101500Z", "END:VEVENT",
+		"END:VCALENDAR", "",
+	}, "\r\n")
+	path := filepath.Join(t.TempDir(), "orphan.ics")
+	if err := os.WriteFile(path, []byte(ics), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	newICSTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/calendars/primary/events" && r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []any{}, "timeZone": "UTC"})
+			return
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	})
+
+	err := Execute([]string{"--account", "a@b.com", "calendar", "import", path, "--insert"})
+	if err == nil || !strings.Contains(err.Error(), "no recurring event on the calendar") {
+		t.Fatalf("expected orphan instance error, got %v", err)
+	}
+}
+
+func TestICSEventToGoogle_Defaults(t *testing.T) {
+	root, err := parseICS(strings.NewReader(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;TZID=/mozilla.org/20050126_1/America/New_York:20260301T100000",
+		"DURATION:PT1H30M",
+		"SUMMARY:Keynote",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:x",
+		"DTSTART;VALUE=DATE:20260302",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\n")))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	events := root.children("VCALENDAR")[0].children("VEVENT")
+	first, err := icsEventToGoogle(events[0], time.UTC)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	if first.Start.TimeZone != "America/New_York" || first.End.DateTime != "2026-03-01T11:30:00-05:00" || !strings.HasSuffix(first.ICalUID, "@gogcli") {
+		t.Fatalf("unexpected event: %+v %+v %q", first.Start, first.End, first.ICalUID)
+	}
+	second, err := icsEventToGoogle(events[1], time.UTC)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	if second.End.Date != "2026-03-03" {
+		t.Fatalf("unexpected all-day end: %+v", second.End)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarQuickAddCmd struct {
+	Text        string `arg:"" name:"text" help:"Event in plain language, e.g. \"Lunch with Sam tomorrow 12:30 at Cafe\""`
+	CalendarID  string `name:"calendar" help:"Calendar ID" default:"primary"`
+	SendUpdates string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+}
+
+// Run hands the text to events.quickAdd, so Google parses the title, time,
+// location and guests exactly as the Calendar web UI's quick add does.
+func (c *CalendarQuickAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSpace(c.Text)
+	if text == "" {
+		return usage("empty text")
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		return usage("empty --calendar")
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Events.QuickAdd(calendarID, text).Context(ctx)
+	if sendUpdates != "" {
+		call = call.SendUpdates(sendUpdates)
+	}
+	created, err := call.Do()
+	if err != nil {
+		return err
+	}
+	tz, loc, _ := getCalendarLocation(ctx, svc, calendarID)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"event": wrapEventWithDaysWithTimezone(created, tz, loc)})
+	}
+	printCalendarEventWithTimezone(u, created, tz, loc)
+	return nil
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func newCalendarQuickAddTestService(t *testing.T) *[]recordedRequest {
+	t.Helper()
+	var reqs []recordedRequest
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recordRequest(&reqs, r)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":      "ev1",
+			"summary": "Lunch with Sam",
+			"start":   map[string]any{"dateTime": "2026-03-03T12:30:00Z"},
+			"end":     map[string]any{"dateTime": "2026-03-03T13:30:00Z"},
+		})
+	})))
+	return &reqs
+}
+
+func TestExecute_CalendarQuickAdd(t *testing.T) {
+	reqs := newCalendarQuickAddTestService(t)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "quick-add", "Lunch with Sam tomorrow 12:30 at Cafe", "--send-updates", "all"}); err != nil {
+			t.Fatalf("quick-add: %v", err)
+		}
+	})
+	got := *reqs
+	if len(got) != 1 || got[0].Method != http.MethodPost || got[0].Path != "/calendars/primary/events/quickAdd" {
+		t.Fatalf("unexpected requests: %+v", got)
+	}
+	if q := got[0].Query; q != "alt=json&prettyPrint=false&sendUpdates=all&text=Lunch+with+Sam+tomorrow+12%3A30+at+Cafe" {
+		t.Fatalf("unexpected query: %s", q)
+	}
+	var parsed struct {
+		Event calendar.Event `json:"event"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil || parsed.Event.Id != "ev1" {
+		t.Fatalf("unexpected output %q (%v)", out, err)
+	}
+
+	if err := Execute([]string{"--account", "a@b.com", "calendar", "quick-add", "  "}); err == nil {
+		t.Fatalf("expected usage error for empty text")
+	}
+}
+
+func TestExecute_CalendarCreateNaturalTimes(t *testing.T) {
+	reqs := newCalendarQuickAddTestService(t)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "create", "primary",
+			"--summary", "Review", "--from", "tomorrow 3pm", "--to", "+45m"}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	})
+	var insert *recordedRequest
+	for i := range *reqs {
+		if (*reqs)[i].Method == http.MethodPost {
+			insert = &(*reqs)[i]
+		}
+	}
+	if insert == nil {
+		t.Fatalf("no insert request: %+v", *reqs)
+	}
+	start := insert.Body["start"].(map[string]any)
+	end := insert.Body["end"].(map[string]any)
+	from, err := time.Parse(time.RFC3339, start["dateTime"].(string))
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	to, err := time.Parse(time.RFC3339, end["dateTime"].(string))
+	if err != nil {
+		t.Fatalf("end: %v", err)
+	}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	if from.Hour() != 15 || from.Day() != tomorrow.Day() || to.Sub(from) != 45*time.Minute || start["timeZone"] != "UTC" {
+		t.Fatalf("unexpected times: %+v -> %+v", start, end)
+	}
+
+	err = Execute([]string{"--account", "a@b.com", "calendar", "create", "primary", "--summary", "x", "--from", "someday", "--to", "+1h"})
+	if err == nil {
+		t.Fatalf("expected parse error for --from")
+	}
+}
+
+func TestExecute_CalendarUpdateRelativeEndUsesCurrentStart(t *testing.T) {
+	reqs := newCalendarQuickAddTestService(t)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "update", "primary", "ev1", "--to", "+45m"}); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	})
+	var patch *recordedRequest
+	for i := range *reqs {
+		if (*reqs)[i].Method == http.MethodPatch {
+			patch = &(*reqs)[i]
+		}
+	}
+	if patch == nil {
+		t.Fatalf("no patch request: %+v", *reqs)
+	}
+	if _, ok := patch.Body["start"]; ok {
+		t.Fatalf("start should not be patched: %+v", patch.Body)
+	}
+	end := patch.Body["end"].(map[string]any)
+	if end["dateTime"] != "2026-03-03T13:15:00Z" {
+		t.Fatalf("expected end 45m after the current start, got %+v", end)
+	}
+}
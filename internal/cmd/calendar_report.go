@@ -0,0 +1,525 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarReportCmd struct {
+	Calendars       string   `name:"calendars" help:"Comma-separated calendar IDs to report on (default: primary)"`
+	Group           string   `name:"group" help:"Report on every member of this Google Group instead"`
+	GroupBy         string   `name:"group-by" help:"Breakdown: attendee, domain, color, event-type, weekday, recurring" enum:"attendee,domain,color,event-type,weekday,recurring" default:"event-type"`
+	InternalDomains []string `name:"internal-domain" help:"Domains counted as internal (default: your account's domain)"`
+	Gap             string   `name:"back-to-back-gap" help:"Max gap between meetings that still counts as back-to-back" default:"5m"`
+	IncludeDeclined bool     `name:"include-declined" help:"Count events you declined"`
+	Top             int      `name:"top" help:"Max breakdown rows (0 = all)" default:"20"`
+	TimeRangeFlags
+}
+
+// Report categories for a timed event.
+const (
+	reportMeeting = "meeting"
+	reportSolo    = "solo"
+	reportFocus   = "focus"
+	reportOOO     = "out-of-office"
+)
+
+type reportEvent struct {
+	Owner string
+	Event *calendar.Event
+}
+
+type calendarReportSummary struct {
+	Events           int     `json:"events"`
+	Meetings         int     `json:"meetings"`
+	MeetingHours     float64 `json:"meetingHours"`
+	FocusHours       float64 `json:"focusHours"`
+	SoloHours        float64 `json:"soloHours"`
+	OutOfOfficeHours float64 `json:"outOfOfficeHours"`
+	FocusShare       float64 `json:"focusShare"`
+	OneOnOneHours    float64 `json:"oneOnOneHours"`
+	GroupHours       float64 `json:"groupHours"`
+	InternalHours    float64 `json:"internalHours"`
+	ExternalHours    float64 `json:"externalHours"`
+	RecurringHours   float64 `json:"recurringHours"`
+	OneOffHours      float64 `json:"oneOffHours"`
+	SkippedAllDay    int     `json:"skippedAllDay,omitempty"`
+	SkippedDeclined  int     `json:"skippedDeclined,omitempty"`
+}
+
+type calendarReportStreaks struct {
+	Streaks         int     `json:"streaks"`
+	MeetingsInRuns  int     `json:"meetingsInStreaks"`
+	LongestMeetings int     `json:"longestMeetings"`
+	LongestHours    float64 `json:"longestHours"`
+	LongestStart    string  `json:"longestStart,omitempty"`
+	LongestOwner    string  `json:"longestOwner,omitempty"`
+}
+
+type calendarReportRow struct {
+	Key    string  `json:"key"`
+	Events int     `json:"events"`
+	Hours  float64 `json:"hours"`
+	Share  float64 `json:"share"`
+}
+
+type calendarReport struct {
+	Summary calendarReportSummary `json:"summary"`
+	Streaks calendarReportStreaks `json:"backToBack"`
+	Groups  []calendarReportRow   `json:"groups"`
+}
+
+type calendarReportOptions struct {
+	From, To        time.Time
+	Location        *time.Location
+	GroupBy         string
+	Internal        map[string]bool
+	Gap             time.Duration
+	IncludeDeclined bool
+}
+
+func (c *CalendarReportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	gap, err := time.ParseDuration(strings.TrimSpace(c.Gap))
+	if err != nil || gap < 0 {
+		return usagef("invalid --back-to-back-gap %q", c.Gap)
+	}
+	if c.Group != "" && c.Calendars != "" {
+		return usage("use either --calendars or --group")
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tr, err := ResolveTimeRange(ctx, svc, c.TimeRangeFlags)
+	if err != nil {
+		return err
+	}
+
+	calendarIDs := splitCSV(c.Calendars)
+	if group := strings.TrimSpace(c.Group); group != "" {
+		cloudSvc, err := newCloudIdentityService(ctx, account)
+		if err != nil {
+			return wrapCloudIdentityError(err, account)
+		}
+		if calendarIDs, err = collectGroupMemberEmails(ctx, cloudSvc, group); err != nil {
+			return fmt.Errorf("failed to list group members: %w", err)
+		}
+	}
+	if len(calendarIDs) == 0 {
+		calendarIDs = []string{"primary"}
+	}
+
+	internal := map[string]bool{}
+	for _, d := range c.InternalDomains {
+		internal[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))] = true
+	}
+	if len(internal) == 0 {
+		internal[emailDomain(account)] = true
+	}
+
+	events, errs := fetchReportEvents(ctx, svc, account, calendarIDs, tr)
+	for _, e := range errs {
+		u.Err().Printf("Warning: %s", e)
+	}
+	if len(errs) == len(calendarIDs) {
+		return fmt.Errorf("could not read any calendar")
+	}
+
+	report := buildCalendarReport(events, calendarReportOptions{
+		From:            tr.From,
+		To:              tr.To,
+		Location:        tr.Location,
+		GroupBy:         c.GroupBy,
+		Internal:        internal,
+		Gap:             gap,
+		IncludeDeclined: c.IncludeDeclined,
+	})
+	if c.Top > 0 && len(report.Groups) > c.Top {
+		report.Groups = report.Groups[:c.Top]
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"timeMin":    tr.From.Format(time.RFC3339),
+			"timeMax":    tr.To.Format(time.RFC3339),
+			"timezone":   tr.Location.String(),
+			"calendars":  calendarIDs,
+			"groupBy":    c.GroupBy,
+			"summary":    report.Summary,
+			"backToBack": report.Streaks,
+			"groups":     report.Groups,
+		})
+	}
+
+	s, st := report.Summary, report.Streaks
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintf(w, "range\t%s\n", tr.FormatHuman())
+	fmt.Fprintf(w, "calendars\t%s\n", strings.Join(calendarIDs, ", "))
+	fmt.Fprintf(w, "meetings\t%d (%.1fh)\n", s.Meetings, s.MeetingHours)
+	fmt.Fprintf(w, "focus vs meetings\t%.1fh focus / %.1fh meetings (%.0f%% focus)\n", s.FocusHours, s.MeetingHours, s.FocusShare*100)
+	fmt.Fprintf(w, "1:1 vs group\t%.1fh / %.1fh\n", s.OneOnOneHours, s.GroupHours)
+	fmt.Fprintf(w, "internal vs external\t%.1fh / %.1fh\n", s.InternalHours, s.ExternalHours)
+	fmt.Fprintf(w, "recurring vs one-off\t%.1fh / %.1fh\n", s.RecurringHours, s.OneOffHours)
+	fmt.Fprintf(w, "solo blocks\t%.1fh\n", s.SoloHours)
+	fmt.Fprintf(w, "back-to-back streaks\t%d (%d meetings); longest %d meetings (%.1fh) %s\n",
+		st.Streaks, st.MeetingsInRuns, st.LongestMeetings, st.LongestHours, st.LongestStart)
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s\tEVENTS\tHOURS\tSHARE\n", strings.ToUpper(strings.ReplaceAll(c.GroupBy, "-", "_")))
+	for _, r := range report.Groups {
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%.0f%%\n", sanitizeTab(r.Key), r.Events, r.Hours, r.Share*100)
+	}
+	return nil
+}
+
+func fetchReportEvents(ctx context.Context, svc *calendar.Service, account string, calendarIDs []string, tr *TimeRange) ([]reportEvent, []string) {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		events []reportEvent
+		errs   []string
+	)
+	sem := make(chan struct{}, 10)
+	for _, id := range calendarIDs {
+		wg.Add(1)
+		go func(calendarID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			owner := calendarID
+			if owner == "primary" {
+				owner = account
+			}
+			var items []reportEvent
+			call := svc.Events.List(calendarID).
+				TimeMin(tr.From.Format(time.RFC3339)).
+				TimeMax(tr.To.Format(time.RFC3339)).
+				SingleEvents(true).
+				OrderBy("startTime").
+				MaxResults(2500)
+			for page := ""; ; {
+				resp, err := call.PageToken(page).Context(ctx).Do()
+				if err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Sprintf("%s: %v", calendarID, err))
+					mu.Unlock()
+					return
+				}
+				for _, ev := range resp.Items {
+					items = append(items, reportEvent{Owner: strings.ToLower(owner), Event: ev})
+				}
+				if resp.NextPageToken == "" {
+					break
+				}
+				page = resp.NextPageToken
+			}
+			mu.Lock()
+			events = append(events, items...)
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	sort.SliceStable(events, func(i, j int) bool {
+		return parseEventStart(events[i].Event, tr.Location).Before(parseEventStart(events[j].Event, tr.Location))
+	})
+	return events, errs
+}
+
+type reportItem struct {
+	owner    string
+	event    *calendar.Event
+	category string
+	start    time.Time
+	end      time.Time
+	others   []string
+}
+
+func (it reportItem) hours() float64 {
+	return it.end.Sub(it.start).Hours()
+}
+
+// buildCalendarReport classifies timed events and aggregates them. Events
+// shared by several reported calendars count once in the totals and the
+// breakdown but still contribute to each owner's back-to-back streaks.
+func buildCalendarReport(events []reportEvent, opts calendarReportOptions) calendarReport {
+	var rep calendarReport
+	seen := map[string]bool{}
+	var counted []reportItem
+	perOwner := map[string][]reportItem{}
+
+	for _, re := range events {
+		ev := re.Event
+		if ev == nil || ev.Status == "cancelled" || ev.Start == nil || ev.End == nil {
+			continue
+		}
+		if ev.EventType == eventTypeWorkingLocation {
+			continue
+		}
+		if ev.Start.DateTime == "" {
+			rep.Summary.SkippedAllDay++
+			continue
+		}
+		start, errS := time.Parse(time.RFC3339, ev.Start.DateTime)
+		end, errE := time.Parse(time.RFC3339, ev.End.DateTime)
+		if errS != nil || errE != nil {
+			continue
+		}
+		if start.Before(opts.From) {
+			start = opts.From
+		}
+		if end.After(opts.To) {
+			end = opts.To
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		it := reportItem{owner: re.Owner, event: ev, start: start, end: end}
+		declined := false
+		for _, a := range ev.Attendees {
+			if a == nil || a.Resource || a.Email == "" {
+				continue
+			}
+			if a.Self || strings.EqualFold(a.Email, re.Owner) {
+				declined = a.ResponseStatus == "declined"
+				continue
+			}
+			it.others = append(it.others, strings.ToLower(a.Email))
+		}
+		if declined && !opts.IncludeDeclined {
+			rep.Summary.SkippedDeclined++
+			continue
+		}
+		switch {
+		case ev.EventType == eventTypeFocusTime:
+			it.category = reportFocus
+		case ev.EventType == eventTypeOutOfOffice:
+			it.category = reportOOO
+		case len(it.others) > 0:
+			it.category = reportMeeting
+		default:
+			it.category = reportSolo
+		}
+		if it.category == reportMeeting {
+			perOwner[re.Owner] = append(perOwner[re.Owner], it)
+		}
+
+		key := eventDedupeKey(ev, start)
+		if key != "" && seen[key] {
+			continue
+		}
+		seen[key] = true
+		counted = append(counted, it)
+	}
+
+	s := &rep.Summary
+	for _, it := range counted {
+		h := it.hours()
+		s.Events++
+		switch it.category {
+		case reportFocus:
+			s.FocusHours += h
+			continue
+		case reportOOO:
+			s.OutOfOfficeHours += h
+			continue
+		case reportSolo:
+			s.SoloHours += h
+			continue
+		}
+		s.Meetings++
+		s.MeetingHours += h
+		if len(it.others) == 1 {
+			s.OneOnOneHours += h
+		} else {
+			s.GroupHours += h
+		}
+		external := false
+		for _, email := range it.others {
+			if !opts.Internal[emailDomain(email)] {
+				external = true
+				break
+			}
+		}
+		if external {
+			s.ExternalHours += h
+		} else {
+			s.InternalHours += h
+		}
+		if it.event.RecurringEventId != "" {
+			s.RecurringHours += h
+		} else {
+			s.OneOffHours += h
+		}
+	}
+	if total := s.FocusHours + s.MeetingHours; total > 0 {
+		s.FocusShare = roundReport(s.FocusHours / total)
+	}
+	for _, f := range []*float64{&s.MeetingHours, &s.FocusHours, &s.SoloHours, &s.OutOfOfficeHours, &s.OneOnOneHours, &s.GroupHours, &s.InternalHours, &s.ExternalHours, &s.RecurringHours, &s.OneOffHours} {
+		*f = roundReport(*f)
+	}
+
+	rep.Streaks = reportStreaks(perOwner, opts.Gap, opts.Location)
+	rep.Groups = reportGroups(counted, opts)
+	return rep
+}
+
+// reportStreaks finds runs of meetings where each starts no later than gap
+// after the previous one ended.
+func reportStreaks(perOwner map[string][]reportItem, gap time.Duration, loc *time.Location) calendarReportStreaks {
+	var st calendarReportStreaks
+	owners := make([]string, 0, len(perOwner))
+	for o := range perOwner {
+		owners = append(owners, o)
+	}
+	sort.Strings(owners)
+	for _, owner := range owners {
+		items := perOwner[owner]
+		sort.SliceStable(items, func(i, j int) bool { return items[i].start.Before(items[j].start) })
+		flush := func(run []reportItem, runEnd time.Time) {
+			if len(run) < 2 {
+				return
+			}
+			st.Streaks++
+			st.MeetingsInRuns += len(run)
+			hours := runEnd.Sub(run[0].start).Hours()
+			if len(run) > st.LongestMeetings || (len(run) == st.LongestMeetings && hours > st.LongestHours) {
+				st.LongestMeetings = len(run)
+				st.LongestHours = roundReport(hours)
+				st.LongestStart = run[0].start.In(loc).Format("Mon Jan 2 15:04")
+				st.LongestOwner = owner
+			}
+		}
+		var run []reportItem
+		var runEnd time.Time
+		for _, it := range items {
+			if len(run) > 0 && it.start.Sub(runEnd) <= gap {
+				run = append(run, it)
+				if it.end.After(runEnd) {
+					runEnd = it.end
+				}
+				continue
+			}
+			flush(run, runEnd)
+			run, runEnd = []reportItem{it}, it.end
+		}
+		flush(run, runEnd)
+	}
+	return st
+}
+
+func reportGroups(items []reportItem, opts calendarReportOptions) []calendarReportRow {
+	rows := map[string]*calendarReportRow{}
+	add := func(key string, h float64) {
+		r, ok := rows[key]
+		if !ok {
+			r = &calendarReportRow{Key: key}
+			rows[key] = r
+		}
+		r.Events++
+		r.Hours += h
+	}
+
+	var total float64
+	for _, it := range items {
+		h := it.hours()
+		switch opts.GroupBy {
+		case "attendee", "domain":
+			// Only meetings have other people to attribute time to.
+			if it.category != reportMeeting {
+				continue
+			}
+			total += h
+			keys := map[string]bool{}
+			for _, email := range it.others {
+				if opts.GroupBy == "domain" {
+					keys[emailDomain(email)] = true
+				} else {
+					keys[email] = true
+				}
+			}
+			for k := range keys {
+				add(k, h)
+			}
+			continue
+		case "color":
+			add(orDefault(it.event.ColorId, "calendar"), h)
+		case "weekday":
+			add(it.start.In(opts.Location).Weekday().String()[:3], h)
+		case "recurring":
+			if it.event.RecurringEventId != "" {
+				add("recurring", h)
+			} else {
+				add("one-off", h)
+			}
+		default:
+			switch {
+			case it.category != reportMeeting:
+				add(it.category, h)
+			case len(it.others) == 1:
+				add("1:1", h)
+			default:
+				add("group meeting", h)
+			}
+		}
+		total += h
+	}
+
+	out := make([]calendarReportRow, 0, len(rows))
+	for _, r := range rows {
+		if total > 0 {
+			r.Share = roundReport(r.Hours / total)
+		}
+		r.Hours = roundReport(r.Hours)
+		out = append(out, *r)
+	}
+	if opts.GroupBy == "weekday" {
+		order := map[string]int{"Mon": 0, "Tue": 1, "Wed": 2, "Thu": 3, "Fri": 4, "Sat": 5, "Sun": 6}
+		sort.Slice(out, func(i, j int) bool { return order[out[i].Key] < order[out[j].Key] })
+		return out
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Hours != out[j].Hours {
+			return out[i].Hours > out[j].Hours
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func emailDomain(email string) string {
+	_, domain, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !ok {
+		return ""
+	}
+	return domain
+}
+
+func orDefault(v, fallback string) string {
+	if strings.TrimSpace(v) == "" {
+		return fallback
+	}
+	return v
+}
+
+func roundReport(v float64) float64 {
+	return math.Round(v*100) / 100
+}
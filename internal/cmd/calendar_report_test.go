@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+)
+
+func TestExecute_CalendarReport(t *testing.T) {
+	timed := func(id, start, end string, extra map[string]any) map[string]any {
+		ev := map[string]any{
+			"id": id, "iCalUID": id, "status": "confirmed",
+			"start": map[string]any{"dateTime": "2026-01-05T" + start + ":00Z"},
+			"end":   map[string]any{"dateTime": "2026-01-05T" + end + ":00Z"},
+		}
+		for k, v := range extra {
+			ev[k] = v
+		}
+		return ev
+	}
+	people := func(list ...map[string]any) map[string]any { return map[string]any{"attendees": list} }
+	self := map[string]any{"email": "a@b.com", "self": true, "responseStatus": "accepted"}
+	bob := map[string]any{"email": "bob@b.com"}
+	carol := map[string]any{"email": "carol@b.com"}
+
+	calendars := map[string][]map[string]any{
+		"primary": {
+			timed("standup", "09:00", "10:00", map[string]any{"recurringEventId": "r1", "attendees": []map[string]any{self, bob, carol}}),
+			timed("vendor", "10:00", "10:30", people(self, map[string]any{"email": "v@vendor.com"})),
+			timed("sync", "10:33", "11:00", people(self, bob)),
+			timed("focus", "13:00", "15:00", map[string]any{"eventType": "focusTime"}),
+			timed("declined", "15:00", "16:00", people(map[string]any{"email": "a@b.com", "self": true, "responseStatus": "declined"}, bob)),
+			timed("solo", "16:00", "16:30", nil),
+			{"id": "offsite", "status": "confirmed", "start": map[string]any{"date": "2026-01-05"}, "end": map[string]any{"date": "2026-01-06"}},
+		},
+		"bob@b.com": {
+			timed("standup", "09:00", "10:00", map[string]any{"recurringEventId": "r1", "attendees": []map[string]any{
+				{"email": "a@b.com"}, {"email": "bob@b.com", "self": true}, carol,
+			}}),
+			timed("bobcarol", "12:00", "13:00", people(map[string]any{"email": "bob@b.com"}, carol)),
+		},
+	}
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/calendars/"), "/events")
+		items, ok := calendars[id]
+		if !ok || r.URL.Query().Get("singleEvents") != "true" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"items": items})
+	})))
+
+	args := []string{
+		"--account", "a@b.com", "calendar", "report",
+		"--calendars", "primary,bob@b.com",
+		"--from", "2026-01-05T00:00:00Z", "--to", "2026-01-06T00:00:00Z",
+	}
+	out := captureStdout(t, func() {
+		if err := Execute(append([]string{"--json"}, append(args, "--group-by", "domain")...)); err != nil {
+			t.Fatalf("report: %v", err)
+		}
+	})
+	var parsed struct {
+		Summary    calendarReportSummary `json:"summary"`
+		BackToBack calendarReportStreaks `json:"backToBack"`
+		Groups     []calendarReportRow   `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+
+	want := calendarReportSummary{
+		Events: 6, Meetings: 4, MeetingHours: 2.95, FocusHours: 2, SoloHours: 0.5, FocusShare: 0.4,
+		OneOnOneHours: 1.95, GroupHours: 1, InternalHours: 2.45, ExternalHours: 0.5,
+		RecurringHours: 1, OneOffHours: 1.95, SkippedAllDay: 1, SkippedDeclined: 1,
+	}
+	if parsed.Summary != want {
+		t.Fatalf("unexpected summary:\n got %+v\nwant %+v", parsed.Summary, want)
+	}
+	if b := parsed.BackToBack; b.Streaks != 1 || b.LongestMeetings != 3 || b.LongestHours != 2 || b.LongestOwner != "a@b.com" {
+		t.Fatalf("unexpected streaks: %+v", b)
+	}
+	if len(parsed.Groups) != 2 || parsed.Groups[0] != (calendarReportRow{Key: "b.com", Events: 3, Hours: 2.45, Share: 0.83}) {
+		t.Fatalf("unexpected groups: %+v", parsed.Groups)
+	}
+
+	text := captureStdout(t, func() {
+		if err := Execute(args); err != nil {
+			t.Fatalf("report text: %v", err)
+		}
+	})
+	for _, want := range []string{"focus vs meetings", "1:1", "group meeting", "back-to-back streaks  1 (3 meetings)"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("text output missing %q:\n%s", want, text)
+		}
+	}
+}
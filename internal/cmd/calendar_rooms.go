@@ -0,0 +1,537 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/errfmt"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var newAdminDirectoryService = googleapi.NewAdminDirectoryResources
+
+const (
+	roomAuto              = "auto"
+	roomCategoryOther     = "OTHER"
+	adminResourcesPerPage = 500
+)
+
+type CalendarRoomsCmd struct {
+	List     CalendarRoomsListCmd     `cmd:"" default:"withargs" help:"List meeting rooms"`
+	FreeBusy CalendarRoomsFreeBusyCmd `cmd:"" name:"freebusy" help:"Show which meeting rooms are free in a time window"`
+	Book     CalendarRoomsBookCmd     `cmd:"" name:"book" help:"Add a meeting room to an existing event"`
+}
+
+// RoomFilterFlags narrows the Workspace room directory. All filters are
+// applied client-side because the Directory API query language does not
+// cover features or minimum capacity.
+type RoomFilterFlags struct {
+	Building string   `name:"building" help:"Only rooms in this building ID"`
+	Floor    string   `name:"floor" help:"Only rooms on this floor"`
+	Capacity int64    `name:"capacity" help:"Minimum room capacity"`
+	Features []string `name:"feature" help:"Required room feature (e.g. 'Video conference'); repeat or comma-separate for several"`
+}
+
+func (f RoomFilterFlags) active() bool {
+	return strings.TrimSpace(f.Building) != "" || strings.TrimSpace(f.Floor) != "" || f.Capacity > 0 || len(f.Features) > 0
+}
+
+func (f RoomFilterFlags) validate() error {
+	if f.Capacity < 0 {
+		return usage("--capacity must be >= 0")
+	}
+	return nil
+}
+
+func (f RoomFilterFlags) matches(r calendarRoom) bool {
+	if b := strings.TrimSpace(f.Building); b != "" && !strings.EqualFold(b, r.BuildingID) {
+		return false
+	}
+	if fl := strings.TrimSpace(f.Floor); fl != "" && !strings.EqualFold(fl, r.Floor) {
+		return false
+	}
+	if r.Capacity < f.Capacity {
+		return false
+	}
+	have := lowerStringSet(r.Features)
+	for _, want := range f.Features {
+		if want = strings.ToLower(strings.TrimSpace(want)); want != "" {
+			if _, ok := have[want]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type calendarRoom struct {
+	Email       string   `json:"email"`
+	Name        string   `json:"name,omitempty"`
+	BuildingID  string   `json:"buildingId,omitempty"`
+	Floor       string   `json:"floor,omitempty"`
+	Capacity    int64    `json:"capacity,omitempty"`
+	Features    []string `json:"features,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+func calendarRoomFromResource(r *admin.CalendarResource) calendarRoom {
+	room := calendarRoom{
+		Email:       r.ResourceEmail,
+		Name:        r.ResourceName,
+		BuildingID:  r.BuildingId,
+		Floor:       r.FloorName,
+		Capacity:    r.Capacity,
+		Features:    roomFeatureNames(r.FeatureInstances),
+		Category:    r.ResourceCategory,
+		Description: r.UserVisibleDescription,
+	}
+	if room.Name == "" {
+		room.Name = r.GeneratedResourceName
+	}
+	return room
+}
+
+// roomFeatureNames extracts feature names from the untyped featureInstances
+// field, a list of {"feature": {"name": ...}} objects.
+func roomFeatureNames(instances any) []string {
+	if instances == nil {
+		return nil
+	}
+	raw, err := json.Marshal(instances)
+	if err != nil {
+		return nil
+	}
+	var parsed []struct {
+		Feature struct {
+			Name string `json:"name"`
+		} `json:"feature"`
+	}
+	if json.Unmarshal(raw, &parsed) != nil {
+		return nil
+	}
+	names := make([]string, 0, len(parsed))
+	for _, p := range parsed {
+		if p.Feature.Name != "" {
+			names = append(names, p.Feature.Name)
+		}
+	}
+	return names
+}
+
+// listCalendarRooms returns the domain's bookable resources that match the
+// filters, smallest first. Non-room resources (category OTHER) are skipped
+// unless includeOther is set.
+func listCalendarRooms(ctx context.Context, account string, filters RoomFilterFlags, includeOther bool) ([]calendarRoom, error) {
+	svc, err := newAdminDirectoryService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	var rooms []calendarRoom
+	err = svc.Resources.Calendars.List("my_customer").
+		MaxResults(adminResourcesPerPage).
+		Pages(ctx, func(resp *admin.CalendarResources) error {
+			for _, r := range resp.Items {
+				if r == nil || r.ResourceEmail == "" {
+					continue
+				}
+				if !includeOther && strings.EqualFold(r.ResourceCategory, roomCategoryOther) {
+					continue
+				}
+				room := calendarRoomFromResource(r)
+				if filters.matches(room) {
+					rooms = append(rooms, room)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, wrapAdminDirectoryError(err, account)
+	}
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if rooms[i].Capacity != rooms[j].Capacity {
+			return rooms[i].Capacity < rooms[j].Capacity
+		}
+		return strings.ToLower(rooms[i].Name) < strings.ToLower(rooms[j].Name)
+	})
+	return rooms, nil
+}
+
+// wrapAdminDirectoryError provides helpful error messages for common Admin SDK issues.
+func wrapAdminDirectoryError(err error, account string) error {
+	errStr := err.Error()
+	if strings.Contains(errStr, "accessNotConfigured") ||
+		strings.Contains(errStr, "Admin SDK API has not been used") {
+		return errfmt.NewUserFacingError("Admin SDK API is not enabled; enable it at: https://console.developers.google.com/apis/api/admin.googleapis.com/overview", err)
+	}
+	if isConsumerAccount(account) {
+		return errfmt.NewUserFacingError("Meeting rooms require a Google Workspace account; consumer accounts (gmail.com/googlemail.com) are not supported.", err)
+	}
+	if strings.Contains(errStr, "insufficientPermissions") ||
+		strings.Contains(errStr, "insufficient authentication scopes") ||
+		strings.Contains(errStr, "Not Authorized") {
+		return errfmt.NewUserFacingError("Insufficient permissions to read meeting rooms; re-authenticate with the rooms scope (gog auth add <account> --services rooms) and make sure your Workspace role can read calendar resources", err)
+	}
+	return err
+}
+
+type roomAvailability struct {
+	calendarRoom
+	Status string             `json:"status"`
+	Busy   []findTimeInterval `json:"-"`
+	Error  string             `json:"error,omitempty"`
+}
+
+const (
+	roomStatusFree    = "free"
+	roomStatusBusy    = "busy"
+	roomStatusUnknown = "unknown"
+)
+
+func roomsAvailability(ctx context.Context, svc *calendar.Service, rooms []calendarRoom, window findTimeInterval) ([]roomAvailability, error) {
+	ids := make([]string, 0, len(rooms))
+	for _, r := range rooms {
+		ids = append(ids, r.Email)
+	}
+	fb, err := queryFreeBusy(ctx, svc, ids, window)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]roomAvailability, 0, len(rooms))
+	for _, r := range rooms {
+		id := strings.ToLower(r.Email)
+		a := roomAvailability{calendarRoom: r, Busy: fb.busy[id]}
+		switch {
+		case fb.errs[id] != "":
+			a.Status, a.Error = roomStatusUnknown, fb.errs[id]
+		case len(a.Busy) > 0:
+			a.Status = roomStatusBusy
+		default:
+			a.Status = roomStatusFree
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+// pickFreeRoom returns the smallest matching room that is free for the whole
+// window. Rooms whose free/busy cannot be read are never picked.
+func pickFreeRoom(ctx context.Context, svc *calendar.Service, account string, filters RoomFilterFlags, window findTimeInterval) (*calendarRoom, error) {
+	rooms, err := listCalendarRooms(ctx, account, filters, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(rooms) == 0 {
+		return nil, usage("no meeting rooms match the filters")
+	}
+	avail, err := roomsAvailability(ctx, svc, rooms, window)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range avail {
+		if a.Status == roomStatusFree {
+			room := a.calendarRoom
+			return &room, nil
+		}
+	}
+	return nil, fmt.Errorf("none of the %d matching rooms is free from %s to %s", len(rooms),
+		window.Start.Format(time.RFC3339), window.End.Format(time.RFC3339))
+}
+
+// resolveRoom turns a --room value into a room: an email is used as-is,
+// "auto" picks a free room matching the filters for the window.
+func resolveRoom(ctx context.Context, svc *calendar.Service, account, value string, filters RoomFilterFlags, window func() (findTimeInterval, error)) (*calendarRoom, error) {
+	value = strings.TrimSpace(value)
+	if !strings.EqualFold(value, roomAuto) {
+		return &calendarRoom{Email: value}, nil
+	}
+	w, err := window()
+	if err != nil {
+		return nil, err
+	}
+	return pickFreeRoom(ctx, svc, account, filters, w)
+}
+
+func validateRoomFlags(room string, filters RoomFilterFlags) error {
+	if err := filters.validate(); err != nil {
+		return err
+	}
+	if filters.active() && !strings.EqualFold(strings.TrimSpace(room), roomAuto) {
+		return usage("--building, --floor, --capacity and --feature require --room auto")
+	}
+	return nil
+}
+
+// addRoomAttendee adds the room as a resource attendee (unless it is already
+// invited) and uses its name as the location when none is set.
+func addRoomAttendee(event *calendar.Event, room *calendarRoom) bool {
+	for _, a := range event.Attendees {
+		if a != nil && strings.EqualFold(a.Email, room.Email) {
+			return false
+		}
+	}
+	event.Attendees = append(event.Attendees, &calendar.EventAttendee{
+		Email:       room.Email,
+		DisplayName: room.Name,
+		Resource:    true,
+	})
+	if strings.TrimSpace(event.Location) == "" && room.Name != "" {
+		event.Location = room.Name
+	}
+	return true
+}
+
+type CalendarRoomsListCmd struct {
+	RoomFilterFlags
+	All bool `name:"all" help:"Include non-room resources (equipment, etc.)"`
+}
+
+func (c *CalendarRoomsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if err = c.validate(); err != nil {
+		return err
+	}
+
+	rooms, err := listCalendarRooms(ctx, account, c.RoomFilterFlags, c.All)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if rooms == nil {
+			rooms = []calendarRoom{}
+		}
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"rooms": rooms})
+	}
+	if len(rooms) == 0 {
+		u.Err().Println("No rooms")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "EMAIL\tNAME\tBUILDING\tFLOOR\tCAPACITY\tFEATURES")
+	for _, r := range rooms {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Email,
+			sanitizeTab(r.Name),
+			orDash(sanitizeTab(r.BuildingID)),
+			orDash(sanitizeTab(r.Floor)),
+			roomCapacity(r.Capacity),
+			orDash(sanitizeTab(strings.Join(r.Features, ", "))),
+		)
+	}
+	return nil
+}
+
+func roomCapacity(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	return strconv.FormatInt(n, 10)
+}
+
+type CalendarRoomsFreeBusyCmd struct {
+	TimeRangeFlags
+	RoomFilterFlags
+	FreeOnly bool `name:"free-only" help:"Only show rooms that are free for the whole window"`
+}
+
+func (c *CalendarRoomsFreeBusyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if err = c.RoomFilterFlags.validate(); err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tr, err := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, TimeRangeDefaults{
+		ToOffset:     time.Hour,
+		ToFromOffset: time.Hour,
+	})
+	if err != nil {
+		return err
+	}
+
+	rooms, err := listCalendarRooms(ctx, account, c.RoomFilterFlags, false)
+	if err != nil {
+		return err
+	}
+	avail := []roomAvailability{}
+	if len(rooms) > 0 {
+		avail, err = roomsAvailability(ctx, svc, rooms, findTimeInterval{Start: tr.From, End: tr.To})
+		if err != nil {
+			return err
+		}
+	}
+	if c.FreeOnly {
+		filtered := avail[:0]
+		for _, a := range avail {
+			if a.Status == roomStatusFree {
+				filtered = append(filtered, a)
+			}
+		}
+		avail = filtered
+	}
+
+	if outfmt.IsJSON(ctx) {
+		type busyItem struct {
+			Start string `json:"start"`
+			End   string `json:"end"`
+		}
+		type item struct {
+			roomAvailability
+			Busy []busyItem `json:"busy"`
+		}
+		items := make([]item, 0, len(avail))
+		for _, a := range avail {
+			it := item{roomAvailability: a, Busy: []busyItem{}}
+			for _, b := range a.Busy {
+				it.Busy = append(it.Busy, busyItem{Start: b.Start.In(tr.Location).Format(time.RFC3339), End: b.End.In(tr.Location).Format(time.RFC3339)})
+			}
+			items = append(items, it)
+		}
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"from":  tr.From.Format(time.RFC3339),
+			"to":    tr.To.Format(time.RFC3339),
+			"rooms": items,
+		})
+	}
+	if len(avail) == 0 {
+		u.Err().Println("No rooms")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "EMAIL\tNAME\tCAPACITY\tSTATUS\tBUSY")
+	for _, a := range avail {
+		busy := make([]string, 0, len(a.Busy))
+		for _, b := range a.Busy {
+			busy = append(busy, b.Start.In(tr.Location).Format("Jan 2 15:04")+"-"+b.End.In(tr.Location).Format("15:04"))
+		}
+		detail := strings.Join(busy, ", ")
+		if a.Error != "" {
+			detail = a.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", a.Email, sanitizeTab(a.Name), roomCapacity(a.Capacity), a.Status, orDash(detail))
+	}
+	return nil
+}
+
+type CalendarRoomsBookCmd struct {
+	EventID     string `arg:"" name:"eventId" help:"Event ID"`
+	CalendarID  string `name:"calendar" help:"Calendar ID containing the event" default:"primary"`
+	Room        string `name:"room" help:"Room email, or 'auto' to pick the smallest free room matching the filters" default:"auto"`
+	SendUpdates string `name:"send-updates" help:"Notification mode: all, externalOnly, none (default: none)"`
+	RoomFilterFlags
+}
+
+func (c *CalendarRoomsBookCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	eventID := strings.TrimSpace(c.EventID)
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if eventID == "" || calendarID == "" {
+		return usage("required: eventId and --calendar")
+	}
+	if strings.TrimSpace(c.Room) == "" {
+		return usage("--room must be a room email or 'auto'")
+	}
+	if err = validateRoomFlags(c.Room, c.RoomFilterFlags); err != nil {
+		return err
+	}
+	sendUpdates, err := validateSendUpdates(c.SendUpdates)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+	event, err := svc.Events.Get(calendarID, eventID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	room, err := resolveRoom(ctx, svc, account, c.Room, c.RoomFilterFlags, func() (findTimeInterval, error) {
+		return eventWindow(event)
+	})
+	if err != nil {
+		return err
+	}
+
+	patch := &calendar.Event{Attendees: event.Attendees, Location: event.Location}
+	if !addRoomAttendee(patch, room) {
+		return usage(fmt.Sprintf("%s is already on event %s", room.Email, eventID))
+	}
+	if patch.Location == event.Location {
+		patch.Location = ""
+	}
+	call := svc.Events.Patch(calendarID, eventID, patch).Context(ctx)
+	if sendUpdates != "" {
+		call = call.SendUpdates(sendUpdates)
+	} else {
+		call = call.SendUpdates(sendUpdatesNone)
+	}
+	updated, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		tz, loc, _ := getCalendarLocation(ctx, svc, calendarID)
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"room": room, "event": wrapEventWithDaysWithTimezone(updated, tz, loc)})
+	}
+	u.Out().Printf("room\t%s", room.Email)
+	if room.Name != "" {
+		u.Out().Printf("name\t%s", room.Name)
+	}
+	u.Out().Printf("event\t%s", updated.Id)
+	if updated.HtmlLink != "" {
+		u.Out().Printf("link\t%s", updated.HtmlLink)
+	}
+	return nil
+}
+
+// eventWindow returns the timed span of an event; all-day events have no
+// meaningful room window.
+func eventWindow(event *calendar.Event) (findTimeInterval, error) {
+	if event.Start == nil || event.End == nil || event.Start.DateTime == "" || event.End.DateTime == "" {
+		return findTimeInterval{}, usage("--room auto needs a timed event (not all-day)")
+	}
+	start, err := time.Parse(time.RFC3339, event.Start.DateTime)
+	if err != nil {
+		return findTimeInterval{}, fmt.Errorf("parse event start: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, event.End.DateTime)
+	if err != nil {
+		return findTimeInterval{}, fmt.Errorf("parse event end: %w", err)
+	}
+	return findTimeInterval{Start: start, End: end}, nil
+}
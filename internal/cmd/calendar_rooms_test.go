@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/calendar/v3"
+)
+
+// newCalendarRoomsTestServices serves three rooms (huddle for 4, small
+// board room for 8 that is busy 10:00-11:00 UTC on 2026-03-02, large board
+// room for 12) plus a projector, and records calendar writes.
+func newCalendarRoomsTestServices(t *testing.T) *[]recordedRequest {
+	t.Helper()
+	video := []map[string]any{{"feature": map[string]any{"name": "Video conference"}}}
+	stubGoogleService(t, &newAdminDirectoryService, admin.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/customer/my_customer/resources/calendars") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("pageToken") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"nextPageToken": "p2",
+				"items": []map[string]any{
+					{"resourceEmail": "large@resource.calendar.google.com", "resourceName": "Board Large", "buildingId": "HQ", "floorName": "2", "capacity": 12, "featureInstances": video, "resourceCategory": "CONFERENCE_ROOM"},
+					{"resourceEmail": "projector@resource.calendar.google.com", "resourceName": "Projector", "resourceCategory": "OTHER"},
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{"resourceEmail": "small@resource.calendar.google.com", "resourceName": "Board Small", "buildingId": "HQ", "floorName": "1", "capacity": 8, "featureInstances": video, "resourceCategory": "CONFERENCE_ROOM"},
+				{"resourceEmail": "huddle@resource.calendar.google.com", "resourceName": "Huddle", "buildingId": "HQ", "floorName": "1", "capacity": 4, "resourceCategory": "CONFERENCE_ROOM"},
+			},
+		})
+	}))
+
+	var reqs []recordedRequest
+	stubGoogleService(t, &newCalendarService, calendar.NewService, withPrimaryCalendar(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/freeBusy" && r.Method == http.MethodPost:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"calendars": map[string]any{
+					"small@resource.calendar.google.com":  map[string]any{"busy": []map[string]any{{"start": "2026-03-02T10:00:00Z", "end": "2026-03-02T11:00:00Z"}}},
+					"large@resource.calendar.google.com":  map[string]any{"busy": []map[string]any{}},
+					"huddle@resource.calendar.google.com": map[string]any{"busy": []map[string]any{}},
+				},
+			})
+			return
+		case r.URL.Path == "/calendars/primary/events/ev1" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":        "ev1",
+				"start":     map[string]any{"dateTime": "2026-03-02T10:00:00Z"},
+				"end":       map[string]any{"dateTime": "2026-03-02T10:30:00Z"},
+				"location":  "Somewhere",
+				"attendees": []map[string]any{{"email": "a@b.com", "self": true}},
+			})
+			return
+		}
+		recordRequest(&reqs, r)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "ev1"})
+	})))
+	return &reqs
+}
+
+func roomAttendee(t *testing.T, body map[string]any) map[string]any {
+	t.Helper()
+	attendees, _ := body["attendees"].([]any)
+	for _, a := range attendees {
+		if m := a.(map[string]any); m["resource"] == true {
+			return m
+		}
+	}
+	t.Fatalf("no resource attendee in %+v", body)
+	return nil
+}
+
+func TestExecute_CalendarRoomsList(t *testing.T) {
+	newCalendarRoomsTestServices(t)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "rooms", "--capacity", "6", "--feature", "video conference"}); err != nil {
+			t.Fatalf("rooms list: %v", err)
+		}
+	})
+	var parsed struct {
+		Rooms []calendarRoom `json:"rooms"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Rooms) != 2 || parsed.Rooms[0].Name != "Board Small" || parsed.Rooms[1].Name != "Board Large" {
+		t.Fatalf("expected rooms sorted by capacity, got %+v", parsed.Rooms)
+	}
+	if got := parsed.Rooms[0].Features; len(got) != 1 || got[0] != "Video conference" {
+		t.Fatalf("unexpected features: %+v", got)
+	}
+
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "calendar", "rooms", "list", "--all", "--floor", "1"}); err != nil {
+			t.Fatalf("rooms list text: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Huddle") || !strings.Contains(out, "Board Small") || strings.Contains(out, "Board Large") {
+		t.Fatalf("unexpected floor filter output:\n%s", out)
+	}
+}
+
+func TestExecute_CalendarRoomsFreeBusy(t *testing.T) {
+	newCalendarRoomsTestServices(t)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "rooms", "freebusy", "--from", "2026-03-02T10:00:00Z", "--to", "2026-03-02T11:00:00Z"}); err != nil {
+			t.Fatalf("rooms freebusy: %v", err)
+		}
+	})
+	var parsed struct {
+		Rooms []struct {
+			Email  string `json:"email"`
+			Status string `json:"status"`
+			Busy   []any  `json:"busy"`
+		} `json:"rooms"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	status := map[string]string{}
+	for _, r := range parsed.Rooms {
+		status[r.Email] = r.Status
+	}
+	if len(parsed.Rooms) != 3 || status["small@resource.calendar.google.com"] != roomStatusBusy || status["huddle@resource.calendar.google.com"] != roomStatusFree {
+		t.Fatalf("unexpected availability: %+v", parsed.Rooms)
+	}
+}
+
+func TestExecute_CalendarCreateWithRoom(t *testing.T) {
+	reqs := newCalendarRoomsTestServices(t)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "create", "primary",
+			"--summary", "Sync", "--from", "2026-03-02T10:00:00Z", "--to", "2026-03-02T10:30:00Z",
+			"--room", "auto", "--capacity", "6"}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	})
+	got := *reqs
+	if len(got) != 1 || got[0].Method != http.MethodPost || got[0].Path != "/calendars/primary/events" {
+		t.Fatalf("unexpected requests: %+v", got)
+	}
+	room := roomAttendee(t, got[0].Body)
+	if room["email"] != "large@resource.calendar.google.com" || got[0].Body["location"] != "Board Large" {
+		t.Fatalf("expected the free 12-person room, got %+v", got[0].Body)
+	}
+
+	for _, args := range [][]string{
+		{"--capacity", "8"},
+		{"--room", "small@resource.calendar.google.com", "--capacity", "8"},
+		{"--room", "auto", "--all-day"},
+	} {
+		base := []string{"--account", "a@b.com", "calendar", "create", "primary", "--summary", "x", "--from", "2026-03-02", "--to", "2026-03-03"}
+		if err := Execute(append(base, args...)); err == nil {
+			t.Fatalf("expected usage error for %v", args)
+		}
+	}
+}
+
+func TestExecute_CalendarRoomsBook(t *testing.T) {
+	reqs := newCalendarRoomsTestServices(t)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "rooms", "book", "ev1", "--building", "hq"}); err != nil {
+			t.Fatalf("book: %v", err)
+		}
+	})
+	got := *reqs
+	if len(got) != 1 || got[0].Method != http.MethodPatch || !strings.Contains(got[0].Query, "sendUpdates=none") {
+		t.Fatalf("unexpected requests: %+v", got)
+	}
+	if room := roomAttendee(t, got[0].Body); room["email"] != "huddle@resource.calendar.google.com" {
+		t.Fatalf("expected the smallest free room, got %+v", room)
+	}
+	if attendees := got[0].Body["attendees"].([]any); len(attendees) != 2 {
+		t.Fatalf("existing attendees must be kept: %+v", attendees)
+	}
+	if _, ok := got[0].Body["location"]; ok {
+		t.Fatalf("existing location must not be replaced: %+v", got[0].Body)
+	}
+}
@@ -12,6 +12,13 @@ import (
 	"github.com/steipete/gogcli/internal/ui"
 )
 
+// calendarSearchDefaults is the window searched when no range flags are set:
+// 30 days back through 90 days ahead.
+var calendarSearchDefaults = TimeRangeDefaults{
+	FromOffset: -30 * 24 * time.Hour,
+	ToOffset:   90 * 24 * time.Hour,
+}
+
 type CalendarSearchCmd struct {
 	Query string `arg:"" name:"query" help:"Search query"`
 	TimeRangeFlags
@@ -35,10 +42,7 @@ func (c *CalendarSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	timeRange, err := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, TimeRangeDefaults{
-		FromOffset: -30 * 24 * time.Hour,
-		ToOffset:   90 * 24 * time.Hour,
-	})
+	timeRange, err := ResolveTimeRangeWithDefaults(ctx, svc, c.TimeRangeFlags, calendarSearchDefaults)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const calendarSyncPageSize = 250
+
+type calendarSyncBatch struct {
+	Events        []*calendar.Event
+	NextSyncToken string
+	// Full is set when the batch is a complete listing of the calendar,
+	// either because there was no token or because the token expired.
+	Full bool
+}
+
+// listCalendarSync returns the events changed since syncToken, or every event
+// when syncToken is empty. An expired token (410 Gone) falls back to a full
+// sync, as the Calendar API requires.
+func listCalendarSync(ctx context.Context, svc *calendar.Service, calendarID, syncToken string) (calendarSyncBatch, error) {
+	batch, err := listCalendarSyncPages(ctx, svc, calendarID, syncToken)
+	if err != nil && syncToken != "" && isSyncTokenExpired(err) {
+		return listCalendarSyncPages(ctx, svc, calendarID, "")
+	}
+	return batch, err
+}
+
+func listCalendarSyncPages(ctx context.Context, svc *calendar.Service, calendarID, syncToken string) (calendarSyncBatch, error) {
+	out := calendarSyncBatch{Events: make([]*calendar.Event, 0), Full: syncToken == ""}
+	page := ""
+	for {
+		call := svc.Events.List(calendarID).MaxResults(calendarSyncPageSize).Context(ctx)
+		if syncToken != "" {
+			call = call.SyncToken(syncToken)
+		}
+		if page != "" {
+			call = call.PageToken(page)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return calendarSyncBatch{}, err
+		}
+		out.Events = append(out.Events, resp.Items...)
+		if resp.NextPageToken == "" {
+			if resp.NextSyncToken == "" {
+				return calendarSyncBatch{}, errors.New("calendar returned no sync token")
+			}
+			out.NextSyncToken = resp.NextSyncToken
+			return out, nil
+		}
+		page = resp.NextPageToken
+	}
+}
+
+func isSyncTokenExpired(err error) bool {
+	var gerr *googleapi.Error
+	return errors.As(err, &gerr) && gerr.Code == http.StatusGone
+}
+
+// runCalendarEventsSync implements `calendar events --sync`.
+func runCalendarEventsSync(ctx context.Context, svc *calendar.Service, account, calendarID string, resync bool) error {
+	u := ui.FromContext(ctx)
+
+	since := ""
+	if !resync {
+		state, ok, err := loadCalendarSyncState(account, calendarID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			since = state.SyncToken
+		}
+	}
+
+	batch, err := listCalendarSync(ctx, svc, calendarID, since)
+	if err != nil {
+		return err
+	}
+	if err := saveCalendarSyncState(account, calendarID, batch.NextSyncToken); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"calendarId":    calendarID,
+			"fullSync":      batch.Full,
+			"events":        wrapEventsWithDays(batch.Events),
+			"nextSyncToken": batch.NextSyncToken,
+		})
+	}
+
+	if batch.Full && since != "" {
+		u.Err().Println("Sync token expired; performed a full sync")
+	}
+	if len(batch.Events) == 0 {
+		if batch.Full {
+			u.Err().Println("No events")
+		} else {
+			u.Err().Println("No changes")
+		}
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tSTATUS\tSTART\tEND\tSUMMARY")
+	for _, e := range batch.Events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Id, orDash(e.Status), orDash(eventStart(e)), orDash(eventEnd(e)), e.Summary)
+	}
+	return nil
+}
+
+type calendarSyncState struct {
+	Account     string `json:"account"`
+	CalendarID  string `json:"calendarId"`
+	SyncToken   string `json:"syncToken"`
+	UpdatedAtMs int64  `json:"updatedAtMs,omitempty"`
+}
+
+func calendarSyncStatePath(account, calendarID string) (string, error) {
+	dir, err := config.EnsureCalendarSyncDir()
+	if err != nil {
+		return "", err
+	}
+	name := sanitizeAccountForPath(account) + "__" + sanitizeAccountForPath(calendarID)
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func loadCalendarSyncState(account, calendarID string) (calendarSyncState, bool, error) {
+	path, err := calendarSyncStatePath(account, calendarID)
+	if err != nil {
+		return calendarSyncState{}, false, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // config-dir path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return calendarSyncState{}, false, nil
+		}
+		return calendarSyncState{}, false, err
+	}
+	var state calendarSyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return calendarSyncState{}, false, fmt.Errorf("parse calendar sync state: %w", err)
+	}
+	return state, strings.TrimSpace(state.SyncToken) != "", nil
+}
+
+func saveCalendarSyncState(account, calendarID, token string) error {
+	if strings.TrimSpace(token) == "" {
+		return nil
+	}
+	path, err := calendarSyncStatePath(account, calendarID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(calendarSyncState{
+		Account:     account,
+		CalendarID:  calendarID,
+		SyncToken:   token,
+		UpdatedAtMs: time.Now().UnixMilli(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(payload, '\n'), 0o600)
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// newCalendarSyncTestService serves a primary calendar whose sync tokens map
+// to canned responses: no token is a two-page full listing ending in "t1",
+// "t1" returns one cancellation and "t2", and "t2" has expired (410).
+func newCalendarSyncTestService(t *testing.T) *[]string {
+	t.Helper()
+	var tokens []string
+	stubGoogleService(t, &newCalendarService, calendar.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/calendars/primary/events" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		tokens = append(tokens, q.Get("syncToken"))
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("syncToken") {
+		case "":
+			if q.Get("pageToken") == "" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"nextPageToken": "p2",
+					"items":         []map[string]any{{"id": "e1", "status": "confirmed", "summary": "Standup"}},
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"nextSyncToken": "t1",
+				"items":         []map[string]any{{"id": "e2", "status": "confirmed", "summary": "Review"}},
+			})
+		case "t1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"nextSyncToken": "t2",
+				"items":         []map[string]any{{"id": "e1", "status": "cancelled"}},
+			})
+		default:
+			w.WriteHeader(http.StatusGone)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 410, "message": "Sync token is no longer valid"}})
+		}
+	}))
+	return &tokens
+}
+
+func TestExecute_CalendarEventsSync(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tokens := newCalendarSyncTestService(t)
+
+	type syncOut struct {
+		FullSync      bool              `json:"fullSync"`
+		Events        []*calendar.Event `json:"events"`
+		NextSyncToken string            `json:"nextSyncToken"`
+	}
+	run := func() syncOut {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "calendar", "events", "--sync"}); err != nil {
+				t.Fatalf("sync: %v", err)
+			}
+		})
+		var parsed syncOut
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json: %v\n%s", err, out)
+		}
+		return parsed
+	}
+
+	first := run()
+	if !first.FullSync || len(first.Events) != 2 || first.NextSyncToken != "t1" {
+		t.Fatalf("unexpected full sync: %+v", first)
+	}
+	second := run()
+	if second.FullSync || len(second.Events) != 1 || second.Events[0].Status != "cancelled" {
+		t.Fatalf("unexpected incremental sync: %+v", second)
+	}
+	third := run()
+	if !third.FullSync || len(third.Events) != 2 || third.NextSyncToken != "t1" {
+		t.Fatalf("expected a full resync after 410, got %+v", third)
+	}
+	if got := strings.Join(*tokens, ","); got != ",,t1,t2,," {
+		t.Fatalf("unexpected sync tokens sent: %q", got)
+	}
+
+	for _, args := range [][]string{
+		{"calendar", "events", "--sync", "--today"},
+		{"calendar", "events", "--sync", "--all"},
+		{"calendar", "events", "--resync"},
+	} {
+		if err := Execute(append([]string{"--account", "a@b.com"}, args...)); err == nil {
+			t.Fatalf("expected usage error for %v", args)
+		}
+	}
+}
+
+func TestCalendarWatchCmd_OnceForwardsChangesToHook(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tokens := newCalendarSyncTestService(t)
+
+	var got calendarHookPayload
+	var auth string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hook.Close()
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	if execErr := runKong(t, &CalendarWatchCmd{}, []string{"--once", "--hook-url", hook.URL, "--hook-token", "secret"}, ctx, flags); execErr != nil {
+		t.Fatalf("execute: %v", execErr)
+	}
+	// The initial full sync only establishes the token; the poll forwards the
+	// cancellation that follows it.
+	if strings.Join(*tokens, ",") != ",,t1" {
+		t.Fatalf("unexpected sync tokens sent: %q", *tokens)
+	}
+	if auth != "Bearer secret" || got.Source != "calendar" || got.CalendarID != "primary" || got.SyncToken != "t2" || got.FullSync {
+		t.Fatalf("unexpected payload: %#v (auth %q)", got, auth)
+	}
+	if len(got.Events) != 1 || got.Events[0].Id != "e1" || got.Events[0].Status != "cancelled" {
+		t.Fatalf("unexpected events: %#v", got.Events)
+	}
+	state, ok, err := loadCalendarSyncState("a@b.com", calendarWatchStateKey("primary"))
+	if err != nil || !ok || state.SyncToken != "t2" {
+		t.Fatalf("expected stored token t2, got %#v ok=%v err=%v", state, ok, err)
+	}
+	if _, ok, _ := loadCalendarSyncState("a@b.com", "primary"); ok {
+		t.Fatalf("watch must not advance the `calendar events --sync` token")
+	}
+
+	if execErr := runKong(t, &CalendarWatchCmd{}, []string{"--address", "http://example.com/hook"}, ctx, flags); execErr == nil ||
+		!strings.Contains(execErr.Error(), "https://") {
+		t.Fatalf("expected https validation error, got %v", execErr)
+	}
+}
+
+func TestCalendarWatchCmd_PushRenewsAndStopsChannel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var (
+		mu      sync.Mutex
+		watches []string
+		stopped []string
+	)
+	firstStopped := make(chan struct{})
+	stubGoogleService(t, &newCalendarService, calendar.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/calendars/primary/events":
+			_ = json.NewEncoder(w).Encode(map[string]any{"nextSyncToken": "t1"})
+		case "/calendars/primary/events/watch":
+			var ch calendar.Channel
+			_ = json.NewDecoder(r.Body).Decode(&ch)
+			mu.Lock()
+			watches = append(watches, ch.Id)
+			resp := map[string]any{"id": ch.Id, "resourceId": "res-" + ch.Id}
+			if len(watches) == 1 {
+				// Expires right away so the renewal fires immediately.
+				resp["expiration"] = strconv.FormatInt(time.Now().Add(time.Second).UnixMilli(), 10)
+			}
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(resp)
+		case "/channels/stop":
+			var ch calendar.Channel
+			_ = json.NewDecoder(r.Body).Decode(&ch)
+			mu.Lock()
+			stopped = append(stopped, ch.Id+"/"+ch.ResourceId)
+			if len(stopped) == 1 {
+				close(firstStopped)
+			}
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	origListen := listenAndServe
+	t.Cleanup(func() { listenAndServe = origListen })
+	listenAndServe = func(*http.Server) error {
+		<-firstStopped
+		return http.ErrServerClosed
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+	if err := runKong(t, &CalendarWatchCmd{}, []string{"--address", "https://example.com/hook"}, ctx, flags); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(watches) != 2 {
+		t.Fatalf("expected the channel to be renewed once, got %v", watches)
+	}
+	want := []string{watches[0] + "/res-" + watches[0], watches[1] + "/res-" + watches[1]}
+	if len(stopped) != 2 || stopped[0] != want[0] || stopped[1] != want[1] {
+		t.Fatalf("expected old channel stopped on renewal and new one on exit, got %v (want %v)", stopped, want)
+	}
+}
+
+func TestCalendarWatchServer_Notifications(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	tokens := newCalendarSyncTestService(t)
+	svc, err := newCalendarService(context.Background(), "a@b.com")
+	if err != nil {
+		t.Fatalf("svc: %v", err)
+	}
+
+	var out strings.Builder
+	watcher := &calendarWatcher{
+		account:    "a@b.com",
+		calendarID: "primary",
+		svc:        svc,
+		out:        &out,
+		warnf:      func(string, ...any) {},
+		syncToken:  "t1",
+	}
+	srv := &watchPushServer{
+		path:      "/calendar-events",
+		channelID: "chan",
+		token:     "tok",
+		poll: func(ctx context.Context) error {
+			_, err := watcher.poll(ctx)
+			return err
+		},
+		warnf: func(string, ...any) {},
+	}
+	send := func(token, state string) int {
+		req := httptest.NewRequest(http.MethodPost, "/calendar-events", nil)
+		req.Header.Set("X-Goog-Channel-ID", "chan")
+		req.Header.Set("X-Goog-Channel-Token", token)
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send("wrong", "exists"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+	if code := send("tok", "sync"); code != http.StatusOK || len(*tokens) != 0 {
+		t.Fatalf("expected sync ack without polling, got code=%d calls=%d", code, len(*tokens))
+	}
+	if code := send("tok", "exists"); code != http.StatusOK || len(*tokens) != 1 {
+		t.Fatalf("expected change to poll, got code=%d calls=%d", code, len(*tokens))
+	}
+	if !strings.Contains(out.String(), `"id":"e1"`) || watcher.syncToken != "t2" {
+		t.Fatalf("unexpected output %q token %q", out.String(), watcher.syncToken)
+	}
+}
@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type CalendarWatchCmd struct {
+	CalendarID   string `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
+	Interval     string `name:"interval" help:"Polling interval (seconds or Go duration)" default:"60s"`
+	Once         bool   `name:"once" help:"Poll once and exit (polling mode only)"`
+	HookURL      string `name:"hook-url" help:"Webhook URL to forward changed events (default: print NDJSON to stdout)"`
+	HookToken    string `name:"hook-token" help:"Webhook bearer token"`
+	Address      string `name:"address" help:"Public HTTPS URL that reaches this receiver; registers an events.watch channel instead of polling"`
+	Bind         string `name:"bind" help:"Bind address (push mode)" default:"127.0.0.1"`
+	Port         int    `name:"port" help:"Listen port (push mode)" default:"8790"`
+	Path         string `name:"path" help:"Notification handler path (push mode)" default:"/calendar-events"`
+	ChannelToken string `name:"token" help:"Channel token expected in X-Goog-Channel-Token (push mode; default: random)"`
+	TTL          string `name:"ttl" help:"Requested channel lifetime (push mode; seconds or Go duration)"`
+}
+
+func (c *CalendarWatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	calendarID := strings.TrimSpace(c.CalendarID)
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	interval, ttl, err := watchFlags{
+		service:      "Calendar",
+		interval:     c.Interval,
+		once:         c.Once,
+		hookURL:      c.HookURL,
+		hookToken:    c.HookToken,
+		address:      c.Address,
+		path:         c.Path,
+		port:         c.Port,
+		channelToken: c.ChannelToken,
+		ttl:          c.TTL,
+	}.validate()
+	if err != nil {
+		return err
+	}
+	address := strings.TrimSpace(c.Address)
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	watcher := &calendarWatcher{
+		account:    account,
+		calendarID: calendarID,
+		svc:        svc,
+		hookURL:    strings.TrimSpace(c.HookURL),
+		hookToken:  c.HookToken,
+		hookClient: &http.Client{Timeout: defaultHookRequestTimeoutSec * time.Second},
+		out:        os.Stdout,
+		warnf:      u.Err().Printf,
+	}
+	if err := watcher.init(ctx); err != nil {
+		return err
+	}
+
+	if address == "" {
+		return watcher.pollLoop(ctx, interval, c.Once)
+	}
+
+	token := strings.TrimSpace(c.ChannelToken)
+	if token == "" {
+		token, err = randomDriveChannelToken()
+		if err != nil {
+			return err
+		}
+	}
+	channel, err := watcher.registerChannel(ctx, strings.TrimSuffix(address, "/"), token, ttl)
+	if err != nil {
+		return err
+	}
+	u.Err().Printf("watch: channel %s (resource %s)", channel.Id, channel.ResourceId)
+	if channel.Expiration > 0 {
+		u.Err().Printf("watch: channel expires %s", formatUnixMillis(channel.Expiration))
+	}
+
+	addr := net.JoinHostPort(c.Bind, strconv.Itoa(c.Port))
+	u.Err().Printf("watch: listening on %s%s", addr, c.Path)
+	handler := &watchPushServer{
+		path:      c.Path,
+		channelID: channel.Id,
+		token:     token,
+		poll: func(ctx context.Context) error {
+			_, err := watcher.poll(ctx)
+			return err
+		},
+		warnf: u.Err().Printf,
+	}
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return serveWatchChannel(ctx, httpServer, watchChannel{
+		ID:         channel.Id,
+		ResourceID: channel.ResourceId,
+		Expiration: channel.Expiration,
+	}, watchChannelOps{
+		renew: func(ctx context.Context) (watchChannel, error) {
+			next, err := watcher.registerChannel(ctx, strings.TrimSuffix(address, "/"), token, ttl)
+			if err != nil {
+				return watchChannel{}, err
+			}
+			handler.setChannelID(next.Id)
+			return watchChannel{ID: next.Id, ResourceID: next.ResourceId, Expiration: next.Expiration}, nil
+		},
+		stop: func(ctx context.Context, ch watchChannel) error {
+			return svc.Channels.Stop(&calendar.Channel{Id: ch.ID, ResourceId: ch.ResourceID}).Context(ctx).Do()
+		},
+		warnf: u.Err().Printf,
+	})
+}
+
+type calendarHookPayload struct {
+	Source     string            `json:"source"`
+	Account    string            `json:"account"`
+	CalendarID string            `json:"calendarId"`
+	SyncToken  string            `json:"syncToken"`
+	FullSync   bool              `json:"fullSync,omitempty"`
+	Events     []*calendar.Event `json:"events"`
+}
+
+type calendarWatcher struct {
+	account    string
+	calendarID string
+	svc        *calendar.Service
+	hookURL    string
+	hookToken  string
+	hookClient *http.Client
+	out        io.Writer
+	warnf      func(string, ...any)
+
+	mu        sync.Mutex
+	syncToken string
+}
+
+// calendarWatchStateKey names the watcher's sync state. It is kept apart from
+// the token `calendar events --sync` advances so neither consumer skips
+// changes only the other has seen.
+func calendarWatchStateKey(calendarID string) string {
+	return calendarID + "__watch"
+}
+
+// init loads the watcher's stored sync token or runs a silent full sync so
+// that only later changes are forwarded.
+func (w *calendarWatcher) init(ctx context.Context) error {
+	state, ok, err := loadCalendarSyncState(w.account, calendarWatchStateKey(w.calendarID))
+	if err != nil {
+		return err
+	}
+	if ok {
+		w.syncToken = state.SyncToken
+		return nil
+	}
+	batch, err := listCalendarSync(ctx, w.svc, w.calendarID, "")
+	if err != nil {
+		return err
+	}
+	w.syncToken = batch.NextSyncToken
+	return saveCalendarSyncState(w.account, calendarWatchStateKey(w.calendarID), batch.NextSyncToken)
+}
+
+func (w *calendarWatcher) pollLoop(ctx context.Context, interval time.Duration, once bool) error {
+	for {
+		if _, err := w.poll(ctx); err != nil {
+			if once {
+				return err
+			}
+			w.warnf("watch: poll failed: %v", err)
+		}
+		if once {
+			return nil
+		}
+		if err := driveWatchSleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// poll forwards every event changed since the stored sync token. When the
+// token has expired the whole calendar is forwarded with fullSync set, so
+// receivers can replace their copy. The token only advances after delivery
+// succeeds.
+func (w *calendarWatcher) poll(ctx context.Context) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	batch, err := listCalendarSync(ctx, w.svc, w.calendarID, w.syncToken)
+	if err != nil {
+		return 0, err
+	}
+	if batch.Full {
+		w.warnf("watch: sync token expired; forwarding a full resync of %d events", len(batch.Events))
+	}
+	if len(batch.Events) > 0 {
+		if err := w.deliver(ctx, batch); err != nil {
+			return 0, err
+		}
+	}
+	if batch.NextSyncToken != w.syncToken {
+		w.syncToken = batch.NextSyncToken
+		if err := saveCalendarSyncState(w.account, calendarWatchStateKey(w.calendarID), batch.NextSyncToken); err != nil {
+			return len(batch.Events), err
+		}
+	}
+	return len(batch.Events), nil
+}
+
+func (w *calendarWatcher) deliver(ctx context.Context, batch calendarSyncBatch) error {
+	if w.hookURL == "" {
+		enc := json.NewEncoder(w.out)
+		for _, ev := range batch.Events {
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return postWatchHook(ctx, w.hookClient, w.hookURL, w.hookToken, calendarHookPayload{
+		Source:     "calendar",
+		Account:    w.account,
+		CalendarID: w.calendarID,
+		SyncToken:  batch.NextSyncToken,
+		FullSync:   batch.Full,
+		Events:     batch.Events,
+	})
+}
+
+func (w *calendarWatcher) registerChannel(ctx context.Context, address, token string, ttl time.Duration) (*calendar.Channel, error) {
+	id, err := randomDriveChannelToken()
+	if err != nil {
+		return nil, err
+	}
+	req := &calendar.Channel{
+		Id:      "gog-" + id,
+		Type:    "web_hook",
+		Address: address,
+		Token:   token,
+	}
+	if ttl > 0 {
+		req.Expiration = time.Now().Add(ttl).UnixMilli()
+	}
+	return w.svc.Events.Watch(w.calendarID, req).Context(ctx).Do()
+}
@@ -3,8 +3,6 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,20 +24,27 @@ import (
 var newDocsService = googleapi.NewDocs
 
 type DocsCmd struct {
-	Export DocsExportCmd `cmd:"" name:"export" help:"Export a Google Doc (pdf|docx|txt)"`
-	Info   DocsInfoCmd   `cmd:"" name:"info" help:"Get Google Doc metadata"`
-	Create DocsCreateCmd `cmd:"" name:"create" help:"Create a Google Doc"`
-	Copy   DocsCopyCmd   `cmd:"" name:"copy" help:"Copy a Google Doc"`
-	Cat    DocsCatCmd    `cmd:"" name:"cat" help:"Print a Google Doc as plain text"`
-	Edit   DocsEditCmd   `cmd:"" name:"edit" help:"Edit Google Doc content"`
+	Export   DocsExportCmd   `cmd:"" name:"export" help:"Export a Google Doc (pdf|docx|txt)"`
+	Info     DocsInfoCmd     `cmd:"" name:"info" help:"Get Google Doc metadata"`
+	Create   DocsCreateCmd   `cmd:"" name:"create" help:"Create a Google Doc"`
+	Copy     DocsCopyCmd     `cmd:"" name:"copy" help:"Copy a Google Doc"`
+	Generate DocsGenerateCmd `cmd:"" name:"generate" help:"Generate Google Docs from a template and JSON/CSV data"`
+	Cat      DocsCatCmd      `cmd:"" name:"cat" help:"Print a Google Doc as plain text or Markdown"`
+	Edit     DocsEditCmd     `cmd:"" name:"edit" help:"Edit Google Doc content"`
 }
 
 type DocsEditCmd struct {
-	Append  DocsAppendCmd  `cmd:"" name:"append" help:"Append text to the end of a Google Doc"`
-	Batch   DocsBatchCmd   `cmd:"" name:"batch" help:"Apply multiple Docs API edit operations from JSON"`
-	Delete  DocsDeleteCmd  `cmd:"" name:"delete" help:"Delete a text range in a Google Doc"`
-	Insert  DocsInsertCmd  `cmd:"" name:"insert" help:"Insert text at a specific index in a Google Doc"`
-	Replace DocsReplaceCmd `cmd:"" name:"replace" help:"Replace text throughout a Google Doc"`
+	Append         DocsAppendCmd         `cmd:"" name:"append" help:"Append text to the end of a Google Doc"`
+	Batch          DocsBatchCmd          `cmd:"" name:"batch" help:"Apply multiple Docs API edit operations from JSON"`
+	Delete         DocsDeleteCmd         `cmd:"" name:"delete" help:"Delete a text range in a Google Doc"`
+	Insert         DocsInsertCmd         `cmd:"" name:"insert" help:"Insert text at a specific index in a Google Doc"`
+	Replace        DocsReplaceCmd        `cmd:"" name:"replace" help:"Replace text throughout a Google Doc"`
+	ImportMarkdown DocsImportMarkdownCmd `cmd:"" name:"import-markdown" help:"Append or replace Google Doc content from a Markdown file"`
+	Table          DocsTableCmd          `cmd:"" name:"table" help:"Insert tables, add rows and set cells"`
+	Image          DocsImageCmd          `cmd:"" name:"image" help:"Insert images"`
+	Style          DocsStyleCmd          `cmd:"" name:"style" help:"Apply heading and text styles to a range or matched text"`
+	Bullets        DocsBulletsCmd        `cmd:"" name:"bullets" help:"Add or remove bullets on a range or matched text"`
+	NamedRange     DocsNamedRangeCmd     `cmd:"" name:"named-range" help:"List, create and fill named ranges"`
 }
 
 type DocsEditSafetyFlags struct {
@@ -47,74 +52,8 @@ type DocsEditSafetyFlags struct {
 	RequireRevision string `name:"require-revision" help:"Require this document revision ID for update (optimistic concurrency guard)"`
 }
 
-type docsEditError struct {
-	Operation    string
-	DocID        string
-	ErrorCode    string
-	Message      string
-	HTTPStatus   int
-	GoogleReason string
-	RequestIndex *int
-	Cause        error
-}
-
-func (e *docsEditError) Error() string {
-	if e == nil {
-		return ""
-	}
-	if strings.TrimSpace(e.Message) != "" {
-		return e.Message
-	}
-	if e.Cause != nil {
-		return e.Cause.Error()
-	}
-	return "docs edit failed"
-}
-
-func (e *docsEditError) Unwrap() error {
-	if e == nil {
-		return nil
-	}
-	return e.Cause
-}
-
-func (e *docsEditError) JSONErrorFields() map[string]any {
-	if e == nil {
-		return map[string]any{}
-	}
-	fields := map[string]any{
-		"error_code": e.ErrorCode,
-		"operation":  e.Operation,
-		"doc_id":     e.DocID,
-	}
-	if e.HTTPStatus > 0 {
-		fields["http_status"] = e.HTTPStatus
-	}
-	if strings.TrimSpace(e.GoogleReason) != "" {
-		fields["google_reason"] = e.GoogleReason
-	}
-	if e.RequestIndex != nil {
-		fields["request_index"] = *e.RequestIndex
-	}
-	return fields
-}
-
 func newDocsEditError(op, docID, code, msg string, cause error) error {
-	e := &docsEditError{
-		Operation: op,
-		DocID:     strings.TrimSpace(docID),
-		ErrorCode: strings.TrimSpace(code),
-		Message:   strings.TrimSpace(msg),
-		Cause:     cause,
-	}
-	var apiErr *gapi.Error
-	if errors.As(cause, &apiErr) {
-		e.HTTPStatus = apiErr.Code
-		if len(apiErr.Errors) > 0 && strings.TrimSpace(apiErr.Errors[0].Reason) != "" {
-			e.GoogleReason = strings.TrimSpace(apiErr.Errors[0].Reason)
-		}
-	}
-	return e
+	return newEditError("doc_id", op, docID, code, msg, cause)
 }
 
 type DocsBatchCmd struct {
@@ -166,25 +105,25 @@ func (c *DocsBatchCmd) Run(ctx context.Context, flags *RootFlags) error {
 		if docsRequestOperationCount(r) != 1 {
 			idx := i
 			err := newDocsEditError("batch", docID, "invalid_request", fmt.Sprintf("request[%d] must set exactly one operation field", i), usage(fmt.Sprintf("request[%d] must set exactly one operation field", i)))
-			if de, ok := err.(*docsEditError); ok {
+			if de, ok := err.(*editError); ok {
 				de.RequestIndex = &idx
 			}
 			return err
 		}
 	}
 	applyDocsEditSafety(&req, c.Safety)
-	requestHash, hashErr := docsRequestHash(&req)
+	requestHash, hashErr := editRequestHash(&req)
 	if hashErr != nil {
 		return newDocsEditError("batch", docID, "invalid_request", "failed to hash normalized request", hashErr)
 	}
 	normalizedForJSON := ""
 	if strings.TrimSpace(c.OutputRequestFile) == "-" && outfmt.IsJSON(ctx) {
-		norm, normErr := docsNormalizedRequestString(&req)
+		norm, normErr := editNormalizedRequestString(&req)
 		if normErr != nil {
 			return newDocsEditError("batch", docID, "invalid_request", "failed to normalize request", normErr)
 		}
 		normalizedForJSON = norm
-	} else if err := docsMaybeWriteNormalizedRequest(c.OutputRequestFile, &req); err != nil {
+	} else if err := editMaybeWriteNormalizedRequest(c.OutputRequestFile, &req); err != nil {
 		return newDocsEditError("batch", docID, "output_write_failed", "write normalized request failed", err)
 	}
 	requestKinds := make([]string, 0, len(req.Requests))
@@ -630,8 +569,9 @@ func (c *DocsInfoCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DocsCreateCmd struct {
-	Title  string `arg:"" name:"title" help:"Doc title"`
-	Parent string `name:"parent" help:"Destination folder ID"`
+	Title        string `arg:"" name:"title" help:"Doc title"`
+	Parent       string `name:"parent" help:"Destination folder ID"`
+	FromMarkdown string `name:"from-markdown" help:"Fill the new doc from a Markdown file ('-' for stdin)"`
 }
 
 func (c *DocsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -646,6 +586,15 @@ func (c *DocsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty title")
 	}
 
+	var blocks []*docsMarkdownBlock
+	if strings.TrimSpace(c.FromMarkdown) != "" {
+		src, readErr := readDocsMarkdownInput(c.FromMarkdown)
+		if readErr != nil {
+			return readErr
+		}
+		blocks = parseDocsMarkdown(src)
+	}
+
 	svc, err := newDriveService(ctx, account)
 	if err != nil {
 		return err
@@ -672,7 +621,21 @@ func (c *DocsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return errors.New("create failed")
 	}
 
+	if len(blocks) > 0 {
+		docsSvc, svcErr := newDocsService(ctx, account)
+		if svcErr != nil {
+			return fmt.Errorf("created doc %s but could not import markdown: %w", created.Id, svcErr)
+		}
+		req := &docs.BatchUpdateDocumentRequest{Requests: docsMarkdownRequests(blocks, 1)}
+		if _, err := docsSvc.Documents.BatchUpdate(created.Id, req).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("created doc %s but could not import markdown: %w", created.Id, err)
+		}
+	}
+
 	if outfmt.IsJSON(ctx) {
+		if len(blocks) > 0 {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{strFile: created, "markdownBlocks": len(blocks)})
+		}
 		return outfmt.WriteJSON(os.Stdout, map[string]any{strFile: created})
 	}
 
@@ -682,6 +645,9 @@ func (c *DocsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if created.WebViewLink != "" {
 		u.Out().Printf("link\t%s", created.WebViewLink)
 	}
+	if len(blocks) > 0 {
+		u.Out().Printf("blocks\t%d", len(blocks))
+	}
 	return nil
 }
 
@@ -692,16 +658,19 @@ type DocsCopyCmd struct {
 }
 
 func (c *DocsCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
-	return copyViaDrive(ctx, flags, copyViaDriveOptions{
-		ArgName:      "docId",
-		ExpectedMime: "application/vnd.google-apps.document",
-		KindLabel:    "Google Doc",
-	}, c.DocID, c.Title, c.Parent)
+	return copyViaDrive(ctx, flags, docsCopyOptions, c.DocID, c.Title, c.Parent)
+}
+
+var docsCopyOptions = copyViaDriveOptions{
+	ArgName:      "docId",
+	ExpectedMime: "application/vnd.google-apps.document",
+	KindLabel:    "Google Doc",
 }
 
 type DocsCatCmd struct {
 	DocID    string `arg:"" name:"docId" help:"Doc ID"`
 	MaxBytes int64  `name:"max-bytes" help:"Max bytes to read (0 = unlimited)" default:"2000000"`
+	Format   string `name:"format" help:"Output format: text|markdown" enum:"text,markdown" default:"text"`
 }
 
 func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -733,6 +702,15 @@ func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return errors.New("doc not found")
 	}
 
+	if c.Format == "markdown" {
+		md := docsMarkdown(doc, c.MaxBytes)
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{"markdown": md})
+		}
+		_, err = io.WriteString(os.Stdout, md)
+		return err
+	}
+
 	text := docsPlainText(doc, c.MaxBytes)
 
 	if outfmt.IsJSON(ctx) {
@@ -928,44 +906,3 @@ func docsRequestOperationName(r *docs.Request) string {
 	}
 	return ""
 }
-
-func docsMaybeWriteNormalizedRequest(path string, req *docs.BatchUpdateDocumentRequest) error {
-	path = strings.TrimSpace(path)
-	if path == "" || req == nil {
-		return nil
-	}
-	pretty, err := json.MarshalIndent(req, "", "  ")
-	if err != nil {
-		return err
-	}
-	pretty = append(pretty, '\n')
-	if path == "-" {
-		_, err = os.Stdout.Write(pretty)
-		return err
-	}
-	return os.WriteFile(path, pretty, 0o600)
-}
-
-func docsNormalizedRequestString(req *docs.BatchUpdateDocumentRequest) (string, error) {
-	if req == nil {
-		return "", errors.New("nil request")
-	}
-	pretty, err := json.MarshalIndent(req, "", "  ")
-	if err != nil {
-		return "", err
-	}
-	pretty = append(pretty, '\n')
-	return string(pretty), nil
-}
-
-func docsRequestHash(req *docs.BatchUpdateDocumentRequest) (string, error) {
-	if req == nil {
-		return "", errors.New("nil request")
-	}
-	b, err := json.Marshal(req)
-	if err != nil {
-		return "", err
-	}
-	sum := sha256.Sum256(b)
-	return hex.EncodeToString(sum[:]), nil
-}
@@ -0,0 +1,915 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// DocsAnchorFlags pick an insertion point by text instead of a raw index.
+type DocsAnchorFlags struct {
+	Index      int64  `name:"index" help:"Insertion index (1-based); defaults to the end of the document"`
+	After      string `name:"after" help:"Insert at the end of the paragraph containing this text"`
+	Before     string `name:"before" help:"Insert at the start of the paragraph containing this text"`
+	Occurrence int    `name:"occurrence" help:"Which match of --after/--before to use (1-based)" default:"1"`
+	MatchCase  bool   `name:"match-case" help:"Case-sensitive text matching"`
+}
+
+// DocsRangeFlags select the text an edit applies to.
+type DocsRangeFlags struct {
+	Range      string `name:"range" help:"Index range START:END (end exclusive)"`
+	Match      string `name:"match" help:"Apply to text matching this string"`
+	Occurrence int    `name:"occurrence" help:"Which match of --match to use (1-based; 0 = all)" default:"1"`
+	MatchCase  bool   `name:"match-case" help:"Case-sensitive text matching"`
+}
+
+// DocsTableFlags select a table in the document body.
+type DocsTableFlags struct {
+	Table      int    `name:"table" help:"Table number in document order (1-based)" default:"1"`
+	TableMatch string `name:"table-match" help:"Use the first table containing this text"`
+}
+
+type DocsTableCmd struct {
+	Insert  DocsTableInsertCmd  `cmd:"" name:"insert" help:"Insert a table, optionally filled from CSV"`
+	AddRow  DocsTableAddRowCmd  `cmd:"" name:"add-row" help:"Add a row to a table"`
+	SetCell DocsTableSetCellCmd `cmd:"" name:"set-cell" help:"Replace the text of a table cell"`
+}
+
+type DocsImageCmd struct {
+	Insert DocsImageInsertCmd `cmd:"" name:"insert" help:"Insert an image from a public URL"`
+}
+
+type DocsNamedRangeCmd struct {
+	List           DocsNamedRangeListCmd           `cmd:"" name:"list" help:"List named ranges"`
+	Create         DocsNamedRangeCreateCmd         `cmd:"" name:"create" help:"Create a named range"`
+	ReplaceContent DocsNamedRangeReplaceContentCmd `cmd:"" name:"replace-content" help:"Replace the content of a named range"`
+}
+
+type docsParagraphRef struct {
+	start, end int64
+	text       string
+}
+
+type docsTextMatch struct {
+	start, end int64
+	para       docsParagraphRef
+}
+
+// docsBodyParagraphs lists body paragraphs in document order, including
+// those inside table cells.
+func docsBodyParagraphs(content []*docs.StructuralElement) []docsParagraphRef {
+	var out []docsParagraphRef
+	for _, el := range content {
+		switch {
+		case el == nil:
+		case el.Paragraph != nil:
+			_, text := docsParagraphIndexText(el.Paragraph)
+			out = append(out, docsParagraphRef{start: el.StartIndex, end: el.EndIndex, text: text})
+		case el.Table != nil:
+			for _, row := range el.Table.TableRows {
+				for _, cell := range row.TableCells {
+					out = append(out, docsBodyParagraphs(cell.Content)...)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// findDocsText returns every occurrence of text in the body. Matches do not
+// span paragraphs.
+func findDocsText(doc *docs.Document, text string, matchCase bool) []docsTextMatch {
+	if doc == nil || doc.Body == nil || text == "" {
+		return nil
+	}
+	pattern := regexp.QuoteMeta(text)
+	if !matchCase {
+		pattern = "(?i)" + pattern
+	}
+	re := regexp.MustCompile(pattern)
+	var out []docsTextMatch
+	for _, p := range docsBodyParagraphs(doc.Body.Content) {
+		for _, loc := range re.FindAllStringIndex(p.text, -1) {
+			start := p.start + docsUTF16Len(p.text[:loc[0]])
+			out = append(out, docsTextMatch{start: start, end: start + docsUTF16Len(p.text[loc[0]:loc[1]]), para: p})
+		}
+	}
+	return out
+}
+
+func (a DocsAnchorFlags) resolve(op string, doc *docs.Document) (int64, error) {
+	docID := doc.DocumentId
+	set := 0
+	for _, on := range []bool{a.Index != 0, a.After != "", a.Before != ""} {
+		if on {
+			set++
+		}
+	}
+	if set > 1 {
+		return 0, newDocsEditError(op, docID, "invalid_argument", "use only one of --index, --after and --before", usage("use only one of --index, --after and --before"))
+	}
+	end := docsAppendIndex(doc)
+	switch {
+	case a.Index != 0:
+		if a.Index < 1 || a.Index > end {
+			msg := fmt.Sprintf("index must be between 1 and %d", end)
+			return 0, newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+		}
+		return a.Index, nil
+	case a.After != "" || a.Before != "":
+		text := a.After + a.Before
+		m, err := pickDocsMatch(op, doc, text, a.MatchCase, a.Occurrence)
+		if err != nil {
+			return 0, err
+		}
+		if a.After != "" {
+			return m.para.end - 1, nil
+		}
+		return m.para.start, nil
+	default:
+		return end, nil
+	}
+}
+
+func pickDocsMatch(op string, doc *docs.Document, text string, matchCase bool, occurrence int) (docsTextMatch, error) {
+	if occurrence < 1 {
+		return docsTextMatch{}, newDocsEditError(op, doc.DocumentId, "invalid_argument", "occurrence must be >= 1", usage("occurrence must be >= 1"))
+	}
+	matches := findDocsText(doc, text, matchCase)
+	if len(matches) < occurrence {
+		msg := fmt.Sprintf("text %q not found", text)
+		if len(matches) > 0 {
+			msg = fmt.Sprintf("text %q found %d time(s), wanted occurrence %d", text, len(matches), occurrence)
+		}
+		return docsTextMatch{}, newDocsEditError(op, doc.DocumentId, "anchor_not_found", msg, nil)
+	}
+	return matches[occurrence-1], nil
+}
+
+func (r DocsRangeFlags) resolve(op string, doc *docs.Document) ([]*docs.Range, error) {
+	docID := doc.DocumentId
+	switch {
+	case r.Range != "" && r.Match != "":
+		return nil, newDocsEditError(op, docID, "invalid_argument", "use only one of --range and --match", usage("use only one of --range and --match"))
+	case r.Range != "":
+		startText, endText, ok := strings.Cut(r.Range, ":")
+		start, startErr := strconv.ParseInt(strings.TrimSpace(startText), 10, 64)
+		end, endErr := strconv.ParseInt(strings.TrimSpace(endText), 10, 64)
+		if !ok || startErr != nil || endErr != nil || start < 1 || end <= start {
+			msg := fmt.Sprintf("invalid --range %q (expected START:END with 1 <= START < END)", r.Range)
+			return nil, newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+		}
+		return []*docs.Range{{StartIndex: start, EndIndex: end}}, nil
+	case r.Match != "":
+		if r.Occurrence == 0 {
+			matches := findDocsText(doc, r.Match, r.MatchCase)
+			if len(matches) == 0 {
+				return nil, newDocsEditError(op, docID, "anchor_not_found", fmt.Sprintf("text %q not found", r.Match), nil)
+			}
+			out := make([]*docs.Range, 0, len(matches))
+			for _, m := range matches {
+				out = append(out, &docs.Range{StartIndex: m.start, EndIndex: m.end})
+			}
+			return out, nil
+		}
+		m, err := pickDocsMatch(op, doc, r.Match, r.MatchCase, r.Occurrence)
+		if err != nil {
+			return nil, err
+		}
+		return []*docs.Range{{StartIndex: m.start, EndIndex: m.end}}, nil
+	default:
+		return nil, newDocsEditError(op, docID, "invalid_argument", "set --range or --match", usage("set --range or --match"))
+	}
+}
+
+func (t DocsTableFlags) resolve(op string, doc *docs.Document) (*docs.StructuralElement, error) {
+	var tables []*docs.StructuralElement
+	var walk func(content []*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		for _, el := range content {
+			if el == nil || el.Table == nil {
+				continue
+			}
+			tables = append(tables, el)
+			for _, row := range el.Table.TableRows {
+				for _, cell := range row.TableCells {
+					walk(cell.Content)
+				}
+			}
+		}
+	}
+	if doc.Body != nil {
+		walk(doc.Body.Content)
+	}
+
+	if t.TableMatch != "" {
+		needle := strings.ToLower(t.TableMatch)
+		for _, el := range tables {
+			for _, p := range docsBodyParagraphs([]*docs.StructuralElement{el}) {
+				if strings.Contains(strings.ToLower(p.text), needle) {
+					return el, nil
+				}
+			}
+		}
+		return nil, newDocsEditError(op, doc.DocumentId, "table_not_found", fmt.Sprintf("no table contains %q", t.TableMatch), nil)
+	}
+	if t.Table < 1 || t.Table > len(tables) {
+		return nil, newDocsEditError(op, doc.DocumentId, "table_not_found", fmt.Sprintf("table %d not found (document has %d)", t.Table, len(tables)), nil)
+	}
+	return tables[t.Table-1], nil
+}
+
+// docsEditDocument resolves the Docs service and fetches the document for
+// edits that need its structure.
+func docsEditDocument(ctx context.Context, flags *RootFlags, op, docID string) (*docs.Service, *docs.Document, error) {
+	if docID == "" {
+		return nil, nil, newDocsEditError(op, docID, "invalid_argument", "empty docId", usage("empty docId"))
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, nil, err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return nil, nil, newDocsEditError(op, docID, "service_init_failed", "create docs service failed", err)
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, nil, newDocsEditError(op, docID, "doc_not_found", fmt.Sprintf("doc not found or not a Google Doc (id=%s)", docID), err)
+		}
+		return nil, nil, newDocsEditError(op, docID, "api_error", "fetch document failed", err)
+	}
+	if doc.DocumentId == "" {
+		doc.DocumentId = docID
+	}
+	return svc, doc, nil
+}
+
+func docsEditBatchUpdate(ctx context.Context, svc *docs.Service, op, docID string, req *docs.BatchUpdateDocumentRequest) (*docs.BatchUpdateDocumentResponse, error) {
+	resp, err := svc.Documents.BatchUpdate(docID, req).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, newDocsEditError(op, docID, "doc_not_found", fmt.Sprintf("doc not found or not a Google Doc (id=%s)", docID), err)
+		}
+		return nil, newDocsEditError(op, docID, "api_error", strings.ReplaceAll(op, "-", " ")+" failed", err)
+	}
+	return resp, nil
+}
+
+type DocsTableInsertCmd struct {
+	DocID  string              `arg:"" name:"docId" help:"Doc ID"`
+	Rows   int                 `name:"rows" help:"Number of rows (defaults to the --data row count)"`
+	Cols   int                 `name:"cols" help:"Number of columns (defaults to the widest --data row)"`
+	Data   string              `name:"data" help:"CSV file with cell values ('-' for stdin)"`
+	Header bool                `name:"header" help:"Bold the first row"`
+	Anchor DocsAnchorFlags     `embed:""`
+	Safety DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsTableInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "table-insert"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+
+	var values [][]string
+	if strings.TrimSpace(c.Data) != "" {
+		src, err := readDocsMarkdownInput(c.Data)
+		if err != nil {
+			return newDocsEditError(op, docID, "input_open_failed", "read --data failed", err)
+		}
+		r := csv.NewReader(strings.NewReader(src))
+		r.FieldsPerRecord = -1
+		if values, err = r.ReadAll(); err != nil {
+			return newDocsEditError(op, docID, "invalid_argument", "parse --data CSV failed", err)
+		}
+	}
+	rows, cols := c.Rows, c.Cols
+	if rows == 0 {
+		rows = len(values)
+	}
+	if cols == 0 {
+		for _, row := range values {
+			cols = max(cols, len(row))
+		}
+	}
+	if rows < 1 || cols < 1 {
+		return newDocsEditError(op, docID, "invalid_argument", "set --rows and --cols or provide --data", usage("set --rows and --cols or provide --data"))
+	}
+	if len(values) > rows {
+		msg := fmt.Sprintf("--data has %d rows but --rows is %d", len(values), rows)
+		return newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+	}
+
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	index, err := c.Anchor.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+
+	cells := make([][]docsMarkdownCell, rows)
+	for r := range cells {
+		cells[r] = make([]docsMarkdownCell, cols)
+		for col := 0; r < len(values) && col < cols && col < len(values[r]); col++ {
+			cells[r][col].text = values[r][col]
+		}
+	}
+	reqs := []*docs.Request{{InsertTable: &docs.InsertTableRequest{
+		Rows:     int64(rows),
+		Columns:  int64(cols),
+		Location: &docs.Location{Index: index},
+	}}}
+	// insertTable puts a newline before the table, so it starts at index+1.
+	reqs = append(reqs, docsTableFillRequests(cells, index+1, c.Header)...)
+
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"index": index, "rows": rows, "columns": cols})
+	}
+	if _, err := docsEditBatchUpdate(ctx, svc, op, docID, req); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"index":      index,
+			"rows":       rows,
+			"columns":    cols,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("index\t%d", index)
+	u.Out().Printf("table\t%dx%d", rows, cols)
+	return nil
+}
+
+type DocsTableAddRowCmd struct {
+	DocID  string              `arg:"" name:"docId" help:"Doc ID"`
+	Values []string            `arg:"" name:"values" optional:"" help:"Cell values for the new row, left to right"`
+	Target DocsTableFlags      `embed:""`
+	Row    int                 `name:"row" help:"Insert relative to this row (1-based; default: last row)"`
+	Above  bool                `name:"above" help:"Insert above --row instead of below"`
+	Safety DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsTableAddRowCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "table-add-row"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	table, err := c.Target.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+	rows := table.Table.TableRows
+	r := len(rows) - 1
+	if c.Row != 0 {
+		if c.Row < 1 || c.Row > len(rows) {
+			msg := fmt.Sprintf("row must be between 1 and %d", len(rows))
+			return newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+		}
+		r = c.Row - 1
+	}
+	ref := rows[r]
+	cols := len(ref.TableCells)
+	if len(c.Values) > cols {
+		msg := fmt.Sprintf("%d values for a %d-column row", len(c.Values), cols)
+		return newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+	}
+
+	reqs := []*docs.Request{{InsertTableRow: &docs.InsertTableRowRequest{
+		TableCellLocation: &docs.TableCellLocation{
+			TableStartLocation: &docs.Location{Index: table.StartIndex},
+			RowIndex:           int64(r),
+		},
+		InsertBelow: !c.Above,
+	}}}
+	// The new row is empty: a row marker, then a cell marker and newline per cell.
+	rowStart := ref.EndIndex
+	if c.Above {
+		rowStart = ref.StartIndex
+	}
+	for col := len(c.Values) - 1; col >= 0; col-- {
+		if c.Values[col] == "" {
+			continue
+		}
+		reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: rowStart + 2 + 2*int64(col)},
+			Text:     c.Values[col],
+		}})
+	}
+
+	newRow := r + 2
+	if c.Above {
+		newRow = r + 1
+	}
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"tableIndex": table.StartIndex, "row": newRow})
+	}
+	if _, err := docsEditBatchUpdate(ctx, svc, op, docID, req); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"tableIndex": table.StartIndex,
+			"row":        newRow,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("row\t%d", newRow)
+	return nil
+}
+
+type DocsTableSetCellCmd struct {
+	DocID  string              `arg:"" name:"docId" help:"Doc ID"`
+	Text   string              `arg:"" name:"text" help:"New cell text (empty clears the cell)"`
+	Target DocsTableFlags      `embed:""`
+	Row    int                 `name:"row" required:"" help:"Row number (1-based)"`
+	Col    int                 `name:"col" required:"" help:"Column number (1-based)"`
+	Safety DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsTableSetCellCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "table-set-cell"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	table, err := c.Target.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+	rows := table.Table.TableRows
+	if c.Row < 1 || c.Row > len(rows) || c.Col < 1 || c.Col > len(rows[c.Row-1].TableCells) {
+		msg := fmt.Sprintf("cell %d,%d is outside the table", c.Row, c.Col)
+		return newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+	}
+	cell := rows[c.Row-1].TableCells[c.Col-1]
+	if len(cell.Content) == 0 {
+		return newDocsEditError(op, docID, "invalid_argument", "cell has no content", nil)
+	}
+	start := cell.Content[0].StartIndex
+	// Keep the cell's final newline; it cannot be deleted.
+	end := cell.Content[len(cell.Content)-1].EndIndex - 1
+
+	var reqs []*docs.Request
+	if end > start {
+		reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+			Range: &docs.Range{StartIndex: start, EndIndex: end},
+		}})
+	}
+	if c.Text != "" {
+		reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: start},
+			Text:     c.Text,
+		}})
+	}
+	if len(reqs) == 0 {
+		return newDocsEditError(op, docID, "invalid_argument", "cell is already empty", usage("cell is already empty"))
+	}
+
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"index": start})
+	}
+	if _, err := docsEditBatchUpdate(ctx, svc, op, docID, req); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"row":        c.Row,
+			"col":        c.Col,
+			"index":      start,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("cell\t%d,%d", c.Row, c.Col)
+	return nil
+}
+
+type DocsImageInsertCmd struct {
+	DocID  string              `arg:"" name:"docId" help:"Doc ID"`
+	URL    string              `arg:"" name:"url" help:"Publicly reachable image URL (PNG, JPEG or GIF)"`
+	Width  float64             `name:"width" help:"Width in points"`
+	Height float64             `name:"height" help:"Height in points"`
+	Anchor DocsAnchorFlags     `embed:""`
+	Safety DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsImageInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "image-insert"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	uri := strings.TrimSpace(c.URL)
+	if !strings.HasPrefix(uri, "https://") && !strings.HasPrefix(uri, "http://") {
+		return newDocsEditError(op, docID, "invalid_argument", "image url must start with http:// or https://", usage("image url must start with http:// or https://"))
+	}
+	if c.Width < 0 || c.Height < 0 {
+		return newDocsEditError(op, docID, "invalid_argument", "width and height must be positive", usage("width and height must be positive"))
+	}
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	index, err := c.Anchor.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+
+	img := &docs.InsertInlineImageRequest{Uri: uri, Location: &docs.Location{Index: index}}
+	if c.Width > 0 || c.Height > 0 {
+		img.ObjectSize = &docs.Size{}
+		if c.Width > 0 {
+			img.ObjectSize.Width = &docs.Dimension{Magnitude: c.Width, Unit: "PT"}
+		}
+		if c.Height > 0 {
+			img.ObjectSize.Height = &docs.Dimension{Magnitude: c.Height, Unit: "PT"}
+		}
+	}
+	req := &docs.BatchUpdateDocumentRequest{Requests: []*docs.Request{{InsertInlineImage: img}}}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"index": index})
+	}
+	resp, err := docsEditBatchUpdate(ctx, svc, op, docID, req)
+	if err != nil {
+		return err
+	}
+
+	objectID := ""
+	if len(resp.Replies) > 0 && resp.Replies[0] != nil && resp.Replies[0].InsertInlineImage != nil {
+		objectID = resp.Replies[0].InsertInlineImage.ObjectId
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"index":      index,
+			"objectId":   objectID,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("index\t%d", index)
+	if objectID != "" {
+		u.Out().Printf("object\t%s", objectID)
+	}
+	return nil
+}
+
+var docsHeadingStyles = map[string]string{
+	"normal":   "NORMAL_TEXT",
+	"title":    "TITLE",
+	"subtitle": "SUBTITLE",
+	"h1":       "HEADING_1",
+	"h2":       "HEADING_2",
+	"h3":       "HEADING_3",
+	"h4":       "HEADING_4",
+	"h5":       "HEADING_5",
+	"h6":       "HEADING_6",
+}
+
+type DocsStyleCmd struct {
+	DocID     string              `arg:"" name:"docId" help:"Doc ID"`
+	Heading   string              `name:"heading" help:"Paragraph style: normal|title|subtitle|H1..H6"`
+	Bold      bool                `name:"bold" help:"Make the text bold"`
+	Italic    bool                `name:"italic" help:"Make the text italic"`
+	Underline bool                `name:"underline" help:"Underline the text"`
+	Link      string              `name:"link" help:"Link the text to this URL"`
+	Target    DocsRangeFlags      `embed:""`
+	Safety    DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsStyleCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "style"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+
+	named := ""
+	if h := strings.ToLower(strings.TrimSpace(c.Heading)); h != "" {
+		h = strings.TrimPrefix(strings.TrimPrefix(h, "heading_"), "heading")
+		if len(h) == 1 {
+			h = "h" + h
+		}
+		var ok bool
+		if named, ok = docsHeadingStyles[h]; !ok {
+			msg := fmt.Sprintf("invalid --heading %q (expected normal, title, subtitle or H1..H6)", c.Heading)
+			return newDocsEditError(op, docID, "invalid_argument", msg, usage(msg))
+		}
+	}
+	ts := &docs.TextStyle{Bold: c.Bold, Italic: c.Italic, Underline: c.Underline}
+	var fields []string
+	if c.Bold {
+		fields = append(fields, "bold")
+	}
+	if c.Italic {
+		fields = append(fields, "italic")
+	}
+	if c.Underline {
+		fields = append(fields, "underline")
+	}
+	if link := strings.TrimSpace(c.Link); link != "" {
+		ts.Link = docsMarkdownLinkTarget(link)
+		fields = append(fields, "link")
+	}
+	if named == "" && len(fields) == 0 {
+		return newDocsEditError(op, docID, "invalid_argument", "set --heading, --bold, --italic, --underline or --link", usage("set --heading, --bold, --italic, --underline or --link"))
+	}
+
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	ranges, err := c.Target.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+
+	var reqs []*docs.Request
+	for _, rng := range ranges {
+		if named != "" {
+			reqs = append(reqs, &docs.Request{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+				Range:          rng,
+				ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: named},
+				Fields:         "namedStyleType",
+			}})
+		}
+		if len(fields) > 0 {
+			reqs = append(reqs, &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+				Range:     rng,
+				TextStyle: ts,
+				Fields:    strings.Join(fields, ","),
+			}})
+		}
+	}
+
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"ranges": len(ranges)})
+	}
+	if _, err := docsEditBatchUpdate(ctx, svc, op, docID, req); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"ranges":     ranges,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("styled\t%d", len(ranges))
+	return nil
+}
+
+type DocsBulletsCmd struct {
+	DocID    string              `arg:"" name:"docId" help:"Doc ID"`
+	Numbered bool                `name:"numbered" help:"Use a numbered list"`
+	Preset   string              `name:"preset" help:"Bullet preset (e.g. BULLET_CHECKBOX, NUMBERED_UPPERALPHA_ALPHA_ROMAN)"`
+	Remove   bool                `name:"remove" help:"Remove bullets instead of adding them"`
+	Target   DocsRangeFlags      `embed:""`
+	Safety   DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsBulletsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "bullets"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+
+	preset := strings.ToUpper(strings.TrimSpace(c.Preset))
+	if c.Remove && (preset != "" || c.Numbered) {
+		return newDocsEditError(op, docID, "invalid_argument", "--remove cannot be combined with --numbered or --preset", usage("--remove cannot be combined with --numbered or --preset"))
+	}
+	if preset == "" {
+		preset = "BULLET_DISC_CIRCLE_SQUARE"
+		if c.Numbered {
+			preset = "NUMBERED_DECIMAL_ALPHA_ROMAN"
+		}
+	}
+
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	ranges, err := c.Target.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+
+	var reqs []*docs.Request
+	for _, rng := range ranges {
+		if c.Remove {
+			reqs = append(reqs, &docs.Request{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{Range: rng}})
+			continue
+		}
+		reqs = append(reqs, &docs.Request{CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{Range: rng, BulletPreset: preset}})
+	}
+
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"ranges": len(ranges)})
+	}
+	if _, err := docsEditBatchUpdate(ctx, svc, op, docID, req); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{"documentId": docID, "ranges": ranges, "removed": c.Remove}
+		if !c.Remove {
+			payload["preset"] = preset
+		}
+		return outfmt.WriteJSON(os.Stdout, payload)
+	}
+	u.Out().Printf("id\t%s", docID)
+	if c.Remove {
+		u.Out().Printf("removed\t%d", len(ranges))
+	} else {
+		u.Out().Printf("preset\t%s", preset)
+	}
+	return nil
+}
+
+type DocsNamedRangeListCmd struct {
+	DocID string `arg:"" name:"docId" help:"Doc ID"`
+}
+
+func (c *DocsNamedRangeListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	docID := strings.TrimSpace(c.DocID)
+	_, doc, err := docsEditDocument(ctx, flags, "named-range-list", docID)
+	if err != nil {
+		return err
+	}
+
+	type item struct {
+		Name   string        `json:"name"`
+		ID     string        `json:"namedRangeId"`
+		Ranges []*docs.Range `json:"ranges"`
+	}
+	var items []item
+	names := make([]string, 0, len(doc.NamedRanges))
+	for name := range doc.NamedRanges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, nr := range doc.NamedRanges[name].NamedRanges {
+			items = append(items, item{Name: name, ID: nr.NamedRangeId, Ranges: nr.Ranges})
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"namedRanges": items})
+	}
+	if len(items) == 0 {
+		ui.FromContext(ctx).Err().Println("No named ranges")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "NAME\tID\tRANGES")
+	for _, it := range items {
+		parts := make([]string, 0, len(it.Ranges))
+		for _, r := range it.Ranges {
+			parts = append(parts, fmt.Sprintf("%d-%d", r.StartIndex, r.EndIndex))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", it.Name, it.ID, strings.Join(parts, ","))
+	}
+	return nil
+}
+
+type DocsNamedRangeCreateCmd struct {
+	DocID  string              `arg:"" name:"docId" help:"Doc ID"`
+	Name   string              `arg:"" name:"name" help:"Range name (1-256 characters)"`
+	Target DocsRangeFlags      `embed:""`
+	Safety DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsNamedRangeCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "named-range-create"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	name := strings.TrimSpace(c.Name)
+	if name == "" || len([]rune(name)) > 256 {
+		return newDocsEditError(op, docID, "invalid_argument", "name must be 1-256 characters", usage("name must be 1-256 characters"))
+	}
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	ranges, err := c.Target.resolve(op, doc)
+	if err != nil {
+		return err
+	}
+
+	reqs := make([]*docs.Request, 0, len(ranges))
+	for _, rng := range ranges {
+		reqs = append(reqs, &docs.Request{CreateNamedRange: &docs.CreateNamedRangeRequest{Name: name, Range: rng}})
+	}
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"name": name, "ranges": len(ranges)})
+	}
+	resp, err := docsEditBatchUpdate(ctx, svc, op, docID, req)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, reply := range resp.Replies {
+		if reply != nil && reply.CreateNamedRange != nil {
+			ids = append(ids, reply.CreateNamedRange.NamedRangeId)
+		}
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId":    docID,
+			"name":          name,
+			"namedRangeIds": ids,
+			"ranges":        ranges,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("name\t%s", name)
+	for _, id := range ids {
+		u.Out().Printf("namedRangeId\t%s", id)
+	}
+	return nil
+}
+
+type DocsNamedRangeReplaceContentCmd struct {
+	DocID  string              `arg:"" name:"docId" help:"Doc ID"`
+	Name   string              `arg:"" name:"name" help:"Range name (all ranges with this name are replaced)"`
+	Text   string              `arg:"" name:"text" help:"Replacement text"`
+	Safety DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsNamedRangeReplaceContentCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "named-range-replace-content"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return newDocsEditError(op, docID, "invalid_argument", "empty name", usage("empty name"))
+	}
+	svc, doc, err := docsEditDocument(ctx, flags, op, docID)
+	if err != nil {
+		return err
+	}
+	if _, ok := doc.NamedRanges[name]; !ok {
+		return newDocsEditError(op, docID, "named_range_not_found", fmt.Sprintf("named range %q not found", name), nil)
+	}
+
+	req := &docs.BatchUpdateDocumentRequest{Requests: []*docs.Request{{
+		ReplaceNamedRangeContent: &docs.ReplaceNamedRangeContentRequest{
+			NamedRangeName:  name,
+			Text:            c.Text,
+			ForceSendFields: []string{"Text"},
+		},
+	}}}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{"name": name})
+	}
+	if _, err := docsEditBatchUpdate(ctx, svc, op, docID, req); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"name":       name,
+			"ranges":     len(doc.NamedRanges[name].NamedRanges),
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("replaced\t%s", name)
+	return nil
+}
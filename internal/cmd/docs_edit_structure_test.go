@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// newDocsStructureTestService serves a document laid out as:
+//
+//	1  "Intro\n"
+//	7  "Pricing details here\n"
+//	28 table [Item | Qty] / [A | 1]
+//	48 "End\n"
+//
+// and records every batchUpdate.
+func newDocsStructureTestService(t *testing.T) *[]docs.BatchUpdateDocumentRequest {
+	t.Helper()
+	para := func(start int64, text string) map[string]any {
+		end := start + int64(len(text))
+		return map[string]any{"startIndex": start, "endIndex": end, "paragraph": map[string]any{
+			"elements": []any{map[string]any{"startIndex": start, "endIndex": end, "textRun": map[string]any{"content": text}}},
+		}}
+	}
+	cell := func(start int64, text string) map[string]any {
+		return map[string]any{"startIndex": start, "endIndex": start + 1 + int64(len(text)), "content": []any{para(start+1, text)}}
+	}
+	doc := map[string]any{
+		"documentId": "d1",
+		"body": map[string]any{"content": []any{
+			para(1, "Intro\n"),
+			para(7, "Pricing details here\n"),
+			map[string]any{"startIndex": 28, "endIndex": 48, "table": map[string]any{"rows": 2, "columns": 2, "tableRows": []any{
+				map[string]any{"startIndex": 29, "endIndex": 41, "tableCells": []any{cell(30, "Item\n"), cell(36, "Qty\n")}},
+				map[string]any{"startIndex": 41, "endIndex": 48, "tableCells": []any{cell(42, "A\n"), cell(45, "1\n")}},
+			}}},
+			para(48, "End\n"),
+		}},
+		"namedRanges": map[string]any{"total": map[string]any{"name": "total", "namedRanges": []any{
+			map[string]any{"namedRangeId": "nr1", "name": "total", "ranges": []any{map[string]any{"startIndex": 7, "endIndex": 14}}},
+		}}},
+	}
+
+	var batches []docs.BatchUpdateDocumentRequest
+	stubGoogleService(t, &newDocsService, docs.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/documents/d1":
+			_ = json.NewEncoder(w).Encode(doc)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/documents/d1:batchUpdate":
+			var req docs.BatchUpdateDocumentRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			batches = append(batches, req)
+			replies := make([]any, len(req.Requests))
+			for i, rq := range req.Requests {
+				switch {
+				case rq.CreateNamedRange != nil:
+					replies[i] = map[string]any{"createNamedRange": map[string]any{"namedRangeId": "nr2"}}
+				case rq.InsertInlineImage != nil:
+					replies[i] = map[string]any{"insertInlineImage": map[string]any{"objectId": "img1"}}
+				default:
+					replies[i] = map[string]any{}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "d1", "replies": replies})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return &batches
+}
+
+// docsRequestSummary renders index-bearing requests compactly for assertions.
+func docsRequestSummary(reqs []*docs.Request) string {
+	var out []string
+	for _, r := range reqs {
+		switch {
+		case r.InsertTable != nil:
+			out = append(out, fmt.Sprintf("table%dx%d@%d", r.InsertTable.Rows, r.InsertTable.Columns, r.InsertTable.Location.Index))
+		case r.InsertTableRow != nil:
+			out = append(out, fmt.Sprintf("row+%d below=%v", r.InsertTableRow.TableCellLocation.RowIndex, r.InsertTableRow.InsertBelow))
+		case r.InsertText != nil:
+			out = append(out, fmt.Sprintf("%s@%d", r.InsertText.Text, r.InsertText.Location.Index))
+		case r.DeleteContentRange != nil:
+			out = append(out, fmt.Sprintf("del%d-%d", r.DeleteContentRange.Range.StartIndex, r.DeleteContentRange.Range.EndIndex))
+		case r.InsertInlineImage != nil:
+			out = append(out, fmt.Sprintf("img@%d", r.InsertInlineImage.Location.Index))
+		case r.UpdateParagraphStyle != nil:
+			out = append(out, fmt.Sprintf("%s:%d-%d", r.UpdateParagraphStyle.ParagraphStyle.NamedStyleType, r.UpdateParagraphStyle.Range.StartIndex, r.UpdateParagraphStyle.Range.EndIndex))
+		case r.UpdateTextStyle != nil:
+			out = append(out, fmt.Sprintf("%s:%d-%d", r.UpdateTextStyle.Fields, r.UpdateTextStyle.Range.StartIndex, r.UpdateTextStyle.Range.EndIndex))
+		case r.CreateParagraphBullets != nil:
+			out = append(out, fmt.Sprintf("%s:%d-%d", r.CreateParagraphBullets.BulletPreset, r.CreateParagraphBullets.Range.StartIndex, r.CreateParagraphBullets.Range.EndIndex))
+		case r.CreateNamedRange != nil:
+			out = append(out, fmt.Sprintf("%s:%d-%d", r.CreateNamedRange.Name, r.CreateNamedRange.Range.StartIndex, r.CreateNamedRange.Range.EndIndex))
+		case r.ReplaceNamedRangeContent != nil:
+			out = append(out, r.ReplaceNamedRangeContent.NamedRangeName+"="+r.ReplaceNamedRangeContent.Text)
+		default:
+			out = append(out, "?")
+		}
+	}
+	return strings.Join(out, ",")
+}
+
+func TestDocsEditStructure_Commands(t *testing.T) {
+	batches := newDocsStructureTestService(t)
+	run := func(args ...string) string {
+		t.Helper()
+		return captureStdout(t, func() {
+			if err := Execute(append([]string{"--json", "--account", "a@b.com", "docs", "edit"}, args...)); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+		})
+	}
+	last := func() string {
+		t.Helper()
+		return docsRequestSummary((*batches)[len(*batches)-1].Requests)
+	}
+
+	csvPath := filepath.Join(t.TempDir(), "t.csv")
+	if err := os.WriteFile(csvPath, []byte("a,b\nc,d\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// --after anchors at the end of the matched paragraph's text (27); the
+	// new table starts one index later.
+	run("table", "insert", "d1", "--after", "pricing", "--data", csvPath)
+	if got := last(); got != "table2x2@27,d@38,c@36,b@33,a@31" {
+		t.Fatalf("table insert: %s", got)
+	}
+
+	run("table", "add-row", "d1", "--table-match", "qty", "B", "2")
+	if got := last(); got != "row+1 below=true,2@52,B@50" {
+		t.Fatalf("add-row: %s", got)
+	}
+	run("table", "add-row", "d1", "--row", "1", "--above", "X")
+	if got := last(); got != "row+0 below=false,X@31" {
+		t.Fatalf("add-row above: %s", got)
+	}
+
+	run("table", "set-cell", "d1", "--row", "2", "--col", "2", "5")
+	if got := last(); got != "del46-47,5@46" {
+		t.Fatalf("set-cell: %s", got)
+	}
+
+	out := run("image", "insert", "d1", "https://x.test/a.png", "--before", "End", "--width", "50")
+	if got := last(); got != "img@48" || !strings.Contains(out, `"objectId": "img1"`) {
+		t.Fatalf("image: %s %s", got, out)
+	}
+	if img := (*batches)[len(*batches)-1].Requests[0].InsertInlineImage; img.ObjectSize.Width.Magnitude != 50 || img.ObjectSize.Height != nil {
+		t.Fatalf("unexpected image size: %+v", img.ObjectSize)
+	}
+
+	run("style", "d1", "--heading", "H2", "--match", "pricing", "--bold")
+	if got := last(); got != "HEADING_2:7-14,bold:7-14" {
+		t.Fatalf("style: %s", got)
+	}
+
+	run("bullets", "d1", "--range", "1:28", "--numbered")
+	if got := last(); got != "NUMBERED_DECIMAL_ALPHA_ROMAN:1-28" {
+		t.Fatalf("bullets: %s", got)
+	}
+
+	out = run("named-range", "create", "d1", "intro", "--match", "intro")
+	if got := last(); got != "intro:1-6" || !strings.Contains(out, "nr2") {
+		t.Fatalf("named-range create: %s %s", got, out)
+	}
+	run("named-range", "replace-content", "d1", "total", "42")
+	if got := last(); got != "total=42" {
+		t.Fatalf("replace-content: %s", got)
+	}
+	out = run("named-range", "list", "d1")
+	if !strings.Contains(out, `"namedRangeId": "nr1"`) {
+		t.Fatalf("list: %s", out)
+	}
+
+	calls := len(*batches)
+	out = run("style", "d1", "--heading", "title", "--match", "e", "--occurrence", "0", "--dry-run")
+	if !strings.Contains(out, `"dryRun": true`) || !strings.Contains(out, `"ranges": 5`) || len(*batches) != calls {
+		t.Fatalf("dry-run: %s", out)
+	}
+}
+
+func TestDocsEditStructure_Errors(t *testing.T) {
+	_ = newDocsStructureTestService(t)
+	for want, args := range map[string][]string{
+		`text "nope" not found`:          {"image", "insert", "d1", "https://x.test/a.png", "--after", "nope"},
+		"use only one of --index":        {"table", "insert", "d1", "--rows", "1", "--cols", "1", "--index", "2", "--before", "End"},
+		"table 3 not found":              {"table", "set-cell", "d1", "--table", "3", "--row", "1", "--col", "1", "x"},
+		"outside the table":              {"table", "set-cell", "d1", "--row", "3", "--col", "1", "x"},
+		"3 values for a 2-column row":    {"table", "add-row", "d1", "a", "b", "c"},
+		`invalid --heading "H7"`:         {"style", "d1", "--heading", "H7", "--range", "1:5"},
+		"set --range or --match":         {"bullets", "d1"},
+		`named range "nope" not found`:   {"named-range", "replace-content", "d1", "nope", "x"},
+		"found 1 time(s), wanted occurr": {"style", "d1", "--bold", "--match", "intro", "--occurrence", "2"},
+	} {
+		err := Execute(append([]string{"--account", "a@b.com", "docs", "edit"}, args...))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%v: expected %q, got %v", args, want, err)
+		}
+		var de *editError
+		if !errors.As(err, &de) {
+			t.Fatalf("%v: expected structured docs edit error, got %T", args, err)
+		}
+	}
+}
@@ -0,0 +1,609 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type DocsGenerateCmd struct {
+	Template   string `name:"template" required:"" help:"Template Doc ID"`
+	Data       string `name:"data" required:"" help:"Values as a JSON object, a JSON array or CSV ('-' for stdin); arrays and CSV produce one doc per row"`
+	DataFormat string `name:"data-format" help:"Data format: auto|json|csv" enum:"auto,json,csv" default:"auto"`
+	Title      string `name:"title" required:"" help:"Title of each generated doc; may contain {{placeholders}}"`
+	Parent     string `name:"parent" help:"Destination folder ID"`
+	Strict     bool   `name:"strict" help:"Fail before copying when the template uses placeholders missing from the data"`
+	DryRun     bool   `name:"dry-run" help:"Print the planned docs and requests without copying"`
+}
+
+type docsGeneratePlan struct {
+	Title    string          `json:"title"`
+	Missing  []string        `json:"missing,omitempty"`
+	Requests []*docs.Request `json:"requests,omitempty"`
+}
+
+type docsGenerated struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Link    string   `json:"link,omitempty"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+func (c *DocsGenerateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	templateID := strings.TrimSpace(c.Template)
+	if templateID == "" {
+		return usage("empty --template")
+	}
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		return usage("empty --title")
+	}
+	records, err := readDocsTemplateData(c.Data, c.DataFormat)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return usage("data has no records")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	docsSvc, err := newDocsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tpl, err := docsSvc.Documents.Get(templateID).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("template not found or not a Google Doc (id=%s)", templateID)
+		}
+		return err
+	}
+
+	// Plan every doc up front so bad data fails before anything is copied.
+	plans := make([]docsGeneratePlan, 0, len(records))
+	for i, rec := range records {
+		plan := docsGeneratePlan{Title: fillDocsTemplateText(title, rec)}
+		if len(records) > 1 && plan.Title == title {
+			plan.Title = fmt.Sprintf("%s (%d)", title, i+1)
+		}
+		plan.Requests, plan.Missing = docsTemplateRequests(tpl, rec)
+		if c.Strict && len(plan.Missing) > 0 {
+			return usagef("record %d: no value for %s", i+1, strings.Join(plan.Missing, ", "))
+		}
+		plans = append(plans, plan)
+	}
+
+	if c.DryRun {
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{
+				"dryRun":    true,
+				"template":  templateID,
+				"documents": plans,
+			})
+		}
+		w, flush := tableWriter(ctx)
+		defer flush()
+		fmt.Fprintln(w, "TITLE\tREQUESTS\tMISSING")
+		for _, p := range plans {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", p.Title, len(p.Requests), orDash(strings.Join(p.Missing, ",")))
+		}
+		return nil
+	}
+
+	driveSvc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	generated := make([]docsGenerated, 0, len(plans))
+	for i, p := range plans {
+		created, err := copyDriveFile(ctx, driveSvc, docsCopyOptions, templateID, p.Title, c.Parent)
+		if err != nil {
+			return fmt.Errorf("record %d: copy template: %w (generated %d of %d)", i+1, err, len(generated), len(plans))
+		}
+		if len(p.Requests) > 0 {
+			req := &docs.BatchUpdateDocumentRequest{Requests: p.Requests}
+			if _, err := docsSvc.Documents.BatchUpdate(created.Id, req).Context(ctx).Do(); err != nil {
+				// The batch is atomic, so an unfilled copy is the only leftover.
+				if delErr := driveSvc.Files.Delete(created.Id).SupportsAllDrives(true).Context(ctx).Do(); delErr != nil {
+					return fmt.Errorf("record %d: fill %s: %w (also failed to delete the copy: %v)", i+1, created.Id, err, delErr)
+				}
+				return fmt.Errorf("record %d: fill template: %w (generated %d of %d)", i+1, err, len(generated), len(plans))
+			}
+		}
+		link := created.WebViewLink
+		if link == "" {
+			link = docsWebViewLink(created.Id)
+		}
+		generated = append(generated, docsGenerated{ID: created.Id, Title: created.Name, Link: link, Missing: p.Missing})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"template":  templateID,
+			"documents": generated,
+		})
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTITLE\tLINK")
+	for _, g := range generated {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", g.ID, g.Title, g.Link)
+	}
+	for _, g := range generated {
+		if len(g.Missing) > 0 {
+			u.Err().Printf("warning: %s: no value for %s", g.ID, strings.Join(g.Missing, ", "))
+		}
+	}
+	return nil
+}
+
+// readDocsTemplateData loads one record per generated doc. JSON keeps
+// nested objects and arrays (used for dotted names and repeating rows);
+// CSV rows are flat strings.
+func readDocsTemplateData(path, format string) ([]map[string]any, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, usage("empty --data")
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		var expanded string
+		if expanded, err = config.ExpandPath(path); err == nil {
+			data, err = os.ReadFile(expanded) //nolint:gosec // user-provided path
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read data: %w", err)
+	}
+
+	if format == "" || format == "auto" {
+		format = detectSheetsRecordsFormat(path, data)
+	}
+	if format == "csv" {
+		recs, err := parseSheetsRecordsCSV(data)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]map[string]any, 0, len(recs.Rows))
+		for _, row := range recs.Rows {
+			rec := make(map[string]any, len(row))
+			for k, v := range row {
+				rec[k] = v
+			}
+			out = append(out, rec)
+		}
+		return out, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+	switch v := raw.(type) {
+	case map[string]any:
+		return []map[string]any{v}, nil
+	case []any:
+		out := make([]map[string]any, 0, len(v))
+		for i, item := range v {
+			rec, ok := item.(map[string]any)
+			if !ok {
+				return nil, usagef("record %d is not a JSON object", i+1)
+			}
+			out = append(out, rec)
+		}
+		return out, nil
+	default:
+		return nil, usage("JSON data must be an object or an array of objects")
+	}
+}
+
+var docsPlaceholderRe = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// lookupDocsTemplateValue resolves a placeholder name, trying the literal
+// key first and then dotted paths into nested objects.
+func lookupDocsTemplateValue(rec map[string]any, name string) (any, bool) {
+	if v, ok := rec[name]; ok {
+		return v, true
+	}
+	head, rest, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil, false
+	}
+	nested, ok := rec[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookupDocsTemplateValue(nested, rest)
+}
+
+func docsTemplateText(v any) (string, bool) {
+	switch t := v.(type) {
+	case nil:
+		return "", true
+	case string:
+		return t, true
+	case json.Number:
+		return t.String(), true
+	case bool:
+		if t {
+			return "true", true
+		}
+		return "false", true
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			s, ok := docsTemplateText(item)
+			if !ok {
+				return "", false
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ", "), true
+	default:
+		return "", false
+	}
+}
+
+// fillDocsTemplateText substitutes scalar placeholders in s, leaving
+// unknown ones untouched.
+func fillDocsTemplateText(s string, rec map[string]any) string {
+	return docsPlaceholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		name := docsPlaceholderRe.FindStringSubmatch(m)[1]
+		if v, ok := lookupDocsTemplateValue(rec, name); ok {
+			if text, ok := docsTemplateText(v); ok {
+				return text
+			}
+		}
+		return m
+	})
+}
+
+// docsTemplateArray returns the array a placeholder name refers to, either
+// the name itself or its longest dotted prefix ("items.qty" -> items).
+func docsTemplateArray(rec map[string]any, name string) (string, []any, bool) {
+	for prefix := name; prefix != ""; {
+		if v, ok := lookupDocsTemplateValue(rec, prefix); ok {
+			if arr, ok := v.([]any); ok && (prefix == name || len(arr) == 0 || isDocsTemplateObject(arr[0])) {
+				return prefix, arr, true
+			}
+			return "", nil, false
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return "", nil, false
+}
+
+func isDocsTemplateObject(v any) bool {
+	_, ok := v.(map[string]any)
+	return ok
+}
+
+type docsTemplateEdit struct {
+	index int64
+	reqs  []*docs.Request
+}
+
+// docsTemplateRequests builds one atomic batch that fills a copy of doc
+// with rec. Index-based edits (repeated table rows, images) run first in
+// descending index order so each leaves earlier indexes untouched;
+// replaceAllText for the remaining placeholders runs last.
+func docsTemplateRequests(doc *docs.Document, rec map[string]any) ([]*docs.Request, []string) {
+	g := &docsTemplateBuilder{rec: rec, consumed: map[string]bool{}, missing: map[string]bool{}}
+	if doc.Body != nil {
+		g.walk(doc.Body.Content)
+	}
+	sort.SliceStable(g.edits, func(i, j int) bool { return g.edits[i].index > g.edits[j].index })
+
+	var reqs []*docs.Request
+	for _, e := range g.edits {
+		reqs = append(reqs, e.reqs...)
+	}
+
+	raws := map[string]bool{}
+	for _, text := range docsTemplateSegmentsText(doc) {
+		for _, m := range docsPlaceholderRe.FindAllString(text, -1) {
+			raws[m] = true
+		}
+	}
+	keys := make([]string, 0, len(raws))
+	for raw := range raws {
+		keys = append(keys, raw)
+	}
+	sort.Strings(keys)
+	for _, raw := range keys {
+		name := docsPlaceholderRe.FindStringSubmatch(raw)[1]
+		if g.consumed[raw] {
+			continue
+		}
+		if strings.HasPrefix(name, "image:") {
+			g.missing[name] = true
+			continue
+		}
+		v, ok := lookupDocsTemplateValue(rec, name)
+		if !ok {
+			g.missing[name] = true
+			continue
+		}
+		text, ok := docsTemplateText(v)
+		if !ok {
+			g.missing[name] = true
+			continue
+		}
+		reqs = append(reqs, &docs.Request{ReplaceAllText: &docs.ReplaceAllTextRequest{
+			ContainsText: &docs.SubstringMatchCriteria{Text: raw, MatchCase: true},
+			ReplaceText:  text,
+			// An empty value must still be sent to clear the placeholder.
+			ForceSendFields: []string{"ReplaceText"},
+		}})
+	}
+
+	missing := make([]string, 0, len(g.missing))
+	for name := range g.missing {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return reqs, missing
+}
+
+type docsTemplateBuilder struct {
+	rec      map[string]any
+	edits    []docsTemplateEdit
+	consumed map[string]bool
+	missing  map[string]bool
+}
+
+func (g *docsTemplateBuilder) walk(content []*docs.StructuralElement) {
+	for _, el := range content {
+		switch {
+		case el == nil:
+		case el.Paragraph != nil:
+			g.images(el.Paragraph)
+		case el.Table != nil:
+			for r, row := range el.Table.TableRows {
+				if g.repeatRow(el.StartIndex, int64(r), row) {
+					continue
+				}
+				for _, cell := range row.TableCells {
+					g.walk(cell.Content)
+				}
+			}
+		}
+	}
+}
+
+// images replaces {{image:key}} placeholders with inline images. Values
+// are a URL or {"url": ..., "width": pt, "height": pt}.
+func (g *docsTemplateBuilder) images(p *docs.Paragraph) {
+	start, text := docsParagraphIndexText(p)
+	for _, loc := range docsPlaceholderRe.FindAllStringSubmatchIndex(text, -1) {
+		raw := text[loc[0]:loc[1]]
+		key, ok := strings.CutPrefix(text[loc[2]:loc[3]], "image:")
+		if !ok {
+			continue
+		}
+		v, ok := lookupDocsTemplateValue(g.rec, strings.TrimSpace(key))
+		if !ok {
+			continue
+		}
+		img := &docs.InsertInlineImageRequest{}
+		switch t := v.(type) {
+		case string:
+			img.Uri = t
+		case map[string]any:
+			img.Uri, _ = t["url"].(string)
+			size := &docs.Size{}
+			if w, ok := t["width"].(json.Number); ok {
+				f, _ := w.Float64()
+				size.Width = &docs.Dimension{Magnitude: f, Unit: "PT"}
+			}
+			if h, ok := t["height"].(json.Number); ok {
+				f, _ := h.Float64()
+				size.Height = &docs.Dimension{Magnitude: f, Unit: "PT"}
+			}
+			if size.Width != nil || size.Height != nil {
+				img.ObjectSize = size
+			}
+		}
+		if strings.TrimSpace(img.Uri) == "" {
+			continue
+		}
+		at := start + docsUTF16Len(text[:loc[0]])
+		img.Location = &docs.Location{Index: at}
+		g.consumed[raw] = true
+		g.edits = append(g.edits, docsTemplateEdit{index: at, reqs: []*docs.Request{
+			{DeleteContentRange: &docs.DeleteContentRangeRequest{Range: &docs.Range{StartIndex: at, EndIndex: at + docsUTF16Len(raw)}}},
+			{InsertInlineImage: img},
+		}})
+	}
+}
+
+// repeatRow expands a table row that references an array ({{items.qty}})
+// into one row per element. New rows are inserted below the template row
+// and filled by index: a row of C cells spans 1+2C indexes, and cell c's
+// empty paragraph sits at rowStart+2+2c.
+func (g *docsTemplateBuilder) repeatRow(tableStart, rowIndex int64, row *docs.TableRow) bool {
+	type cellText struct {
+		start, end int64
+		text       string
+	}
+	cells := make([]cellText, 0, len(row.TableCells))
+	var arrayName string
+	var items []any
+	for _, cell := range row.TableCells {
+		ct := cellText{start: cell.StartIndex + 1, end: cell.EndIndex}
+		var b strings.Builder
+		for _, el := range cell.Content {
+			if el.Paragraph != nil {
+				_, text := docsParagraphIndexText(el.Paragraph)
+				b.WriteString(text)
+			}
+		}
+		ct.text = strings.TrimSuffix(b.String(), "\n")
+		for _, m := range docsPlaceholderRe.FindAllStringSubmatch(ct.text, -1) {
+			if arrayName != "" {
+				break
+			}
+			arrayName, items, _ = docsTemplateArray(g.rec, m[1])
+		}
+		cells = append(cells, ct)
+	}
+	if arrayName == "" {
+		return false
+	}
+
+	fill := func(text string, item any) string {
+		return docsPlaceholderRe.ReplaceAllStringFunc(text, func(m string) string {
+			name := docsPlaceholderRe.FindStringSubmatch(m)[1]
+			var v any
+			var ok bool
+			switch {
+			case name == arrayName:
+				v, ok = item, true
+			case strings.HasPrefix(name, arrayName+"."):
+				if obj, isObj := item.(map[string]any); isObj {
+					v, ok = lookupDocsTemplateValue(obj, strings.TrimPrefix(name, arrayName+"."))
+				}
+			default:
+				return m
+			}
+			text, isText := docsTemplateText(v)
+			if !ok || !isText {
+				g.missing[name] = true
+				return ""
+			}
+			return text
+		})
+	}
+	for _, ct := range cells {
+		for _, m := range docsPlaceholderRe.FindAllStringSubmatch(ct.text, -1) {
+			if m[1] == arrayName || strings.HasPrefix(m[1], arrayName+".") {
+				g.consumed[m[0]] = true
+			}
+		}
+	}
+
+	loc := &docs.TableCellLocation{TableStartLocation: &docs.Location{Index: tableStart}, RowIndex: rowIndex}
+	if len(items) == 0 {
+		g.edits = append(g.edits, docsTemplateEdit{index: row.StartIndex, reqs: []*docs.Request{
+			{DeleteTableRow: &docs.DeleteTableRowRequest{TableCellLocation: loc}},
+		}})
+		return true
+	}
+
+	var reqs []*docs.Request
+	for range items[1:] {
+		reqs = append(reqs, &docs.Request{InsertTableRow: &docs.InsertTableRowRequest{TableCellLocation: loc, InsertBelow: true}})
+	}
+	width := int64(1 + 2*len(cells))
+	for j := len(items) - 1; j >= 1; j-- {
+		rowStart := row.EndIndex + int64(j-1)*width
+		for c := len(cells) - 1; c >= 0; c-- {
+			if text := fill(cells[c].text, items[j]); text != "" {
+				reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+					Location: &docs.Location{Index: rowStart + 2 + 2*int64(c)},
+					Text:     text,
+				}})
+			}
+		}
+	}
+	for c := len(cells) - 1; c >= 0; c-- {
+		ct := cells[c]
+		if ct.end-1 > ct.start {
+			reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: ct.start, EndIndex: ct.end - 1},
+			}})
+		}
+		if text := fill(ct.text, items[0]); text != "" {
+			reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{Index: ct.start},
+				Text:     text,
+			}})
+		}
+	}
+	g.edits = append(g.edits, docsTemplateEdit{index: row.StartIndex, reqs: reqs})
+	return true
+}
+
+// docsParagraphIndexText returns the paragraph's start index and text.
+// Inline objects count as one U+FFFC character so UTF-16 offsets into the
+// text line up with document indexes.
+func docsParagraphIndexText(p *docs.Paragraph) (int64, string) {
+	if len(p.Elements) == 0 {
+		return 0, ""
+	}
+	var b strings.Builder
+	for _, el := range p.Elements {
+		switch {
+		case el.TextRun != nil:
+			b.WriteString(el.TextRun.Content)
+		case el.EndIndex > el.StartIndex:
+			b.WriteString(strings.Repeat("\ufffc", int(el.EndIndex-el.StartIndex)))
+		}
+	}
+	return p.Elements[0].StartIndex, b.String()
+}
+
+// docsTemplateSegmentsText returns the text of the body, headers, footers
+// and footnotes, which replaceAllText all reach.
+func docsTemplateSegmentsText(doc *docs.Document) []string {
+	var out []string
+	var walk func(content []*docs.StructuralElement)
+	walk = func(content []*docs.StructuralElement) {
+		var b strings.Builder
+		for _, el := range content {
+			switch {
+			case el == nil:
+			case el.Paragraph != nil:
+				_, text := docsParagraphIndexText(el.Paragraph)
+				b.WriteString(text)
+			case el.Table != nil:
+				for _, row := range el.Table.TableRows {
+					for _, cell := range row.TableCells {
+						walk(cell.Content)
+					}
+				}
+			}
+		}
+		out = append(out, b.String())
+	}
+	if doc.Body != nil {
+		walk(doc.Body.Content)
+	}
+	for _, h := range doc.Headers {
+		walk(h.Content)
+	}
+	for _, f := range doc.Footers {
+		walk(f.Content)
+	}
+	for _, f := range doc.Footnotes {
+		walk(f.Content)
+	}
+	return out
+}
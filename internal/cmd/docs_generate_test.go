@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/option"
+)
+
+// docsGenerateTemplate is laid out by hand with real indexes:
+//
+//	1  "Dear {{name}},\n"
+//	16 "{{image:logo}}\n"
+//	31 table: [Item | Qty] / [{{items.name}} | {{ items.qty }}]
+//	78 "{{missing}}\n"
+func docsGenerateTemplate() *docs.Document {
+	para := func(start int64, text string) *docs.StructuralElement {
+		end := start + int64(len(text))
+		return &docs.StructuralElement{StartIndex: start, EndIndex: end, Paragraph: &docs.Paragraph{
+			Elements: []*docs.ParagraphElement{{StartIndex: start, EndIndex: end, TextRun: &docs.TextRun{Content: text}}},
+		}}
+	}
+	cell := func(start int64, text string) *docs.TableCell {
+		return &docs.TableCell{StartIndex: start, EndIndex: start + 1 + int64(len(text)), Content: []*docs.StructuralElement{para(start+1, text)}}
+	}
+	return &docs.Document{Body: &docs.Body{Content: []*docs.StructuralElement{
+		para(1, "Dear {{name}},\n"),
+		para(16, "{{image:logo}}\n"),
+		{StartIndex: 31, EndIndex: 78, Table: &docs.Table{Rows: 2, Columns: 2, TableRows: []*docs.TableRow{
+			{StartIndex: 32, EndIndex: 44, TableCells: []*docs.TableCell{cell(33, "Item\n"), cell(39, "Qty\n")}},
+			{StartIndex: 44, EndIndex: 78, TableCells: []*docs.TableCell{cell(45, "{{items.name}}\n"), cell(61, "{{ items.qty }}\n")}},
+		}}},
+		para(78, "{{missing}}\n"),
+	}}}
+}
+
+func TestDocsTemplateRequests(t *testing.T) {
+	var rec map[string]any
+	dec := json.NewDecoder(strings.NewReader(`{"name":"Ada","logo":{"url":"https://x.test/logo.png","width":100},"items":[{"name":"A","qty":1},{"name":"B","qty":2},{"name":"C","qty":3}]}`))
+	dec.UseNumber()
+	if err := dec.Decode(&rec); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	reqs, missing := docsTemplateRequests(docsGenerateTemplate(), rec)
+	if strings.Join(missing, ",") != "missing" {
+		t.Fatalf("unexpected missing: %v", missing)
+	}
+
+	var got []string
+	for _, r := range reqs {
+		switch {
+		case r.InsertTableRow != nil:
+			got = append(got, fmt.Sprintf("row+%d", r.InsertTableRow.TableCellLocation.RowIndex))
+		case r.InsertText != nil:
+			got = append(got, fmt.Sprintf("%s@%d", r.InsertText.Text, r.InsertText.Location.Index))
+		case r.DeleteContentRange != nil:
+			got = append(got, fmt.Sprintf("del%d-%d", r.DeleteContentRange.Range.StartIndex, r.DeleteContentRange.Range.EndIndex))
+		case r.InsertInlineImage != nil:
+			img := r.InsertInlineImage
+			got = append(got, fmt.Sprintf("img@%d:%s:%v", img.Location.Index, img.Uri, img.ObjectSize.Width.Magnitude))
+		case r.ReplaceAllText != nil:
+			got = append(got, r.ReplaceAllText.ContainsText.Text+"="+r.ReplaceAllText.ReplaceText)
+		default:
+			got = append(got, "?")
+		}
+	}
+	// New rows start at the template row's end (78) and span 5 indexes each.
+	want := "row+1,row+1,3@87,C@85,2@82,B@80,del62-77,1@62,del46-60,A@46," +
+		"del16-30,img@16:https://x.test/logo.png:100,{{name}}=Ada"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("unexpected requests:\n%s\nwant:\n%s", strings.Join(got, ","), want)
+	}
+
+	// An empty array removes the template row.
+	reqs, _ = docsTemplateRequests(docsGenerateTemplate(), map[string]any{"items": []any{}})
+	if reqs[0].DeleteTableRow == nil || reqs[0].DeleteTableRow.TableCellLocation.RowIndex != 1 || reqs[0].DeleteTableRow.TableCellLocation.TableStartLocation.Index != 31 {
+		t.Fatalf("expected row delete, got %+v", reqs[0])
+	}
+}
+
+func TestDocsGenerate_CSV(t *testing.T) {
+	origDrive := newDriveService
+	origDocs := newDocsService
+	t.Cleanup(func() {
+		newDriveService = origDrive
+		newDocsService = origDocs
+	})
+
+	var mu sync.Mutex
+	var copies []string
+	batches := map[string]docs.BatchUpdateDocumentRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path := r.URL.Path
+		drivePath := strings.TrimPrefix(path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/v1/documents/tpl" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(docsGenerateTemplate())
+		case strings.HasPrefix(path, "/v1/documents/") && strings.HasSuffix(path, ":batchUpdate"):
+			var req docs.BatchUpdateDocumentRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			id := strings.TrimSuffix(strings.TrimPrefix(path, "/v1/documents/"), ":batchUpdate")
+			batches[id] = req
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": id})
+		case drivePath == "/files/tpl" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "tpl", "mimeType": "application/vnd.google-apps.document"})
+		case drivePath == "/files/tpl/copy" && r.Method == http.MethodPost:
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			copies = append(copies, f.Name)
+			id := fmt.Sprintf("gen%d", len(copies))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "name": f.Name, "mimeType": "application/vnd.google-apps.document"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	driveSvc, err := drive.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("drive: %v", err)
+	}
+	docSvc, err := docs.NewService(context.Background(), option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("docs: %v", err)
+	}
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return driveSvc, nil }
+	newDocsService = func(context.Context, string) (*docs.Service, error) { return docSvc, nil }
+
+	data := filepath.Join(t.TempDir(), "people.csv")
+	if err := os.WriteFile(data, []byte("name,missing\nAda,x\nGrace,\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if execErr := Execute([]string{"--json", "--account", "a@b.com", "docs", "generate", "--template", "tpl", "--data", data, "--title", "Offer - {{name}}", "--parent", "f1"}); execErr != nil {
+			t.Fatalf("generate: %v", execErr)
+		}
+	})
+	var parsed struct {
+		Documents []docsGenerated `json:"documents"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Documents) != 2 || parsed.Documents[1].ID != "gen2" || parsed.Documents[1].Title != "Offer - Grace" {
+		t.Fatalf("unexpected documents: %+v", parsed.Documents)
+	}
+	if strings.Join(copies, "|") != "Offer - Ada|Offer - Grace" {
+		t.Fatalf("unexpected copies: %v", copies)
+	}
+	// CSV has no items column, so the repeat row placeholders are reported.
+	if strings.Join(parsed.Documents[0].Missing, ",") != "image:logo,items.name,items.qty" {
+		t.Fatalf("unexpected missing: %v", parsed.Documents[0].Missing)
+	}
+	var replaced []string
+	for _, r := range batches["gen2"].Requests {
+		if r.ReplaceAllText != nil {
+			replaced = append(replaced, r.ReplaceAllText.ContainsText.Text+"="+r.ReplaceAllText.ReplaceText)
+		}
+	}
+	if strings.Join(replaced, ",") != "{{missing}}=,{{name}}=Grace" {
+		t.Fatalf("unexpected replacements: %v", replaced)
+	}
+
+	err = Execute([]string{"--account", "a@b.com", "docs", "generate", "--template", "tpl", "--data", data, "--title", "Offer", "--strict"})
+	if err == nil || !strings.Contains(err.Error(), "no value for image:logo") {
+		t.Fatalf("expected strict error, got %v", err)
+	}
+	if len(copies) != 2 {
+		t.Fatalf("strict mode must not copy, got %v", copies)
+	}
+}
@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// docsMonospaceFonts are treated as inline code / code blocks when
+// converting to Markdown.
+var docsMonospaceFonts = map[string]bool{
+	"courier new":     true,
+	"courier":         true,
+	"consolas":        true,
+	"roboto mono":     true,
+	"source code pro": true,
+	"inconsolata":     true,
+	"ubuntu mono":     true,
+	"jetbrains mono":  true,
+	"fira code":       true,
+	"menlo":           true,
+	"monaco":          true,
+}
+
+var (
+	docsMarkdownEscaper  = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+	docsMarkdownBlockRe  = regexp.MustCompile(`^(#|>|[-*+] |\d+[.)] )`)
+	docsMarkdownOrdered  = map[string]bool{"DECIMAL": true, "ZERO_DECIMAL": true, "UPPER_ALPHA": true, "ALPHA": true, "UPPER_ROMAN": true, "ROMAN": true}
+	docsMarkdownHeadings = map[string]string{
+		"TITLE":     "#",
+		"SUBTITLE":  "##",
+		"HEADING_1": "#",
+		"HEADING_2": "##",
+		"HEADING_3": "###",
+		"HEADING_4": "####",
+		"HEADING_5": "#####",
+		"HEADING_6": "######",
+	}
+)
+
+// docsMarkdown renders the document body as Markdown: headings, nested
+// bullet/numbered lists, tables, links, bold/italic/strikethrough, inline
+// code, monospace code blocks, indented quotes and images.
+func docsMarkdown(doc *docs.Document, maxBytes int64) string {
+	if doc == nil || doc.Body == nil {
+		return ""
+	}
+	w := &docsMarkdownWriter{doc: doc}
+	w.walk(doc.Body.Content)
+	w.flushCode()
+	out := strings.TrimRight(w.buf.String(), "\n")
+	if out != "" {
+		out += "\n"
+	}
+	if maxBytes > 0 && int64(len(out)) > maxBytes {
+		out = out[:maxBytes]
+	}
+	return out
+}
+
+type docsMarkdownWriter struct {
+	doc      *docs.Document
+	buf      strings.Builder
+	lastList bool
+	code     []string
+	// listIndent holds the indentation for each nesting level of the
+	// current list, derived from the parent item's marker width.
+	listIndent []string
+}
+
+func (w *docsMarkdownWriter) walk(content []*docs.StructuralElement) {
+	for _, el := range content {
+		switch {
+		case el == nil:
+		case el.Paragraph != nil:
+			w.paragraph(el.Paragraph)
+		case el.Table != nil:
+			w.flushCode()
+			w.block(w.table(el.Table))
+		case el.TableOfContents != nil:
+			// The table of contents is regenerated by Docs; skip it.
+		}
+	}
+}
+
+// block writes a standalone block separated by a blank line.
+func (w *docsMarkdownWriter) block(s string) {
+	if s == "" {
+		return
+	}
+	if w.buf.Len() > 0 {
+		w.buf.WriteString("\n")
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\n")
+	w.lastList = false
+	w.listIndent = nil
+}
+
+func (w *docsMarkdownWriter) paragraph(p *docs.Paragraph) {
+	style := ""
+	if p.ParagraphStyle != nil {
+		style = p.ParagraphStyle.NamedStyleType
+	}
+
+	if p.Bullet == nil && docsMarkdownHeadings[style] == "" {
+		if code, ok := docsCodeLine(p); ok {
+			w.code = append(w.code, code)
+			return
+		}
+	}
+	w.flushCode()
+
+	text := strings.TrimRight(w.inline(p.Elements), " \n")
+	for _, el := range p.Elements {
+		if el.HorizontalRule != nil {
+			w.block("---")
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		// Empty paragraphs only add spacing in Docs. A blank line between
+		// list items would also split the list.
+		return
+	}
+
+	if p.Bullet != nil {
+		w.listItem(p.Bullet, text)
+		return
+	}
+	if prefix := docsMarkdownHeadings[style]; prefix != "" {
+		w.block(prefix + " " + text)
+		return
+	}
+	if m := docsMarkdownBlockRe.FindString(text); m != "" {
+		// Escape markers that would otherwise turn the line into a block.
+		if i := strings.IndexAny(m, ".)"); i > 0 && m[0] >= '0' && m[0] <= '9' {
+			text = text[:i] + `\` + text[i:]
+		} else {
+			text = `\` + text
+		}
+	}
+	if p.ParagraphStyle != nil && p.ParagraphStyle.IndentStart != nil && p.ParagraphStyle.IndentStart.Magnitude > 0 {
+		text = "> " + strings.ReplaceAll(text, "\n", "\n> ")
+	}
+	w.block(text)
+}
+
+func (w *docsMarkdownWriter) listItem(b *docs.Bullet, text string) {
+	level := int(b.NestingLevel)
+	marker := "- "
+	if w.listOrdered(b.ListId, b.NestingLevel) {
+		marker = "1. "
+	}
+
+	if !w.lastList {
+		if w.buf.Len() > 0 {
+			w.buf.WriteString("\n")
+		}
+		w.listIndent = []string{""}
+	}
+	for len(w.listIndent) <= level {
+		// Skipped levels fall back to four spaces, which nests under any marker.
+		w.listIndent = append(w.listIndent, w.listIndent[len(w.listIndent)-1]+"    ")
+	}
+	indent := w.listIndent[level]
+	w.listIndent = append(w.listIndent[:level+1], indent+strings.Repeat(" ", len(marker)))
+
+	w.buf.WriteString(indent + marker + strings.ReplaceAll(text, "\n", "\n"+indent+strings.Repeat(" ", len(marker))) + "\n")
+	w.lastList = true
+}
+
+func (w *docsMarkdownWriter) listOrdered(listID string, level int64) bool {
+	list, ok := w.doc.Lists[listID]
+	if !ok || list.ListProperties == nil || int(level) >= len(list.ListProperties.NestingLevels) {
+		return false
+	}
+	nl := list.ListProperties.NestingLevels[level]
+	return nl != nil && nl.GlyphSymbol == "" && docsMarkdownOrdered[nl.GlyphType]
+}
+
+func (w *docsMarkdownWriter) flushCode() {
+	if len(w.code) == 0 {
+		return
+	}
+	fence := "```"
+	for strings.Contains(strings.Join(w.code, "\n"), fence) {
+		fence += "`"
+	}
+	lines := w.code
+	w.code = nil
+	w.block(fence + "\n" + strings.Join(lines, "\n") + "\n" + fence)
+}
+
+func (w *docsMarkdownWriter) table(t *docs.Table) string {
+	var rows [][]string
+	cols := 0
+	for _, row := range t.TableRows {
+		var cells []string
+		for _, cell := range row.TableCells {
+			var parts []string
+			for _, content := range cell.Content {
+				if content.Paragraph == nil {
+					continue
+				}
+				if s := strings.TrimSpace(w.inline(content.Paragraph.Elements)); s != "" {
+					parts = append(parts, s)
+				}
+			}
+			text := strings.ReplaceAll(strings.Join(parts, " "), "|", `\|`)
+			cells = append(cells, strings.ReplaceAll(text, "\n", " "))
+		}
+		cols = max(cols, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 || cols == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(cells) {
+				cell = cells[i]
+			}
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(rows[0])
+	sep := make([]string, cols)
+	for i := range sep {
+		sep[i] = "---"
+	}
+	writeRow(sep)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+type docsInlineStyle struct {
+	bold, italic, strike, code bool
+	link                       string
+}
+
+// inline renders paragraph elements, merging adjacent runs that share a
+// Markdown-relevant style so bold text split by Docs stays one **span**.
+func (w *docsMarkdownWriter) inline(elements []*docs.ParagraphElement) string {
+	type run struct {
+		text  string
+		style docsInlineStyle
+	}
+	var runs []run
+	var out strings.Builder
+	flush := func() {
+		for _, r := range runs {
+			out.WriteString(renderDocsMarkdownRun(r.text, r.style))
+		}
+		runs = nil
+	}
+
+	for _, el := range elements {
+		switch {
+		case el.TextRun != nil:
+			content := strings.ReplaceAll(el.TextRun.Content, "\v", "\n")
+			style := docsRunStyle(el.TextRun.TextStyle)
+			if n := len(runs); n > 0 && runs[n-1].style == style {
+				runs[n-1].text += content
+				continue
+			}
+			runs = append(runs, run{text: content, style: style})
+		case el.InlineObjectElement != nil:
+			flush()
+			if uri := w.inlineImageURI(el.InlineObjectElement.InlineObjectId); uri != "" {
+				out.WriteString("![](" + uri + ")")
+			}
+		}
+	}
+	flush()
+	return out.String()
+}
+
+func (w *docsMarkdownWriter) inlineImageURI(id string) string {
+	obj, ok := w.doc.InlineObjects[id]
+	if !ok || obj.InlineObjectProperties == nil || obj.InlineObjectProperties.EmbeddedObject == nil {
+		return ""
+	}
+	img := obj.InlineObjectProperties.EmbeddedObject.ImageProperties
+	if img == nil {
+		return ""
+	}
+	if img.SourceUri != "" {
+		return img.SourceUri
+	}
+	return img.ContentUri
+}
+
+func docsRunStyle(ts *docs.TextStyle) docsInlineStyle {
+	if ts == nil {
+		return docsInlineStyle{}
+	}
+	style := docsInlineStyle{bold: ts.Bold, italic: ts.Italic, strike: ts.Strikethrough}
+	if ts.WeightedFontFamily != nil && docsMonospaceFonts[strings.ToLower(ts.WeightedFontFamily.FontFamily)] {
+		style.code = true
+	}
+	if ts.Link != nil {
+		switch {
+		case ts.Link.Url != "":
+			style.link = ts.Link.Url
+		case ts.Link.HeadingId != "":
+			style.link = "#" + ts.Link.HeadingId
+		case ts.Link.BookmarkId != "":
+			style.link = "#" + ts.Link.BookmarkId
+		}
+	}
+	return style
+}
+
+func renderDocsMarkdownRun(text string, style docsInlineStyle) string {
+	// The paragraph's trailing newline is structural, not content.
+	text = strings.TrimSuffix(text, "\n")
+	body := strings.Trim(text, " ")
+	if body == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, body)]
+	trail := text[len(lead)+len(body):]
+
+	if style.code {
+		fence := "`"
+		for strings.Contains(body, fence) {
+			fence += "`"
+		}
+		if strings.HasPrefix(body, "`") || strings.HasSuffix(body, "`") {
+			body = " " + body + " "
+		}
+		body = fence + body + fence
+	} else {
+		body = docsMarkdownEscaper.Replace(body)
+		body = strings.ReplaceAll(body, "\n", "  \n")
+	}
+	if style.strike {
+		body = "~~" + body + "~~"
+	}
+	if style.italic {
+		body = "*" + body + "*"
+	}
+	if style.bold {
+		body = "**" + body + "**"
+	}
+	if style.link != "" {
+		body = fmt.Sprintf("[%s](%s)", body, style.link)
+	}
+	return lead + body + trail
+}
+
+// docsCodeLine reports whether the paragraph is monospace throughout, and
+// returns the line without its trailing newline. Blank lines inside code
+// blocks keep their monospace newline, so they stay part of the block.
+func docsCodeLine(p *docs.Paragraph) (string, bool) {
+	var b strings.Builder
+	for _, el := range p.Elements {
+		if el.TextRun == nil {
+			return "", false
+		}
+		style := docsRunStyle(el.TextRun.TextStyle)
+		if !style.code || style.link != "" {
+			return "", false
+		}
+		b.WriteString(el.TextRun.Content)
+	}
+	if b.Len() == 0 {
+		return "", false
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(b.String(), "\n"), "\v", "\n"), true
+}
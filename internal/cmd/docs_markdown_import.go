@@ -0,0 +1,788 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type DocsImportMarkdownCmd struct {
+	DocID   string              `arg:"" name:"docId" help:"Doc ID"`
+	File    string              `arg:"" name:"file" help:"Markdown file, or '-' for stdin"`
+	Replace bool                `name:"replace" help:"Replace the whole document body instead of appending (requires --force unless --dry-run)"`
+	Safety  DocsEditSafetyFlags `embed:""`
+}
+
+func (c *DocsImportMarkdownCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "import-markdown"
+	u := ui.FromContext(ctx)
+	docID := strings.TrimSpace(c.DocID)
+	if docID == "" {
+		return newDocsEditError(op, docID, "invalid_argument", "empty docId", usage("empty docId"))
+	}
+	src, err := readDocsMarkdownInput(c.File)
+	if err != nil {
+		return newDocsEditError(op, docID, "input_open_failed", "read markdown failed", err)
+	}
+	blocks := parseDocsMarkdown(src)
+	if len(blocks) == 0 {
+		return newDocsEditError(op, docID, "invalid_argument", "markdown has no content", usage("markdown has no content"))
+	}
+	if c.Replace && !c.Safety.DryRun && (flags == nil || !flags.Force) {
+		return newDocsEditError(op, docID, "confirmation_required", "--replace deletes the document body; rerun with --force or use --dry-run", usage("--replace deletes the document body; rerun with --force or use --dry-run"))
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newDocsService(ctx, account)
+	if err != nil {
+		return newDocsEditError(op, docID, "service_init_failed", "create docs service failed", err)
+	}
+	doc, err := svc.Documents.Get(docID).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return newDocsEditError(op, docID, "doc_not_found", fmt.Sprintf("doc not found or not a Google Doc (id=%s)", docID), err)
+		}
+		return newDocsEditError(op, docID, "api_error", "fetch document failed", err)
+	}
+
+	var reqs []*docs.Request
+	index := docsAppendIndex(doc)
+	switch {
+	case c.Replace:
+		if index > 1 {
+			reqs = append(reqs, &docs.Request{DeleteContentRange: &docs.DeleteContentRangeRequest{
+				Range: &docs.Range{StartIndex: 1, EndIndex: index},
+			}})
+		}
+		index = 1
+	case docsLastParagraphHasText(doc):
+		// Start a fresh paragraph so the first block doesn't merge into the
+		// document's last line.
+		reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: index},
+			Text:     "\n",
+		}})
+		index++
+	}
+	reqs = append(reqs, docsMarkdownRequests(blocks, index)...)
+
+	req := &docs.BatchUpdateDocumentRequest{Requests: reqs}
+	applyDocsEditSafety(req, c.Safety)
+	if c.Safety.DryRun {
+		return docsDryRunOutput(ctx, u, docID, req, map[string]any{
+			"blocks": len(blocks),
+			"index":  index,
+		})
+	}
+	if _, err := svc.Documents.BatchUpdate(docID, req).Context(ctx).Do(); err != nil {
+		if isDocsNotFound(err) {
+			return newDocsEditError(op, docID, "doc_not_found", fmt.Sprintf("doc not found or not a Google Doc (id=%s)", docID), err)
+		}
+		return newDocsEditError(op, docID, "api_error", "import markdown failed", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"documentId": docID,
+			"blocks":     len(blocks),
+			"operations": len(reqs),
+			"replaced":   c.Replace,
+		})
+	}
+	u.Out().Printf("id\t%s", docID)
+	u.Out().Printf("blocks\t%d", len(blocks))
+	u.Out().Printf("operations\t%d", len(reqs))
+	return nil
+}
+
+func readDocsMarkdownInput(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", usage("empty markdown file")
+	}
+	if path == "-" {
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	}
+	expanded, err := config.ExpandPath(path)
+	if err != nil {
+		return "", err
+	}
+	b, err := os.ReadFile(expanded) //nolint:gosec // user-provided path
+	return string(b), err
+}
+
+func docsLastParagraphHasText(doc *docs.Document) bool {
+	if doc == nil || doc.Body == nil || len(doc.Body.Content) == 0 {
+		return false
+	}
+	last := doc.Body.Content[len(doc.Body.Content)-1]
+	if last == nil || last.Paragraph == nil {
+		return false
+	}
+	for _, el := range last.Paragraph.Elements {
+		if el.TextRun != nil && strings.Trim(el.TextRun.Content, "\n") != "" {
+			return true
+		}
+		if el.InlineObjectElement != nil {
+			return true
+		}
+	}
+	return false
+}
+
+type docsMarkdownSpan struct {
+	start, end int64 // UTF-16 offsets within the block text
+	style      docsInlineStyle
+}
+
+type docsMarkdownCell struct {
+	text  string
+	spans []docsMarkdownSpan
+}
+
+type docsMarkdownBlock struct {
+	kind    string // paragraph, heading, quote, list, code, table
+	level   int
+	ordered bool
+	text    string
+	spans   []docsMarkdownSpan
+	rows    [][]docsMarkdownCell
+}
+
+var (
+	docsMdFenceRe    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	docsMdHeadingRe  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	docsMdRuleRe     = regexp.MustCompile(`^ {0,3}([-*_])(?:\s*([-*_])){2,}\s*$`)
+	docsMdListRe     = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	docsMdQuoteRe    = regexp.MustCompile(`^ {0,3}>\s?(.*)$`)
+	docsMdTableSepRe = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	docsMdSetext1Re  = regexp.MustCompile(`^ {0,3}=+\s*$`)
+	docsMdSetext2Re  = regexp.MustCompile(`^ {0,3}-+\s*$`)
+)
+
+// parseDocsMarkdown parses the Markdown subset that maps onto Docs:
+// ATX/setext headings, paragraphs, nested lists, block quotes, fenced code,
+// pipe tables and inline emphasis, code and links. Horizontal rules are
+// dropped because the Docs API cannot insert them.
+func parseDocsMarkdown(src string) []*docsMarkdownBlock {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	p := &docsMarkdownParser{}
+	for i := 0; i < len(lines); i++ {
+		raw := strings.ReplaceAll(lines[i], "\t", "    ")
+		line := strings.TrimRight(raw, " ")
+
+		if m := docsMdFenceRe.FindStringSubmatch(line); m != nil {
+			p.flush()
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+				code = append(code, strings.TrimRight(lines[i], "\r"))
+			}
+			p.blocks = append(p.blocks, &docsMarkdownBlock{kind: "code", text: strings.Join(code, "\n") + "\n"})
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			p.flushParagraph()
+			p.flushQuote()
+			p.listBlank = true
+			continue
+		}
+		if len(p.para) > 0 && p.list == nil && (docsMdSetext1Re.MatchString(line) || docsMdSetext2Re.MatchString(line)) {
+			level := 1
+			if docsMdSetext2Re.MatchString(line) {
+				level = 2
+			}
+			text := strings.Join(p.para, " ")
+			p.para = nil
+			p.addText("heading", level, text)
+			continue
+		}
+		if m := docsMdHeadingRe.FindStringSubmatch(line); m != nil {
+			p.flush()
+			p.addText("heading", len(m[1]), m[2])
+			continue
+		}
+		if docsMdRuleRe.MatchString(line) {
+			p.flush()
+			continue
+		}
+		if strings.Contains(line, "|") && i+1 < len(lines) && strings.Contains(lines[i+1], "-") && docsMdTableSepRe.MatchString(lines[i+1]) {
+			p.flush()
+			header := splitDocsMarkdownRow(line)
+			rows := [][]string{header}
+			for i += 2; i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.Contains(lines[i], "|"); i++ {
+				rows = append(rows, splitDocsMarkdownRow(lines[i]))
+			}
+			i--
+			p.addTable(rows, len(header))
+			continue
+		}
+		if m := docsMdListRe.FindStringSubmatch(line); m != nil {
+			p.flushParagraph()
+			p.flushQuote()
+			p.addListItem(len(m[1]), m[2], m[3])
+			continue
+		}
+		if p.list != nil && (!p.listBlank || strings.HasPrefix(raw, "  ")) {
+			// Continuation of the previous list item.
+			last := &p.list.items[len(p.list.items)-1]
+			last.text += " " + strings.TrimSpace(line)
+			p.listBlank = false
+			continue
+		}
+		if m := docsMdQuoteRe.FindStringSubmatch(line); m != nil {
+			p.flushParagraph()
+			p.flushList()
+			p.quote = append(p.quote, m[1])
+			continue
+		}
+		p.flushList()
+		p.flushQuote()
+		p.para = append(p.para, strings.TrimSpace(line))
+	}
+	p.flush()
+	return p.blocks
+}
+
+type docsMarkdownListItem struct {
+	level int
+	text  string
+}
+
+type docsMarkdownList struct {
+	ordered bool
+	indents []int
+	items   []docsMarkdownListItem
+}
+
+type docsMarkdownParser struct {
+	blocks    []*docsMarkdownBlock
+	para      []string
+	quote     []string
+	list      *docsMarkdownList
+	listBlank bool
+}
+
+func (p *docsMarkdownParser) flush() {
+	p.flushParagraph()
+	p.flushQuote()
+	p.flushList()
+}
+
+func (p *docsMarkdownParser) flushParagraph() {
+	if len(p.para) == 0 {
+		return
+	}
+	p.addText("paragraph", 0, strings.Join(p.para, " "))
+	p.para = nil
+}
+
+func (p *docsMarkdownParser) flushQuote() {
+	if len(p.quote) == 0 {
+		return
+	}
+	var parts []string
+	for _, q := range p.quote {
+		if q = strings.TrimSpace(q); q != "" {
+			parts = append(parts, q)
+		}
+	}
+	p.quote = nil
+	if len(parts) > 0 {
+		p.addText("quote", 0, strings.Join(parts, " "))
+	}
+}
+
+func (p *docsMarkdownParser) flushList() {
+	if p.list == nil {
+		return
+	}
+	block := &docsMarkdownBlock{kind: "list", ordered: p.list.ordered}
+	var b strings.Builder
+	var offset int64
+	for _, item := range p.list.items {
+		// createParagraphBullets derives nesting from leading tabs.
+		prefix := strings.Repeat("\t", item.level)
+		text, spans := parseDocsMarkdownInline(item.text)
+		base := offset + docsUTF16Len(prefix)
+		for _, s := range spans {
+			block.spans = append(block.spans, docsMarkdownSpan{start: base + s.start, end: base + s.end, style: s.style})
+		}
+		line := prefix + text + "\n"
+		b.WriteString(line)
+		offset += docsUTF16Len(line)
+	}
+	block.text = b.String()
+	p.blocks = append(p.blocks, block)
+	p.list = nil
+}
+
+func (p *docsMarkdownParser) addText(kind string, level int, src string) {
+	text, spans := parseDocsMarkdownInline(strings.TrimSpace(src))
+	if text == "" && kind != "heading" {
+		return
+	}
+	p.blocks = append(p.blocks, &docsMarkdownBlock{kind: kind, level: level, text: text + "\n", spans: spans})
+}
+
+func (p *docsMarkdownParser) addListItem(indent int, marker, text string) {
+	ordered := marker[len(marker)-1] == '.' || marker[len(marker)-1] == ')'
+	if p.list != nil && len(p.list.indents) > 0 && indent <= p.list.indents[0] && ordered != p.list.ordered {
+		p.flushList()
+	}
+	if p.list == nil {
+		p.list = &docsMarkdownList{ordered: ordered}
+	}
+	l := p.list
+	switch {
+	case len(l.indents) == 0 || indent > l.indents[len(l.indents)-1]:
+		l.indents = append(l.indents, indent)
+	default:
+		for len(l.indents) > 1 && indent < l.indents[len(l.indents)-1] {
+			l.indents = l.indents[:len(l.indents)-1]
+		}
+	}
+	l.items = append(l.items, docsMarkdownListItem{level: len(l.indents) - 1, text: text})
+	p.listBlank = false
+}
+
+func (p *docsMarkdownParser) addTable(rows [][]string, cols int) {
+	block := &docsMarkdownBlock{kind: "table"}
+	for _, row := range rows {
+		cells := make([]docsMarkdownCell, cols)
+		for i := 0; i < cols && i < len(row); i++ {
+			cells[i].text, cells[i].spans = parseDocsMarkdownInline(row[i])
+		}
+		block.rows = append(block.rows, cells)
+	}
+	p.blocks = append(p.blocks, block)
+}
+
+func splitDocsMarkdownRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+	var cells []string
+	var cur strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cur.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cur.String()))
+			cur.Reset()
+		default:
+			cur.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cur.String()))
+}
+
+const docsMarkdownPunct = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+type docsInlineParser struct {
+	out   strings.Builder
+	pos   int64
+	spans []docsMarkdownSpan
+}
+
+// parseDocsMarkdownInline strips inline Markdown and returns the plain text
+// with styled spans in UTF-16 offsets, as the Docs API counts indexes.
+func parseDocsMarkdownInline(src string) (string, []docsMarkdownSpan) {
+	p := &docsInlineParser{}
+	p.parse(src, docsInlineStyle{})
+	return p.out.String(), p.spans
+}
+
+func (p *docsInlineParser) emit(text string, style docsInlineStyle) {
+	if text == "" {
+		return
+	}
+	n := docsUTF16Len(text)
+	if style != (docsInlineStyle{}) {
+		if k := len(p.spans); k > 0 && p.spans[k-1].end == p.pos && p.spans[k-1].style == style {
+			p.spans[k-1].end += n
+		} else {
+			p.spans = append(p.spans, docsMarkdownSpan{start: p.pos, end: p.pos + n, style: style})
+		}
+	}
+	p.out.WriteString(text)
+	p.pos += n
+}
+
+func (p *docsInlineParser) parse(s string, style docsInlineStyle) {
+	var lit strings.Builder
+	flush := func() {
+		p.emit(lit.String(), style)
+		lit.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == '\\' && i+1 < len(s) && strings.IndexByte(docsMarkdownPunct, s[i+1]) >= 0:
+			lit.WriteByte(s[i+1])
+			i += 2
+			continue
+		case ch == '`':
+			n := len(s[i:]) - len(strings.TrimLeft(s[i:], "`"))
+			fence := s[i : i+n]
+			if end := strings.Index(s[i+n:], fence); end >= 0 {
+				flush()
+				code := s[i+n : i+n+end]
+				if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+					code = code[1 : len(code)-1]
+				}
+				cs := style
+				cs.code = true
+				p.emit(code, cs)
+				i += n + end + n
+				continue
+			}
+			lit.WriteString(fence)
+			i += n
+			continue
+		case ch == '!' && strings.HasPrefix(s[i+1:], "["):
+			if text, url, n, ok := docsMarkdownLink(s[i+1:]); ok {
+				flush()
+				ls := style
+				ls.link = url
+				if text == "" {
+					text = url
+				}
+				p.emit(text, ls)
+				i += 1 + n
+				continue
+			}
+		case ch == '[':
+			if text, url, n, ok := docsMarkdownLink(s[i:]); ok {
+				flush()
+				ls := style
+				ls.link = url
+				p.parse(text, ls)
+				i += n
+				continue
+			}
+		case ch == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				inner := s[i+1 : i+end]
+				if !strings.ContainsAny(inner, " <") && (strings.HasPrefix(inner, "http://") || strings.HasPrefix(inner, "https://") || strings.HasPrefix(inner, "mailto:")) {
+					flush()
+					ls := style
+					ls.link = inner
+					p.emit(strings.TrimPrefix(inner, "mailto:"), ls)
+					i += end + 1
+					continue
+				}
+			}
+		case ch == '*' || ch == '_' || ch == '~':
+			if delim, end, ok := docsMarkdownEmphasis(s, i); ok {
+				flush()
+				es := style
+				switch {
+				case delim == "~~":
+					es.strike = true
+				case len(delim) == 2:
+					es.bold = true
+				default:
+					es.italic = true
+				}
+				p.parse(s[i+len(delim):end], es)
+				i = end + len(delim)
+				continue
+			}
+		}
+		lit.WriteByte(ch)
+		i++
+	}
+	flush()
+}
+
+// docsMarkdownEmphasis finds the closing delimiter for an emphasis run
+// starting at s[i]. Underscores inside words stay literal.
+func docsMarkdownEmphasis(s string, i int) (string, int, bool) {
+	ch := s[i]
+	delim := string(ch)
+	if i+1 < len(s) && s[i+1] == ch {
+		delim += string(ch)
+	}
+	if ch == '~' && delim != "~~" {
+		return "", 0, false
+	}
+	if ch == '_' && i > 0 && isDocsWordByte(s[i-1]) {
+		return "", 0, false
+	}
+	start := i + len(delim)
+	if start >= len(s) || s[start] == ' ' {
+		return "", 0, false
+	}
+	for j := start + 1; j <= len(s)-len(delim); j++ {
+		if s[j-1] == '\\' || s[j] != ch || !strings.HasPrefix(s[j:], delim) {
+			continue
+		}
+		if len(delim) == 1 && j+1 < len(s) && s[j+1] == ch {
+			// Part of a nested double delimiter; skip both characters.
+			j++
+			continue
+		}
+		if s[j-1] == ' ' {
+			continue
+		}
+		if ch == '_' && j+len(delim) < len(s) && isDocsWordByte(s[j+len(delim)]) {
+			continue
+		}
+		return delim, j, true
+	}
+	return "", 0, false
+}
+
+func isDocsWordByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b >= 0x80
+}
+
+// docsMarkdownLink parses "[text](url "title")" at the start of s and
+// returns the text, URL and consumed byte count.
+func docsMarkdownLink(s string) (string, string, int, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return "", "", 0, false
+	}
+	depth := 0
+	closeText := -1
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeText = i
+			}
+		}
+		if closeText >= 0 {
+			break
+		}
+	}
+	if closeText < 0 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return "", "", 0, false
+	}
+	depth = 0
+	for i := closeText + 1; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				target := strings.TrimSpace(s[closeText+2 : i])
+				if url, _, ok := strings.Cut(target, " "); ok {
+					target = url
+				}
+				target = strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+				if target == "" {
+					return "", "", 0, false
+				}
+				return s[1:closeText], target, i + 1, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+func docsUTF16Len(s string) int64 {
+	return int64(len(utf16.Encode([]rune(s))))
+}
+
+// docsMarkdownRequests converts parsed blocks into batchUpdate requests
+// that insert them at index, which must be the start of the body's last
+// (empty) paragraph. Blocks are inserted last-first at the same index, so
+// earlier requests never shift the indexes used by later ones. Every block
+// resets paragraph and text style because inserted text inherits the style
+// of its neighbour.
+func docsMarkdownRequests(blocks []*docsMarkdownBlock, index int64) []*docs.Request {
+	var reqs []*docs.Request
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b := blocks[i]
+		if b.kind == "table" {
+			reqs = append(reqs, docsMarkdownTableRequests(b, index)...)
+			continue
+		}
+
+		text := b.text
+		if i == len(blocks)-1 {
+			// The body's final newline terminates the last block.
+			text = strings.TrimSuffix(text, "\n")
+		}
+		n := docsUTF16Len(text)
+		if n == 0 {
+			continue
+		}
+		rng := &docs.Range{StartIndex: index, EndIndex: index + n}
+		reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+			Location: &docs.Location{Index: index},
+			Text:     text,
+		}})
+
+		pstyle := &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"}
+		switch b.kind {
+		case "heading":
+			pstyle.NamedStyleType = fmt.Sprintf("HEADING_%d", b.level)
+		case "quote":
+			pstyle.IndentStart = &docs.Dimension{Magnitude: 36, Unit: "PT"}
+			pstyle.IndentFirstLine = &docs.Dimension{Magnitude: 36, Unit: "PT"}
+		}
+		reqs = append(reqs, &docs.Request{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+			Range:          rng,
+			ParagraphStyle: pstyle,
+			Fields:         "namedStyleType,indentStart,indentFirstLine",
+		}})
+		if b.kind != "list" {
+			reqs = append(reqs, &docs.Request{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{Range: rng}})
+		}
+
+		base := &docs.TextStyle{}
+		if b.kind == "code" {
+			base.WeightedFontFamily = &docs.WeightedFontFamily{FontFamily: "Courier New"}
+		}
+		reqs = append(reqs, &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+			Range:     rng,
+			TextStyle: base,
+			Fields:    "bold,italic,strikethrough,link,weightedFontFamily",
+		}})
+		reqs = append(reqs, docsMarkdownSpanRequests(b.spans, index, false)...)
+
+		if b.kind == "list" {
+			preset := "BULLET_DISC_CIRCLE_SQUARE"
+			if b.ordered {
+				preset = "NUMBERED_DECIMAL_ALPHA_ROMAN"
+			}
+			reqs = append(reqs, &docs.Request{CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{
+				Range:        rng,
+				BulletPreset: preset,
+			}})
+		}
+	}
+	return reqs
+}
+
+// docsMarkdownTableRequests inserts a table at index and fills its cells.
+// insertTable adds a newline before the table, so the table starts at
+// index+1 and cell (r, c) content sits at start + 3 + r*(2*cols+1) + 2*c.
+func docsMarkdownTableRequests(b *docsMarkdownBlock, index int64) []*docs.Request {
+	rows := int64(len(b.rows))
+	if rows == 0 || len(b.rows[0]) == 0 {
+		return nil
+	}
+	cols := int64(len(b.rows[0]))
+	reqs := []*docs.Request{
+		{InsertTable: &docs.InsertTableRequest{Rows: rows, Columns: cols, Location: &docs.Location{Index: index}}},
+		{UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+			Range:          &docs.Range{StartIndex: index, EndIndex: index + 1},
+			ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"},
+			Fields:         "namedStyleType,indentStart,indentFirstLine",
+		}},
+		{DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{Range: &docs.Range{StartIndex: index, EndIndex: index + 1}}},
+	}
+	return append(reqs, docsTableFillRequests(b.rows, index+1, true)...)
+}
+
+// docsTableFillRequests fills the cells of a freshly inserted, empty table
+// starting at tableStart, last cell first. With header, row 0 is bold.
+func docsTableFillRequests(rows [][]docsMarkdownCell, tableStart int64, header bool) []*docs.Request {
+	if len(rows) == 0 {
+		return nil
+	}
+	cols := int64(len(rows[0]))
+	var reqs []*docs.Request
+	for r := int64(len(rows)) - 1; r >= 0; r-- {
+		for c := cols - 1; c >= 0; c-- {
+			if c >= int64(len(rows[r])) {
+				continue
+			}
+			cell := rows[r][c]
+			if cell.text == "" {
+				continue
+			}
+			at := tableStart + 3 + r*(2*cols+1) + 2*c
+			reqs = append(reqs, &docs.Request{InsertText: &docs.InsertTextRequest{
+				Location: &docs.Location{Index: at},
+				Text:     cell.text,
+			}})
+			reqs = append(reqs, docsMarkdownSpanRequests(cell.spans, at, false)...)
+			if header && r == 0 {
+				reqs = append(reqs, docsMarkdownSpanRequests([]docsMarkdownSpan{{
+					start: 0, end: docsUTF16Len(cell.text), style: docsInlineStyle{bold: true},
+				}}, at, true)...)
+			}
+		}
+	}
+	return reqs
+}
+
+// docsMarkdownSpanRequests styles spans offset by base. With boldOnly, only
+// the bold field is written so existing span styles are kept.
+func docsMarkdownSpanRequests(spans []docsMarkdownSpan, base int64, boldOnly bool) []*docs.Request {
+	var reqs []*docs.Request
+	for _, s := range spans {
+		ts := &docs.TextStyle{}
+		var fields []string
+		if s.style.bold {
+			ts.Bold = true
+			fields = append(fields, "bold")
+		}
+		if !boldOnly {
+			if s.style.italic {
+				ts.Italic = true
+				fields = append(fields, "italic")
+			}
+			if s.style.strike {
+				ts.Strikethrough = true
+				fields = append(fields, "strikethrough")
+			}
+			if s.style.code {
+				ts.WeightedFontFamily = &docs.WeightedFontFamily{FontFamily: "Courier New"}
+				fields = append(fields, "weightedFontFamily")
+			}
+			if s.style.link != "" {
+				ts.Link = docsMarkdownLinkTarget(s.style.link)
+				fields = append(fields, "link")
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		reqs = append(reqs, &docs.Request{UpdateTextStyle: &docs.UpdateTextStyleRequest{
+			Range:     &docs.Range{StartIndex: base + s.start, EndIndex: base + s.end},
+			TextStyle: ts,
+			Fields:    strings.Join(fields, ","),
+		}})
+	}
+	return reqs
+}
+
+func docsMarkdownLinkTarget(target string) *docs.Link {
+	if id, ok := strings.CutPrefix(target, "#"); ok && strings.HasPrefix(id, "h.") {
+		return &docs.Link{HeadingId: id}
+	}
+	return &docs.Link{Url: target}
+}
@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/docs/v1"
+)
+
+func docsTestParagraph(style string, bullet *docs.Bullet, runs ...*docs.TextRun) *docs.StructuralElement {
+	p := &docs.Paragraph{ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: style}, Bullet: bullet}
+	for _, r := range runs {
+		p.Elements = append(p.Elements, &docs.ParagraphElement{TextRun: r})
+	}
+	return &docs.StructuralElement{Paragraph: p}
+}
+
+func docsTestRun(text string, style *docs.TextStyle) *docs.TextRun {
+	return &docs.TextRun{Content: text, TextStyle: style}
+}
+
+func TestDocsMarkdown_Export(t *testing.T) {
+	mono := &docs.TextStyle{WeightedFontFamily: &docs.WeightedFontFamily{FontFamily: "Courier New"}}
+	cell := func(text string) *docs.TableCell {
+		return &docs.TableCell{Content: []*docs.StructuralElement{docsTestParagraph("NORMAL_TEXT", nil, docsTestRun(text+"\n", nil))}}
+	}
+	doc := &docs.Document{
+		Lists: map[string]docs.List{
+			"num": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphType: "DECIMAL"}, {GlyphType: "ALPHA"}}}},
+			"dot": {ListProperties: &docs.ListProperties{NestingLevels: []*docs.NestingLevel{{GlyphSymbol: "●"}}}},
+		},
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			docsTestParagraph("HEADING_1", nil, docsTestRun("Plan\n", nil)),
+			docsTestParagraph("NORMAL_TEXT", nil,
+				docsTestRun("Ship ", nil),
+				docsTestRun("now", &docs.TextStyle{Bold: true}),
+				docsTestRun(", see ", nil),
+				docsTestRun("docs", &docs.TextStyle{Link: &docs.Link{Url: "https://example.com"}}),
+				docsTestRun(" and run ", nil),
+				docsTestRun("make", mono),
+				docsTestRun(" *today*\n", nil),
+			),
+			docsTestParagraph("NORMAL_TEXT", nil, docsTestRun("\n", nil)),
+			docsTestParagraph("NORMAL_TEXT", &docs.Bullet{ListId: "num"}, docsTestRun("First\n", nil)),
+			docsTestParagraph("NORMAL_TEXT", &docs.Bullet{ListId: "num", NestingLevel: 1}, docsTestRun("Nested\n", nil)),
+			docsTestParagraph("NORMAL_TEXT", &docs.Bullet{ListId: "dot"}, docsTestRun("Dot\n", nil)),
+			docsTestParagraph("NORMAL_TEXT", nil, docsTestRun("func main() {\n", mono)),
+			docsTestParagraph("NORMAL_TEXT", nil, docsTestRun("}\n", mono)),
+			{Table: &docs.Table{TableRows: []*docs.TableRow{
+				{TableCells: []*docs.TableCell{cell("Name"), cell("Qty")}},
+				{TableCells: []*docs.TableCell{cell("a|b"), cell("2")}},
+			}}},
+			docsTestParagraph("NORMAL_TEXT", nil, docsTestRun("1. not a list\n", nil)),
+		}},
+	}
+
+	want := strings.Join([]string{
+		"# Plan",
+		"",
+		"Ship **now**, see [docs](https://example.com) and run `make` \\*today\\*",
+		"",
+		"1. First",
+		"   1. Nested",
+		"- Dot",
+		"",
+		"```",
+		"func main() {",
+		"}",
+		"```",
+		"",
+		"| Name | Qty |",
+		"| --- | --- |",
+		"| a\\|b | 2 |",
+		"",
+		"1\\. not a list",
+		"",
+	}, "\n")
+	if got := docsMarkdown(doc, 0); got != want {
+		t.Fatalf("unexpected markdown:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseDocsMarkdown(t *testing.T) {
+	src := strings.Join([]string{
+		"Title",
+		"=====",
+		"",
+		"Some **bold** and _it_ with `code`, [a link](https://x.io \"t\") and snake_case.",
+		"",
+		"- one",
+		"  - two",
+		"- three",
+		"",
+		"1. first",
+		"",
+		"> quoted",
+		"> more",
+		"",
+		"```go",
+		"x := 1",
+		"```",
+		"",
+		"| A | B |",
+		"|---|:-:|",
+		"| 1 | **2** |",
+		"",
+		"---",
+		"## Héllo 😀 *there*",
+	}, "\n")
+	blocks := parseDocsMarkdown(src)
+
+	var kinds []string
+	for _, b := range blocks {
+		kinds = append(kinds, b.kind)
+	}
+	if got := strings.Join(kinds, ","); got != "heading,paragraph,list,list,quote,code,table,heading" {
+		t.Fatalf("unexpected blocks: %s", got)
+	}
+
+	para := blocks[1]
+	if para.text != "Some bold and it with code, a link and snake_case.\n" {
+		t.Fatalf("unexpected paragraph text: %q", para.text)
+	}
+	if len(para.spans) != 4 || !para.spans[0].style.bold || !para.spans[1].style.italic || !para.spans[2].style.code || para.spans[3].style.link != "https://x.io" {
+		t.Fatalf("unexpected spans: %+v", para.spans)
+	}
+	if s := para.spans[3]; para.text[s.start:s.end] != "a link" {
+		t.Fatalf("unexpected link span %+v", s)
+	}
+	if blocks[2].text != "one\n\ttwo\nthree\n" || blocks[2].ordered || !blocks[3].ordered {
+		t.Fatalf("unexpected lists: %+v %+v", blocks[2], blocks[3])
+	}
+	if blocks[4].text != "quoted more\n" || blocks[5].text != "x := 1\n" {
+		t.Fatalf("unexpected quote/code: %q %q", blocks[4].text, blocks[5].text)
+	}
+	table := blocks[6]
+	if len(table.rows) != 2 || table.rows[1][1].text != "2" || !table.rows[1][1].spans[0].style.bold {
+		t.Fatalf("unexpected table: %+v", table.rows)
+	}
+	last := blocks[7]
+	// "😀" is two UTF-16 units, so "there" starts at offset 9.
+	if last.level != 2 || len(last.spans) != 1 || last.spans[0].start != 9 || last.spans[0].end != 14 {
+		t.Fatalf("unexpected heading: %+v", last)
+	}
+}
+
+func TestDocsMarkdownRequests(t *testing.T) {
+	blocks := parseDocsMarkdown("# Hi\n\n| A | B |\n| - | - |\n| c | d |\n\n- x\n- y\n")
+	reqs := docsMarkdownRequests(blocks, 5)
+
+	// Blocks are inserted last-first at the same index.
+	if r := reqs[0].InsertText; r == nil || r.Text != "x\ny" || r.Location.Index != 5 {
+		t.Fatalf("expected list inserted first without final newline, got %+v", reqs[0])
+	}
+	var bullets *docs.CreateParagraphBulletsRequest
+	var table *docs.InsertTableRequest
+	var cells []string
+	var heading *docs.UpdateParagraphStyleRequest
+	for _, r := range reqs {
+		switch {
+		case r.CreateParagraphBullets != nil:
+			bullets = r.CreateParagraphBullets
+		case r.InsertTable != nil:
+			table = r.InsertTable
+		case r.InsertText != nil && table != nil && r.InsertText.Text != "Hi\n":
+			cells = append(cells, fmt.Sprintf("%s@%d", r.InsertText.Text, r.InsertText.Location.Index))
+		case r.UpdateParagraphStyle != nil && r.UpdateParagraphStyle.ParagraphStyle.NamedStyleType == "HEADING_1":
+			heading = r.UpdateParagraphStyle
+		}
+	}
+	if bullets == nil || bullets.BulletPreset != "BULLET_DISC_CIRCLE_SQUARE" || bullets.Range.EndIndex != 8 {
+		t.Fatalf("unexpected bullets: %+v", bullets)
+	}
+	if table == nil || table.Rows != 2 || table.Columns != 2 || table.Location.Index != 5 {
+		t.Fatalf("unexpected table: %+v", table)
+	}
+	// Table starts at 6; cells sit at 9, 11 (row 0) and 14, 16 (row 1).
+	if got := strings.Join(cells, ","); got != "d@16,c@14,B@11,A@9" {
+		t.Fatalf("unexpected cell inserts: %s", got)
+	}
+	if heading == nil || heading.Range.StartIndex != 5 || heading.Range.EndIndex != 8 {
+		t.Fatalf("unexpected heading style: %+v", heading)
+	}
+}
+
+func TestExecute_DocsMarkdown_CatAndImport(t *testing.T) {
+	var batch docs.BatchUpdateDocumentRequest
+	stubGoogleService(t, &newDocsService, docs.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/documents/d1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"documentId": "d1",
+				"revisionId": "r1",
+				"body": map[string]any{"content": []any{
+					map[string]any{"startIndex": 1, "endIndex": 7, "paragraph": map[string]any{
+						"paragraphStyle": map[string]any{"namedStyleType": "HEADING_2"},
+						"elements":       []any{map[string]any{"textRun": map[string]any{"content": "Notes\n"}}},
+					}},
+				}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/documents/d1:batchUpdate":
+			if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"documentId": "d1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	out := captureStdout(t, func() {
+		if execErr := Execute([]string{"--account", "a@b.com", "docs", "cat", "d1", "--format", "markdown"}); execErr != nil {
+			t.Fatalf("cat: %v", execErr)
+		}
+	})
+	if out != "## Notes\n" {
+		t.Fatalf("unexpected markdown: %q", out)
+	}
+
+	path := filepath.Join(t.TempDir(), "in.md")
+	if err := os.WriteFile(path, []byte("More **text**\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out = captureStdout(t, func() {
+		if execErr := Execute([]string{"--json", "--account", "a@b.com", "docs", "edit", "import-markdown", "d1", path, "--require-revision", "r1"}); execErr != nil {
+			t.Fatalf("import: %v", execErr)
+		}
+	})
+	if !strings.Contains(out, `"blocks": 1`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	if batch.WriteControl == nil || batch.WriteControl.RequiredRevisionId != "r1" {
+		t.Fatalf("expected revision guard, got %+v", batch.WriteControl)
+	}
+	// The last paragraph has text, so a separator newline goes first.
+	if r := batch.Requests[0].InsertText; r == nil || r.Text != "\n" || r.Location.Index != 6 {
+		t.Fatalf("expected separator newline, got %+v", batch.Requests[0])
+	}
+	if r := batch.Requests[1].InsertText; r == nil || r.Text != "More text" || r.Location.Index != 7 {
+		t.Fatalf("unexpected insert: %+v", batch.Requests[1])
+	}
+
+	batch = docs.BatchUpdateDocumentRequest{}
+	execErr := Execute([]string{"--account", "a@b.com", "docs", "edit", "import-markdown", "d1", path, "--replace"})
+	if execErr == nil || !strings.Contains(execErr.Error(), "rerun with --force") || batch.Requests != nil {
+		t.Fatalf("expected --replace to need --force, got %v (requests %+v)", execErr, batch.Requests)
+	}
+	_ = captureStderr(t, func() {
+		execErr = Execute([]string{"--json", "--account", "a@b.com", "docs", "edit", "import-markdown", "d1", path, "--replace"})
+	})
+	var de *editError
+	if !errors.As(execErr, &de) || de.ErrorCode != "confirmation_required" || batch.Requests != nil {
+		t.Fatalf("expected JSON-mode --replace to need --force, got %v (requests %+v)", execErr, batch.Requests)
+	}
+	_ = captureStdout(t, func() {
+		if execErr := Execute([]string{"--json", "--force", "--account", "a@b.com", "docs", "edit", "import-markdown", "d1", path, "--replace"}); execErr != nil {
+			t.Fatalf("import replace: %v", execErr)
+		}
+	})
+	if r := batch.Requests[0].DeleteContentRange; r == nil || r.Range.StartIndex != 1 || r.Range.EndIndex != 6 {
+		t.Fatalf("expected body delete, got %+v", batch.Requests[0])
+	}
+	if r := batch.Requests[1].InsertText; r == nil || r.Location.Index != 1 {
+		t.Fatalf("expected insert at 1, got %+v", batch.Requests[1])
+	}
+}
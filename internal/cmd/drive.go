@@ -21,11 +21,16 @@ import (
 
 var newDriveService = googleapi.NewDrive
 
+const driveGetFields = "id, name, mimeType, size, modifiedTime, createdTime, parents, webViewLink, description, starred, " +
+	"shortcutDetails, properties, appProperties, contentRestrictions, copyRequiresWriterPermission"
+
 const (
 	driveMimeGoogleDoc     = "application/vnd.google-apps.document"
 	driveMimeGoogleSheet   = "application/vnd.google-apps.spreadsheet"
 	driveMimeGoogleSlides  = "application/vnd.google-apps.presentation"
 	driveMimeGoogleDrawing = "application/vnd.google-apps.drawing"
+	driveMimeFolder        = "application/vnd.google-apps.folder"
+	driveMimeShortcut      = "application/vnd.google-apps.shortcut"
 	mimePDF                = "application/pdf"
 	mimeCSV                = "text/csv"
 	mimeDocx               = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
@@ -57,7 +62,7 @@ type DriveCmd struct {
 	Copy        DriveCopyCmd        `cmd:"" name:"copy" help:"Copy a file"`
 	Upload      DriveUploadCmd      `cmd:"" name:"upload" help:"Upload a file"`
 	Mkdir       DriveMkdirCmd       `cmd:"" name:"mkdir" help:"Create a folder"`
-	Delete      DriveDeleteCmd      `cmd:"" name:"delete" help:"Delete a file (moves to trash)" aliases:"rm,del"`
+	Delete      DriveDeleteCmd      `cmd:"" name:"delete" help:"Move a file to trash (--permanent to delete forever)" aliases:"rm,del"`
 	Move        DriveMoveCmd        `cmd:"" name:"move" help:"Move a file to a different folder"`
 	Rename      DriveRenameCmd      `cmd:"" name:"rename" help:"Rename a file or folder"`
 	Share       DriveShareCmd       `cmd:"" name:"share" help:"Share a file or folder"`
@@ -65,7 +70,17 @@ type DriveCmd struct {
 	Permissions DrivePermissionsCmd `cmd:"" name:"permissions" help:"List permissions on a file"`
 	URL         DriveURLCmd         `cmd:"" name:"url" help:"Print web URLs for files"`
 	Comments    DriveCommentsCmd    `cmd:"" name:"comments" help:"Manage comments on files"`
+	Labels      DriveLabelsCmd      `cmd:"" name:"labels" help:"List and inspect Drive labels"`
+	Files       DriveFilesCmd       `cmd:"" name:"files" help:"File-level operations (labels)"`
+	Shortcut    DriveShortcutCmd    `cmd:"" name:"shortcut" help:"Create shortcuts to files or folders"`
+	Properties  DrivePropertiesCmd  `cmd:"" name:"properties" aliases:"props" help:"Read and write custom file properties"`
+	Restrict    DriveRestrictCmd    `cmd:"" name:"restrict" help:"Show or set read-only lock and copy restrictions"`
+	Trash       DriveTrashCmd       `cmd:"" name:"trash" help:"List, restore, or empty trashed files"`
+	Du          DriveDuCmd          `cmd:"" name:"du" help:"Summarize storage usage of a folder tree (by folder, owner, MIME type) plus account quota"`
+	Dupes       DriveDupesCmd       `cmd:"" name:"dupes" help:"Find duplicate files by checksum and size (optionally trash extra copies)"`
 	Drives      DriveDrivesCmd      `cmd:"" name:"drives" help:"List shared drives (Team Drives)"`
+	Changes     DriveChangesCmd     `cmd:"" name:"changes" help:"List file changes since a stored page token"`
+	Watch       DriveWatchCmd       `cmd:"" name:"watch" help:"Watch for file changes and forward them to a hook or stdout"`
 }
 
 type DriveLsCmd struct {
@@ -139,9 +154,13 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveSearchCmd struct {
-	Query []string `arg:"" name:"query" help:"Search query"`
-	Max   int64    `name:"max" aliases:"limit" help:"Max results" default:"20"`
-	Page  string   `name:"page" help:"Page token"`
+	Query      []string `arg:"" name:"query" optional:"" help:"Search query"`
+	Label      []string `name:"label" help:"Only files with this label applied (repeatable)"`
+	LabelField []string `name:"label-field" help:"Filter by label field: labelId.fieldId=value (also !=, <, <=, >, >=; int:N for integer fields; repeatable)" sep:"none"`
+	Property   []string `name:"property" help:"Filter by custom property: key=value (repeatable)" sep:"none"`
+	AppProp    []string `name:"app-property" help:"Filter by appProperty: key=value (repeatable)" sep:"none"`
+	Max        int64    `name:"max" aliases:"limit" help:"Max results" default:"20"`
+	Page       string   `name:"page" help:"Page token"`
 }
 
 func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -151,17 +170,39 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 	query := strings.TrimSpace(strings.Join(c.Query, " "))
-	if query == "" {
+	labelQuery, err := buildDriveLabelQuery(c.Label, c.LabelField)
+	if err != nil {
+		return err
+	}
+	propQuery, err := buildDrivePropertyQuery("properties", c.Property)
+	if err != nil {
+		return err
+	}
+	appPropQuery, err := buildDrivePropertyQuery("appProperties", c.AppProp)
+	if err != nil {
+		return err
+	}
+	if query == "" && labelQuery == "" && propQuery == "" && appPropQuery == "" {
 		return usage("missing query")
 	}
 
+	q := "trashed = false"
+	if query != "" {
+		q = buildDriveSearchQuery(query)
+	}
+	for _, extra := range []string{labelQuery, propQuery, appPropQuery} {
+		if extra != "" {
+			q += " and " + extra
+		}
+	}
+
 	svc, err := newDriveService(ctx, account)
 	if err != nil {
 		return err
 	}
 
 	resp, err := svc.Files.List().
-		Q(buildDriveSearchQuery(query)).
+		Q(q).
 		PageSize(c.Max).
 		PageToken(c.Page).
 		OrderBy("modifiedTime desc").
@@ -205,7 +246,8 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveGetCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID          string `arg:"" name:"fileId" help:"File ID"`
+	ResolveShortcut bool   `name:"resolve-shortcut" help:"If the file is a shortcut, show its target instead"`
 }
 
 func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -226,17 +268,33 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	f, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
-		Fields("id, name, mimeType, size, modifiedTime, createdTime, parents, webViewLink, description, starred").
+		Fields(driveGetFields).
 		Context(ctx).
 		Do()
 	if err != nil {
 		return err
 	}
 
+	var shortcut *drive.File
+	if c.ResolveShortcut && f.MimeType == driveMimeShortcut {
+		shortcut = f
+		f, err = resolveDriveShortcut(ctx, svc, shortcut, driveGetFields)
+		if err != nil {
+			return err
+		}
+	}
+
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(os.Stdout, map[string]any{strFile: f})
+		out := map[string]any{strFile: f}
+		if shortcut != nil {
+			out["shortcut"] = shortcut
+		}
+		return outfmt.WriteJSON(os.Stdout, out)
 	}
 
+	if shortcut != nil {
+		u.Out().Printf("shortcut\t%s", shortcut.Id)
+	}
 	u.Out().Printf("id\t%s", f.Id)
 	u.Out().Printf("name\t%s", f.Name)
 	u.Out().Printf("type\t%s", f.MimeType)
@@ -247,6 +305,20 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 		u.Out().Printf("description\t%s", f.Description)
 	}
 	u.Out().Printf("starred\t%t", f.Starred)
+	if f.ShortcutDetails != nil && f.ShortcutDetails.TargetId != "" {
+		u.Out().Printf("target\t%s", f.ShortcutDetails.TargetId)
+	}
+	if f.CopyRequiresWriterPermission {
+		u.Out().Printf("copy_requires_writer\t%t", f.CopyRequiresWriterPermission)
+	}
+	if r := driveReadOnlyRestriction(f); r != nil {
+		u.Out().Printf("read_only\ttrue")
+		if r.Reason != "" {
+			u.Out().Printf("read_only_reason\t%s", r.Reason)
+		}
+	}
+	writeDriveProperties(u, "property", f.Properties)
+	writeDriveProperties(u, "app_property", f.AppProperties)
 	if f.WebViewLink != "" {
 		u.Out().Printf("link\t%s", f.WebViewLink)
 	}
@@ -278,7 +350,7 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	meta, err := svc.Files.Get(fileID).
 		SupportsAllDrives(true).
-		Fields("id, name, mimeType").
+		Fields("id, name, mimeType, shortcutDetails").
 		Context(ctx).
 		Do()
 	if err != nil {
@@ -413,7 +485,7 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	f := &drive.File{
 		Name:     name,
-		MimeType: "application/vnd.google-apps.folder",
+		MimeType: driveMimeFolder,
 	}
 	if strings.TrimSpace(c.Parent) != "" {
 		f.Parents = []string{strings.TrimSpace(c.Parent)}
@@ -441,7 +513,8 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 }
 
 type DriveDeleteCmd struct {
-	FileID string `arg:"" name:"fileId" help:"File ID"`
+	FileID    string `arg:"" name:"fileId" help:"File ID"`
+	Permanent bool   `name:"permanent" help:"Permanently delete instead of moving to trash (cannot be undone)"`
 }
 
 func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -455,7 +528,11 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty fileId")
 	}
 
-	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("delete drive file %s", fileID)); confirmErr != nil {
+	action := fmt.Sprintf("move drive file %s to trash", fileID)
+	if c.Permanent {
+		action = fmt.Sprintf("permanently delete drive file %s", fileID)
+	}
+	if confirmErr := confirmDestructive(ctx, flags, action); confirmErr != nil {
 		return confirmErr
 	}
 
@@ -464,16 +541,28 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	if err := svc.Files.Delete(fileID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
-		return err
+	if c.Permanent {
+		if err := svc.Files.Delete(fileID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
+			return err
+		}
+	} else {
+		if _, err := svc.Files.Update(fileID, &drive.File{Trashed: true}).
+			SupportsAllDrives(true).
+			Fields("id, trashed").
+			Context(ctx).
+			Do(); err != nil {
+			return err
+		}
 	}
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(os.Stdout, map[string]any{
-			"deleted": true,
-			"id":      fileID,
+			"deleted":   true,
+			"permanent": c.Permanent,
+			"id":        fileID,
 		})
 	}
 	u.Out().Printf("deleted\ttrue")
+	u.Out().Printf("permanent\t%t", c.Permanent)
 	u.Out().Printf("id\t%s", fileID)
 	return nil
 }
@@ -882,7 +971,7 @@ func escapeDriveQueryString(s string) string {
 }
 
 func driveType(mimeType string) string {
-	if mimeType == "application/vnd.google-apps.folder" {
+	if mimeType == driveMimeFolder {
 		return "folder"
 	}
 	return strFile
@@ -961,6 +1050,15 @@ func guessMimeType(path string) string {
 }
 
 func downloadDriveFile(ctx context.Context, svc *drive.Service, meta *drive.File, destPath string, format string) (string, int64, error) {
+	// Shortcuts have no content of their own; download what they point at.
+	if meta.MimeType == driveMimeShortcut {
+		target, err := resolveDriveShortcut(ctx, svc, meta, "id, name, mimeType")
+		if err != nil {
+			return "", 0, fmt.Errorf("resolve shortcut: %w", err)
+		}
+		meta = target
+	}
+
 	isGoogleDoc := strings.HasPrefix(meta.MimeType, "application/vnd.google-apps.")
 
 	var (
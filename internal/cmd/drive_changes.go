@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveChangeFileFields = "id, name, mimeType, size, modifiedTime, parents, trashed, md5Checksum, webViewLink"
+
+type DriveChangesCmd struct {
+	List  DriveChangesListCmd  `cmd:"" name:"list" default:"withargs" help:"List changes since a page token (default: stored token)"`
+	Token DriveChangesTokenCmd `cmd:"" name:"token" help:"Show or reset the stored changes page token"`
+}
+
+type DriveChangesListCmd struct {
+	Since          string `name:"since" help:"Page token to list changes from (default: stored token for account/drive)"`
+	Drive          string `name:"drive" help:"Shared drive ID (default: My Drive and all shared drives)"`
+	Max            int64  `name:"max" aliases:"limit" help:"Max changes to return" default:"100"`
+	IncludeRemoved bool   `name:"include-removed" help:"Include changes for files that were removed or lost access"`
+	NoSave         bool   `name:"no-save" help:"Do not persist the resulting page token"`
+}
+
+func (c *DriveChangesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if c.Max <= 0 {
+		return usage("--max must be > 0")
+	}
+	driveID := strings.TrimSpace(c.Drive)
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	since := strings.TrimSpace(c.Since)
+	if since == "" {
+		state, ok, loadErr := loadDriveChangesState(account, driveID)
+		if loadErr != nil {
+			return loadErr
+		}
+		if ok {
+			since = state.PageToken
+		}
+	}
+
+	// Without a starting point there is nothing to diff against yet: record the
+	// current start token so the next call returns changes from now on.
+	if since == "" {
+		start, startErr := driveStartPageToken(ctx, svc, driveID)
+		if startErr != nil {
+			return startErr
+		}
+		if !c.NoSave {
+			if saveErr := saveDriveChangesState(account, driveID, start); saveErr != nil {
+				return saveErr
+			}
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{
+				"changes":           []*drive.Change{},
+				"newStartPageToken": start,
+				"initialized":       true,
+			})
+		}
+		u.Err().Println("No stored page token; starting from now")
+		u.Out().Printf("token\t%s", start)
+		return nil
+	}
+
+	batch, err := listDriveChanges(ctx, svc, driveChangesQuery{
+		PageToken:      since,
+		DriveID:        driveID,
+		Max:            c.Max,
+		IncludeRemoved: c.IncludeRemoved,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !c.NoSave {
+		if saveErr := saveDriveChangesState(account, driveID, batch.ResumeToken()); saveErr != nil {
+			return saveErr
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"since":             since,
+			"changes":           batch.Changes,
+			"nextPageToken":     batch.NextPageToken,
+			"newStartPageToken": batch.NewStartPageToken,
+		})
+	}
+
+	if len(batch.Changes) == 0 {
+		u.Err().Println("No changes")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "TIME\tCHANGE\tFILE_ID\tNAME\tTYPE")
+	for _, ch := range batch.Changes {
+		name, kind := "-", "-"
+		if ch.File != nil {
+			name = ch.File.Name
+			kind = driveType(ch.File.MimeType)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			formatDateTime(ch.Time),
+			driveChangeKind(ch),
+			ch.FileId,
+			name,
+			kind,
+		)
+	}
+	if batch.NextPageToken != "" {
+		u.Err().Printf("# More changes: --since %s", batch.NextPageToken)
+	}
+	return nil
+}
+
+type DriveChangesTokenCmd struct {
+	Drive string `name:"drive" help:"Shared drive ID (default: My Drive and all shared drives)"`
+	Reset bool   `name:"reset" help:"Replace the stored token with the current start page token"`
+}
+
+func (c *DriveChangesTokenCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.Drive)
+
+	state, ok, err := loadDriveChangesState(account, driveID)
+	if err != nil {
+		return err
+	}
+	if !ok || c.Reset {
+		svc, svcErr := newDriveService(ctx, account)
+		if svcErr != nil {
+			return svcErr
+		}
+		start, startErr := driveStartPageToken(ctx, svc, driveID)
+		if startErr != nil {
+			return startErr
+		}
+		if saveErr := saveDriveChangesState(account, driveID, start); saveErr != nil {
+			return saveErr
+		}
+		state, _, err = loadDriveChangesState(account, driveID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"state": state})
+	}
+	u.Out().Printf("account\t%s", state.Account)
+	if state.DriveID != "" {
+		u.Out().Printf("drive\t%s", state.DriveID)
+	}
+	u.Out().Printf("token\t%s", state.PageToken)
+	if state.UpdatedAtMs > 0 {
+		u.Out().Printf("updated_at\t%s", formatUnixMillis(state.UpdatedAtMs))
+	}
+	return nil
+}
+
+type driveChangesQuery struct {
+	PageToken      string
+	DriveID        string
+	Max            int64
+	IncludeRemoved bool
+}
+
+type driveChangesBatch struct {
+	Changes           []*drive.Change
+	NextPageToken     string
+	NewStartPageToken string
+}
+
+// ResumeToken is the token a later call should continue from: the next page
+// when the listing was truncated by --max, otherwise the new start token.
+func (b driveChangesBatch) ResumeToken() string {
+	if b.NextPageToken != "" {
+		return b.NextPageToken
+	}
+	return b.NewStartPageToken
+}
+
+func listDriveChanges(ctx context.Context, svc *drive.Service, q driveChangesQuery) (driveChangesBatch, error) {
+	out := driveChangesBatch{Changes: make([]*drive.Change, 0)}
+	token := q.PageToken
+	for token != "" {
+		call := svc.Changes.List(token).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			IncludeRemoved(q.IncludeRemoved).
+			Fields("nextPageToken, newStartPageToken, changes(changeType, time, removed, fileId, driveId, file(" + driveChangeFileFields + "))").
+			Context(ctx)
+		if q.DriveID != "" {
+			call = call.DriveId(q.DriveID)
+		}
+		if q.Max > 0 {
+			remaining := q.Max - int64(len(out.Changes))
+			call = call.PageSize(min(remaining, 1000))
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return driveChangesBatch{}, err
+		}
+		out.Changes = append(out.Changes, resp.Changes...)
+		if resp.NewStartPageToken != "" {
+			out.NewStartPageToken = resp.NewStartPageToken
+			return out, nil
+		}
+		token = resp.NextPageToken
+		if q.Max > 0 && int64(len(out.Changes)) >= q.Max {
+			out.NextPageToken = token
+			return out, nil
+		}
+	}
+	return out, nil
+}
+
+func driveStartPageToken(ctx context.Context, svc *drive.Service, driveID string) (string, error) {
+	call := svc.Changes.GetStartPageToken().SupportsAllDrives(true).Context(ctx)
+	if driveID != "" {
+		call = call.DriveId(driveID)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return "", err
+	}
+	if resp.StartPageToken == "" {
+		return "", errors.New("drive returned empty start page token")
+	}
+	return resp.StartPageToken, nil
+}
+
+func driveChangeKind(ch *drive.Change) string {
+	switch {
+	case ch.Removed:
+		return "removed"
+	case ch.File != nil && ch.File.Trashed:
+		return "trashed"
+	case ch.ChangeType == "drive":
+		return "drive"
+	default:
+		return "changed"
+	}
+}
+
+type driveChangesState struct {
+	Account     string `json:"account"`
+	DriveID     string `json:"driveId,omitempty"`
+	PageToken   string `json:"pageToken"`
+	UpdatedAtMs int64  `json:"updatedAtMs,omitempty"`
+}
+
+func driveChangesStatePath(account, driveID string) (string, error) {
+	dir, err := config.EnsureDriveChangesDir()
+	if err != nil {
+		return "", err
+	}
+	name := sanitizeAccountForPath(account)
+	if driveID != "" {
+		name += "__" + sanitizeAccountForPath(driveID)
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func loadDriveChangesState(account, driveID string) (driveChangesState, bool, error) {
+	path, err := driveChangesStatePath(account, driveID)
+	if err != nil {
+		return driveChangesState{}, false, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // config-dir path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return driveChangesState{}, false, nil
+		}
+		return driveChangesState{}, false, err
+	}
+	var state driveChangesState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return driveChangesState{}, false, fmt.Errorf("parse drive changes state: %w", err)
+	}
+	return state, strings.TrimSpace(state.PageToken) != "", nil
+}
+
+func saveDriveChangesState(account, driveID, token string) error {
+	if strings.TrimSpace(token) == "" {
+		return nil
+	}
+	path, err := driveChangesStatePath(account, driveID)
+	if err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(driveChangesState{
+		Account:     account,
+		DriveID:     driveID,
+		PageToken:   token,
+		UpdatedAtMs: time.Now().UnixMilli(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(payload, '\n'), 0o600)
+}
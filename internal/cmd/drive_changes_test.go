@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func newDriveChangesTestService(t *testing.T, handler http.Handler) {
+	t.Helper()
+
+	stubGoogleService(t, &newDriveService, drive.NewService, handler)
+}
+
+func TestDriveChangesList_InitializesThenResumes(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var listTokens []string
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/changes/startPageToken"):
+			if got := r.URL.Query().Get("driveId"); got != "0AD" {
+				t.Errorf("expected driveId=0AD, got %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"startPageToken": "100"})
+		case strings.HasSuffix(r.URL.Path, "/changes"):
+			token := r.URL.Query().Get("pageToken")
+			listTokens = append(listTokens, token)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"newStartPageToken": "105",
+				"changes": []map[string]any{
+					{
+						"changeType": "file",
+						"fileId":     "f1",
+						"time":       "2026-01-02T03:04:05Z",
+						"file":       map[string]any{"id": "f1", "name": "hero.fig", "mimeType": "application/octet-stream"},
+					},
+					{"changeType": "file", "fileId": "f2", "removed": true},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	first := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveChangesListCmd{}, []string{"--drive", "0AD"}, ctx, flags); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	if !strings.Contains(first, `"initialized": true`) || !strings.Contains(first, `"100"`) {
+		t.Fatalf("expected initialization output, got %q", first)
+	}
+	if len(listTokens) != 0 {
+		t.Fatalf("expected no changes.list call on first run, got %v", listTokens)
+	}
+
+	second := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveChangesListCmd{}, []string{"--drive", "0AD"}, ctx, flags); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	var parsed struct {
+		Since             string          `json:"since"`
+		Changes           []*drive.Change `json:"changes"`
+		NewStartPageToken string          `json:"newStartPageToken"`
+	}
+	if unmarshalErr := json.Unmarshal([]byte(second), &parsed); unmarshalErr != nil {
+		t.Fatalf("json: %v\n%s", unmarshalErr, second)
+	}
+	if parsed.Since != "100" || parsed.NewStartPageToken != "105" || len(parsed.Changes) != 2 {
+		t.Fatalf("unexpected output: %#v", parsed)
+	}
+
+	state, ok, err := loadDriveChangesState("a@b.com", "0AD")
+	if err != nil || !ok {
+		t.Fatalf("load state: ok=%v err=%v", ok, err)
+	}
+	if state.PageToken != "105" || state.DriveID != "0AD" {
+		t.Fatalf("unexpected stored state: %#v", state)
+	}
+	if _, ok, _ := loadDriveChangesState("a@b.com", ""); ok {
+		t.Fatalf("expected drive-scoped state to be separate from My Drive state")
+	}
+}
+
+func TestDriveChangesList_SinceMaxAndNoSave(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/changes") {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("pageToken"); got != "7" {
+			t.Errorf("expected pageToken=7, got %q", got)
+		}
+		if got := r.URL.Query().Get("pageSize"); got != "1" {
+			t.Errorf("expected pageSize=1, got %q", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"nextPageToken": "8",
+			"changes": []map[string]any{
+				{"changeType": "file", "fileId": "f1", "file": map[string]any{"id": "f1", "name": "Doc", "trashed": true}},
+			},
+		})
+	}))
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{})
+
+	out := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveChangesListCmd{}, []string{"--since", "7", "--max", "1", "--no-save"}, ctx, flags); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	if !strings.Contains(out, "trashed") || !strings.Contains(out, "f1") {
+		t.Fatalf("unexpected table: %q", out)
+	}
+	if _, ok, _ := loadDriveChangesState("a@b.com", ""); ok {
+		t.Fatalf("expected --no-save to skip persisting the token")
+	}
+}
+
+func TestDriveChangesBatchResumeToken(t *testing.T) {
+	if got := (driveChangesBatch{NextPageToken: "a", NewStartPageToken: "b"}).ResumeToken(); got != "a" {
+		t.Fatalf("expected next page token, got %q", got)
+	}
+	if got := (driveChangesBatch{NewStartPageToken: "b"}).ResumeToken(); got != "b" {
+		t.Fatalf("expected new start token, got %q", got)
+	}
+}
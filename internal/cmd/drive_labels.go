@@ -0,0 +1,621 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/drivelabels/v2"
+
+	"github.com/steipete/gogcli/internal/errfmt"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var newDriveLabelsService = googleapi.NewDriveLabels
+
+const (
+	driveLabelViewBasic = "LABEL_VIEW_BASIC"
+	driveLabelViewFull  = "LABEL_VIEW_FULL"
+)
+
+type DriveLabelsCmd struct {
+	List DriveLabelsListCmd `cmd:"" name:"list" default:"withargs" help:"List Drive labels available to you"`
+	Get  DriveLabelsGetCmd  `cmd:"" name:"get" help:"Get a label with its fields and choices"`
+}
+
+type DriveLabelsListCmd struct {
+	Max         int64  `name:"max" aliases:"limit" help:"Max results" default:"50"`
+	Page        string `name:"page" help:"Page token"`
+	All         bool   `name:"all" help:"Include unpublished and disabled labels (requires label editor access)"`
+	MinimumRole string `name:"minimum-role" help:"Only labels where you have at least this role" enum:"reader,applier,organizer,editor" default:"reader"`
+}
+
+func (c *DriveLabelsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveLabelsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Labels.List().
+		View(driveLabelViewFull).
+		PublishedOnly(!c.All).
+		MinimumRole(strings.ToUpper(c.MinimumRole)).
+		Context(ctx)
+	if c.Max > 0 {
+		call = call.PageSize(c.Max)
+	}
+	if strings.TrimSpace(c.Page) != "" {
+		call = call.PageToken(c.Page)
+	}
+
+	resp, err := call.Do()
+	if err != nil {
+		return wrapDriveLabelsError(err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"labels":        resp.Labels,
+			"nextPageToken": resp.NextPageToken,
+		})
+	}
+
+	if len(resp.Labels) == 0 {
+		u.Err().Println("No labels")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTITLE\tTYPE\tSTATE\tFIELDS")
+	for _, l := range resp.Labels {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", l.Id, driveLabelTitle(l), driveLabelType(l.LabelType), driveLabelState(l.Lifecycle), len(l.Fields))
+	}
+	printNextPageHint(u, resp.NextPageToken)
+	return nil
+}
+
+type DriveLabelsGetCmd struct {
+	LabelID string `arg:"" name:"labelId" help:"Label ID (or labels/<id>)"`
+	Basic   bool   `name:"basic" help:"Fetch the basic view (omit fields and choices)"`
+}
+
+func (c *DriveLabelsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	labelID := driveLabelID(c.LabelID)
+	if labelID == "" {
+		return usage("empty labelId")
+	}
+
+	svc, err := newDriveLabelsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	view := driveLabelViewFull
+	if c.Basic {
+		view = driveLabelViewBasic
+	}
+	label, err := svc.Labels.Get("labels/" + labelID).View(view).Context(ctx).Do()
+	if err != nil {
+		return wrapDriveLabelsError(err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"label": label})
+	}
+
+	u.Out().Printf("id\t%s", label.Id)
+	u.Out().Printf("title\t%s", driveLabelTitle(label))
+	u.Out().Printf("type\t%s", driveLabelType(label.LabelType))
+	u.Out().Printf("state\t%s", driveLabelState(label.Lifecycle))
+	u.Out().Printf("revision\t%s", label.RevisionId)
+	if label.Properties != nil && label.Properties.Description != "" {
+		u.Out().Printf("description\t%s", label.Properties.Description)
+	}
+	if len(label.Fields) == 0 {
+		return nil
+	}
+
+	u.Out().Println("")
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "FIELD\tNAME\tTYPE\tQUERY KEY\tCHOICES")
+	for _, f := range label.Fields {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.Id, driveLabelFieldName(f), driveLabelFieldType(f), orDash(f.QueryKey), driveLabelFieldChoices(f))
+	}
+	return nil
+}
+
+type DriveFilesCmd struct {
+	Labels DriveFileLabelsCmd `cmd:"" name:"labels" help:"List, apply, or remove labels on a file"`
+}
+
+type DriveFileLabelsCmd struct {
+	List   DriveFileLabelsListCmd   `cmd:"" name:"list" default:"withargs" help:"List labels applied to a file"`
+	Apply  DriveFileLabelsApplyCmd  `cmd:"" name:"apply" help:"Apply a label (and set field values) on a file"`
+	Remove DriveFileLabelsRemoveCmd `cmd:"" name:"remove" aliases:"rm" help:"Remove a label from a file"`
+}
+
+type DriveFileLabelsListCmd struct {
+	FileID string `arg:"" name:"fileId" help:"File ID"`
+	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page   string `name:"page" help:"Page token"`
+}
+
+func (c *DriveFileLabelsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Files.ListLabels(fileID).Context(ctx)
+	if c.Max > 0 {
+		call = call.MaxResults(c.Max)
+	}
+	if strings.TrimSpace(c.Page) != "" {
+		call = call.PageToken(c.Page)
+	}
+	resp, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"fileId":        fileID,
+			"labels":        resp.Labels,
+			"nextPageToken": resp.NextPageToken,
+		})
+	}
+
+	if len(resp.Labels) == 0 {
+		u.Err().Println("No labels")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "LABEL\tREVISION\tFIELD\tVALUE")
+	for _, l := range resp.Labels {
+		writeDriveAppliedLabel(w, l)
+	}
+	printNextPageHint(u, resp.NextPageToken)
+	return nil
+}
+
+type DriveFileLabelsApplyCmd struct {
+	FileID    string   `arg:"" name:"fileId" help:"File ID"`
+	LabelID   string   `arg:"" name:"labelId" help:"Label ID (or labels/<id>)"`
+	Text      []string `name:"text" help:"Set a text field: fieldId=value (repeatable)" sep:"none"`
+	Integer   []string `name:"integer" help:"Set an integer field: fieldId=value (repeatable)" sep:"none"`
+	Date      []string `name:"date" help:"Set a date field: fieldId=YYYY-MM-DD (repeatable)" sep:"none"`
+	Selection []string `name:"selection" help:"Set a selection field: fieldId=choiceId (repeat for multi-select)" sep:"none"`
+	User      []string `name:"user" help:"Set a user field: fieldId=email (repeat for multiple users)" sep:"none"`
+	Unset     []string `name:"unset" help:"Clear a field's values: fieldId (repeatable)"`
+}
+
+func (c *DriveFileLabelsApplyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	labelID := driveLabelID(c.LabelID)
+	if labelID == "" {
+		return usage("empty labelId")
+	}
+
+	fieldMods, err := c.fieldModifications()
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Files.ModifyLabels(fileID, &drive.ModifyLabelsRequest{
+		LabelModifications: []*drive.LabelModification{{
+			LabelId:            labelID,
+			FieldModifications: fieldMods,
+		}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return writeDriveModifiedLabels(ctx, fileID, "applied", labelID, resp.ModifiedLabels)
+}
+
+// fieldModifications groups repeated flags per field so multi-value selection
+// and user fields are sent as a single modification.
+func (c *DriveFileLabelsApplyCmd) fieldModifications() ([]*drive.LabelFieldModification, error) {
+	byField := map[string]*drive.LabelFieldModification{}
+	var order []string
+	get := func(fieldID string) *drive.LabelFieldModification {
+		if m, ok := byField[fieldID]; ok {
+			return m
+		}
+		m := &drive.LabelFieldModification{FieldId: fieldID}
+		byField[fieldID] = m
+		order = append(order, fieldID)
+		return m
+	}
+
+	add := func(flag string, values []string, set func(*drive.LabelFieldModification, string) error) error {
+		for _, raw := range values {
+			fieldID, value, ok := strings.Cut(raw, "=")
+			fieldID = strings.TrimSpace(fieldID)
+			if !ok || fieldID == "" {
+				return usagef("invalid --%s %q (expected fieldId=value)", flag, raw)
+			}
+			if err := set(get(fieldID), strings.TrimSpace(value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := add("text", c.Text, func(m *drive.LabelFieldModification, v string) error {
+		m.SetTextValues = append(m.SetTextValues, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := add("integer", c.Integer, func(m *drive.LabelFieldModification, v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return usagef("invalid --integer value %q for field %s", v, m.FieldId)
+		}
+		m.SetIntegerValues = append(m.SetIntegerValues, n)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := add("date", c.Date, func(m *drive.LabelFieldModification, v string) error {
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return usagef("invalid --date value %q for field %s (expected YYYY-MM-DD)", v, m.FieldId)
+		}
+		m.SetDateValues = append(m.SetDateValues, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := add("selection", c.Selection, func(m *drive.LabelFieldModification, v string) error {
+		m.SetSelectionValues = append(m.SetSelectionValues, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := add("user", c.User, func(m *drive.LabelFieldModification, v string) error {
+		m.SetUserValues = append(m.SetUserValues, v)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, raw := range c.Unset {
+		fieldID := strings.TrimSpace(raw)
+		if fieldID == "" {
+			continue
+		}
+		if _, ok := byField[fieldID]; ok {
+			return nil, usagef("field %s cannot be both set and unset", fieldID)
+		}
+		get(fieldID).UnsetValues = true
+	}
+
+	out := make([]*drive.LabelFieldModification, 0, len(order))
+	for _, id := range order {
+		out = append(out, byField[id])
+	}
+	return out, nil
+}
+
+type DriveFileLabelsRemoveCmd struct {
+	FileID  string `arg:"" name:"fileId" help:"File ID"`
+	LabelID string `arg:"" name:"labelId" help:"Label ID (or labels/<id>)"`
+}
+
+func (c *DriveFileLabelsRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	labelID := driveLabelID(c.LabelID)
+	if labelID == "" {
+		return usage("empty labelId")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Files.ModifyLabels(fileID, &drive.ModifyLabelsRequest{
+		LabelModifications: []*drive.LabelModification{{LabelId: labelID, RemoveLabel: true}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return writeDriveModifiedLabels(ctx, fileID, "removed", labelID, resp.ModifiedLabels)
+}
+
+func writeDriveModifiedLabels(ctx context.Context, fileID, action, labelID string, labels []*drive.Label) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			action:           true,
+			"fileId":         fileID,
+			"labelId":        labelID,
+			"modifiedLabels": labels,
+		})
+	}
+
+	u := ui.FromContext(ctx)
+	u.Out().Printf("%s\ttrue", action)
+	u.Out().Printf("file\t%s", fileID)
+	u.Out().Printf("label\t%s", labelID)
+	for _, l := range labels {
+		if l == nil || l.Id != labelID {
+			continue
+		}
+		for _, id := range sortedDriveLabelFieldIDs(l.Fields) {
+			u.Out().Printf("field.%s\t%s", id, formatDriveLabelFieldValue(l.Fields[id]))
+		}
+	}
+	return nil
+}
+
+func writeDriveAppliedLabel(w interface{ Write([]byte) (int, error) }, l *drive.Label) {
+	if l == nil {
+		return
+	}
+	if len(l.Fields) == 0 {
+		fmt.Fprintf(w, "%s\t%s\t-\t-\n", l.Id, orDash(l.RevisionId))
+		return
+	}
+	for _, id := range sortedDriveLabelFieldIDs(l.Fields) {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", l.Id, orDash(l.RevisionId), id, formatDriveLabelFieldValue(l.Fields[id]))
+	}
+}
+
+func sortedDriveLabelFieldIDs(fields map[string]drive.LabelField) []string {
+	ids := make([]string, 0, len(fields))
+	for id := range fields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func formatDriveLabelFieldValue(f drive.LabelField) string {
+	var values []string
+	switch {
+	case len(f.Text) > 0:
+		values = f.Text
+	case len(f.Selection) > 0:
+		values = f.Selection
+	case len(f.DateString) > 0:
+		values = f.DateString
+	case len(f.Integer) > 0:
+		for _, n := range f.Integer {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+	case len(f.User) > 0:
+		for _, usr := range f.User {
+			if usr == nil {
+				continue
+			}
+			values = append(values, usr.EmailAddress)
+		}
+	}
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+// driveLabelID accepts both bare IDs and resource names ("labels/<id>", with an
+// optional "@revision" suffix) since the Labels API returns the latter.
+func driveLabelID(raw string) string {
+	id := strings.TrimSpace(raw)
+	id = strings.TrimPrefix(id, "labels/")
+	if before, _, ok := strings.Cut(id, "@"); ok {
+		id = before
+	}
+	return id
+}
+
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+func driveLabelTitle(l *drivelabels.GoogleAppsDriveLabelsV2Label) string {
+	if l == nil || l.Properties == nil || l.Properties.Title == "" {
+		return "-"
+	}
+	return l.Properties.Title
+}
+
+func driveLabelType(t string) string {
+	switch t {
+	case "SHARED":
+		return "shared"
+	case "ADMIN":
+		return "admin"
+	case "GOOGLE_APP":
+		return "google-app"
+	case "":
+		return "-"
+	default:
+		return strings.ToLower(t)
+	}
+}
+
+func driveLabelState(lc *drivelabels.GoogleAppsDriveLabelsV2Lifecycle) string {
+	if lc == nil || lc.State == "" {
+		return "-"
+	}
+	return strings.ToLower(lc.State)
+}
+
+func driveLabelFieldName(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	if f == nil || f.Properties == nil || f.Properties.DisplayName == "" {
+		return "-"
+	}
+	return f.Properties.DisplayName
+}
+
+func driveLabelFieldType(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	switch {
+	case f == nil:
+		return "-"
+	case f.TextOptions != nil:
+		return "text"
+	case f.IntegerOptions != nil:
+		return "integer"
+	case f.DateOptions != nil:
+		return "date"
+	case f.SelectionOptions != nil:
+		return "selection"
+	case f.UserOptions != nil:
+		return "user"
+	default:
+		return "-"
+	}
+}
+
+func driveLabelFieldChoices(f *drivelabels.GoogleAppsDriveLabelsV2Field) string {
+	if f == nil || f.SelectionOptions == nil || len(f.SelectionOptions.Choices) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(f.SelectionOptions.Choices))
+	for _, ch := range f.SelectionOptions.Choices {
+		if ch == nil {
+			continue
+		}
+		name := ch.Id
+		if ch.Properties != nil && ch.Properties.DisplayName != "" {
+			name = ch.Id + "=" + ch.Properties.DisplayName
+		}
+		parts = append(parts, name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// buildDriveLabelQuery turns --label/--label-field flags into Drive search
+// clauses. Field filters take labelId.fieldId=value (or !=, <, <=, >, >=).
+// Values are quoted as text, choice, user or date values; integer fields take
+// an explicit int:N, which is left unquoted as the Drive query grammar expects.
+// Guessing from the value alone would turn a text field holding "007" into an
+// integer comparison.
+func buildDriveLabelQuery(labels []string, fields []string) (string, error) {
+	var clauses []string
+	for _, raw := range labels {
+		id := driveLabelID(raw)
+		if id == "" {
+			continue
+		}
+		clauses = append(clauses, fmt.Sprintf("'labels/%s' in labels", escapeDriveQueryString(id)))
+	}
+	for _, raw := range fields {
+		clause, err := driveLabelFieldClause(raw)
+		if err != nil {
+			return "", err
+		}
+		clauses = append(clauses, clause)
+	}
+	return strings.Join(clauses, " and "), nil
+}
+
+func driveLabelFieldClause(raw string) (string, error) {
+	expr := strings.TrimSpace(raw)
+	idx := strings.IndexAny(expr, "=!<>")
+	if idx <= 0 {
+		return "", usagef("invalid --label-field %q (expected labelId.fieldId=value)", raw)
+	}
+	key := strings.TrimSpace(expr[:idx])
+	rest := expr[idx:]
+	op := ""
+	for _, candidate := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(rest, candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return "", usagef("invalid --label-field %q (expected labelId.fieldId=value)", raw)
+	}
+	value := strings.TrimSpace(strings.TrimPrefix(rest, op))
+
+	labelID, fieldID, ok := strings.Cut(key, ".")
+	labelID = driveLabelID(labelID)
+	fieldID = strings.TrimSpace(fieldID)
+	if !ok || labelID == "" || fieldID == "" {
+		return "", usagef("invalid --label-field %q (expected labelId.fieldId=value)", raw)
+	}
+	if value == "" {
+		return "", usagef("invalid --label-field %q (missing value)", raw)
+	}
+
+	if n, isInt := strings.CutPrefix(value, "int:"); isInt {
+		n = strings.TrimSpace(n)
+		if _, err := strconv.ParseInt(n, 10, 64); err != nil {
+			return "", usagef("invalid --label-field %q (int: needs an integer)", raw)
+		}
+		value = n
+	} else {
+		value = "'" + escapeDriveQueryString(value) + "'"
+	}
+	return fmt.Sprintf("labels/%s.%s %s %s", labelID, fieldID, op, value), nil
+}
+
+// wrapDriveLabelsError points at the opt-in drivelabels service when the
+// token lacks the Drive Labels scope.
+func wrapDriveLabelsError(err error) error {
+	errStr := err.Error()
+	if strings.Contains(errStr, "insufficientPermissions") ||
+		strings.Contains(errStr, "insufficient authentication scopes") ||
+		strings.Contains(errStr, "ACCESS_TOKEN_SCOPE_INSUFFICIENT") {
+		return errfmt.NewUserFacingError("Insufficient permissions for Drive Labels API; re-authenticate with the drive.labels.readonly scope: gog auth add <account> --services drive,drivelabels", err)
+	}
+	return err
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drivelabels/v2"
+)
+
+func TestDriveFileLabelsApply_BuildsFieldModifications(t *testing.T) {
+	var gotPath string
+	var body map[string]any
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		gotPath = strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"modifiedLabels": []map[string]any{{
+				"id": "L1",
+				"fields": map[string]any{
+					"status": map[string]any{"id": "status", "selection": []string{"c1", "c2"}},
+					"owner":  map[string]any{"id": "owner", "text": []string{"Ops, EMEA"}},
+				},
+			}},
+		})
+	}))
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+	args := []string{
+		"file1", "labels/L1@3",
+		"--text", "owner=Ops, EMEA",
+		"--selection", "status=c1", "--selection", "status=c2",
+		"--integer", "prio=2",
+		"--date", "due=2026-11-01",
+		"--unset", "old",
+	}
+	if err := runKong(t, &DriveFileLabelsApplyCmd{}, args, ctx, flags); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	if gotPath != "/files/file1/modifyLabels" {
+		t.Fatalf("unexpected path %q", gotPath)
+	}
+	mods, _ := body["labelModifications"].([]any)
+	if len(mods) != 1 {
+		t.Fatalf("expected one label modification, got %v", body)
+	}
+	mod := mods[0].(map[string]any)
+	if mod["labelId"] != "L1" {
+		t.Fatalf("expected bare label id, got %v", mod["labelId"])
+	}
+	fields, _ := mod["fieldModifications"].([]any)
+	byID := map[string]map[string]any{}
+	for _, f := range fields {
+		m := f.(map[string]any)
+		byID[m["fieldId"].(string)] = m
+	}
+	if got := byID["owner"]["setTextValues"]; len(got.([]any)) != 1 || got.([]any)[0] != "Ops, EMEA" {
+		t.Fatalf("text value should keep commas, got %v", got)
+	}
+	if got := byID["status"]["setSelectionValues"]; len(got.([]any)) != 2 {
+		t.Fatalf("expected two selection values merged into one modification, got %v", got)
+	}
+	if got := byID["prio"]["setIntegerValues"]; got.([]any)[0] != "2" {
+		t.Fatalf("unexpected integer values %v", got)
+	}
+	if byID["old"]["unsetValues"] != true {
+		t.Fatalf("expected unsetValues for old, got %v", byID["old"])
+	}
+
+	out := textOut.String()
+	if !strings.Contains(out, "applied\ttrue") || !strings.Contains(out, "field.status\tc1, c2") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestDriveFileLabelsApply_Validation(t *testing.T) {
+	ctx, _ := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+
+	cases := map[string][]string{
+		"expected fieldId=value": {"f", "L1", "--text", "novalue"},
+		"invalid --integer":      {"f", "L1", "--integer", "n=abc"},
+		"expected YYYY-MM-DD":    {"f", "L1", "--date", "d=11/01/2026"},
+		"both set and unset":     {"f", "L1", "--text", "a=x", "--unset", "a"},
+	}
+	for want, args := range cases {
+		if err := runKong(t, &DriveFileLabelsApplyCmd{}, args, ctx, flags); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("args %v: expected %q error, got %v", args, want, err)
+		}
+	}
+}
+
+func TestDriveFileLabelsRemove(t *testing.T) {
+	var body map[string]any
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]any{})
+	}))
+
+	ctx, _ := driveTrashTestContext(t, true)
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveFileLabelsRemoveCmd{}, []string{"file1", "L1"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	mod := body["labelModifications"].([]any)[0].(map[string]any)
+	if mod["removeLabel"] != true || mod["labelId"] != "L1" {
+		t.Fatalf("unexpected modification: %v", mod)
+	}
+	if !strings.Contains(out, `"removed": true`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDriveFileLabelsList(t *testing.T) {
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/files/file1/listLabels") {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"labels": []map[string]any{{
+				"id":         "L1",
+				"revisionId": "4",
+				"fields": map[string]any{
+					"reviewer": map[string]any{"user": []map[string]any{{"emailAddress": "r@b.com"}}},
+					"prio":     map[string]any{"integer": []string{"3"}},
+				},
+			}},
+		})
+	}))
+
+	ctx, _ := driveTrashTestContext(t, false)
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveFileLabelsListCmd{}, []string{"file1"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if !strings.Contains(out, "prio") || !strings.Contains(out, "r@b.com") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestDriveLabelsListAndGet(t *testing.T) {
+	var listQuery, getPath string
+	stubGoogleService(t, &newDriveLabelsService, drivelabels.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v2/labels":
+			listQuery = r.URL.RawQuery
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"labels": []map[string]any{{
+					"id":         "L1",
+					"labelType":  "SHARED",
+					"properties": map[string]any{"title": "Project"},
+					"lifecycle":  map[string]any{"state": "PUBLISHED"},
+				}},
+			})
+		case strings.HasPrefix(r.URL.Path, "/v2/labels/"):
+			getPath = r.URL.Path
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":         "L1",
+				"properties": map[string]any{"title": "Project"},
+				"fields": []map[string]any{{
+					"id":         "status",
+					"queryKey":   "labels/L1.status",
+					"properties": map[string]any{"displayName": "Status"},
+					"selectionOptions": map[string]any{"choices": []map[string]any{
+						{"id": "c1", "properties": map[string]any{"displayName": "Active"}},
+					}},
+				}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	flags := &RootFlags{Account: "a@b.com"}
+	ctx, textOut := driveTrashTestContext(t, false)
+	listOut := captureStdout(t, func() {
+		if err := runKong(t, &DriveLabelsListCmd{}, nil, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(listQuery, "publishedOnly=true") || !strings.Contains(listQuery, "minimumRole=READER") {
+		t.Fatalf("unexpected list query %q", listQuery)
+	}
+	if !strings.Contains(listOut, "Project") || !strings.Contains(listOut, "shared") {
+		t.Fatalf("unexpected list output: %q", listOut)
+	}
+
+	fieldsOut := captureStdout(t, func() {
+		if err := runKong(t, &DriveLabelsGetCmd{}, []string{"labels/L1"}, ctx, flags); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	})
+	if getPath != "/v2/labels/L1" {
+		t.Fatalf("unexpected get path %q", getPath)
+	}
+	if !strings.Contains(textOut.String(), "title\tProject") {
+		t.Fatalf("unexpected get output: %q", textOut.String())
+	}
+	if !strings.Contains(fieldsOut, "c1=Active") || !strings.Contains(fieldsOut, "selection") {
+		t.Fatalf("unexpected get fields: %q", fieldsOut)
+	}
+}
+
+func TestDriveSearch_LabelFilters(t *testing.T) {
+	var gotQ string
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		gotQ = r.URL.Query().Get("q")
+		_ = json.NewEncoder(w).Encode(map[string]any{"files": []any{}})
+	}))
+
+	ctx, _ := driveTrashTestContext(t, true)
+	flags := &RootFlags{Account: "a@b.com"}
+	_ = captureStdout(t, func() {
+		args := []string{"--label", "L1", "--label-field", "L1.status=c1", "--label-field", "L1.prio>=int:2", "--label-field", "L1.code=007"}
+		if err := runKong(t, &DriveSearchCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	want := `trashed = false and 'labels/L1' in labels and labels/L1.status = 'c1' and labels/L1.prio >= 2 and labels/L1.code = '007'`
+	if gotQ != want {
+		t.Fatalf("unexpected query:\n got %q\nwant %q", gotQ, want)
+	}
+
+	if err := runKong(t, &DriveSearchCmd{}, nil, ctx, flags); err == nil || !strings.Contains(err.Error(), "missing query") {
+		t.Fatalf("expected missing query error, got %v", err)
+	}
+}
+
+func TestDriveLabelFieldClause_Invalid(t *testing.T) {
+	for _, raw := range []string{"status=c1", "L1.=x", "L1.status=", "=x", "L1.prio=int:high"} {
+		if _, err := driveLabelFieldClause(raw); err == nil {
+			t.Fatalf("expected error for %q", raw)
+		}
+	}
+}
+
+func TestWrapDriveLabelsError(t *testing.T) {
+	err := wrapDriveLabelsError(errors.New("googleapi: Error 403: Request had insufficient authentication scopes."))
+	if !strings.Contains(err.Error(), "--services drive,drivelabels") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other := errors.New("other")
+	if !errors.Is(wrapDriveLabelsError(other), other) {
+		t.Fatalf("expected passthrough error")
+	}
+}
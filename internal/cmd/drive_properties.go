@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveRestrictFields = "id, name, contentRestrictions, copyRequiresWriterPermission"
+
+type DrivePropertiesCmd struct {
+	List   DrivePropertiesListCmd   `cmd:"" name:"list" default:"withargs" help:"List custom properties on a file"`
+	Set    DrivePropertiesSetCmd    `cmd:"" name:"set" help:"Set custom properties (key=value)"`
+	Delete DrivePropertiesDeleteCmd `cmd:"" name:"delete" aliases:"rm,unset" help:"Delete custom properties by key"`
+}
+
+// DrivePropertyScopeFlags selects between public properties (visible to all
+// apps) and appProperties (private to this OAuth client).
+type DrivePropertyScopeFlags struct {
+	App bool `name:"app" help:"Use appProperties (private to this OAuth client) instead of properties"`
+}
+
+func (f DrivePropertyScopeFlags) field() string {
+	if f.App {
+		return "appProperties"
+	}
+	return "properties"
+}
+
+type DrivePropertiesListCmd struct {
+	FileID string                  `arg:"" name:"fileId" help:"File ID"`
+	Scope  DrivePropertyScopeFlags `embed:""`
+}
+
+func (c *DrivePropertiesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	f, err := svc.Files.Get(fileID).
+		SupportsAllDrives(true).
+		Fields("id, properties, appProperties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	props := f.Properties
+	if c.Scope.App {
+		props = f.AppProperties
+	}
+	if props == nil {
+		props = map[string]string{}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"fileId":        fileID,
+			c.Scope.field(): props,
+		})
+	}
+
+	if len(props) == 0 {
+		u.Err().Println("No properties")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "KEY\tVALUE")
+	for _, k := range sortedStringMapKeys(props) {
+		fmt.Fprintf(w, "%s\t%s\n", k, props[k])
+	}
+	return nil
+}
+
+type DrivePropertiesSetCmd struct {
+	FileID string                  `arg:"" name:"fileId" help:"File ID"`
+	Pairs  []string                `arg:"" name:"key=value" help:"Properties to set"`
+	Scope  DrivePropertyScopeFlags `embed:""`
+}
+
+func (c *DrivePropertiesSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	props := make(map[string]string, len(c.Pairs))
+	for _, raw := range c.Pairs {
+		k, v, ok := strings.Cut(raw, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return usagef("invalid property %q (expected key=value)", raw)
+		}
+		props[k] = v
+	}
+
+	meta := &drive.File{}
+	if c.Scope.App {
+		meta.AppProperties = props
+	} else {
+		meta.Properties = props
+	}
+	return updateDriveProperties(ctx, account, fileID, meta, c.Scope)
+}
+
+type DrivePropertiesDeleteCmd struct {
+	FileID string                  `arg:"" name:"fileId" help:"File ID"`
+	Keys   []string                `arg:"" name:"key" help:"Property keys to delete"`
+	Scope  DrivePropertyScopeFlags `embed:""`
+}
+
+func (c *DrivePropertiesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+
+	// Drive removes a property when its value is sent as JSON null.
+	mapField := "Properties"
+	if c.Scope.App {
+		mapField = "AppProperties"
+	}
+	meta := &drive.File{ForceSendFields: []string{mapField}}
+	for _, k := range c.Keys {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		meta.NullFields = append(meta.NullFields, mapField+"."+k)
+	}
+	if len(meta.NullFields) == 0 {
+		return usage("missing property key")
+	}
+	return updateDriveProperties(ctx, account, fileID, meta, c.Scope)
+}
+
+func updateDriveProperties(ctx context.Context, account, fileID string, meta *drive.File, scope DrivePropertyScopeFlags) error {
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	updated, err := svc.Files.Update(fileID, meta).
+		SupportsAllDrives(true).
+		Fields("id, name, properties, appProperties").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	props := updated.Properties
+	if scope.App {
+		props = updated.AppProperties
+	}
+	if props == nil {
+		props = map[string]string{}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"fileId":      updated.Id,
+			scope.field(): props,
+		})
+	}
+
+	u := ui.FromContext(ctx)
+	u.Out().Printf("id\t%s", updated.Id)
+	u.Out().Printf("name\t%s", updated.Name)
+	prefix := "property"
+	if scope.App {
+		prefix = "app_property"
+	}
+	writeDriveProperties(u, prefix, props)
+	return nil
+}
+
+func writeDriveProperties(u *ui.UI, prefix string, props map[string]string) {
+	for _, k := range sortedStringMapKeys(props) {
+		u.Out().Printf("%s.%s\t%s", prefix, k, props[k])
+	}
+}
+
+func sortedStringMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// buildDrivePropertyQuery turns key=value pairs into Drive
+// "properties has { ... }" clauses.
+func buildDrivePropertyQuery(field string, pairs []string) (string, error) {
+	clauses := make([]string, 0, len(pairs))
+	for _, raw := range pairs {
+		k, v, ok := strings.Cut(raw, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return "", usagef("invalid property filter %q (expected key=value)", raw)
+		}
+		clauses = append(clauses, fmt.Sprintf("%s has { key='%s' and value='%s' }", field, escapeDriveQueryString(k), escapeDriveQueryString(v)))
+	}
+	return strings.Join(clauses, " and "), nil
+}
+
+type DriveRestrictCmd struct {
+	FileID             string `arg:"" name:"fileId" help:"File ID"`
+	ReadOnly           *bool  `name:"read-only" help:"Lock the file content (--read-only=false to unlock)"`
+	Reason             string `name:"reason" help:"Reason shown to users for the read-only lock"`
+	CopyRequiresWriter *bool  `name:"copy-requires-writer" help:"Disable copy, print and download for readers/commenters (--copy-requires-writer=false to allow)"`
+}
+
+func (c *DriveRestrictCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	fileID := strings.TrimSpace(c.FileID)
+	if fileID == "" {
+		return usage("empty fileId")
+	}
+	reason := strings.TrimSpace(c.Reason)
+	if reason != "" && (c.ReadOnly == nil || !*c.ReadOnly) {
+		return usage("--reason requires --read-only")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var f *drive.File
+	if c.ReadOnly == nil && c.CopyRequiresWriter == nil {
+		f, err = svc.Files.Get(fileID).
+			SupportsAllDrives(true).
+			Fields(driveRestrictFields).
+			Context(ctx).
+			Do()
+	} else {
+		meta := &drive.File{}
+		if c.ReadOnly != nil {
+			restriction := &drive.ContentRestriction{ReadOnly: *c.ReadOnly, Reason: reason}
+			if !*c.ReadOnly {
+				restriction.ForceSendFields = []string{"ReadOnly"}
+			}
+			meta.ContentRestrictions = []*drive.ContentRestriction{restriction}
+		}
+		if c.CopyRequiresWriter != nil {
+			meta.CopyRequiresWriterPermission = *c.CopyRequiresWriter
+			meta.ForceSendFields = append(meta.ForceSendFields, "CopyRequiresWriterPermission")
+		}
+		f, err = svc.Files.Update(fileID, meta).
+			SupportsAllDrives(true).
+			Fields(driveRestrictFields).
+			Context(ctx).
+			Do()
+	}
+	if err != nil {
+		return err
+	}
+
+	restriction := driveReadOnlyRestriction(f)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"fileId":                       f.Id,
+			"readOnly":                     restriction != nil,
+			"contentRestrictions":          f.ContentRestrictions,
+			"copyRequiresWriterPermission": f.CopyRequiresWriterPermission,
+		})
+	}
+
+	u.Out().Printf("id\t%s", f.Id)
+	u.Out().Printf("name\t%s", f.Name)
+	u.Out().Printf("read_only\t%t", restriction != nil)
+	if restriction != nil && restriction.Reason != "" {
+		u.Out().Printf("read_only_reason\t%s", restriction.Reason)
+	}
+	u.Out().Printf("copy_requires_writer\t%t", f.CopyRequiresWriterPermission)
+	return nil
+}
+
+// driveReadOnlyRestriction returns the active read-only content restriction,
+// if any.
+func driveReadOnlyRestriction(f *drive.File) *drive.ContentRestriction {
+	if f == nil {
+		return nil
+	}
+	for _, r := range f.ContentRestrictions {
+		if r != nil && r.ReadOnly {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDriveProperties_SetAndDelete(t *testing.T) {
+	var bodies []string
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != http.MethodPatch {
+			http.NotFound(w, r)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":            "f1",
+			"name":          "Report",
+			"appProperties": map[string]any{"pipeline": "ingest", "run": "42"},
+		})
+	}))
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &DrivePropertiesSetCmd{}, []string{"f1", "pipeline=ingest", "run=42", "--app"}, ctx, flags); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := runKong(t, &DrivePropertiesDeleteCmd{}, []string{"f1", "stage"}, ctx, flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected two updates, got %d", len(bodies))
+	}
+	var set map[string]map[string]string
+	if err := json.Unmarshal([]byte(bodies[0]), &set); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	if set["appProperties"]["pipeline"] != "ingest" || set["appProperties"]["run"] != "42" {
+		t.Fatalf("unexpected set body: %s", bodies[0])
+	}
+	if !strings.Contains(bodies[1], `"properties":{"stage":null}`) {
+		t.Fatalf("expected null property on delete, got %s", bodies[1])
+	}
+	if !strings.Contains(textOut.String(), "app_property.pipeline\tingest") {
+		t.Fatalf("unexpected output: %q", textOut.String())
+	}
+
+	if err := runKong(t, &DrivePropertiesSetCmd{}, []string{"f1", "novalue"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "key=value") {
+		t.Fatalf("expected key=value error, got %v", err)
+	}
+}
+
+func TestDriveRestrict(t *testing.T) {
+	var body map[string]any
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		body = nil
+		if r.Method == http.MethodPatch {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":                           "f1",
+			"name":                         "Contract",
+			"contentRestrictions":          []map[string]any{{"readOnly": true, "reason": "Signed"}},
+			"copyRequiresWriterPermission": true,
+		})
+	}))
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &DriveRestrictCmd{}, []string{"f1", "--read-only", "--reason", "Signed", "--copy-requires-writer"}, ctx, flags); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	restrictions, _ := body["contentRestrictions"].([]any)
+	if len(restrictions) != 1 || restrictions[0].(map[string]any)["readOnly"] != true || body["copyRequiresWriterPermission"] != true {
+		t.Fatalf("unexpected lock body: %v", body)
+	}
+	if out := textOut.String(); !strings.Contains(out, "read_only\ttrue") || !strings.Contains(out, "read_only_reason\tSigned") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	if err := runKong(t, &DriveRestrictCmd{}, []string{"f1", "--read-only=false", "--copy-requires-writer=false"}, ctx, flags); err != nil {
+		t.Fatalf("unlock: %v", err)
+	}
+	restrictions, _ = body["contentRestrictions"].([]any)
+	if len(restrictions) != 1 || restrictions[0].(map[string]any)["readOnly"] != false {
+		t.Fatalf("expected explicit readOnly=false, got %v", body)
+	}
+	if v, ok := body["copyRequiresWriterPermission"]; !ok || v != false {
+		t.Fatalf("expected explicit copyRequiresWriterPermission=false, got %v", body)
+	}
+
+	if err := runKong(t, &DriveRestrictCmd{}, []string{"f1"}, ctx, flags); err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	if body != nil {
+		t.Fatalf("expected read-only get without flags, got body %v", body)
+	}
+
+	if err := runKong(t, &DriveRestrictCmd{}, []string{"f1", "--reason", "x"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--reason requires") {
+		t.Fatalf("expected reason validation error, got %v", err)
+	}
+}
+
+func TestBuildDrivePropertyQuery(t *testing.T) {
+	got, err := buildDrivePropertyQuery("properties", []string{"stage=it's done", "team=ops"})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	want := `properties has { key='stage' and value='it\'s done' } and properties has { key='team' and value='ops' }`
+	if got != want {
+		t.Fatalf("unexpected query:\n got %q\nwant %q", got, want)
+	}
+}
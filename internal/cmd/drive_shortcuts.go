@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type DriveShortcutCmd struct {
+	Create DriveShortcutCreateCmd `cmd:"" name:"create" default:"withargs" help:"Create a shortcut to a file or folder"`
+}
+
+type DriveShortcutCreateCmd struct {
+	TargetID string `arg:"" name:"targetId" help:"File or folder ID the shortcut points to"`
+	Name     string `name:"name" help:"Shortcut name (default: target name)"`
+	Parent   string `name:"parent" help:"Folder ID to place the shortcut in (default: root)"`
+}
+
+func (c *DriveShortcutCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	targetID := strings.TrimSpace(c.TargetID)
+	if targetID == "" {
+		return usage("empty targetId")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		target, getErr := svc.Files.Get(targetID).
+			SupportsAllDrives(true).
+			Fields("id, name").
+			Context(ctx).
+			Do()
+		if getErr != nil {
+			return getErr
+		}
+		name = target.Name
+	}
+
+	meta := &drive.File{
+		Name:            name,
+		MimeType:        driveMimeShortcut,
+		ShortcutDetails: &drive.FileShortcutDetails{TargetId: targetID},
+	}
+	if parent := strings.TrimSpace(c.Parent); parent != "" {
+		meta.Parents = []string{parent}
+	}
+
+	created, err := svc.Files.Create(meta).
+		SupportsAllDrives(true).
+		Fields("id, name, mimeType, parents, webViewLink, shortcutDetails").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{strFile: created})
+	}
+
+	u.Out().Printf("id\t%s", created.Id)
+	u.Out().Printf("name\t%s", created.Name)
+	u.Out().Printf("target\t%s", targetID)
+	if created.ShortcutDetails != nil && created.ShortcutDetails.TargetMimeType != "" {
+		u.Out().Printf("target_type\t%s", created.ShortcutDetails.TargetMimeType)
+	}
+	if created.WebViewLink != "" {
+		u.Out().Printf("link\t%s", created.WebViewLink)
+	}
+	return nil
+}
+
+// resolveDriveShortcut returns the target of a shortcut, fetched with the
+// given fields. Non-shortcuts are returned unchanged.
+func resolveDriveShortcut(ctx context.Context, svc *drive.Service, f *drive.File, fields string) (*drive.File, error) {
+	if f == nil || f.MimeType != driveMimeShortcut {
+		return f, nil
+	}
+	details := f.ShortcutDetails
+	if details == nil || details.TargetId == "" {
+		meta, err := svc.Files.Get(f.Id).
+			SupportsAllDrives(true).
+			Fields("id, shortcutDetails").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, err
+		}
+		details = meta.ShortcutDetails
+	}
+	if details == nil || details.TargetId == "" {
+		return nil, errors.New("shortcut has no target")
+	}
+
+	call := svc.Files.Get(details.TargetId).SupportsAllDrives(true).Context(ctx)
+	if details.TargetResourceKey != "" {
+		call.Header().Set("X-Goog-Drive-Resource-Keys", details.TargetId+"/"+details.TargetResourceKey)
+	}
+	return call.Fields(gapi.Field(fields)).Do()
+}
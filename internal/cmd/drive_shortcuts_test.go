@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+)
+
+func TestDriveShortcutCreate_DefaultsNameToTarget(t *testing.T) {
+	var created map[string]any
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && path == "/files/target1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "target1", "name": "Roadmap"})
+		case r.Method == http.MethodPost && path == "/files":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":              "sc1",
+				"name":            created["name"],
+				"mimeType":        driveMimeShortcut,
+				"shortcutDetails": map[string]any{"targetId": "target1", "targetMimeType": driveMimeGoogleDoc},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	if err := runKong(t, &DriveShortcutCreateCmd{}, []string{"target1", "--parent", "folder1"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	if created["name"] != "Roadmap" || created["mimeType"] != driveMimeShortcut {
+		t.Fatalf("unexpected create body: %v", created)
+	}
+	details, _ := created["shortcutDetails"].(map[string]any)
+	if details["targetId"] != "target1" {
+		t.Fatalf("missing target in body: %v", created)
+	}
+	if parents, _ := created["parents"].([]any); len(parents) != 1 || parents[0] != "folder1" {
+		t.Fatalf("unexpected parents: %v", created["parents"])
+	}
+	if out := textOut.String(); !strings.Contains(out, "id\tsc1") || !strings.Contains(out, "target\ttarget1") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestDriveGet_ResolveShortcut(t *testing.T) {
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimPrefix(r.URL.Path, "/drive/v3") {
+		case "/files/sc1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":              "sc1",
+				"name":            "Roadmap",
+				"mimeType":        driveMimeShortcut,
+				"shortcutDetails": map[string]any{"targetId": "target1"},
+			})
+		case "/files/target1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":         "target1",
+				"name":       "Roadmap",
+				"mimeType":   driveMimeGoogleDoc,
+				"properties": map[string]any{"stage": "draft"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx, _ := driveTrashTestContext(t, true)
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		if err := runKong(t, &DriveGetCmd{}, []string{"sc1", "--resolve-shortcut"}, ctx, flags); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	var parsed struct {
+		File     drive.File `json:"file"`
+		Shortcut drive.File `json:"shortcut"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.File.Id != "target1" || parsed.Shortcut.Id != "sc1" || parsed.File.Properties["stage"] != "draft" {
+		t.Fatalf("unexpected output: %#v", parsed)
+	}
+
+	plain := captureStdout(t, func() {
+		if err := runKong(t, &DriveGetCmd{}, []string{"sc1"}, ctx, flags); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if strings.Contains(plain, `"shortcut"`) || !strings.Contains(plain, `"sc1"`) {
+		t.Fatalf("expected unresolved shortcut without flag, got %s", plain)
+	}
+}
+
+func TestDownloadDriveFile_ResolvesShortcut(t *testing.T) {
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case path == "/files/target1" && r.URL.Query().Get("alt") == "media":
+			_, _ = io.WriteString(w, "payload")
+		case path == "/files/target1":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "target1", "name": "data.bin", "mimeType": "application/octet-stream"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	svc, err := newDriveService(context.Background(), "a@b.com")
+	if err != nil {
+		t.Fatalf("svc: %v", err)
+	}
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	meta := &drive.File{
+		Id:              "sc1",
+		Name:            "data.bin",
+		MimeType:        driveMimeShortcut,
+		ShortcutDetails: &drive.FileShortcutDetails{TargetId: "target1"},
+	}
+	outPath, n, err := downloadDriveFile(context.Background(), svc, meta, dest, "")
+	if err != nil {
+		t.Fatalf("downloadDriveFile: %v", err)
+	}
+	b, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(b) != "payload" || n != int64(len("payload")) {
+		t.Fatalf("expected target content, got %q (%d bytes)", string(b), n)
+	}
+}
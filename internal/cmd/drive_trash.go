@@ -0,0 +1,438 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveTrashFileFields = "id, name, mimeType, size, parents, owners(emailAddress), trashedTime, explicitlyTrashed, trashingUser(emailAddress)"
+
+type DriveTrashCmd struct {
+	List    DriveTrashListCmd    `cmd:"" name:"list" default:"withargs" help:"List trashed files"`
+	Restore DriveTrashRestoreCmd `cmd:"" name:"restore" aliases:"untrash" help:"Restore trashed files (by ID or by filter)"`
+	Empty   DriveTrashEmptyCmd   `cmd:"" name:"empty" help:"Permanently delete trashed files (all, or those matching filters)"`
+}
+
+// DriveTrashFilterFlags selects trashed files. Owner/parent/query are pushed
+// into the Drive query. Drive search cannot filter on trashedTime, so --to is
+// sent as a modifiedTime bound (a file is not modified after it is trashed) and
+// the exact trashed-time window is applied client-side.
+type DriveTrashFilterFlags struct {
+	Owner  string `name:"owner" help:"Only files owned by this email (or 'me')"`
+	Parent string `name:"parent" help:"Only files whose original parent is this folder ID"`
+	From   string `name:"from" help:"Only files trashed at or after this time (RFC3339, date, or relative: today, yesterday, monday)"`
+	To     string `name:"to" help:"Only files trashed before this time (RFC3339, date, or relative)"`
+	Query  string `name:"query" help:"Additional Drive query filter"`
+}
+
+func (f DriveTrashFilterFlags) isSet() bool {
+	return strings.TrimSpace(f.Owner) != "" ||
+		strings.TrimSpace(f.Parent) != "" ||
+		strings.TrimSpace(f.From) != "" ||
+		strings.TrimSpace(f.To) != "" ||
+		strings.TrimSpace(f.Query) != ""
+}
+
+type driveTrashFilter struct {
+	Query string
+	From  time.Time
+	To    time.Time
+}
+
+func (f DriveTrashFilterFlags) resolve(now time.Time) (driveTrashFilter, error) {
+	out := driveTrashFilter{Query: buildDriveTrashQuery(f.Owner, f.Parent, f.Query)}
+	if from := strings.TrimSpace(f.From); from != "" {
+		t, err := parseTimeExpr(from, now, now.Location())
+		if err != nil {
+			return driveTrashFilter{}, usage(fmt.Sprintf("invalid --from: %v", err))
+		}
+		out.From = t
+	}
+	if to := strings.TrimSpace(f.To); to != "" {
+		t, err := parseTimeExpr(to, now, now.Location())
+		if err != nil {
+			return driveTrashFilter{}, usage(fmt.Sprintf("invalid --to: %v", err))
+		}
+		out.To = t
+	}
+	if !out.From.IsZero() && !out.To.IsZero() && !out.To.After(out.From) {
+		return driveTrashFilter{}, usage("--to must be after --from")
+	}
+	if !out.To.IsZero() {
+		out.Query += fmt.Sprintf(" and modifiedTime < '%s'", out.To.UTC().Format(time.RFC3339))
+	}
+	return out, nil
+}
+
+func (f driveTrashFilter) hasWindow() bool {
+	return !f.From.IsZero() || !f.To.IsZero()
+}
+
+func (f driveTrashFilter) Match(file *drive.File) bool {
+	if file == nil {
+		return false
+	}
+	if !f.hasWindow() {
+		return true
+	}
+	trashedAt, err := time.Parse(time.RFC3339, file.TrashedTime)
+	if err != nil {
+		return false
+	}
+	if !f.From.IsZero() && trashedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !trashedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+func buildDriveTrashQuery(owner, parent, userQuery string) string {
+	parts := []string{"trashed = true"}
+	if owner = strings.TrimSpace(owner); owner != "" {
+		parts = append(parts, fmt.Sprintf("'%s' in owners", escapeDriveQueryString(owner)))
+	}
+	if parent = strings.TrimSpace(parent); parent != "" {
+		parts = append(parts, fmt.Sprintf("'%s' in parents", escapeDriveQueryString(parent)))
+	}
+	if q := strings.TrimSpace(userQuery); q != "" {
+		parts = append(parts, "("+q+")")
+	}
+	return strings.Join(parts, " and ")
+}
+
+type DriveTrashListCmd struct {
+	Filter DriveTrashFilterFlags `embed:""`
+	Max    int64                 `name:"max" aliases:"limit" help:"Max results" default:"50"`
+	Page   string                `name:"page" help:"Page token"`
+}
+
+func (c *DriveTrashListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	filter, err := c.Filter.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	// The window is checked after each page comes back, so keep paging (never
+	// asking for more than the remaining --max) until enough files match.
+	files := make([]*drive.File, 0)
+	page := c.Page
+	for {
+		size := c.Max - int64(len(files))
+		resp, err := svc.Files.List().
+			Q(filter.Query).
+			PageSize(size).
+			PageToken(page).
+			OrderBy("modifiedTime desc").
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields("nextPageToken, files(" + driveTrashFileFields + ")").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+		for _, f := range resp.Files {
+			if filter.Match(f) {
+				files = append(files, f)
+			}
+		}
+		page = resp.NextPageToken
+		if page == "" || !filter.hasWindow() || c.Max <= 0 || int64(len(files)) >= c.Max {
+			break
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"files":         files,
+			"nextPageToken": page,
+		})
+	}
+
+	if len(files) == 0 {
+		u.Err().Println("No trashed files")
+		printNextPageHint(u, page)
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tSIZE\tTRASHED\tOWNER")
+	for _, f := range files {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.Id,
+			f.Name,
+			driveType(f.MimeType),
+			formatDriveSize(f.Size),
+			formatDateTime(f.TrashedTime),
+			driveFileOwner(f),
+		)
+	}
+	printNextPageHint(u, page)
+	return nil
+}
+
+type DriveTrashRestoreCmd struct {
+	FileIDs []string              `arg:"" optional:"" name:"fileId" help:"File IDs to restore (omit to restore by filter)"`
+	Filter  DriveTrashFilterFlags `embed:""`
+	All     bool                  `name:"all" help:"Restore everything in the trash (when no filters are given)"`
+	DryRun  bool                  `name:"dry-run" help:"List the files that would be restored without restoring them"`
+}
+
+func (c *DriveTrashRestoreCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	ids := trimNonEmpty(c.FileIDs)
+	if len(ids) > 0 && (c.All || c.Filter.isSet()) {
+		return usage("file IDs cannot be combined with --all or filters")
+	}
+	if len(ids) == 0 && !c.All && !c.Filter.isSet() {
+		return usage("specify file IDs, filters (--from/--to/--owner/--parent/--query), or --all")
+	}
+	filter, err := c.Filter.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var targets []*drive.File
+	if len(ids) > 0 {
+		targets = make([]*drive.File, 0, len(ids))
+		for _, id := range ids {
+			targets = append(targets, &drive.File{Id: id})
+		}
+	} else {
+		// Restoring a folder restores everything trashed with it, so only
+		// explicitly trashed items are touched.
+		targets, err = collectTrashedFiles(ctx, svc, filter, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.DryRun {
+		return writeDriveTrashResult(ctx, u, "restore", targets, nil, true)
+	}
+
+	done := make([]*drive.File, 0, len(targets))
+	failed := make([]driveTrashFailure, 0)
+	for _, f := range targets {
+		updated, updateErr := svc.Files.Update(f.Id, &drive.File{Trashed: false, ForceSendFields: []string{"Trashed"}}).
+			SupportsAllDrives(true).
+			Fields("id, name, mimeType, trashed").
+			Context(ctx).
+			Do()
+		if updateErr != nil {
+			failed = append(failed, driveTrashFailure{ID: f.Id, Name: f.Name, Error: updateErr.Error()})
+			continue
+		}
+		done = append(done, updated)
+	}
+	if err := writeDriveTrashResult(ctx, u, "restore", done, failed, false); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restore %d of %d files", len(failed), len(targets))
+	}
+	return nil
+}
+
+type DriveTrashEmptyCmd struct {
+	Filter DriveTrashFilterFlags `embed:""`
+	Drive  string                `name:"drive" help:"Empty the trash of this shared drive (requires organizer role)"`
+	DryRun bool                  `name:"dry-run" help:"List the files that would be deleted without deleting them"`
+}
+
+func (c *DriveTrashEmptyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	driveID := strings.TrimSpace(c.Drive)
+	if driveID != "" && c.Filter.isSet() {
+		return usage("--drive cannot be combined with filters")
+	}
+	filter, err := c.Filter.resolve(time.Now())
+	if err != nil {
+		return err
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if !c.Filter.isSet() {
+		if c.DryRun {
+			return usage("--dry-run requires filters; use `drive trash list` to review the whole trash")
+		}
+		action := "permanently delete everything in the drive trash"
+		if driveID != "" {
+			action = fmt.Sprintf("permanently delete everything in the trash of shared drive %s", driveID)
+		}
+		if confirmErr := confirmDestructive(ctx, flags, action); confirmErr != nil {
+			return confirmErr
+		}
+		call := svc.Files.EmptyTrash().Context(ctx)
+		if driveID != "" {
+			call = call.DriveId(driveID)
+		}
+		if err := call.Do(); err != nil {
+			return err
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{"emptied": true, "driveId": driveID})
+		}
+		u.Out().Printf("emptied\ttrue")
+		return nil
+	}
+
+	targets, err := collectTrashedFiles(ctx, svc, filter, true)
+	if err != nil {
+		return err
+	}
+	if c.DryRun {
+		return writeDriveTrashResult(ctx, u, "delete", targets, nil, true)
+	}
+	if len(targets) == 0 {
+		return writeDriveTrashResult(ctx, u, "delete", targets, nil, false)
+	}
+	if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("permanently delete %d trashed files", len(targets))); confirmErr != nil {
+		return confirmErr
+	}
+
+	done := make([]*drive.File, 0, len(targets))
+	failed := make([]driveTrashFailure, 0)
+	for _, f := range targets {
+		if delErr := svc.Files.Delete(f.Id).SupportsAllDrives(true).Context(ctx).Do(); delErr != nil {
+			failed = append(failed, driveTrashFailure{ID: f.Id, Name: f.Name, Error: delErr.Error()})
+			continue
+		}
+		done = append(done, f)
+	}
+	if err := writeDriveTrashResult(ctx, u, "delete", done, failed, false); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d of %d files", len(failed), len(targets))
+	}
+	return nil
+}
+
+type driveTrashFailure struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Error string `json:"error"`
+}
+
+func collectTrashedFiles(ctx context.Context, svc *drive.Service, filter driveTrashFilter, explicitOnly bool) ([]*drive.File, error) {
+	out := make([]*drive.File, 0)
+	page := ""
+	for {
+		resp, err := svc.Files.List().
+			Q(filter.Query).
+			PageSize(1000).
+			PageToken(page).
+			SupportsAllDrives(true).
+			IncludeItemsFromAllDrives(true).
+			Fields("nextPageToken, files(" + driveTrashFileFields + ")").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range resp.Files {
+			if explicitOnly && !f.ExplicitlyTrashed {
+				continue
+			}
+			if filter.Match(f) {
+				out = append(out, f)
+			}
+		}
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		page = resp.NextPageToken
+	}
+}
+
+func writeDriveTrashResult(ctx context.Context, u *ui.UI, action string, files []*drive.File, failed []driveTrashFailure, dryRun bool) error {
+	key := "restored"
+	if action == "delete" {
+		key = "deleted"
+	}
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"count":  len(files),
+			"failed": failed,
+		}
+		if dryRun {
+			payload["dryRun"] = true
+			payload["files"] = files
+		} else {
+			payload[key] = files
+		}
+		return outfmt.WriteJSON(os.Stdout, payload)
+	}
+
+	if dryRun {
+		u.Out().Printf("dry-run\ttrue")
+	}
+	u.Out().Printf("%s\t%d", key, len(files))
+	for _, f := range files {
+		name := f.Name
+		if name == "" {
+			name = "-"
+		}
+		u.Out().Printf("%s\t%s", f.Id, name)
+	}
+	for _, f := range failed {
+		u.Err().Printf("failed\t%s\t%s", f.ID, f.Error)
+	}
+	return nil
+}
+
+func driveFileOwner(f *drive.File) string {
+	if f == nil || len(f.Owners) == 0 || f.Owners[0] == nil || f.Owners[0].EmailAddress == "" {
+		return "-"
+	}
+	return f.Owners[0].EmailAddress
+}
+
+func trimNonEmpty(items []string) []string {
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if v := strings.TrimSpace(item); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func driveTrashTestFiles() []map[string]any {
+	return []map[string]any{
+		{"id": "f1", "name": "Folder", "mimeType": "application/vnd.google-apps.folder", "explicitlyTrashed": true, "trashedTime": "2026-10-17T10:15:00Z", "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+		{"id": "f2", "name": "child.txt", "explicitlyTrashed": false, "trashedTime": "2026-10-17T10:15:00Z"},
+		{"id": "f3", "name": "old.txt", "explicitlyTrashed": true, "trashedTime": "2026-09-01T08:00:00Z"},
+	}
+}
+
+type driveTrashRecorder struct {
+	mu       sync.Mutex
+	queries  []string
+	restored []string
+	deleted  []string
+	emptied  int
+}
+
+func (rec *driveTrashRecorder) handler(t *testing.T) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && path == "/files":
+			rec.queries = append(rec.queries, r.URL.Query().Get("q"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": driveTrashTestFiles()})
+		case r.Method == http.MethodPatch && strings.HasPrefix(path, "/files/"):
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if trashed, ok := body["trashed"].(bool); !ok || trashed {
+				t.Errorf("expected trashed=false in restore body, got %v", body)
+			}
+			id := strings.TrimPrefix(path, "/files/")
+			rec.restored = append(rec.restored, id)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "name": "restored-" + id, "trashed": false})
+		case r.Method == http.MethodDelete && path == "/files/trash":
+			rec.emptied++
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "/files/"):
+			rec.deleted = append(rec.deleted, strings.TrimPrefix(path, "/files/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func driveTrashTestContext(t *testing.T, json bool) (context.Context, *bytes.Buffer) {
+	t.Helper()
+	var out bytes.Buffer
+	u, err := ui.New(ui.Options{Stdout: &out, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	return outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: json}), &out
+}
+
+func TestDriveTrashList_FiltersAndWindow(t *testing.T) {
+	rec := &driveTrashRecorder{}
+	newDriveChangesTestService(t, rec.handler(t))
+
+	ctx, _ := driveTrashTestContext(t, true)
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		args := []string{"--owner", "me", "--parent", "p'1", "--from", "2026-10-17T00:00:00Z", "--to", "2026-10-18T00:00:00Z"}
+		if err := runKong(t, &DriveTrashListCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+
+	if len(rec.queries) != 1 {
+		t.Fatalf("expected one list call, got %d", len(rec.queries))
+	}
+	wantQ := `trashed = true and 'me' in owners and 'p\'1' in parents and modifiedTime < '2026-10-18T00:00:00Z'`
+	if rec.queries[0] != wantQ {
+		t.Fatalf("unexpected query:\n got %q\nwant %q", rec.queries[0], wantQ)
+	}
+	var parsed struct {
+		Files []struct {
+			ID string `json:"id"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Files) != 2 || parsed.Files[0].ID != "f1" || parsed.Files[1].ID != "f2" {
+		t.Fatalf("expected files trashed in window, got %#v", parsed.Files)
+	}
+}
+
+func TestDriveTrashList_WindowPagesUntilMax(t *testing.T) {
+	var pages []string
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		pages = append(pages, q.Get("pageToken")+"/"+q.Get("pageSize"))
+		files := driveTrashTestFiles()
+		switch q.Get("pageToken") {
+		case "":
+			// Only the old file, outside the window.
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files[2:], "nextPageToken": "p2"})
+		case "p2":
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files[:1], "nextPageToken": "p3"})
+		default:
+			t.Errorf("unexpected page %q", q.Get("pageToken"))
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx, _ := driveTrashTestContext(t, true)
+	out := captureStdout(t, func() {
+		args := []string{"--from", "2026-10-17T00:00:00Z", "--max", "1"}
+		if err := runKong(t, &DriveTrashListCmd{}, args, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if strings.Join(pages, ",") != "/1,p2/1" {
+		t.Fatalf("unexpected pages: %v", pages)
+	}
+	var parsed struct {
+		Files []struct {
+			ID string `json:"id"`
+		} `json:"files"`
+		NextPageToken string `json:"nextPageToken"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Files) != 1 || parsed.Files[0].ID != "f1" || parsed.NextPageToken != "p3" {
+		t.Fatalf("unexpected result: %+v", parsed)
+	}
+}
+
+func TestDriveTrashRestore_ByWindowSkipsImplicitChildren(t *testing.T) {
+	rec := &driveTrashRecorder{}
+	newDriveChangesTestService(t, rec.handler(t))
+
+	ctx, _ := driveTrashTestContext(t, true)
+	flags := &RootFlags{Account: "a@b.com"}
+	out := captureStdout(t, func() {
+		args := []string{"--from", "2026-10-17T10:00:00Z", "--to", "2026-10-17T11:00:00Z"}
+		if err := runKong(t, &DriveTrashRestoreCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if strings.Join(rec.restored, ",") != "f1" {
+		t.Fatalf("expected only explicitly trashed f1 restored, got %v", rec.restored)
+	}
+	if !strings.Contains(out, `"restored"`) || !strings.Contains(out, `"count": 1`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestDriveTrashRestore_ByIDAndDryRun(t *testing.T) {
+	rec := &driveTrashRecorder{}
+	newDriveChangesTestService(t, rec.handler(t))
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashRestoreCmd{}, []string{"x1", "x2"}, ctx, flags); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if strings.Join(rec.restored, ",") != "x1,x2" {
+		t.Fatalf("unexpected restored ids: %v", rec.restored)
+	}
+
+	textOut.Reset()
+	if err := runKong(t, &DriveTrashRestoreCmd{}, []string{"--all", "--dry-run"}, ctx, flags); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	out := textOut.String()
+	if len(rec.restored) != 2 {
+		t.Fatalf("dry-run should not restore, got %v", rec.restored)
+	}
+	if !strings.Contains(out, "dry-run\ttrue") || !strings.Contains(out, "restored\t2") {
+		t.Fatalf("unexpected dry-run output: %q", out)
+	}
+}
+
+func TestDriveTrashRestore_Validation(t *testing.T) {
+	ctx, _ := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+
+	if err := runKong(t, &DriveTrashRestoreCmd{}, nil, ctx, flags); err == nil || !strings.Contains(err.Error(), "--all") {
+		t.Fatalf("expected selection error, got %v", err)
+	}
+	if err := runKong(t, &DriveTrashRestoreCmd{}, []string{"x1", "--all"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("expected combination error, got %v", err)
+	}
+	if err := runKong(t, &DriveTrashRestoreCmd{}, []string{"--from", "2026-10-18", "--to", "2026-10-17"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--to must be after --from") {
+		t.Fatalf("expected window error, got %v", err)
+	}
+}
+
+func TestDriveTrashEmpty(t *testing.T) {
+	rec := &driveTrashRecorder{}
+	newDriveChangesTestService(t, rec.handler(t))
+
+	ctx, _ := driveTrashTestContext(t, true)
+
+	if err := runKong(t, &DriveTrashEmptyCmd{}, nil, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "refusing") {
+		t.Fatalf("expected confirmation refusal, got %v", err)
+	}
+	if rec.emptied != 0 {
+		t.Fatalf("trash emptied without confirmation")
+	}
+	var exitErr *ExitError
+	if err := runKong(t, &DriveTrashEmptyCmd{}, []string{"--dry-run"}, ctx, &RootFlags{Account: "a@b.com"}); !errors.As(err, &exitErr) || exitErr.Code != 2 || !strings.Contains(err.Error(), "--dry-run requires filters") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+
+	force := &RootFlags{Account: "a@b.com", Force: true}
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashEmptyCmd{}, nil, ctx, force); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if rec.emptied != 1 {
+		t.Fatalf("expected emptyTrash call, got %d", rec.emptied)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &DriveTrashEmptyCmd{}, []string{"--to", "2026-10-01T00:00:00Z"}, ctx, force); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	})
+	if strings.Join(rec.deleted, ",") != "f3" {
+		t.Fatalf("expected only f3 deleted, got %v", rec.deleted)
+	}
+}
+
+func TestDriveDelete_TrashesByDefault(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["trashed"] != true {
+				t.Errorf("expected trashed=true, got %v", body)
+			}
+			calls = append(calls, "trash")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "id1", "trashed": true})
+		case http.MethodDelete:
+			calls = append(calls, "delete")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+	if err := runKong(t, &DriveDeleteCmd{}, []string{"id1"}, ctx, flags); err != nil {
+		t.Fatalf("trash: %v", err)
+	}
+	if err := runKong(t, &DriveDeleteCmd{}, []string{"id1", "--permanent"}, ctx, flags); err != nil {
+		t.Fatalf("permanent: %v", err)
+	}
+	out := textOut.String()
+	if strings.Join(calls, ",") != "trash,delete" {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+	if !strings.Contains(out, "permanent\tfalse") || !strings.Contains(out, "permanent\ttrue") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestDriveTrashFilter_Match(t *testing.T) {
+	from := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	f := driveTrashFilter{From: from, To: from.Add(24 * time.Hour)}
+	if f.Match(nil) {
+		t.Fatalf("nil should not match")
+	}
+	if f.Match(driveTrashFileAt("")) {
+		t.Fatalf("missing trashedTime should not match a window")
+	}
+	if !f.Match(driveTrashFileAt("2026-10-17T00:00:00Z")) {
+		t.Fatalf("start bound should be inclusive")
+	}
+	if f.Match(driveTrashFileAt("2026-10-18T00:00:00Z")) {
+		t.Fatalf("end bound should be exclusive")
+	}
+	if !(driveTrashFilter{}).Match(driveTrashFileAt("")) {
+		t.Fatalf("no window should match everything")
+	}
+}
+
+func driveTrashFileAt(trashedTime string) *drive.File {
+	return &drive.File{Id: "x", TrashedTime: trashedTime}
+}
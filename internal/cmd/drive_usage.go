@@ -0,0 +1,497 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const driveUsageFileFields = "id, name, mimeType, size, md5Checksum, createdTime, modifiedTime, parents, owners(emailAddress), webViewLink"
+
+type DriveDuCmd struct {
+	FolderID string `arg:"" optional:"" name:"folderId" help:"Folder ID to analyze (default: root)"`
+	By       string `name:"by" help:"Breakdown to print: folder|owner|mime" default:"folder" enum:"folder,owner,mime"`
+	Depth    int    `name:"depth" help:"Folder depth to include in the folder breakdown" default:"1"`
+	Top      int    `name:"top" help:"Max rows per breakdown (0 = all)" default:"20"`
+	NoQuota  bool   `name:"no-quota" help:"Skip the account storage quota lookup"`
+}
+
+func (c *DriveDuCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	rootID := strings.TrimSpace(c.FolderID)
+	if rootID == "" {
+		rootID = "root"
+	}
+	if c.Depth < 0 {
+		return usage("--depth must be >= 0")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	usageReport, err := driveFolderUsage(ctx, svc, rootID)
+	if err != nil {
+		return err
+	}
+
+	var quota *drive.AboutStorageQuota
+	if !c.NoQuota {
+		quota, err = driveStorageQuota(ctx, svc)
+		if err != nil {
+			return err
+		}
+	}
+
+	folders := make([]driveUsageFolder, 0, len(usageReport.Folders))
+	for _, f := range usageReport.Folders {
+		if f.Depth <= c.Depth {
+			folders = append(folders, f)
+		}
+	}
+	sort.SliceStable(folders, func(i, j int) bool { return folders[i].Bytes > folders[j].Bytes })
+	owners := sortedDriveUsageBuckets(usageReport.Owners)
+	mimeTypes := sortedDriveUsageBuckets(usageReport.MimeTypes)
+
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"root":        usageReport.Root,
+			"totalBytes":  usageReport.TotalBytes,
+			"fileCount":   usageReport.FileCount,
+			"folderCount": len(usageReport.Folders) - 1,
+			"folders":     limitSlice(folders, c.Top),
+			"owners":      limitSlice(owners, c.Top),
+			"mimeTypes":   limitSlice(mimeTypes, c.Top),
+		}
+		if quota != nil {
+			payload["quota"] = quota
+		}
+		return outfmt.WriteJSON(os.Stdout, payload)
+	}
+
+	u.Out().Printf("root\t%s", usageReport.Root.Path)
+	u.Out().Printf("total\t%s", formatDriveSize(usageReport.TotalBytes))
+	u.Out().Printf("files\t%d", usageReport.FileCount)
+	u.Out().Printf("folders\t%d", len(usageReport.Folders)-1)
+	if quota != nil {
+		writeDriveQuota(u, quota)
+	}
+	u.Out().Println("")
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	switch c.By {
+	case "owner":
+		fmt.Fprintln(w, "OWNER\tSIZE\tFILES")
+		for _, b := range limitSlice(owners, c.Top) {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", b.Key, formatDriveSize(b.Bytes), b.Files)
+		}
+	case "mime":
+		fmt.Fprintln(w, "MIME_TYPE\tSIZE\tFILES")
+		for _, b := range limitSlice(mimeTypes, c.Top) {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", b.Key, formatDriveSize(b.Bytes), b.Files)
+		}
+	default:
+		fmt.Fprintln(w, "SIZE\tFILES\tID\tPATH")
+		for _, f := range limitSlice(folders, c.Top) {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", formatDriveSize(f.Bytes), f.Files, f.ID, f.Path)
+		}
+	}
+	return nil
+}
+
+type DriveDupesCmd struct {
+	FolderID string `arg:"" optional:"" name:"folderId" help:"Folder ID to scan recursively (default: all files you own)"`
+	MinSize  int64  `name:"min-size" help:"Ignore files smaller than this many bytes" default:"1"`
+	Keep     string `name:"keep" help:"Which copy to keep when trashing: oldest|newest" default:"oldest" enum:"oldest,newest"`
+	Trash    bool   `name:"trash" help:"Move the extra copies to trash"`
+	DryRun   bool   `name:"dry-run" help:"With --trash, show what would be trashed without changing anything"`
+}
+
+func (c *DriveDupesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if c.DryRun && !c.Trash {
+		return usage("--dry-run requires --trash")
+	}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var files []*drive.File
+	if folderID := strings.TrimSpace(c.FolderID); folderID != "" {
+		err = walkDriveTree(ctx, svc, folderID, func(f *drive.File, _ driveUsageFolder) {
+			files = append(files, f)
+		})
+	} else {
+		files, err = listDriveOwnedFiles(ctx, svc)
+	}
+	if err != nil {
+		return err
+	}
+
+	groups := groupDriveDuplicates(files, c.MinSize, c.Keep == "newest")
+	var wasted int64
+	extras := make([]*drive.File, 0)
+	for _, g := range groups {
+		wasted += g.WastedBytes
+		extras = append(extras, g.Extra...)
+	}
+
+	trashed := make([]string, 0)
+	failed := make([]driveTrashFailure, 0)
+	if c.Trash && !c.DryRun && len(extras) > 0 {
+		if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("move %d duplicate files to trash", len(extras))); confirmErr != nil {
+			return confirmErr
+		}
+		for _, f := range extras {
+			if _, trashErr := svc.Files.Update(f.Id, &drive.File{Trashed: true}).
+				SupportsAllDrives(true).
+				Fields("id").
+				Context(ctx).
+				Do(); trashErr != nil {
+				failed = append(failed, driveTrashFailure{ID: f.Id, Name: f.Name, Error: trashErr.Error()})
+				continue
+			}
+			trashed = append(trashed, f.Id)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{
+			"groups":      groups,
+			"groupCount":  len(groups),
+			"wastedBytes": wasted,
+		}
+		if c.Trash {
+			payload["dryRun"] = c.DryRun
+			payload["trashed"] = trashed
+			payload["failed"] = failed
+		}
+		if err := outfmt.WriteJSON(os.Stdout, payload); err != nil {
+			return err
+		}
+	} else {
+		if len(groups) == 0 {
+			u.Err().Println("No duplicates")
+			return nil
+		}
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "GROUP\tACTION\tID\tNAME\tSIZE\tCREATED")
+		for i, g := range groups {
+			action := "keep"
+			for _, f := range append([]*drive.File{g.Keep}, g.Extra...) {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, action, f.Id, f.Name, formatDriveSize(f.Size), formatDateTime(f.CreatedTime))
+				action = "extra"
+			}
+		}
+		flush()
+		u.Err().Printf("%d duplicate groups, %s reclaimable", len(groups), formatDriveSize(wasted))
+		if c.DryRun {
+			u.Err().Printf("dry-run: would trash %d files", len(extras))
+		} else if c.Trash {
+			u.Err().Printf("trashed %d files", len(trashed))
+		}
+		for _, f := range failed {
+			u.Err().Printf("failed\t%s\t%s", f.ID, f.Error)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to trash %d of %d duplicates", len(failed), len(extras))
+	}
+	return nil
+}
+
+type driveUsageFolder struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Depth  int    `json:"depth"`
+	Bytes  int64  `json:"bytes"`
+	Files  int64  `json:"files"`
+	parent int
+}
+
+type driveUsageBucket struct {
+	Key   string `json:"key"`
+	Bytes int64  `json:"bytes"`
+	Files int64  `json:"files"`
+}
+
+type driveUsageReport struct {
+	Root       driveUsageFolder
+	TotalBytes int64
+	FileCount  int64
+	Folders    []driveUsageFolder
+	Owners     map[string]*driveUsageBucket
+	MimeTypes  map[string]*driveUsageBucket
+}
+
+// walkDriveTree visits every non-folder file below rootID breadth-first.
+// Shortcuts are not followed and each folder is visited once, so multi-parent
+// folders cannot loop.
+func walkDriveTree(ctx context.Context, svc *drive.Service, rootID string, visit func(*drive.File, driveUsageFolder)) error {
+	_, err := walkDriveFolders(ctx, svc, rootID, visit)
+	return err
+}
+
+func walkDriveFolders(ctx context.Context, svc *drive.Service, rootID string, visit func(*drive.File, driveUsageFolder)) ([]driveUsageFolder, error) {
+	root, err := svc.Files.Get(rootID).
+		SupportsAllDrives(true).
+		Fields("id, name").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	rootName := root.Name
+	if rootName == "" {
+		rootName = rootID
+	}
+	folders := []driveUsageFolder{{ID: root.Id, Name: rootName, Path: rootName, parent: -1}}
+	seen := map[string]struct{}{root.Id: {}}
+
+	for i := 0; i < len(folders); i++ {
+		current := folders[i]
+		page := ""
+		for {
+			resp, err := svc.Files.List().
+				Q(fmt.Sprintf("'%s' in parents and trashed = false", escapeDriveQueryString(current.ID))).
+				PageSize(1000).
+				PageToken(page).
+				SupportsAllDrives(true).
+				IncludeItemsFromAllDrives(true).
+				Fields("nextPageToken, files(" + driveUsageFileFields + ")").
+				Context(ctx).
+				Do()
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range resp.Files {
+				if f == nil {
+					continue
+				}
+				if f.MimeType == driveMimeFolder {
+					if _, ok := seen[f.Id]; ok {
+						continue
+					}
+					seen[f.Id] = struct{}{}
+					folders = append(folders, driveUsageFolder{
+						ID:     f.Id,
+						Name:   f.Name,
+						Path:   current.Path + "/" + f.Name,
+						Depth:  current.Depth + 1,
+						parent: i,
+					})
+					continue
+				}
+				if f.MimeType == driveMimeShortcut {
+					continue
+				}
+				visit(f, current)
+			}
+			if resp.NextPageToken == "" {
+				break
+			}
+			page = resp.NextPageToken
+		}
+	}
+	return folders, nil
+}
+
+func driveFolderUsage(ctx context.Context, svc *drive.Service, rootID string) (driveUsageReport, error) {
+	report := driveUsageReport{
+		Owners:    map[string]*driveUsageBucket{},
+		MimeTypes: map[string]*driveUsageBucket{},
+	}
+	direct := map[string]*driveUsageBucket{}
+	// A file with several parents is listed under each of them; count it
+	// once, under the first folder it was found in.
+	seen := map[string]struct{}{}
+	folders, err := walkDriveFolders(ctx, svc, rootID, func(f *drive.File, parent driveUsageFolder) {
+		if _, ok := seen[f.Id]; ok {
+			return
+		}
+		seen[f.Id] = struct{}{}
+		report.TotalBytes += f.Size
+		report.FileCount++
+		addDriveUsage(direct, parent.ID, f.Size)
+		addDriveUsage(report.Owners, driveFileOwner(f), f.Size)
+		addDriveUsage(report.MimeTypes, f.MimeType, f.Size)
+	})
+	if err != nil {
+		return driveUsageReport{}, err
+	}
+
+	for i := range folders {
+		if b, ok := direct[folders[i].ID]; ok {
+			folders[i].Bytes = b.Bytes
+			folders[i].Files = b.Files
+		}
+	}
+	// Children are always appended after their parent, so a reverse pass
+	// rolls totals up the tree.
+	for i := len(folders) - 1; i > 0; i-- {
+		p := folders[i].parent
+		folders[p].Bytes += folders[i].Bytes
+		folders[p].Files += folders[i].Files
+	}
+	report.Folders = folders
+	report.Root = folders[0]
+	return report, nil
+}
+
+func addDriveUsage(buckets map[string]*driveUsageBucket, key string, size int64) {
+	if key == "" {
+		key = "-"
+	}
+	b, ok := buckets[key]
+	if !ok {
+		b = &driveUsageBucket{Key: key}
+		buckets[key] = b
+	}
+	b.Bytes += size
+	b.Files++
+}
+
+func sortedDriveUsageBuckets(buckets map[string]*driveUsageBucket) []driveUsageBucket {
+	out := make([]driveUsageBucket, 0, len(buckets))
+	for _, b := range buckets {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes != out[j].Bytes {
+			return out[i].Bytes > out[j].Bytes
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func limitSlice[T any](items []T, n int) []T {
+	if n <= 0 || len(items) <= n {
+		return items
+	}
+	return items[:n]
+}
+
+func driveStorageQuota(ctx context.Context, svc *drive.Service) (*drive.AboutStorageQuota, error) {
+	about, err := svc.About.Get().Fields("storageQuota").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return about.StorageQuota, nil
+}
+
+func writeDriveQuota(u *ui.UI, q *drive.AboutStorageQuota) {
+	if q == nil {
+		return
+	}
+	if q.Limit > 0 {
+		u.Out().Printf("quota_limit\t%s", formatDriveSize(q.Limit))
+	} else {
+		u.Out().Printf("quota_limit\tunlimited")
+	}
+	u.Out().Printf("quota_usage\t%s", formatDriveSize(q.Usage))
+	u.Out().Printf("quota_usage_in_drive\t%s", formatDriveSize(q.UsageInDrive))
+	u.Out().Printf("quota_usage_in_trash\t%s", formatDriveSize(q.UsageInDriveTrash))
+	if q.Limit > 0 {
+		u.Out().Printf("quota_used_pct\t%.1f%%", float64(q.Usage)*100/float64(q.Limit))
+	}
+}
+
+type driveDuplicateGroup struct {
+	MD5Checksum string        `json:"md5Checksum"`
+	Size        int64         `json:"size"`
+	WastedBytes int64         `json:"wastedBytes"`
+	Keep        *drive.File   `json:"keep"`
+	Extra       []*drive.File `json:"extra"`
+}
+
+func listDriveOwnedFiles(ctx context.Context, svc *drive.Service) ([]*drive.File, error) {
+	out := make([]*drive.File, 0)
+	page := ""
+	for {
+		resp, err := svc.Files.List().
+			Q(fmt.Sprintf("'me' in owners and trashed = false and mimeType != '%s'", driveMimeFolder)).
+			PageSize(1000).
+			PageToken(page).
+			Fields("nextPageToken, files(" + driveUsageFileFields + ")").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Files...)
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		page = resp.NextPageToken
+	}
+}
+
+// groupDriveDuplicates groups files by md5Checksum+size. Google-native files
+// have no checksum and are never reported. Within a group the oldest file is
+// kept (or the newest with keepNewest); ties fall back to file ID.
+func groupDriveDuplicates(files []*drive.File, minSize int64, keepNewest bool) []driveDuplicateGroup {
+	byKey := map[string][]*drive.File{}
+	seen := map[string]struct{}{}
+	for _, f := range files {
+		if f == nil || f.Md5Checksum == "" || f.Size < minSize {
+			continue
+		}
+		if _, ok := seen[f.Id]; ok {
+			continue
+		}
+		seen[f.Id] = struct{}{}
+		key := fmt.Sprintf("%s:%d", f.Md5Checksum, f.Size)
+		byKey[key] = append(byKey[key], f)
+	}
+
+	groups := make([]driveDuplicateGroup, 0)
+	for _, items := range byKey {
+		if len(items) < 2 {
+			continue
+		}
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].CreatedTime != items[j].CreatedTime {
+				if keepNewest {
+					return items[i].CreatedTime > items[j].CreatedTime
+				}
+				return items[i].CreatedTime < items[j].CreatedTime
+			}
+			return items[i].Id < items[j].Id
+		})
+		groups = append(groups, driveDuplicateGroup{
+			MD5Checksum: items[0].Md5Checksum,
+			Size:        items[0].Size,
+			WastedBytes: items[0].Size * int64(len(items)-1),
+			Keep:        items[0],
+			Extra:       items[1:],
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].WastedBytes != groups[j].WastedBytes {
+			return groups[i].WastedBytes > groups[j].WastedBytes
+		}
+		return groups[i].MD5Checksum < groups[j].MD5Checksum
+	})
+	return groups
+}
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type driveUsageFixture struct {
+	mu      sync.Mutex
+	trashed []string
+}
+
+func (fx *driveUsageFixture) handler(t *testing.T) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fx.mu.Lock()
+		defer fx.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		switch {
+		case r.Method == http.MethodGet && path == "/about":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"storageQuota": map[string]any{"limit": "1000", "usage": "250", "usageInDrive": "200", "usageInDriveTrash": "50"},
+			})
+		case r.Method == http.MethodGet && path == "/files/root":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "root", "name": "My Drive"})
+		case r.Method == http.MethodGet && path == "/files":
+			q := r.URL.Query().Get("q")
+			var files []map[string]any
+			switch {
+			case strings.Contains(q, "'root' in parents"):
+				files = []map[string]any{
+					{"id": "A", "name": "Designs", "mimeType": driveMimeFolder},
+					{"id": "x", "name": "big.bin", "mimeType": "application/octet-stream", "size": "100", "md5Checksum": "m0", "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+					{"id": "y", "name": "logo.png", "mimeType": "image/png", "size": "50", "md5Checksum": "m1", "createdTime": "2026-01-01T00:00:00Z", "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+				}
+			case strings.Contains(q, "'A' in parents"):
+				files = []map[string]any{
+					{"id": "z", "name": "logo copy.png", "mimeType": "image/png", "size": "50", "md5Checksum": "m1", "createdTime": "2026-02-01T00:00:00Z", "owners": []map[string]any{{"emailAddress": "c@d.com"}}},
+					{"id": "s", "name": "link", "mimeType": driveMimeShortcut},
+					// big.bin also lives in Designs (a second parent); it counts once.
+					{"id": "x", "name": "big.bin", "mimeType": "application/octet-stream", "size": "100", "md5Checksum": "m0", "owners": []map[string]any{{"emailAddress": "a@b.com"}}},
+					{"id": "root", "name": "loop", "mimeType": driveMimeFolder},
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"files": files})
+		case r.Method == http.MethodPatch && strings.HasPrefix(path, "/files/"):
+			fx.trashed = append(fx.trashed, strings.TrimPrefix(path, "/files/"))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": strings.TrimPrefix(path, "/files/")})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestDriveDuCmd_JSON(t *testing.T) {
+	fx := &driveUsageFixture{}
+	newDriveChangesTestService(t, fx.handler(t))
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveDuCmd{}, []string{}, ctx, &RootFlags{Account: "a@b.com"}); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+
+	var parsed struct {
+		TotalBytes  int64                   `json:"totalBytes"`
+		FileCount   int64                   `json:"fileCount"`
+		FolderCount int                     `json:"folderCount"`
+		Folders     []driveUsageFolder      `json:"folders"`
+		Owners      []driveUsageBucket      `json:"owners"`
+		MimeTypes   []driveUsageBucket      `json:"mimeTypes"`
+		Quota       drive.AboutStorageQuota `json:"quota"`
+	}
+	if unmarshalErr := json.Unmarshal([]byte(out), &parsed); unmarshalErr != nil {
+		t.Fatalf("json: %v\n%s", unmarshalErr, out)
+	}
+	if parsed.TotalBytes != 200 || parsed.FileCount != 3 || parsed.FolderCount != 1 {
+		t.Fatalf("unexpected totals: %#v", parsed)
+	}
+	if len(parsed.Folders) != 2 || parsed.Folders[0].Path != "My Drive" || parsed.Folders[0].Bytes != 200 {
+		t.Fatalf("unexpected folders: %#v", parsed.Folders)
+	}
+	if parsed.Folders[1].Path != "My Drive/Designs" || parsed.Folders[1].Bytes != 50 || parsed.Folders[1].Files != 1 {
+		t.Fatalf("unexpected subfolder: %#v", parsed.Folders[1])
+	}
+	if len(parsed.Owners) != 2 || parsed.Owners[0].Key != "a@b.com" || parsed.Owners[0].Bytes != 150 {
+		t.Fatalf("unexpected owners: %#v", parsed.Owners)
+	}
+	if len(parsed.MimeTypes) != 2 || parsed.MimeTypes[0].Key != "application/octet-stream" {
+		t.Fatalf("unexpected mime types: %#v", parsed.MimeTypes)
+	}
+	if parsed.Quota.Limit != 1000 || parsed.Quota.UsageInDriveTrash != 50 {
+		t.Fatalf("unexpected quota: %#v", parsed.Quota)
+	}
+}
+
+func TestDriveDuCmd_TextByOwner(t *testing.T) {
+	fx := &driveUsageFixture{}
+	newDriveChangesTestService(t, fx.handler(t))
+
+	var out bytes.Buffer
+	u, err := ui.New(ui.Options{Stdout: &out, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{})
+
+	table := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveDuCmd{}, []string{"root", "--by", "owner", "--no-quota"}, ctx, &RootFlags{Account: "a@b.com"}); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	if !strings.Contains(out.String(), "total\t200 B") || strings.Contains(out.String(), "quota_") {
+		t.Fatalf("unexpected summary: %q", out.String())
+	}
+	if !strings.Contains(table, "OWNER") || !strings.Contains(table, "c@d.com") {
+		t.Fatalf("unexpected owner table: %q", table)
+	}
+}
+
+func TestDriveDupesCmd_TrashExtras(t *testing.T) {
+	fx := &driveUsageFixture{}
+	newDriveChangesTestService(t, fx.handler(t))
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveDupesCmd{}, []string{"root", "--trash", "--dry-run"}, ctx, &RootFlags{Account: "a@b.com"}); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	if len(fx.trashed) != 0 {
+		t.Fatalf("dry-run trashed files: %v", fx.trashed)
+	}
+	var parsed struct {
+		Groups      []driveDuplicateGroup `json:"groups"`
+		WastedBytes int64                 `json:"wastedBytes"`
+	}
+	if unmarshalErr := json.Unmarshal([]byte(out), &parsed); unmarshalErr != nil {
+		t.Fatalf("json: %v\n%s", unmarshalErr, out)
+	}
+	if len(parsed.Groups) != 1 || parsed.Groups[0].Keep.Id != "y" || parsed.Groups[0].Extra[0].Id != "z" || parsed.WastedBytes != 50 {
+		t.Fatalf("unexpected groups: %#v", parsed)
+	}
+
+	if execErr := runKong(t, &DriveDupesCmd{}, []string{"root", "--trash"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true}); execErr == nil || !strings.Contains(execErr.Error(), "refusing") {
+		t.Fatalf("expected confirmation refusal, got %v", execErr)
+	}
+
+	_ = captureStdout(t, func() {
+		if execErr := runKong(t, &DriveDupesCmd{}, []string{"root", "--trash", "--keep", "newest"}, ctx, &RootFlags{Account: "a@b.com", Force: true}); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	if strings.Join(fx.trashed, ",") != "y" {
+		t.Fatalf("expected older copy trashed with --keep newest, got %v", fx.trashed)
+	}
+}
+
+func TestGroupDriveDuplicates_IgnoresNativeAndSmallFiles(t *testing.T) {
+	files := []*drive.File{
+		{Id: "a", Size: 10, Md5Checksum: "m"},
+		{Id: "b", Size: 10, Md5Checksum: "m"},
+		{Id: "b", Size: 10, Md5Checksum: "m"},
+		{Id: "c", Size: 0},
+		{Id: "d", Size: 0},
+		{Id: "e", Size: 10, Md5Checksum: "other"},
+	}
+	groups := groupDriveDuplicates(files, 1, false)
+	if len(groups) != 1 || len(groups[0].Extra) != 1 || groups[0].Keep.Id != "a" {
+		t.Fatalf("unexpected groups: %#v", groups)
+	}
+	if got := groupDriveDuplicates(files, 11, false); len(got) != 0 {
+		t.Fatalf("expected --min-size to filter, got %#v", got)
+	}
+}
@@ -0,0 +1,659 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	defaultDriveWatchInterval = time.Minute
+	driveWatchBatchMax        = 1000
+)
+
+var driveWatchSleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+type DriveWatchCmd struct {
+	Drive          string   `name:"drive" help:"Shared drive ID (default: My Drive and all shared drives)"`
+	Parents        []string `name:"parent" help:"Only forward changes to files directly inside these folder IDs (repeatable, comma-separated)"`
+	Name           string   `name:"name" help:"Only forward changes whose file name matches this glob (e.g. '*.fig')"`
+	MimeTypes      []string `name:"mime-type" help:"Only forward changes with these MIME types (repeatable, comma-separated)"`
+	IncludeRemoved bool     `name:"include-removed" help:"Forward removals (only when no file filters are set)"`
+	Interval       string   `name:"interval" help:"Polling interval (seconds or Go duration)" default:"60s"`
+	Once           bool     `name:"once" help:"Poll once and exit (polling mode only)"`
+	HookURL        string   `name:"hook-url" help:"Webhook URL to forward changes (default: print NDJSON to stdout)"`
+	HookToken      string   `name:"hook-token" help:"Webhook bearer token"`
+	Address        string   `name:"address" help:"Public HTTPS URL that reaches this receiver; registers a changes.watch channel instead of polling"`
+	Bind           string   `name:"bind" help:"Bind address (push mode)" default:"127.0.0.1"`
+	Port           int      `name:"port" help:"Listen port (push mode)" default:"8789"`
+	Path           string   `name:"path" help:"Notification handler path (push mode)" default:"/drive-changes"`
+	ChannelToken   string   `name:"token" help:"Channel token expected in X-Goog-Channel-Token (push mode; default: random)"`
+	TTL            string   `name:"ttl" help:"Requested channel lifetime (push mode; seconds or Go duration)"`
+}
+
+func (c *DriveWatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	interval, ttl, err := watchFlags{
+		service:      "Drive",
+		interval:     c.Interval,
+		once:         c.Once,
+		hookURL:      c.HookURL,
+		hookToken:    c.HookToken,
+		address:      c.Address,
+		path:         c.Path,
+		port:         c.Port,
+		channelToken: c.ChannelToken,
+		ttl:          c.TTL,
+	}.validate()
+	if err != nil {
+		return err
+	}
+	if name := strings.TrimSpace(c.Name); name != "" {
+		if _, matchErr := path.Match(name, ""); matchErr != nil {
+			return usage(fmt.Sprintf("invalid --name pattern: %v", matchErr))
+		}
+	}
+	address := strings.TrimSpace(c.Address)
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	driveID := strings.TrimSpace(c.Drive)
+	watcher := &driveWatcher{
+		account: account,
+		driveID: driveID,
+		svc:     svc,
+		filter: driveChangeFilter{
+			Parents:        lowerStringSet(c.Parents),
+			NamePattern:    strings.TrimSpace(c.Name),
+			MimeTypes:      lowerStringSet(c.MimeTypes),
+			IncludeRemoved: c.IncludeRemoved,
+		},
+		hookURL:    strings.TrimSpace(c.HookURL),
+		hookToken:  c.HookToken,
+		hookClient: &http.Client{Timeout: defaultHookRequestTimeoutSec * time.Second},
+		out:        os.Stdout,
+		warnf:      u.Err().Printf,
+	}
+	if err := watcher.init(ctx); err != nil {
+		return err
+	}
+
+	if address == "" {
+		return watcher.pollLoop(ctx, interval, c.Once)
+	}
+
+	token := strings.TrimSpace(c.ChannelToken)
+	if token == "" {
+		token, err = randomDriveChannelToken()
+		if err != nil {
+			return err
+		}
+	}
+	channel, err := watcher.registerChannel(ctx, strings.TrimSuffix(address, "/"), token, ttl)
+	if err != nil {
+		return err
+	}
+	u.Err().Printf("watch: channel %s (resource %s)", channel.Id, channel.ResourceId)
+	if channel.Expiration > 0 {
+		u.Err().Printf("watch: channel expires %s", formatUnixMillis(channel.Expiration))
+	}
+
+	addr := net.JoinHostPort(c.Bind, strconv.Itoa(c.Port))
+	u.Err().Printf("watch: listening on %s%s", addr, c.Path)
+	handler := &watchPushServer{
+		path:      c.Path,
+		channelID: channel.Id,
+		token:     token,
+		poll: func(ctx context.Context) error {
+			_, err := watcher.poll(ctx)
+			return err
+		},
+		warnf: u.Err().Printf,
+	}
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return serveWatchChannel(ctx, httpServer, watchChannel{
+		ID:         channel.Id,
+		ResourceID: channel.ResourceId,
+		Expiration: channel.Expiration,
+	}, watchChannelOps{
+		renew: func(ctx context.Context) (watchChannel, error) {
+			next, err := watcher.registerChannel(ctx, strings.TrimSuffix(address, "/"), token, ttl)
+			if err != nil {
+				return watchChannel{}, err
+			}
+			handler.setChannelID(next.Id)
+			return watchChannel{ID: next.Id, ResourceID: next.ResourceId, Expiration: next.Expiration}, nil
+		},
+		stop: func(ctx context.Context, ch watchChannel) error {
+			return svc.Channels.Stop(&drive.Channel{Id: ch.ID, ResourceId: ch.ResourceID}).Context(ctx).Do()
+		},
+		warnf: u.Err().Printf,
+	})
+}
+
+type driveChangeFilter struct {
+	Parents        map[string]struct{}
+	NamePattern    string
+	MimeTypes      map[string]struct{}
+	IncludeRemoved bool
+}
+
+func (f driveChangeFilter) hasFileFilters() bool {
+	return len(f.Parents) > 0 || f.NamePattern != "" || len(f.MimeTypes) > 0
+}
+
+func (f driveChangeFilter) Match(ch *drive.Change) bool {
+	if ch == nil || ch.ChangeType == "drive" {
+		return false
+	}
+	if ch.Removed || ch.File == nil {
+		return f.IncludeRemoved && !f.hasFileFilters()
+	}
+	file := ch.File
+	if len(f.Parents) > 0 {
+		found := false
+		for _, p := range file.Parents {
+			if _, ok := f.Parents[strings.ToLower(p)]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.MimeTypes) > 0 {
+		if _, ok := f.MimeTypes[strings.ToLower(file.MimeType)]; !ok {
+			return false
+		}
+	}
+	if f.NamePattern != "" {
+		if ok, _ := path.Match(f.NamePattern, file.Name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type driveHookChange struct {
+	FileID       string   `json:"fileId"`
+	Change       string   `json:"change"`
+	Time         string   `json:"time,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	MimeType     string   `json:"mimeType,omitempty"`
+	Parents      []string `json:"parents,omitempty"`
+	ModifiedTime string   `json:"modifiedTime,omitempty"`
+	Size         int64    `json:"size,omitempty"`
+	MD5Checksum  string   `json:"md5Checksum,omitempty"`
+	WebViewLink  string   `json:"webViewLink,omitempty"`
+}
+
+type driveHookPayload struct {
+	Source    string            `json:"source"`
+	Account   string            `json:"account"`
+	DriveID   string            `json:"driveId,omitempty"`
+	PageToken string            `json:"pageToken"`
+	Changes   []driveHookChange `json:"changes"`
+}
+
+func driveHookChangeFrom(ch *drive.Change) driveHookChange {
+	item := driveHookChange{
+		FileID: ch.FileId,
+		Change: driveChangeKind(ch),
+		Time:   ch.Time,
+	}
+	if f := ch.File; f != nil {
+		item.Name = f.Name
+		item.MimeType = f.MimeType
+		item.Parents = f.Parents
+		item.ModifiedTime = f.ModifiedTime
+		item.Size = f.Size
+		item.MD5Checksum = f.Md5Checksum
+		item.WebViewLink = f.WebViewLink
+	}
+	return item
+}
+
+type driveWatcher struct {
+	account    string
+	driveID    string
+	svc        *drive.Service
+	filter     driveChangeFilter
+	hookURL    string
+	hookToken  string
+	hookClient *http.Client
+	out        io.Writer
+	warnf      func(string, ...any)
+
+	mu        sync.Mutex
+	pageToken string
+}
+
+// driveWatchStateKey names the watcher's page-token state. It is kept apart
+// from the token `drive changes list` advances so neither consumer skips
+// changes only the other has seen.
+func driveWatchStateKey(driveID string) string {
+	return driveID + "__watch"
+}
+
+// init loads the watcher's stored page token or starts from the current
+// state of the drive.
+func (w *driveWatcher) init(ctx context.Context) error {
+	state, ok, err := loadDriveChangesState(w.account, driveWatchStateKey(w.driveID))
+	if err != nil {
+		return err
+	}
+	if ok {
+		w.pageToken = state.PageToken
+		return nil
+	}
+	start, err := driveStartPageToken(ctx, w.svc, w.driveID)
+	if err != nil {
+		return err
+	}
+	w.pageToken = start
+	return saveDriveChangesState(w.account, driveWatchStateKey(w.driveID), start)
+}
+
+func (w *driveWatcher) pollLoop(ctx context.Context, interval time.Duration, once bool) error {
+	for {
+		if _, err := w.poll(ctx); err != nil {
+			if once {
+				return err
+			}
+			w.warnf("watch: poll failed: %v", err)
+		}
+		if once {
+			return nil
+		}
+		if err := driveWatchSleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// poll drains all pending changes and forwards the matching ones. The stored
+// token only advances after delivery succeeds, so a failed hook is retried on
+// the next poll.
+func (w *driveWatcher) poll(ctx context.Context) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	forwarded := 0
+	for {
+		batch, err := listDriveChanges(ctx, w.svc, driveChangesQuery{
+			PageToken:      w.pageToken,
+			DriveID:        w.driveID,
+			Max:            driveWatchBatchMax,
+			IncludeRemoved: w.filter.IncludeRemoved,
+		})
+		if err != nil {
+			return forwarded, err
+		}
+
+		matched := make([]driveHookChange, 0, len(batch.Changes))
+		for _, ch := range batch.Changes {
+			if w.filter.Match(ch) {
+				matched = append(matched, driveHookChangeFrom(ch))
+			}
+		}
+		next := batch.ResumeToken()
+		if len(matched) > 0 {
+			if err := w.deliver(ctx, next, matched); err != nil {
+				return forwarded, err
+			}
+			forwarded += len(matched)
+		}
+		if next != "" && next != w.pageToken {
+			w.pageToken = next
+			if err := saveDriveChangesState(w.account, driveWatchStateKey(w.driveID), next); err != nil {
+				return forwarded, err
+			}
+		}
+		if batch.NextPageToken == "" {
+			return forwarded, nil
+		}
+	}
+}
+
+func (w *driveWatcher) deliver(ctx context.Context, pageToken string, changes []driveHookChange) error {
+	if w.hookURL == "" {
+		enc := json.NewEncoder(w.out)
+		for _, ch := range changes {
+			if err := enc.Encode(ch); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return postWatchHook(ctx, w.hookClient, w.hookURL, w.hookToken, driveHookPayload{
+		Source:    "drive",
+		Account:   w.account,
+		DriveID:   w.driveID,
+		PageToken: pageToken,
+		Changes:   changes,
+	})
+}
+
+// postWatchHook POSTs payload as JSON to a watch hook and treats any non-2xx
+// status as a delivery failure.
+func postWatchHook(ctx context.Context, client *http.Client, hookURL, hookToken string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hookURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hookToken != "" {
+		req.Header.Set("Authorization", "Bearer "+hookToken)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("hook status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *driveWatcher) registerChannel(ctx context.Context, address, token string, ttl time.Duration) (*drive.Channel, error) {
+	id, err := randomDriveChannelToken()
+	if err != nil {
+		return nil, err
+	}
+	req := &drive.Channel{
+		Id:      "gog-" + id,
+		Type:    "web_hook",
+		Address: address,
+		Token:   token,
+	}
+	if ttl > 0 {
+		req.Expiration = time.Now().Add(ttl).UnixMilli()
+	}
+	w.mu.Lock()
+	pageToken := w.pageToken
+	w.mu.Unlock()
+	call := w.svc.Changes.Watch(pageToken, req).
+		SupportsAllDrives(true).
+		IncludeItemsFromAllDrives(true).
+		Context(ctx)
+	if w.driveID != "" {
+		call = call.DriveId(w.driveID)
+	}
+	return call.Do()
+}
+
+func randomDriveChannelToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// watchPushServer receives Drive changes.watch and Calendar events.watch
+// notifications. They carry no payload, so each one (other than the initial
+// "sync" handshake) simply triggers poll from the stored token.
+type watchPushServer struct {
+	path  string
+	token string
+	poll  func(ctx context.Context) error
+	warnf func(string, ...any)
+
+	mu        sync.RWMutex
+	channelID string
+}
+
+// setChannelID switches the accepted channel after a renewal.
+func (s *watchPushServer) setChannelID(id string) {
+	s.mu.Lock()
+	s.channelID = id
+	s.mu.Unlock()
+}
+
+func (s *watchPushServer) currentChannelID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.channelID
+}
+
+func (s *watchPushServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !pathMatches(s.path, r.URL.Path) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(r.Body, defaultPushBodyLimitBytes))
+	_ = r.Body.Close()
+
+	if id := s.currentChannelID(); id != "" && r.Header.Get("X-Goog-Channel-ID") != id {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	got := r.Header.Get("X-Goog-Channel-Token")
+	if s.token != "" && subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if strings.EqualFold(r.Header.Get("X-Goog-Resource-State"), "sync") {
+		// The handshake confirms the channel; nothing has changed yet.
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if err := s.poll(r.Context()); err != nil {
+		s.warnf("watch: poll failed: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// watchFlags are the polling/push flags shared by `drive watch` and
+// `calendar watch`.
+type watchFlags struct {
+	service      string // named in the --address error
+	interval     string
+	once         bool
+	hookURL      string
+	hookToken    string
+	address      string
+	path         string
+	port         int
+	channelToken string
+	ttl          string
+}
+
+// validate checks the flag combination and returns the polling interval and
+// the requested channel lifetime.
+func (f watchFlags) validate() (interval, ttl time.Duration, err error) {
+	interval, err = parseDurationSeconds(f.interval)
+	if err != nil {
+		return 0, 0, usage(fmt.Sprintf("invalid --interval: %v", err))
+	}
+	if interval <= 0 {
+		interval = defaultDriveWatchInterval
+	}
+	if f.hookToken != "" && strings.TrimSpace(f.hookURL) == "" {
+		return 0, 0, usage("--hook-url required when using --hook-token")
+	}
+
+	address := strings.TrimSpace(f.address)
+	if address == "" {
+		if f.channelToken != "" {
+			return 0, 0, usage("--token requires --address")
+		}
+		return interval, 0, nil
+	}
+	if f.once {
+		return 0, 0, usage("--once cannot be combined with --address")
+	}
+	if !strings.HasPrefix(f.path, "/") {
+		return 0, 0, usage("--path must start with '/'")
+	}
+	if f.port <= 0 {
+		return 0, 0, usage("--port must be > 0")
+	}
+	if !strings.HasPrefix(strings.ToLower(address), "https://") {
+		return 0, 0, usage(fmt.Sprintf("--address must be an https:// URL (%s only delivers to HTTPS)", f.service))
+	}
+	ttl, err = parseDurationSeconds(f.ttl)
+	if err != nil {
+		return 0, 0, usage(fmt.Sprintf("invalid --ttl: %v", err))
+	}
+	return interval, ttl, nil
+}
+
+const (
+	watchChannelRenewMarginMin = 30 * time.Second
+	watchChannelRenewMarginMax = 10 * time.Minute
+	watchChannelRetryInterval  = time.Minute
+	watchChannelStopTimeout    = 10 * time.Second
+)
+
+// watchChannel identifies a registered push channel (Drive or Calendar).
+type watchChannel struct {
+	ID         string
+	ResourceID string
+	Expiration int64 // unix millis, 0 when the API set none
+}
+
+type watchChannelOps struct {
+	renew func(ctx context.Context) (watchChannel, error)
+	stop  func(ctx context.Context, ch watchChannel) error
+	warnf func(string, ...any)
+}
+
+// serveWatchChannel runs the push receiver until ctx ends (or SIGINT/SIGTERM)
+// or the server fails. The channel is re-registered shortly before it expires
+// and the active one is stopped on the way out, so Google does not keep
+// posting to a receiver that is gone.
+func serveWatchChannel(ctx context.Context, srv *http.Server, ch watchChannel, ops watchChannelOps) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() { errc <- listenAndServe(srv) }()
+
+	current := ch
+	defer func() {
+		stopCtx, stopCancel := context.WithTimeout(context.WithoutCancel(ctx), watchChannelStopTimeout)
+		defer stopCancel()
+		if err := ops.stop(stopCtx, current); err != nil {
+			ops.warnf("watch: stop channel %s: %v", current.ID, err)
+			return
+		}
+		ops.warnf("watch: stopped channel %s", current.ID)
+	}()
+
+	var retry time.Duration
+	for {
+		var renewC <-chan time.Time
+		var timer *time.Timer
+		if d, ok := watchChannelRenewIn(current, time.Now()); ok || retry > 0 {
+			if retry > 0 {
+				d = retry
+			}
+			timer = time.NewTimer(d)
+			renewC = timer.C
+		}
+
+		select {
+		case err := <-errc:
+			stopTimer(timer)
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			stopTimer(timer)
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), watchChannelStopTimeout)
+			defer shutdownCancel()
+			_ = srv.Shutdown(shutdownCtx)
+			return nil
+		case <-renewC:
+			next, err := ops.renew(ctx)
+			if err != nil {
+				ops.warnf("watch: renew channel failed: %v", err)
+				retry = watchChannelRetryInterval
+				continue
+			}
+			retry = 0
+			old := current
+			current = next
+			ops.warnf("watch: renewed channel %s (expires %s)", next.ID, formatUnixMillis(next.Expiration))
+			if err := ops.stop(ctx, old); err != nil {
+				ops.warnf("watch: stop channel %s: %v", old.ID, err)
+			}
+		}
+	}
+}
+
+// watchChannelRenewIn returns how long to wait before re-registering ch: a
+// tenth of the remaining lifetime early, clamped to a sane margin.
+func watchChannelRenewIn(ch watchChannel, now time.Time) (time.Duration, bool) {
+	if ch.Expiration <= 0 {
+		return 0, false
+	}
+	left := time.UnixMilli(ch.Expiration).Sub(now)
+	margin := min(max(left/10, watchChannelRenewMarginMin), watchChannelRenewMarginMax)
+	return max(left-margin, 0), true
+}
+
+func stopTimer(t *time.Timer) {
+	if t != nil {
+		t.Stop()
+	}
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func driveWatchChangesHandler(t *testing.T, listCalls *int) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/changes/startPageToken"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"startPageToken": "1"})
+		case strings.HasSuffix(r.URL.Path, "/changes"):
+			*listCalls++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"newStartPageToken": "2",
+				"changes": []map[string]any{
+					{"changeType": "file", "fileId": "a", "file": map[string]any{"id": "a", "name": "hero.fig", "parents": []string{"designs"}}},
+					{"changeType": "file", "fileId": "b", "file": map[string]any{"id": "b", "name": "notes.txt", "parents": []string{"designs"}}},
+					{"changeType": "file", "fileId": "c", "file": map[string]any{"id": "c", "name": "other.fig", "parents": []string{"elsewhere"}}},
+					{"changeType": "file", "fileId": "d", "removed": true},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestDriveWatchCmd_OnceForwardsMatchingChangesToHook(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	listCalls := 0
+	newDriveChangesTestService(t, driveWatchChangesHandler(t, &listCalls))
+
+	var got driveHookPayload
+	var auth string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hook.Close()
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{})
+
+	args := []string{"--once", "--parent", "designs", "--name", "*.fig", "--hook-url", hook.URL, "--hook-token", "secret"}
+	if execErr := runKong(t, &DriveWatchCmd{}, args, ctx, flags); execErr != nil {
+		t.Fatalf("execute: %v", execErr)
+	}
+
+	if listCalls != 1 {
+		t.Fatalf("expected one changes.list call, got %d", listCalls)
+	}
+	if auth != "Bearer secret" {
+		t.Fatalf("unexpected hook auth %q", auth)
+	}
+	if got.Source != "drive" || got.Account != "a@b.com" || got.PageToken != "2" {
+		t.Fatalf("unexpected payload: %#v", got)
+	}
+	if len(got.Changes) != 1 || got.Changes[0].FileID != "a" {
+		t.Fatalf("expected only hero.fig to match, got %#v", got.Changes)
+	}
+	state, ok, err := loadDriveChangesState("a@b.com", driveWatchStateKey(""))
+	if err != nil || !ok || state.PageToken != "2" {
+		t.Fatalf("expected stored token 2, got %#v ok=%v err=%v", state, ok, err)
+	}
+}
+
+func TestDriveWatchCmd_HookFailureKeepsToken(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	listCalls := 0
+	newDriveChangesTestService(t, driveWatchChangesHandler(t, &listCalls))
+
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer hook.Close()
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	execErr := runKong(t, &DriveWatchCmd{}, []string{"--once", "--hook-url", hook.URL}, ctx, flags)
+	if execErr == nil || !strings.Contains(execErr.Error(), "hook status 502") {
+		t.Fatalf("expected hook error, got %v", execErr)
+	}
+	state, ok, err := loadDriveChangesState("a@b.com", driveWatchStateKey(""))
+	if err != nil || !ok || state.PageToken != "1" {
+		t.Fatalf("expected token to stay at 1, got %#v ok=%v err=%v", state, ok, err)
+	}
+}
+
+func TestDriveWatchCmd_KeepsTokenApartFromChangesList(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	listCalls := 0
+	newDriveChangesTestService(t, driveWatchChangesHandler(t, &listCalls))
+	ctx := context.Background()
+
+	// The watcher records start token 1 without polling past it.
+	svc, err := newDriveService(ctx, "a@b.com")
+	if err != nil {
+		t.Fatalf("svc: %v", err)
+	}
+	watcher := &driveWatcher{account: "a@b.com", svc: svc, out: io.Discard, warnf: func(string, ...any) {}}
+	if err := watcher.init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	// `changes list` initializes and then advances its own token to 2.
+	for range 2 {
+		_ = captureStdout(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "drive", "changes", "list"}); err != nil {
+				t.Fatalf("changes list: %v", err)
+			}
+		})
+	}
+	if state, ok, _ := loadDriveChangesState("a@b.com", ""); !ok || state.PageToken != "2" {
+		t.Fatalf("expected changes list token 2, got %#v", state)
+	}
+	state, ok, err := loadDriveChangesState("a@b.com", driveWatchStateKey(""))
+	if err != nil || !ok || state.PageToken != "1" {
+		t.Fatalf("changes list must not move the watch token, got %#v ok=%v err=%v", state, ok, err)
+	}
+}
+
+func TestDriveWatchCmd_PrintsNDJSONWithoutHook(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	listCalls := 0
+	newDriveChangesTestService(t, driveWatchChangesHandler(t, &listCalls))
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	out := captureStdout(t, func() {
+		if execErr := runKong(t, &DriveWatchCmd{}, []string{"--once", "--include-removed"}, ctx, flags); execErr != nil {
+			t.Fatalf("execute: %v", execErr)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 NDJSON lines, got %d: %q", len(lines), out)
+	}
+	var last driveHookChange
+	if err := json.Unmarshal([]byte(lines[3]), &last); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	if last.FileID != "d" || last.Change != "removed" {
+		t.Fatalf("unexpected removal line: %#v", last)
+	}
+}
+
+func TestDriveWatchCmd_Validation(t *testing.T) {
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	cases := map[string][]string{
+		"--hook-url required":    {"--hook-token", "x"},
+		"--token requires":       {"--token", "x"},
+		"https://":               {"--address", "http://example.com/hook"},
+		"--once cannot":          {"--address", "https://example.com/hook", "--once"},
+		"invalid --name pattern": {"--name", "["},
+	}
+	for want, args := range cases {
+		execErr := runKong(t, &DriveWatchCmd{}, args, ctx, flags)
+		if execErr == nil || !strings.Contains(execErr.Error(), want) {
+			t.Fatalf("args %v: expected %q error, got %v", args, want, execErr)
+		}
+	}
+}
+
+func TestCalendarWatchCmd_Validation(t *testing.T) {
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	cases := map[string][]string{
+		"--hook-url required":    {"--hook-token", "x"},
+		"--token requires":       {"--token", "x"},
+		"Calendar only delivers": {"--address", "http://example.com/hook"},
+		"--once cannot":          {"--address", "https://example.com/hook", "--once"},
+		"invalid --ttl":          {"--address", "https://example.com/hook", "--ttl", "soon"},
+	}
+	for want, args := range cases {
+		execErr := runKong(t, &CalendarWatchCmd{}, args, ctx, flags)
+		if execErr == nil || !strings.Contains(execErr.Error(), want) {
+			t.Fatalf("args %v: expected %q error, got %v", args, want, execErr)
+		}
+	}
+}
+
+func TestDriveWatchCmd_PushRenewsAndStopsChannel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var (
+		mu      sync.Mutex
+		watches []string
+		stopped []string
+	)
+	firstStopped := make(chan struct{})
+	listCalls := 0
+	changes := driveWatchChangesHandler(t, &listCalls)
+	newDriveChangesTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/changes/watch"):
+			var ch drive.Channel
+			_ = json.NewDecoder(r.Body).Decode(&ch)
+			mu.Lock()
+			watches = append(watches, ch.Id)
+			resp := map[string]any{"id": ch.Id, "resourceId": "res-" + ch.Id}
+			if len(watches) == 1 {
+				// Expires right away so the renewal fires immediately.
+				resp["expiration"] = strconv.FormatInt(time.Now().Add(time.Second).UnixMilli(), 10)
+			}
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(resp)
+		case strings.HasSuffix(r.URL.Path, "/channels/stop"):
+			var ch drive.Channel
+			_ = json.NewDecoder(r.Body).Decode(&ch)
+			mu.Lock()
+			stopped = append(stopped, ch.Id+"/"+ch.ResourceId)
+			if len(stopped) == 1 {
+				close(firstStopped)
+			}
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			changes.ServeHTTP(w, r)
+		}
+	}))
+
+	origListen := listenAndServe
+	t.Cleanup(func() { listenAndServe = origListen })
+	listenAndServe = func(*http.Server) error {
+		<-firstStopped
+		return http.ErrServerClosed
+	}
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+	if err := runKong(t, &DriveWatchCmd{}, []string{"--address", "https://example.com/hook"}, ctx, flags); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(watches) != 2 {
+		t.Fatalf("expected the channel to be renewed once, got %v", watches)
+	}
+	want := []string{watches[0] + "/res-" + watches[0], watches[1] + "/res-" + watches[1]}
+	if len(stopped) != 2 || stopped[0] != want[0] || stopped[1] != want[1] {
+		t.Fatalf("expected old channel stopped on renewal and new one on exit, got %v (want %v)", stopped, want)
+	}
+}
+
+func TestWatchChannelRenewIn(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if _, ok := watchChannelRenewIn(watchChannel{}, now); ok {
+		t.Fatalf("channel without expiration must not be renewed")
+	}
+	week := watchChannel{Expiration: now.Add(7 * 24 * time.Hour).UnixMilli()}
+	if d, _ := watchChannelRenewIn(week, now); d != 7*24*time.Hour-watchChannelRenewMarginMax {
+		t.Fatalf("unexpected renewal delay %s", d)
+	}
+	soon := watchChannel{Expiration: now.Add(10 * time.Second).UnixMilli()}
+	if d, _ := watchChannelRenewIn(soon, now); d != 0 {
+		t.Fatalf("expected immediate renewal, got %s", d)
+	}
+}
+
+func TestDriveWatchServer_Notifications(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	listCalls := 0
+	newDriveChangesTestService(t, driveWatchChangesHandler(t, &listCalls))
+	svc, err := newDriveService(context.Background(), "a@b.com")
+	if err != nil {
+		t.Fatalf("svc: %v", err)
+	}
+
+	var out strings.Builder
+	watcher := &driveWatcher{
+		account:   "a@b.com",
+		svc:       svc,
+		out:       &out,
+		warnf:     func(string, ...any) {},
+		pageToken: "1",
+	}
+	srv := &watchPushServer{
+		path:      "/drive-changes",
+		channelID: "chan",
+		token:     "tok",
+		poll: func(ctx context.Context) error {
+			_, err := watcher.poll(ctx)
+			return err
+		},
+		warnf: func(string, ...any) {},
+	}
+
+	send := func(path, channel, token, state string) int {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set("X-Goog-Channel-ID", channel)
+		req.Header.Set("X-Goog-Channel-Token", token)
+		req.Header.Set("X-Goog-Resource-State", state)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send("/other", "chan", "tok", "change"); code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", code)
+	}
+	if code := send("/drive-changes", "chan", "wrong", "change"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", code)
+	}
+	if code := send("/drive-changes", "chan", "tok", "sync"); code != http.StatusOK || listCalls != 0 {
+		t.Fatalf("expected sync ack without polling, got code=%d calls=%d", code, listCalls)
+	}
+	if code := send("/drive-changes", "chan", "tok", "change"); code != http.StatusOK || listCalls != 1 {
+		t.Fatalf("expected change to poll, got code=%d calls=%d", code, listCalls)
+	}
+	if !strings.Contains(out.String(), `"fileId":"a"`) {
+		t.Fatalf("expected NDJSON output, got %q", out.String())
+	}
+	if watcher.pageToken != "2" {
+		t.Fatalf("expected token to advance, got %q", watcher.pageToken)
+	}
+}
+
+func TestDriveChangeFilter_Match(t *testing.T) {
+	f := driveChangeFilter{MimeTypes: lowerStringSet([]string{"image/png"})}
+	if f.Match(&drive.Change{ChangeType: "drive", DriveId: "x"}) {
+		t.Fatalf("drive-level changes should not match")
+	}
+	if f.Match(&drive.Change{FileId: "a", File: &drive.File{MimeType: "text/plain"}}) {
+		t.Fatalf("unexpected mime match")
+	}
+	if !f.Match(&drive.Change{FileId: "a", File: &drive.File{MimeType: "IMAGE/PNG"}}) {
+		t.Fatalf("expected case-insensitive mime match")
+	}
+	f.IncludeRemoved = true
+	if f.Match(&drive.Change{FileId: "a", Removed: true}) {
+		t.Fatalf("removals cannot match file filters")
+	}
+}
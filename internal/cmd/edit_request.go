@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+
+	gapi "google.golang.org/api/googleapi"
+)
+
+// editError is the structured error of the docs, sheets and slides edit
+// commands. In JSON mode its fields are merged into the error object; the
+// target ID is reported under idField (doc_id, spreadsheet_id, ...).
+type editError struct {
+	Operation    string
+	idField      string
+	TargetID     string
+	ErrorCode    string
+	Message      string
+	HTTPStatus   int
+	GoogleReason string
+	RequestIndex *int
+	Cause        error
+}
+
+func newEditError(idField, op, targetID, code, msg string, cause error) *editError {
+	e := &editError{
+		Operation: op,
+		idField:   idField,
+		TargetID:  strings.TrimSpace(targetID),
+		ErrorCode: strings.TrimSpace(code),
+		Message:   strings.TrimSpace(msg),
+		Cause:     cause,
+	}
+	var apiErr *gapi.Error
+	if errors.As(cause, &apiErr) {
+		e.HTTPStatus = apiErr.Code
+		if len(apiErr.Errors) > 0 && strings.TrimSpace(apiErr.Errors[0].Reason) != "" {
+			e.GoogleReason = strings.TrimSpace(apiErr.Errors[0].Reason)
+		}
+	}
+	return e
+}
+
+func (e *editError) Error() string {
+	if e == nil {
+		return ""
+	}
+	if strings.TrimSpace(e.Message) != "" {
+		return e.Message
+	}
+	if e.Cause != nil {
+		return e.Cause.Error()
+	}
+	return "edit failed"
+}
+
+func (e *editError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+func (e *editError) JSONErrorFields() map[string]any {
+	if e == nil {
+		return map[string]any{}
+	}
+	fields := map[string]any{
+		"error_code": e.ErrorCode,
+		"operation":  e.Operation,
+	}
+	if e.idField != "" {
+		fields[e.idField] = e.TargetID
+	}
+	if e.HTTPStatus > 0 {
+		fields["http_status"] = e.HTTPStatus
+	}
+	if strings.TrimSpace(e.GoogleReason) != "" {
+		fields["google_reason"] = e.GoogleReason
+	}
+	if e.RequestIndex != nil {
+		fields["request_index"] = *e.RequestIndex
+	}
+	return fields
+}
+
+// editMaybeWriteNormalizedRequest writes the normalized request JSON to path
+// ("-" for stdout); an empty path is a no-op.
+func editMaybeWriteNormalizedRequest(path string, req any) error {
+	path = strings.TrimSpace(path)
+	if path == "" || editRequestIsNil(req) {
+		return nil
+	}
+	pretty, err := editNormalizedRequestString(req)
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		_, err = os.Stdout.WriteString(pretty)
+		return err
+	}
+	return os.WriteFile(path, []byte(pretty), 0o600)
+}
+
+func editNormalizedRequestString(req any) (string, error) {
+	if editRequestIsNil(req) {
+		return "", errors.New("nil request")
+	}
+	pretty, err := json.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(pretty) + "\n", nil
+}
+
+func editRequestHash(req any) (string, error) {
+	if editRequestIsNil(req) {
+		return "", errors.New("nil request")
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// editRequestIsNil also catches typed nil pointers, which compare unequal to
+// nil once stored in an interface.
+func editRequestIsNil(req any) bool {
+	if req == nil {
+		return true
+	}
+	v := reflect.ValueOf(req)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func TestEditRequestHelpers_TypedNil(t *testing.T) {
+	var req *sheets.BatchUpdateSpreadsheetRequest
+	if _, err := editRequestHash(req); err == nil {
+		t.Fatalf("expected error hashing a typed nil request")
+	}
+	if _, err := editNormalizedRequestString(req); err == nil {
+		t.Fatalf("expected error normalizing a typed nil request")
+	}
+	if err := editMaybeWriteNormalizedRequest(filepath.Join(t.TempDir(), "req.json"), req); err != nil {
+		t.Fatalf("typed nil request should be skipped, got %v", err)
+	}
+	if _, err := editRequestHash(&sheets.BatchUpdateSpreadsheetRequest{}); err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kong"
@@ -61,8 +62,8 @@ type GmailVacationUpdateCmd struct {
 	Disable      bool   `name:"disable" help:"Disable vacation responder"`
 	Subject      string `name:"subject" help:"Subject line for auto-reply"`
 	Body         string `name:"body" help:"HTML body of the auto-reply message"`
-	Start        string `name:"start" help:"Start time in RFC3339 format (e.g., 2024-12-20T00:00:00Z)"`
-	End          string `name:"end" help:"End time in RFC3339 format (e.g., 2024-12-31T23:59:59Z)"`
+	Start        string `name:"start" help:"Start time (RFC3339, date, or natural: tomorrow, next monday 9am, +2d)"`
+	End          string `name:"end" help:"End time (RFC3339, date, or natural; a bare day runs until the end of that day)"`
 	ContactsOnly bool   `name:"contacts-only" help:"Only respond to contacts"`
 	DomainOnly   bool   `name:"domain-only" help:"Only respond to same domain"`
 }
@@ -117,7 +118,7 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	}
 	if flagProvided(kctx, "start") {
 		var t int64
-		t, err = parseRFC3339ToMillis(c.Start)
+		t, err = parseVacationTimeMillis(c.Start, false)
 		if err != nil {
 			return err
 		}
@@ -125,7 +126,7 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	}
 	if flagProvided(kctx, "end") {
 		var t int64
-		t, err = parseRFC3339ToMillis(c.End)
+		t, err = parseVacationTimeMillis(c.End, true)
 		if err != nil {
 			return err
 		}
@@ -161,6 +162,27 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	return nil
 }
 
+// parseVacationTimeMillis accepts anything parseTimeExpr does, in local
+// time. A bare day used as the end ("2024-12-31", "friday") covers that
+// whole day.
+func parseVacationTimeMillis(value string, end bool) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if ms, err := parseRFC3339ToMillis(value); err == nil {
+		return ms, nil
+	}
+	t, err := parseTimeExpr(value, time.Now(), time.Local)
+	if err != nil {
+		return 0, err
+	}
+	if end && t.Equal(startOfDay(t)) {
+		t = endOfDay(t)
+	}
+	return t.UnixMilli(), nil
+}
+
 func parseRFC3339ToMillis(rfc3339 string) (int64, error) {
 	if rfc3339 == "" {
 		return 0, nil
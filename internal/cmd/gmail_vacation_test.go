@@ -103,3 +103,23 @@ func TestVacationCommandExists(t *testing.T) {
 	_ = GmailVacationGetCmd{}
 	_ = GmailVacationUpdateCmd{}
 }
+
+func TestParseVacationTimeMillis(t *testing.T) {
+	got, err := parseVacationTimeMillis("2024-12-20T00:00:00Z", true)
+	if err != nil || got != time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC).UnixMilli() {
+		t.Fatalf("rfc3339: %d %v", got, err)
+	}
+
+	start, err := parseVacationTimeMillis("2024-12-31", false)
+	if err != nil || start != time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local).UnixMilli() {
+		t.Fatalf("date start: %d %v", start, err)
+	}
+	end, err := parseVacationTimeMillis("2024-12-31", true)
+	if err != nil || end != endOfDay(time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)).UnixMilli() {
+		t.Fatalf("date end should cover the whole day: %d %v", end, err)
+	}
+
+	if _, err = parseVacationTimeMillis("someday", false); err == nil {
+		t.Fatalf("expected error for invalid time")
+	}
+}
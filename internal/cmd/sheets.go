@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -24,15 +23,31 @@ func cleanRange(r string) string {
 }
 
 type SheetsCmd struct {
-	Get      SheetsGetCmd      `cmd:"" name:"get" help:"Get values from a range"`
-	Update   SheetsUpdateCmd   `cmd:"" name:"update" help:"Update values in a range"`
-	Append   SheetsAppendCmd   `cmd:"" name:"append" help:"Append values to a range"`
-	Clear    SheetsClearCmd    `cmd:"" name:"clear" help:"Clear values in a range"`
-	Format   SheetsFormatCmd   `cmd:"" name:"format" help:"Apply cell formatting to a range"`
-	Metadata SheetsMetadataCmd `cmd:"" name:"metadata" help:"Get spreadsheet metadata"`
-	Create   SheetsCreateCmd   `cmd:"" name:"create" help:"Create a new spreadsheet"`
-	Copy     SheetsCopyCmd     `cmd:"" name:"copy" help:"Copy a Google Sheet"`
-	Export   SheetsExportCmd   `cmd:"" name:"export" help:"Export a Google Sheet (pdf|xlsx|csv) via Drive"`
+	Get         SheetsGetCmd               `cmd:"" name:"get" help:"Get values from a range"`
+	Update      SheetsUpdateCmd            `cmd:"" name:"update" help:"Update values in a range"`
+	Append      SheetsAppendCmd            `cmd:"" name:"append" help:"Append values to a range"`
+	Clear       SheetsClearCmd             `cmd:"" name:"clear" help:"Clear values in a range"`
+	Upsert      SheetsUpsertCmd            `cmd:"" name:"upsert" help:"Update or append rows matched by key column(s)"`
+	Import      SheetsImportCmd            `cmd:"" name:"import" help:"Import CSV or JSON records into a tab"`
+	Edit        SheetsEditCmd              `cmd:"" name:"edit" help:"Agent-safe edits with dry-run, validation and request files"`
+	Format      SheetsFormatCmd            `cmd:"" name:"format" help:"Apply cell formatting to a range"`
+	Tabs        SheetsTabsCmd              `cmd:"" name:"tabs" help:"List and manage tabs"`
+	Rows        SheetsRowsCmd              `cmd:"" name:"rows" help:"Insert, delete and resize rows"`
+	Cols        SheetsColsCmd              `cmd:"" name:"cols" aliases:"columns" help:"Insert, delete and resize columns"`
+	Freeze      SheetsFreezeCmd            `cmd:"" name:"freeze" help:"Freeze header rows and columns"`
+	Sort        SheetsSortCmd              `cmd:"" name:"sort" help:"Sort a range by one or more columns"`
+	Filter      SheetsFilterCmd            `cmd:"" name:"filter" help:"Set or clear the basic filter"`
+	NamedRanges SheetsNamedRangesCmd       `cmd:"" name:"named-ranges" aliases:"names" help:"List and manage named ranges"`
+	Protect     SheetsProtectCmd           `cmd:"" name:"protect" help:"List and manage protected ranges"`
+	Validation  SheetsValidationCmd        `cmd:"" name:"validation" help:"Set, clear and inspect data validation"`
+	CondFormat  SheetsConditionalFormatCmd `cmd:"" name:"conditional-format" aliases:"cf" help:"Add, list and delete conditional format rules"`
+	Chart       SheetsChartCmd             `cmd:"" name:"chart" aliases:"charts" help:"Create, list and delete charts"`
+	Diff        SheetsDiffCmd              `cmd:"" name:"diff" help:"Compare cell values between spreadsheets or snapshots"`
+	Snapshot    SheetsSnapshotCmd          `cmd:"" name:"snapshot" help:"Save a range as a local JSON snapshot"`
+	Metadata    SheetsMetadataCmd          `cmd:"" name:"metadata" help:"Get spreadsheet metadata"`
+	Create      SheetsCreateCmd            `cmd:"" name:"create" help:"Create a new spreadsheet"`
+	Copy        SheetsCopyCmd              `cmd:"" name:"copy" help:"Copy a Google Sheet"`
+	Export      SheetsExportCmd            `cmd:"" name:"export" help:"Export a Google Sheet (pdf|xlsx|csv) via Drive"`
 }
 
 type SheetsExportCmd struct {
@@ -70,6 +85,8 @@ type SheetsGetCmd struct {
 	Range             string `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B10)"`
 	MajorDimension    string `name:"dimension" help:"Major dimension: ROWS or COLUMNS"`
 	ValueRenderOption string `name:"render" help:"Value render option: FORMATTED_VALUE, UNFORMATTED_VALUE, or FORMULA"`
+	Records           bool   `name:"records" help:"Return rows as objects keyed by the header row (typed values, dates as ISO strings)"`
+	RawDates          bool   `name:"raw-dates" help:"With --records, keep date cells as spreadsheet serial numbers"`
 }
 
 func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -87,12 +104,26 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if strings.TrimSpace(rangeSpec) == "" {
 		return usage("empty range")
 	}
+	if c.Records {
+		if dim := strings.TrimSpace(c.MajorDimension); dim != "" && !strings.EqualFold(dim, "ROWS") {
+			return usage("--records requires --dimension ROWS")
+		}
+		if render := strings.TrimSpace(c.ValueRenderOption); render != "" && !strings.EqualFold(render, "UNFORMATTED_VALUE") {
+			return usage("--records always uses UNFORMATTED_VALUE; drop --render")
+		}
+	} else if c.RawDates {
+		return usage("--raw-dates requires --records")
+	}
 
 	svc, err := newSheetsService(ctx, account)
 	if err != nil {
 		return err
 	}
 
+	if c.Records {
+		return c.runRecords(ctx, svc, spreadsheetID, rangeSpec)
+	}
+
 	call := svc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec)
 	if strings.TrimSpace(c.MajorDimension) != "" {
 		call = call.MajorDimension(c.MajorDimension)
@@ -130,6 +161,92 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (c *SheetsGetCmd) runRecords(ctx context.Context, svc *sheets.Service, spreadsheetID, rangeSpec string) error {
+	u := ui.FromContext(ctx)
+
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec).
+		ValueRenderOption("UNFORMATTED_VALUE").
+		DateTimeRenderOption("SERIAL_NUMBER").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	startCol := 1
+	if r, parseErr := parseA1Range(resp.Range); parseErr == nil {
+		startCol = r.StartCol
+	}
+
+	// Date cells come back as serial numbers; the number format type tells
+	// which of them are dates.
+	var formats [][]string
+	if !c.RawDates && len(resp.Values) > 1 {
+		formats, err = fetchSheetsNumberFormatTypes(ctx, svc, spreadsheetID, rangeSpec)
+		if err != nil {
+			return err
+		}
+	}
+
+	columns, records := sheetsValuesToRecords(resp.Values, formats, startCol)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"range":   resp.Range,
+			"columns": columns,
+			"records": records,
+		})
+	}
+
+	if len(records) == 0 {
+		u.Err().Println("No data found")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, rec := range records {
+		cells := make([]string, len(columns))
+		for i, name := range columns {
+			if v := rec[name]; v != nil {
+				cells[i] = fmt.Sprintf("%v", v)
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	_ = tw.Flush()
+	return nil
+}
+
+// fetchSheetsNumberFormatTypes returns the effective number format type of
+// every cell in rangeSpec, aligned with a values.get of the same range.
+func fetchSheetsNumberFormatTypes(ctx context.Context, svc *sheets.Service, spreadsheetID, rangeSpec string) ([][]string, error) {
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Ranges(rangeSpec).
+		Fields("sheets(data(rowData(values(effectiveFormat/numberFormat/type))))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("get number formats: %w", err)
+	}
+
+	var out [][]string
+	for _, sheet := range resp.Sheets {
+		for _, data := range sheet.Data {
+			for _, row := range data.RowData {
+				types := make([]string, len(row.Values))
+				for i, cell := range row.Values {
+					if cell != nil && cell.EffectiveFormat != nil && cell.EffectiveFormat.NumberFormat != nil {
+						types[i] = cell.EffectiveFormat.NumberFormat.Type
+					}
+				}
+				out = append(out, types)
+			}
+		}
+	}
+	return out, nil
+}
+
 type SheetsUpdateCmd struct {
 	SpreadsheetID      string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Range              string   `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B2)"`
@@ -155,27 +272,9 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty range")
 	}
 
-	var values [][]interface{}
-
-	switch {
-	case strings.TrimSpace(c.ValuesJSON) != "":
-		if unmarshalErr := json.Unmarshal([]byte(c.ValuesJSON), &values); unmarshalErr != nil {
-			return fmt.Errorf("invalid JSON values: %w", unmarshalErr)
-		}
-	case len(c.Values) > 0:
-		// Parse comma-separated rows, pipe-separated cells
-		rawValues := strings.Join(c.Values, " ")
-		rows := strings.Split(rawValues, ",")
-		for _, row := range rows {
-			cells := strings.Split(strings.TrimSpace(row), "|")
-			rowData := make([]interface{}, len(cells))
-			for i, cell := range cells {
-				rowData[i] = strings.TrimSpace(cell)
-			}
-			values = append(values, rowData)
-		}
-	default:
-		return fmt.Errorf("provide values as args or via --values-json")
+	values, err := parseSheetsValuesInput(c.Values, c.ValuesJSON)
+	if err != nil {
+		return err
 	}
 
 	svc, err := newSheetsService(ctx, account)
@@ -247,26 +346,9 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return usage("empty range")
 	}
 
-	var values [][]interface{}
-
-	switch {
-	case strings.TrimSpace(c.ValuesJSON) != "":
-		if unmarshalErr := json.Unmarshal([]byte(c.ValuesJSON), &values); unmarshalErr != nil {
-			return fmt.Errorf("invalid JSON values: %w", unmarshalErr)
-		}
-	case len(c.Values) > 0:
-		rawValues := strings.Join(c.Values, " ")
-		rows := strings.Split(rawValues, ",")
-		for _, row := range rows {
-			cells := strings.Split(strings.TrimSpace(row), "|")
-			rowData := make([]interface{}, len(cells))
-			for i, cell := range cells {
-				rowData[i] = strings.TrimSpace(cell)
-			}
-			values = append(values, rowData)
-		}
-	default:
-		return fmt.Errorf("provide values as args or via --values-json")
+	values, err := parseSheetsValuesInput(c.Values, c.ValuesJSON)
+	if err != nil {
+		return err
 	}
 
 	svc, err := newSheetsService(ctx, account)
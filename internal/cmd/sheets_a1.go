@@ -134,3 +134,96 @@ func colLettersToIndex(letters string) (int, error) {
 	}
 	return col, nil
 }
+
+func colIndexToLetters(col int) string {
+	if col <= 0 {
+		return ""
+	}
+	var out []byte
+	for col > 0 {
+		col--
+		out = append([]byte{byte('A' + col%26)}, out...)
+		col /= 26
+	}
+	return string(out)
+}
+
+// quoteSheetName quotes a sheet title for use in an A1 range when needed.
+func quoteSheetName(name string) string {
+	if name == "" {
+		return ""
+	}
+	simple := true
+	for _, r := range name {
+		if !(r == '_' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')) {
+			simple = false
+			break
+		}
+	}
+	if simple && (name[0] < '0' || name[0] > '9') {
+		return name
+	}
+	return "'" + strings.ReplaceAll(name, "'", "''") + "'"
+}
+
+// formatA1Range builds a sheet-qualified A1 range from 1-based row/column bounds.
+func formatA1Range(sheetName string, startRow, startCol, endRow, endCol int) string {
+	ref := fmt.Sprintf("%s%d:%s%d", colIndexToLetters(startCol), startRow, colIndexToLetters(endCol), endRow)
+	if sheetName == "" {
+		return ref
+	}
+	return quoteSheetName(sheetName) + "!" + ref
+}
+
+var (
+	a1RowRe = regexp.MustCompile(`^[0-9]+$`)
+	a1ColRe = regexp.MustCompile(`^[A-Za-z]+$`)
+)
+
+// parseA1Span parses whole-row (Sheet1!5:7) or whole-column (Sheet1!B:D)
+// references into a sheet name and 1-based inclusive bounds. dimension is
+// ROWS or COLUMNS.
+func parseA1Span(spec, dimension string) (string, int, int, error) {
+	raw := cleanRange(strings.TrimSpace(spec))
+	sheetName, part, err := splitA1Sheet(raw)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if sheetName == "" {
+		example := "5:7"
+		if dimension == "COLUMNS" {
+			example = "B:D"
+		}
+		return "", 0, 0, fmt.Errorf("%q must include a sheet name (eg. Sheet1!%s)", raw, example)
+	}
+
+	parts := strings.Split(strings.ReplaceAll(part, "$", ""), ":")
+	if len(parts) > 2 {
+		return "", 0, 0, fmt.Errorf("invalid span %q", raw)
+	}
+	bounds := make([]int, len(parts))
+	for i, p := range parts {
+		p = strings.TrimSpace(p)
+		switch {
+		case dimension == "ROWS" && a1RowRe.MatchString(p):
+			n, convErr := strconv.Atoi(p)
+			if convErr != nil || n <= 0 {
+				return "", 0, 0, fmt.Errorf("invalid row %q in %q", p, raw)
+			}
+			bounds[i] = n
+		case dimension == "COLUMNS" && a1ColRe.MatchString(p):
+			n, convErr := colLettersToIndex(p)
+			if convErr != nil {
+				return "", 0, 0, convErr
+			}
+			bounds[i] = n
+		default:
+			return "", 0, 0, fmt.Errorf("invalid %s span %q", strings.ToLower(dimension), raw)
+		}
+	}
+	start, end := bounds[0], bounds[len(bounds)-1]
+	if end < start {
+		start, end = end, start
+	}
+	return sheetName, start, end, nil
+}
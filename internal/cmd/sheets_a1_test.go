@@ -49,3 +49,21 @@ func TestParseA1Range(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatA1Range(t *testing.T) {
+	for col, want := range map[int]string{1: "A", 26: "Z", 27: "AA", 28: "AB", 702: "ZZ", 703: "AAA"} {
+		if got := colIndexToLetters(col); got != want {
+			t.Fatalf("colIndexToLetters(%d) = %q, want %q", col, got, want)
+		}
+	}
+	if got := formatA1Range("Sheet1", 2, 1, 2, 3); got != "Sheet1!A2:C2" {
+		t.Fatalf("unexpected range: %q", got)
+	}
+	if got := formatA1Range("Bob's Sheet", 1, 27, 5, 28); got != "'Bob''s Sheet'!AA1:AB5" {
+		t.Fatalf("unexpected quoted range: %q", got)
+	}
+	r, err := parseA1Range(formatA1Range("My Sheet", 3, 4, 9, 6))
+	if err != nil || r.SheetName != "My Sheet" || r.StartRow != 3 || r.EndCol != 6 {
+		t.Fatalf("round trip failed: %#v %v", r, err)
+	}
+}
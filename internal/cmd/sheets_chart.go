@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsChartCmd struct {
+	List   SheetsChartListCmd   `cmd:"" name:"list" default:"withargs" help:"List embedded charts"`
+	Create SheetsChartCreateCmd `cmd:"" name:"create" aliases:"add" help:"Create a chart from a data range"`
+	Delete SheetsChartDeleteCmd `cmd:"" name:"delete" aliases:"rm" help:"Delete a chart"`
+}
+
+type SheetsChartListCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+}
+
+func (c *SheetsChartListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets(properties(sheetId,title),charts)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	type chartItem struct {
+		ChartID int64                 `json:"chartId"`
+		Tab     string                `json:"tab"`
+		Type    string                `json:"type"`
+		Title   string                `json:"title"`
+		Chart   *sheets.EmbeddedChart `json:"chart"`
+	}
+	items := []chartItem{}
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties == nil {
+			continue
+		}
+		for _, chart := range sheet.Charts {
+			item := chartItem{ChartID: chart.ChartId, Tab: sheet.Properties.Title, Chart: chart}
+			if chart.Spec != nil {
+				item.Title = chart.Spec.Title
+				item.Type = sheetsChartType(chart.Spec)
+			}
+			items = append(items, item)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"charts":        items,
+		})
+	}
+
+	if len(items) == 0 {
+		u.Err().Println("No charts")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTAB\tTYPE\tTITLE")
+	for _, item := range items {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", item.ChartID, item.Tab, orDash(item.Type), orDash(item.Title))
+	}
+	return nil
+}
+
+func sheetsChartType(spec *sheets.ChartSpec) string {
+	switch {
+	case spec.BasicChart != nil:
+		return strings.ToLower(spec.BasicChart.ChartType)
+	case spec.PieChart != nil:
+		return "pie"
+	default:
+		return "other"
+	}
+}
+
+type SheetsChartCreateCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Type          string   `name:"type" help:"Chart type: line|bar|column|area|scatter|pie" enum:"line,bar,column,area,scatter,pie" default:"line"`
+	Data          string   `name:"data" required:"" help:"Sheet-qualified data range; first column is the domain (eg. Sheet1!A1:C20)"`
+	Anchor        string   `name:"anchor" help:"Top-left cell for the chart (eg. E2 or Dashboard!B2); omit to put the chart on its own sheet"`
+	Title         string   `name:"title" help:"Chart title"`
+	Subtitle      string   `name:"subtitle" help:"Chart subtitle"`
+	XTitle        string   `name:"x-title" help:"Domain (category) axis title"`
+	YTitle        string   `name:"y-title" help:"Value axis title"`
+	Domain        string   `name:"domain" help:"Domain column letter (default: first column of --data)"`
+	Series        []string `name:"series" help:"Series column letters to plot (default: every other column of --data)"`
+	Headers       int64    `name:"headers" help:"Header rows at the top of --data (series names)" default:"1"`
+	Legend        string   `name:"legend" help:"Legend position: bottom|top|left|right|none" enum:"bottom,top,left,right,none" default:"bottom"`
+	Stacked       bool     `name:"stacked" help:"Stack series (bar, column, area)"`
+	Donut         bool     `name:"donut" help:"With --type pie, draw a donut"`
+	Width         int64    `name:"width" help:"Width in pixels" default:"600"`
+	Height        int64    `name:"height" help:"Height in pixels" default:"371"`
+}
+
+func (c *SheetsChartCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Headers < 0 {
+		return usage("--headers must be >= 0")
+	}
+	if c.Width <= 0 || c.Height <= 0 {
+		return usage("--width and --height must be > 0")
+	}
+	if c.Donut && c.Type != "pie" {
+		return usage("--donut only applies to --type pie")
+	}
+	if c.Stacked && c.Type != "bar" && c.Type != "column" && c.Type != "area" {
+		return usage("--stacked only applies to --type bar, column or area")
+	}
+
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	data, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, c.Data)
+	if err != nil {
+		return err
+	}
+
+	spec, err := c.spec(data)
+	if err != nil {
+		return err
+	}
+	position, err := c.position(ctx, svc, spreadsheetID, data.SheetId)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		AddChart: &sheets.AddChartRequest{Chart: &sheets.EmbeddedChart{Spec: spec, Position: position}},
+	})
+	if err != nil {
+		return err
+	}
+	var chartID int64
+	if len(resp.Replies) > 0 && resp.Replies[0].AddChart != nil && resp.Replies[0].AddChart.Chart != nil {
+		chartID = resp.Replies[0].AddChart.Chart.ChartId
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"chartId":       chartID,
+			"type":          c.Type,
+			"data":          cleanRange(c.Data),
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Created %s chart", c.Type)
+	u.Out().Printf("id\t%d", chartID)
+	u.Out().Printf("data\t%s", cleanRange(c.Data))
+	return nil
+}
+
+func (c *SheetsChartCreateCmd) spec(data *sheets.GridRange) (*sheets.ChartSpec, error) {
+	domainCol := data.StartColumnIndex
+	if strings.TrimSpace(c.Domain) != "" {
+		col, err := c.column(data, c.Domain)
+		if err != nil {
+			return nil, err
+		}
+		domainCol = col
+	}
+	var seriesCols []int64
+	for _, raw := range c.Series {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		col, err := c.column(data, raw)
+		if err != nil {
+			return nil, err
+		}
+		seriesCols = append(seriesCols, col)
+	}
+	if len(seriesCols) == 0 {
+		for col := data.StartColumnIndex; col < data.EndColumnIndex; col++ {
+			if col != domainCol {
+				seriesCols = append(seriesCols, col)
+			}
+		}
+	}
+	if len(seriesCols) == 0 {
+		return nil, usage("--data needs at least one series column besides the domain")
+	}
+
+	spec := &sheets.ChartSpec{
+		Title:    strings.TrimSpace(c.Title),
+		Subtitle: strings.TrimSpace(c.Subtitle),
+	}
+	legend := strings.ToUpper(c.Legend) + "_LEGEND"
+	if c.Legend == "none" {
+		legend = "NO_LEGEND"
+	}
+
+	if c.Type == "pie" {
+		if len(seriesCols) != 1 {
+			return nil, usage("--type pie takes exactly one series column (use --series)")
+		}
+		// Pie charts have no header count, so skip header rows in the sources.
+		spec.PieChart = &sheets.PieChartSpec{
+			Domain:         &sheets.ChartData{SourceRange: sheetsChartColumnSource(data, domainCol, c.Headers)},
+			Series:         &sheets.ChartData{SourceRange: sheetsChartColumnSource(data, seriesCols[0], c.Headers)},
+			LegendPosition: legend,
+		}
+		if c.Donut {
+			spec.PieChart.PieHole = 0.5
+		}
+		return spec, nil
+	}
+
+	// Bar charts are horizontal: the domain runs along the left axis.
+	domainAxis, valueAxis := "BOTTOM_AXIS", "LEFT_AXIS"
+	if c.Type == "bar" {
+		domainAxis, valueAxis = "LEFT_AXIS", "BOTTOM_AXIS"
+	}
+	basic := &sheets.BasicChartSpec{
+		ChartType:      strings.ToUpper(c.Type),
+		LegendPosition: legend,
+		HeaderCount:    c.Headers,
+		Domains: []*sheets.BasicChartDomain{{
+			Domain: &sheets.ChartData{SourceRange: sheetsChartColumnSource(data, domainCol, 0)},
+		}},
+	}
+	if c.Stacked {
+		basic.StackedType = "STACKED"
+	}
+	for _, col := range seriesCols {
+		basic.Series = append(basic.Series, &sheets.BasicChartSeries{
+			Series:     &sheets.ChartData{SourceRange: sheetsChartColumnSource(data, col, 0)},
+			TargetAxis: valueAxis,
+		})
+	}
+	if t := strings.TrimSpace(c.XTitle); t != "" {
+		basic.Axis = append(basic.Axis, &sheets.BasicChartAxis{Position: domainAxis, Title: t})
+	}
+	if t := strings.TrimSpace(c.YTitle); t != "" {
+		basic.Axis = append(basic.Axis, &sheets.BasicChartAxis{Position: valueAxis, Title: t})
+	}
+	spec.BasicChart = basic
+	return spec, nil
+}
+
+// column resolves a column letter that must fall inside the data range.
+func (c *SheetsChartCreateCmd) column(data *sheets.GridRange, letters string) (int64, error) {
+	idx, err := colLettersToIndex(strings.TrimSpace(letters))
+	if err != nil {
+		return 0, usagef("invalid column %q", letters)
+	}
+	col := int64(idx - 1)
+	if col < data.StartColumnIndex || col >= data.EndColumnIndex {
+		return 0, usagef("column %s is outside --data", strings.ToUpper(strings.TrimSpace(letters)))
+	}
+	return col, nil
+}
+
+func (c *SheetsChartCreateCmd) position(ctx context.Context, svc *sheets.Service, spreadsheetID string, dataSheetID int64) (*sheets.EmbeddedObjectPosition, error) {
+	anchor := cleanRange(strings.TrimSpace(c.Anchor))
+	if anchor == "" {
+		return &sheets.EmbeddedObjectPosition{NewSheet: true}, nil
+	}
+	sheetName, cell, err := splitA1Sheet(anchor)
+	if err != nil {
+		return nil, usage(err.Error())
+	}
+	col, row, err := parseA1Cell(strings.ReplaceAll(cell, "$", ""))
+	if err != nil {
+		return nil, usagef("invalid --anchor %q: %v", c.Anchor, err)
+	}
+	sheetID := dataSheetID
+	if sheetName != "" {
+		ids, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
+		if err != nil {
+			return nil, err
+		}
+		id, ok := ids[sheetName]
+		if !ok {
+			return nil, usagef("tab %q not found", sheetName)
+		}
+		sheetID = id
+	}
+	return &sheets.EmbeddedObjectPosition{
+		OverlayPosition: &sheets.OverlayPosition{
+			AnchorCell: &sheets.GridCoordinate{
+				SheetId:         sheetID,
+				RowIndex:        int64(row - 1),
+				ColumnIndex:     int64(col - 1),
+				ForceSendFields: []string{"SheetId", "RowIndex", "ColumnIndex"},
+			},
+			WidthPixels:  c.Width,
+			HeightPixels: c.Height,
+		},
+	}, nil
+}
+
+func sheetsChartColumnSource(data *sheets.GridRange, col, skipRows int64) *sheets.ChartSourceRange {
+	g := &sheets.GridRange{
+		SheetId:          data.SheetId,
+		StartRowIndex:    data.StartRowIndex + skipRows,
+		EndRowIndex:      data.EndRowIndex,
+		StartColumnIndex: col,
+		EndColumnIndex:   col + 1,
+		ForceSendFields:  []string{"SheetId"},
+	}
+	return &sheets.ChartSourceRange{Sources: []*sheets.GridRange{g}}
+}
+
+type SheetsChartDeleteCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	ChartID       string `arg:"" name:"chartId" help:"Chart ID (see chart list)"`
+}
+
+func (c *SheetsChartDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.ChartID), 10, 64)
+	if err != nil {
+		return usagef("invalid chartId %q", c.ChartID)
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete chart %d", id)); err != nil {
+		return err
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		DeleteEmbeddedObject: &sheets.DeleteEmbeddedObjectRequest{ObjectId: id, ForceSendFields: []string{"ObjectId"}},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"deleted":       true,
+			"chartId":       id,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Deleted chart %d", id)
+	return nil
+}
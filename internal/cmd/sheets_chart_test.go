@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// newSheetsChartTestService serves one line chart (id 555, "KPIs") on Data
+// and answers addChart with id 777.
+func newSheetsChartTestService(t *testing.T) *[]map[string]any {
+	t.Helper()
+	return newSheetsSpreadsheetTestService(t, map[string]any{
+		"sheets": []any{
+			map[string]any{
+				"properties": map[string]any{"sheetId": 0, "title": "Data", "index": 0, "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26, "frozenRowCount": 1}},
+				"charts":     []any{map[string]any{"chartId": 555, "spec": map[string]any{"title": "KPIs", "basicChart": map[string]any{"chartType": "LINE"}}}},
+			},
+			map[string]any{"properties": map[string]any{"sheetId": 7, "title": "Archive", "index": 1, "hidden": true}},
+		},
+	}, map[string]any{
+		"addChart": map[string]any{"chart": map[string]any{"chartId": 777}},
+	})
+}
+
+func TestSheetsChart_Commands(t *testing.T) {
+	batches := newSheetsChartTestService(t)
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsChartListCmd{}, []string{"s1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "555") || !strings.Contains(out, "KPIs") || !strings.Contains(out, "line") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsChartCreateCmd{}, []string{"s1", "--type", "bar", "--data", "Data!A1:C20", "--anchor", "E2", "--title", "Weekly", "--x-title", "Week", "--y-title", "Signups", "--stacked"}, ctx, flags); err != nil {
+		t.Fatalf("create bar: %v", err)
+	}
+	chart := lastSheetsRequest(t, batches)["addChart"].(map[string]any)["chart"].(map[string]any)
+	spec := chart["spec"].(map[string]any)
+	basic := spec["basicChart"].(map[string]any)
+	if spec["title"] != "Weekly" || basic["chartType"] != "BAR" || basic["headerCount"] != float64(1) || basic["stackedType"] != "STACKED" {
+		t.Fatalf("unexpected spec: %v", spec)
+	}
+	series := basic["series"].([]any)
+	if len(series) != 2 || series[0].(map[string]any)["targetAxis"] != "BOTTOM_AXIS" {
+		t.Fatalf("unexpected series: %v", series)
+	}
+	src := series[1].(map[string]any)["series"].(map[string]any)["sourceRange"].(map[string]any)["sources"].([]any)[0].(map[string]any)
+	if src["startColumnIndex"] != float64(2) || src["endColumnIndex"] != float64(3) || src["endRowIndex"] != float64(20) {
+		t.Fatalf("unexpected series source: %v", src)
+	}
+	axis := basic["axis"].([]any)
+	if axis[0].(map[string]any)["position"] != "LEFT_AXIS" || axis[0].(map[string]any)["title"] != "Week" {
+		t.Fatalf("expected domain title on the left axis of a bar chart, got %v", axis)
+	}
+	anchor := chart["position"].(map[string]any)["overlayPosition"].(map[string]any)["anchorCell"].(map[string]any)
+	if anchor["sheetId"] != float64(0) || anchor["rowIndex"] != float64(1) || anchor["columnIndex"] != float64(4) {
+		t.Fatalf("unexpected anchor: %v", anchor)
+	}
+	if !strings.Contains(textOut.String(), "id\t777") {
+		t.Fatalf("unexpected output: %q", textOut.String())
+	}
+
+	if err := runKong(t, &SheetsChartCreateCmd{}, []string{"s1", "--type", "pie", "--data", "Data!A1:C10", "--series", "C", "--legend", "right", "--donut"}, ctx, flags); err != nil {
+		t.Fatalf("create pie: %v", err)
+	}
+	chart = lastSheetsRequest(t, batches)["addChart"].(map[string]any)["chart"].(map[string]any)
+	pie := chart["spec"].(map[string]any)["pieChart"].(map[string]any)
+	src = pie["series"].(map[string]any)["sourceRange"].(map[string]any)["sources"].([]any)[0].(map[string]any)
+	if pie["legendPosition"] != "RIGHT_LEGEND" || pie["pieHole"] != 0.5 || src["startColumnIndex"] != float64(2) || src["startRowIndex"] != float64(1) {
+		t.Fatalf("unexpected pie: %v", pie)
+	}
+	if chart["position"].(map[string]any)["newSheet"] != true {
+		t.Fatalf("expected chart on a new sheet, got %v", chart["position"])
+	}
+
+	if err := runKong(t, &SheetsChartDeleteCmd{}, []string{"s1", "555"}, ctx, flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if del := lastSheetsRequest(t, batches)["deleteEmbeddedObject"].(map[string]any); del["objectId"] != float64(555) {
+		t.Fatalf("unexpected delete: %v", del)
+	}
+
+	for want, args := range map[string][]string{
+		"exactly one series":   {"--type", "pie", "--data", "Data!A1:C10"},
+		"outside --data":       {"--data", "Data!A1:C10", "--series", "F"},
+		"--donut only":         {"--data", "Data!A1:C10", "--donut"},
+		"at least one series":  {"--data", "Data!A1:A10"},
+		`tab "Nope" not found`: {"--data", "Data!A1:C10", "--anchor", "Nope!A1"},
+	} {
+		if err := runKong(t, &SheetsChartCreateCmd{}, append([]string{"s1"}, args...), ctx, flags); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%v: expected %q error, got %v", args, want, err)
+		}
+	}
+}
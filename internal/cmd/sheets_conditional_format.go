@@ -0,0 +1,485 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsConditionalFormatCmd struct {
+	List   SheetsConditionalFormatListCmd   `cmd:"" name:"list" default:"withargs" help:"List conditional format rules"`
+	Add    SheetsConditionalFormatAddCmd    `cmd:"" name:"add" help:"Add a conditional format rule"`
+	Delete SheetsConditionalFormatDeleteCmd `cmd:"" name:"delete" aliases:"rm" help:"Delete a conditional format rule by tab and index"`
+}
+
+type SheetsConditionalFormatListCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `name:"tab" help:"Only show rules on this tab"`
+}
+
+type sheetsConditionalFormatItem struct {
+	Tab     string                        `json:"tab"`
+	SheetID int64                         `json:"sheetId"`
+	Index   int                           `json:"index"`
+	Ranges  []string                      `json:"ranges"`
+	Kind    string                        `json:"kind"`
+	Rule    *sheets.ConditionalFormatRule `json:"rule"`
+}
+
+func (c *SheetsConditionalFormatListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets(properties(sheetId,title),conditionalFormats)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	tab := strings.TrimSpace(c.Tab)
+	items := []sheetsConditionalFormatItem{}
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties == nil || (tab != "" && sheet.Properties.Title != tab) {
+			continue
+		}
+		for i, rule := range sheet.ConditionalFormats {
+			item := sheetsConditionalFormatItem{
+				Tab:     sheet.Properties.Title,
+				SheetID: sheet.Properties.SheetId,
+				Index:   i,
+				Kind:    "boolean",
+				Rule:    rule,
+			}
+			if rule.GradientRule != nil {
+				item.Kind = "gradient"
+			}
+			for _, g := range rule.Ranges {
+				item.Ranges = append(item.Ranges, formatGridRangeA1(sheet.Properties.Title, g))
+			}
+			items = append(items, item)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"rules":         items,
+		})
+	}
+
+	if len(items) == 0 {
+		u.Err().Println("No conditional format rules")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "TAB\tINDEX\tRANGES\tKIND\tRULE")
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", item.Tab, item.Index, strings.Join(item.Ranges, ","), item.Kind, describeSheetsConditionalRule(item.Rule))
+	}
+	return nil
+}
+
+type SheetsConditionalFormatAddCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Ranges        []string `arg:"" name:"range" help:"Sheet-qualified A1 range(s) on the same tab (eg. Sheet1!B2:B100)"`
+	Formula       string   `name:"formula" help:"Custom formula rule, relative to the top-left cell (eg. '=$C2>100')"`
+	Condition     string   `name:"condition" help:"Built-in rule: gt|gte|lt|lte|eq|ne|between|not-between|contains|not-contains|starts-with|ends-with|text-eq|blank|not-blank|date-before|date-after|date-eq"`
+	Values        []string `name:"values" help:"Operand(s) for --condition; dates accept YYYY-MM-DD or today|yesterday|tomorrow|past-week|past-month|past-year"`
+	Scale         []string `name:"scale" help:"Color scale points min|max|N%|N followed by :#RRGGBB (eg. min:#F8696B,50%:#FFEB84,max:#63BE7B)"`
+	Background    string   `name:"bg" help:"Background color (#RRGGBB) for matching cells"`
+	Foreground    string   `name:"fg" help:"Text color (#RRGGBB) for matching cells"`
+	Bold          bool     `name:"bold" help:"Bold text for matching cells"`
+	Italic        bool     `name:"italic" help:"Italic text for matching cells"`
+	Strikethrough bool     `name:"strikethrough" help:"Strike through matching cells"`
+	FormatJSON    string   `name:"format-json" help:"Format for matching cells as JSON (Sheets API CellFormat; bold, italic, strikethrough, colors)"`
+	Index         int64    `name:"index" help:"Position in the tab's rule list (0 = highest priority)" default:"0"`
+}
+
+var sheetsConditionalConditions = map[string]string{
+	"gt":           "NUMBER_GREATER",
+	"gte":          "NUMBER_GREATER_THAN_EQ",
+	"lt":           "NUMBER_LESS",
+	"lte":          "NUMBER_LESS_THAN_EQ",
+	"eq":           "NUMBER_EQ",
+	"ne":           "NUMBER_NOT_EQ",
+	"between":      "NUMBER_BETWEEN",
+	"not-between":  "NUMBER_NOT_BETWEEN",
+	"contains":     "TEXT_CONTAINS",
+	"not-contains": "TEXT_NOT_CONTAINS",
+	"starts-with":  "TEXT_STARTS_WITH",
+	"ends-with":    "TEXT_ENDS_WITH",
+	"text-eq":      "TEXT_EQ",
+	"blank":        "BLANK",
+	"not-blank":    "NOT_BLANK",
+	"date-before":  "DATE_BEFORE",
+	"date-after":   "DATE_AFTER",
+	"date-eq":      "DATE_EQ",
+}
+
+var sheetsRelativeDates = map[string]string{
+	"today":      "TODAY",
+	"yesterday":  "YESTERDAY",
+	"tomorrow":   "TOMORROW",
+	"past-week":  "PAST_WEEK",
+	"past-month": "PAST_MONTH",
+	"past-year":  "PAST_YEAR",
+}
+
+func (c *SheetsConditionalFormatAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	rule, err := c.rule()
+	if err != nil {
+		return err
+	}
+	if c.Index < 0 {
+		return usage("--index must be >= 0")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range c.Ranges {
+		grid, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, raw)
+		if err != nil {
+			return err
+		}
+		if len(rule.Ranges) > 0 && grid.SheetId != rule.Ranges[0].SheetId {
+			return usage("all ranges of a conditional format rule must be on the same tab")
+		}
+		rule.Ranges = append(rule.Ranges, grid)
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		AddConditionalFormatRule: &sheets.AddConditionalFormatRuleRequest{
+			Rule:            rule,
+			Index:           c.Index,
+			ForceSendFields: []string{"Index"},
+		},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"index":         c.Index,
+			"rule":          rule,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Added conditional format on %s", strings.Join(c.Ranges, ","))
+	u.Out().Printf("index\t%d", c.Index)
+	u.Out().Printf("rule\t%s", describeSheetsConditionalRule(rule))
+	return nil
+}
+
+func (c *SheetsConditionalFormatAddCmd) rule() (*sheets.ConditionalFormatRule, error) {
+	modes := 0
+	for _, set := range []bool{strings.TrimSpace(c.Formula) != "", strings.TrimSpace(c.Condition) != "", len(c.Scale) > 0} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return nil, usage("provide exactly one of --formula, --condition or --scale")
+	}
+
+	if len(c.Scale) > 0 {
+		if c.Background != "" || c.Foreground != "" || c.Bold || c.Italic || c.Strikethrough || c.FormatJSON != "" || len(c.Values) > 0 {
+			return nil, usage("--scale cannot be combined with --values or cell format flags")
+		}
+		gradient, err := parseSheetsColorScale(c.Scale)
+		if err != nil {
+			return nil, err
+		}
+		return &sheets.ConditionalFormatRule{GradientRule: gradient}, nil
+	}
+
+	cond, err := c.condition()
+	if err != nil {
+		return nil, err
+	}
+	format, err := c.format()
+	if err != nil {
+		return nil, err
+	}
+	return &sheets.ConditionalFormatRule{
+		BooleanRule: &sheets.BooleanRule{Condition: cond, Format: format},
+	}, nil
+}
+
+func (c *SheetsConditionalFormatAddCmd) condition() (*sheets.BooleanCondition, error) {
+	var values []string
+	for _, v := range c.Values {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	if formula := strings.TrimSpace(c.Formula); formula != "" {
+		if len(values) > 0 {
+			return nil, usage("--values only applies to --condition")
+		}
+		if !strings.HasPrefix(formula, "=") {
+			formula = "=" + formula
+		}
+		return &sheets.BooleanCondition{
+			Type:   "CUSTOM_FORMULA",
+			Values: []*sheets.ConditionValue{{UserEnteredValue: formula}},
+		}, nil
+	}
+
+	op := strings.ToLower(strings.TrimSpace(c.Condition))
+	condType, ok := sheetsConditionalConditions[op]
+	if !ok {
+		return nil, usagef("invalid --condition %q", c.Condition)
+	}
+	want := 1
+	switch op {
+	case "between", "not-between":
+		want = 2
+	case "blank", "not-blank":
+		want = 0
+	}
+	if len(values) != want {
+		return nil, usagef("--condition %s needs %d value(s) in --values, got %d", op, want, len(values))
+	}
+
+	cond := &sheets.BooleanCondition{Type: condType}
+	for _, v := range values {
+		cv := &sheets.ConditionValue{UserEnteredValue: v}
+		if strings.HasPrefix(condType, "NUMBER_") {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, usagef("invalid number %q", v)
+			}
+		}
+		if strings.HasPrefix(condType, "DATE_") {
+			if rel, ok := sheetsRelativeDates[strings.ToLower(v)]; ok {
+				cv = &sheets.ConditionValue{RelativeDate: rel}
+			}
+		}
+		cond.Values = append(cond.Values, cv)
+	}
+	return cond, nil
+}
+
+func (c *SheetsConditionalFormatAddCmd) format() (*sheets.CellFormat, error) {
+	format := &sheets.CellFormat{}
+	if strings.TrimSpace(c.FormatJSON) != "" {
+		if err := json.Unmarshal([]byte(c.FormatJSON), format); err != nil {
+			return nil, fmt.Errorf("invalid format JSON: %w", err)
+		}
+	}
+	if c.Background != "" {
+		color, err := parseSheetsColor(c.Background)
+		if err != nil {
+			return nil, err
+		}
+		format.BackgroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+	}
+	if c.Bold || c.Italic || c.Strikethrough || c.Foreground != "" {
+		if format.TextFormat == nil {
+			format.TextFormat = &sheets.TextFormat{}
+		}
+		format.TextFormat.Bold = format.TextFormat.Bold || c.Bold
+		format.TextFormat.Italic = format.TextFormat.Italic || c.Italic
+		format.TextFormat.Strikethrough = format.TextFormat.Strikethrough || c.Strikethrough
+		if c.Foreground != "" {
+			color, err := parseSheetsColor(c.Foreground)
+			if err != nil {
+				return nil, err
+			}
+			format.TextFormat.ForegroundColorStyle = &sheets.ColorStyle{RgbColor: color}
+		}
+	}
+	if format.BackgroundColor == nil && format.BackgroundColorStyle == nil && format.TextFormat == nil {
+		return nil, usage("boolean rules need a format: --bg, --fg, --bold, --italic, --strikethrough or --format-json")
+	}
+	return format, nil
+}
+
+// parseSheetsColorScale turns 2 or 3 "point:#RRGGBB" specs into a gradient
+// rule. The first point is the minimum, the last the maximum.
+func parseSheetsColorScale(specs []string) (*sheets.GradientRule, error) {
+	var points []*sheets.InterpolationPoint
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		idx := strings.LastIndex(spec, ":")
+		if idx <= 0 {
+			return nil, usagef("invalid --scale point %q (expected min|max|N%%|N:#RRGGBB)", spec)
+		}
+		color, err := parseSheetsColor(spec[idx+1:])
+		if err != nil {
+			return nil, err
+		}
+		point := &sheets.InterpolationPoint{ColorStyle: &sheets.ColorStyle{RgbColor: color}}
+		kind := strings.ToLower(strings.TrimSpace(spec[:idx]))
+		switch {
+		case kind == "min":
+			point.Type = "MIN"
+		case kind == "max":
+			point.Type = "MAX"
+		case strings.HasSuffix(kind, "%"):
+			n := strings.TrimSuffix(kind, "%")
+			if v, err := strconv.ParseFloat(n, 64); err != nil || v < 0 || v > 100 {
+				return nil, usagef("invalid percentile in --scale point %q", spec)
+			}
+			point.Type = "PERCENTILE"
+			point.Value = n
+		default:
+			if _, err := strconv.ParseFloat(kind, 64); err != nil {
+				return nil, usagef("invalid --scale point %q (expected min|max|N%%|N:#RRGGBB)", spec)
+			}
+			point.Type = "NUMBER"
+			point.Value = kind
+		}
+		points = append(points, point)
+	}
+
+	switch len(points) {
+	case 2:
+		return &sheets.GradientRule{Minpoint: points[0], Maxpoint: points[1]}, nil
+	case 3:
+		return &sheets.GradientRule{Minpoint: points[0], Midpoint: points[1], Maxpoint: points[2]}, nil
+	default:
+		return nil, usagef("--scale needs 2 or 3 points, got %d", len(points))
+	}
+}
+
+// parseSheetsColor parses #RRGGBB (the # is optional) into an RGB color.
+func parseSheetsColor(raw string) (*sheets.Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(raw), "#")
+	if len(hex) != 6 {
+		return nil, usagef("invalid color %q (expected #RRGGBB)", raw)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, usagef("invalid color %q (expected #RRGGBB)", raw)
+	}
+	return &sheets.Color{
+		Red:   float64((v>>16)&0xff) / 255,
+		Green: float64((v>>8)&0xff) / 255,
+		Blue:  float64(v&0xff) / 255,
+	}, nil
+}
+
+func formatSheetsColor(style *sheets.ColorStyle) string {
+	if style == nil || style.RgbColor == nil {
+		if style != nil && style.ThemeColor != "" {
+			return style.ThemeColor
+		}
+		return "-"
+	}
+	c := style.RgbColor
+	return fmt.Sprintf("#%02X%02X%02X", int(c.Red*255+0.5), int(c.Green*255+0.5), int(c.Blue*255+0.5))
+}
+
+func describeSheetsConditionalRule(rule *sheets.ConditionalFormatRule) string {
+	if rule == nil {
+		return "-"
+	}
+	if g := rule.GradientRule; g != nil {
+		var parts []string
+		for _, p := range []*sheets.InterpolationPoint{g.Minpoint, g.Midpoint, g.Maxpoint} {
+			if p == nil {
+				continue
+			}
+			label := strings.ToLower(p.Type)
+			if p.Value != "" {
+				label += " " + p.Value
+			}
+			parts = append(parts, label+" "+formatSheetsColor(p.ColorStyle))
+		}
+		return "scale " + strings.Join(parts, " -> ")
+	}
+	if rule.BooleanRule == nil {
+		return "-"
+	}
+	desc := describeSheetsCondition(rule.BooleanRule.Condition)
+	if f := rule.BooleanRule.Format; f != nil {
+		var style []string
+		if f.BackgroundColorStyle != nil {
+			style = append(style, "bg "+formatSheetsColor(f.BackgroundColorStyle))
+		}
+		if t := f.TextFormat; t != nil {
+			if t.ForegroundColorStyle != nil {
+				style = append(style, "fg "+formatSheetsColor(t.ForegroundColorStyle))
+			}
+			if t.Bold {
+				style = append(style, "bold")
+			}
+			if t.Italic {
+				style = append(style, "italic")
+			}
+			if t.Strikethrough {
+				style = append(style, "strikethrough")
+			}
+		}
+		if len(style) > 0 {
+			desc += " (" + strings.Join(style, ", ") + ")"
+		}
+	}
+	return desc
+}
+
+type SheetsConditionalFormatDeleteCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+	Index         int64  `arg:"" name:"index" help:"Rule index on the tab (see conditional-format list)"`
+}
+
+func (c *SheetsConditionalFormatDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Index < 0 {
+		return usage("index must be >= 0")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	tab, err := resolveSheetTab(ctx, svc, spreadsheetID, c.Tab)
+	if err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete conditional format rule %d on %s", c.Index, tab.Title)); err != nil {
+		return err
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		DeleteConditionalFormatRule: &sheets.DeleteConditionalFormatRuleRequest{
+			SheetId:         tab.SheetId,
+			Index:           c.Index,
+			ForceSendFields: []string{"SheetId", "Index"},
+		},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"deleted":       true,
+			"tab":           tab.Title,
+			"index":         c.Index,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Deleted conditional format rule %d on %s", c.Index, tab.Title)
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// newSheetsConditionalFormatTestService serves one red-to-green color scale
+// on Data!B2:B.
+func newSheetsConditionalFormatTestService(t *testing.T) *[]map[string]any {
+	t.Helper()
+	return newSheetsSpreadsheetTestService(t, map[string]any{
+		"sheets": []any{
+			map[string]any{
+				"properties": map[string]any{"sheetId": 0, "title": "Data", "index": 0, "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26, "frozenRowCount": 1}},
+				"conditionalFormats": []any{map[string]any{
+					"ranges":       []any{map[string]any{"sheetId": 0, "startRowIndex": 1, "startColumnIndex": 1, "endColumnIndex": 2}},
+					"gradientRule": map[string]any{"minpoint": map[string]any{"type": "MIN", "colorStyle": map[string]any{"rgbColor": map[string]any{"red": 1}}}, "maxpoint": map[string]any{"type": "MAX", "colorStyle": map[string]any{"rgbColor": map[string]any{"green": 1}}}},
+				}},
+			},
+			map[string]any{"properties": map[string]any{"sheetId": 7, "title": "Archive", "index": 1, "hidden": true}},
+		},
+	}, map[string]any{})
+}
+
+func TestSheetsConditionalFormat_Commands(t *testing.T) {
+	batches := newSheetsConditionalFormatTestService(t)
+	ctx, _ := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsConditionalFormatListCmd{}, []string{"s1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Data!B2:B") || !strings.Contains(out, "scale min #FF0000 -> max #00FF00") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsConditionalFormatAddCmd{}, []string{"s1", "Data!A2:F", "--formula", "$C2>100", "--bg", "#FFC7CE", "--bold"}, ctx, flags); err != nil {
+		t.Fatalf("add formula: %v", err)
+	}
+	add := lastSheetsRequest(t, batches)["addConditionalFormatRule"].(map[string]any)
+	rule := add["rule"].(map[string]any)["booleanRule"].(map[string]any)
+	cond := rule["condition"].(map[string]any)
+	if add["index"] != float64(0) || cond["type"] != "CUSTOM_FORMULA" || cond["values"].([]any)[0].(map[string]any)["userEnteredValue"] != "=$C2>100" {
+		t.Fatalf("unexpected formula rule: %v", add)
+	}
+	format := rule["format"].(map[string]any)
+	bg := format["backgroundColorStyle"].(map[string]any)["rgbColor"].(map[string]any)
+	if bg["red"] != float64(1) || format["textFormat"].(map[string]any)["bold"] != true {
+		t.Fatalf("unexpected format: %v", format)
+	}
+
+	if err := runKong(t, &SheetsConditionalFormatAddCmd{}, []string{"s1", "Data!D2:D", "--condition", "date-before", "--values", "today", "--fg", "9C0006"}, ctx, flags); err != nil {
+		t.Fatalf("add condition: %v", err)
+	}
+	cond = lastSheetsRequest(t, batches)["addConditionalFormatRule"].(map[string]any)["rule"].(map[string]any)["booleanRule"].(map[string]any)["condition"].(map[string]any)
+	if cond["type"] != "DATE_BEFORE" || cond["values"].([]any)[0].(map[string]any)["relativeDate"] != "TODAY" {
+		t.Fatalf("unexpected date condition: %v", cond)
+	}
+
+	if err := runKong(t, &SheetsConditionalFormatAddCmd{}, []string{"s1", "Data!B2:B", "--scale", "min:#F8696B,50%:#FFEB84,max:#63BE7B", "--index", "2"}, ctx, flags); err != nil {
+		t.Fatalf("add scale: %v", err)
+	}
+	add = lastSheetsRequest(t, batches)["addConditionalFormatRule"].(map[string]any)
+	grad := add["rule"].(map[string]any)["gradientRule"].(map[string]any)
+	mid := grad["midpoint"].(map[string]any)
+	if add["index"] != float64(2) || mid["type"] != "PERCENTILE" || mid["value"] != "50" || grad["maxpoint"].(map[string]any)["type"] != "MAX" {
+		t.Fatalf("unexpected gradient: %v", add)
+	}
+
+	if err := runKong(t, &SheetsConditionalFormatDeleteCmd{}, []string{"s1", "Data", "0"}, ctx, flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	del := lastSheetsRequest(t, batches)["deleteConditionalFormatRule"].(map[string]any)
+	if del["sheetId"] != float64(0) || del["index"] != float64(0) {
+		t.Fatalf("expected explicit zero sheetId/index, got %v", del)
+	}
+
+	for want, args := range map[string][]string{
+		"exactly one of":          {"--formula", "=A1", "--condition", "blank", "--bold"},
+		"need a format":           {"--condition", "blank"},
+		"needs 2 value(s)":        {"--condition", "between", "--values", "1", "--bold"},
+		"invalid number":          {"--condition", "gt", "--values", "lots", "--bold"},
+		"2 or 3 points":           {"--scale", "min:#000000"},
+		"invalid color":           {"--scale", "min:#000,max:#FFFFFF"},
+		"cannot be combined":      {"--scale", "min:#000000,max:#FFFFFF", "--bold"},
+		`invalid --condition "x"`: {"--condition", "x", "--bold"},
+	} {
+		if err := runKong(t, &SheetsConditionalFormatAddCmd{}, append([]string{"s1", "Data!A1:A5"}, args...), ctx, flags); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%v: expected %q error, got %v", args, want, err)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// sheetsSnapshot is the on-disk format written by `sheets snapshot` and read
+// back by `sheets diff`.
+type sheetsSnapshot struct {
+	SpreadsheetID string     `json:"spreadsheetId"`
+	Range         string     `json:"range"`
+	TakenAt       string     `json:"takenAt"`
+	Values        [][]string `json:"values"`
+	Formulas      [][]string `json:"formulas,omitempty"`
+}
+
+type SheetsSnapshotCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string `arg:"" name:"range" help:"Range to capture (eg. Pricing!A1:F200 or a tab title)"`
+	Out           string `name:"out" short:"o" required:"" help:"Snapshot file to write (- for stdout)"`
+}
+
+func (c *SheetsSnapshotCmd) Run(ctx context.Context, flags *RootFlags) error {
+	rangeSpec := cleanRange(strings.TrimSpace(c.Range))
+	if rangeSpec == "" {
+		return usage("empty range")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	snap, err := fetchSheetsSnapshot(ctx, svc, spreadsheetID, rangeSpec)
+	if err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+
+	out := strings.TrimSpace(c.Out)
+	if out == "-" {
+		_, err = os.Stdout.Write(payload)
+		return err
+	}
+	if out, err = config.ExpandPath(out); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, payload, 0o600); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         snap.Range,
+			"rows":          len(snap.Values),
+			"path":          out,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Saved %s (%d rows)", snap.Range, len(snap.Values))
+	u.Out().Printf("path\t%s", out)
+	return nil
+}
+
+func fetchSheetsSnapshot(ctx context.Context, svc *sheets.Service, spreadsheetID, rangeSpec string) (*sheetsSnapshot, error) {
+	values, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec).
+		ValueRenderOption("FORMATTED_VALUE").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	formulas, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec).
+		ValueRenderOption("FORMULA").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	resolved := values.Range
+	if resolved == "" {
+		resolved = rangeSpec
+	}
+	return &sheetsSnapshot{
+		SpreadsheetID: spreadsheetID,
+		Range:         resolved,
+		TakenAt:       time.Now().UTC().Format(time.RFC3339),
+		Values:        sheetsStringGrid(values.Values),
+		Formulas:      sheetsStringGrid(formulas.Values),
+	}, nil
+}
+
+func sheetsStringGrid(rows [][]interface{}) [][]string {
+	out := make([][]string, len(rows))
+	for i, row := range rows {
+		out[i] = make([]string, len(row))
+		for j, v := range row {
+			out[i][j] = fmt.Sprint(v)
+		}
+	}
+	return out
+}
+
+type SheetsDiffCmd struct {
+	Left      string `arg:"" name:"a" help:"Base: spreadsheetId[!range] or snapshot.json"`
+	Right     string `arg:"" name:"b" help:"Compared: spreadsheetId[!range] or snapshot.json"`
+	Formulas  bool   `name:"formulas" help:"Also report cells whose formula changed"`
+	ExitCode  bool   `name:"exit-code" help:"Exit with status 1 when differences are found"`
+	MaxReport int    `name:"max" help:"Max changed cells to print in table output (0 = all)" default:"0"`
+}
+
+type sheetsDiffSide struct {
+	Source   string `json:"source"`
+	Range    string `json:"range"`
+	Snapshot bool   `json:"snapshot,omitempty"`
+
+	sheet    string
+	startRow int
+	startCol int
+	values   [][]string
+	formulas [][]string
+}
+
+type sheetsCellChange struct {
+	Cell          string `json:"cell"`
+	CellB         string `json:"cellB,omitempty"`
+	Change        string `json:"change"`
+	Before        string `json:"before"`
+	After         string `json:"after"`
+	BeforeFormula string `json:"beforeFormula,omitempty"`
+	AfterFormula  string `json:"afterFormula,omitempty"`
+}
+
+func (c *SheetsDiffCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	leftSource, leftRange := splitSheetsDiffSpec(c.Left)
+	rightSource, rightRange := splitSheetsDiffSpec(c.Right)
+	if leftSource == "" || rightSource == "" {
+		return usage("both sides need a spreadsheet ID or snapshot file")
+	}
+
+	// Load snapshots first so a live side without a range can borrow theirs.
+	left, right := &sheetsDiffSide{Source: leftSource}, &sheetsDiffSide{Source: rightSource}
+	for _, side := range []struct {
+		s   *sheetsDiffSide
+		rng string
+	}{{left, leftRange}, {right, rightRange}} {
+		if isSheetsSnapshotPath(side.s.Source) {
+			if err := loadSheetsSnapshotSide(side.s, side.rng); err != nil {
+				return err
+			}
+		} else {
+			side.s.Range = side.rng
+		}
+	}
+	if left.Range == "" {
+		left.Range = right.Range
+	}
+	if right.Range == "" {
+		right.Range = left.Range
+	}
+	if left.Range == "" {
+		return usage("provide a range on at least one side (eg. <id>!Sheet1!A1:F100)")
+	}
+
+	var svc *sheets.Service
+	for _, side := range []*sheetsDiffSide{left, right} {
+		if side.Snapshot {
+			continue
+		}
+		if svc == nil {
+			var err error
+			if _, svc, err = sheetsServiceFor(ctx, flags, side.Source); err != nil {
+				return err
+			}
+		}
+		snap, err := fetchSheetsSnapshot(ctx, svc, side.Source, side.Range)
+		if err != nil {
+			return err
+		}
+		side.applySnapshot(snap)
+	}
+
+	changes := diffSheetsSides(left, right, c.Formulas)
+	summary := map[string]int{"changed": 0, "added": 0, "removed": 0}
+	for _, ch := range changes {
+		summary[ch.Change]++
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(os.Stdout, map[string]any{
+			"a":       left,
+			"b":       right,
+			"changes": changes,
+			"summary": summary,
+		}); err != nil {
+			return err
+		}
+	} else if len(changes) == 0 {
+		u.Err().Println("No differences")
+	} else {
+		shown := changes
+		if c.MaxReport > 0 && len(shown) > c.MaxReport {
+			shown = shown[:c.MaxReport]
+		}
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "CELL\tCHANGE\tBEFORE\tAFTER")
+		for _, ch := range shown {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ch.Cell, ch.Change, describeSheetsDiffCell(ch.Before, ch.BeforeFormula), describeSheetsDiffCell(ch.After, ch.AfterFormula))
+		}
+		flush()
+		u.Err().Printf("%d changed, %d added, %d removed", summary["changed"], summary["added"], summary["removed"])
+		if len(shown) < len(changes) {
+			u.Err().Printf("(%d more not shown; use --max 0 or --json)", len(changes)-len(shown))
+		}
+	}
+
+	if c.ExitCode && len(changes) > 0 {
+		return &ExitError{Code: 1, Err: errors.New("sheets differ")}
+	}
+	return nil
+}
+
+// splitSheetsDiffSpec splits "source!range" at the first "!". Spreadsheet IDs
+// never contain "!", so "id!Sheet1!A1:B2" keeps the sheet-qualified range.
+func splitSheetsDiffSpec(spec string) (string, string) {
+	source, rng, _ := strings.Cut(cleanRange(strings.TrimSpace(spec)), "!")
+	return strings.TrimSpace(source), strings.TrimSpace(rng)
+}
+
+func isSheetsSnapshotPath(source string) bool {
+	if strings.HasSuffix(strings.ToLower(source), ".json") {
+		return true
+	}
+	_, err := os.Stat(source)
+	return err == nil
+}
+
+func loadSheetsSnapshotSide(side *sheetsDiffSide, rng string) error {
+	path, err := config.ExpandPath(side.Source)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided path
+	if err != nil {
+		return err
+	}
+	var snap sheetsSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("invalid snapshot %s: %w", side.Source, err)
+	}
+	if rng != "" && rng != snap.Range {
+		return usagef("snapshot %s covers %s; drop the !%s suffix or take a new snapshot", side.Source, snap.Range, rng)
+	}
+	side.Snapshot = true
+	side.applySnapshot(&snap)
+	return nil
+}
+
+func (s *sheetsDiffSide) applySnapshot(snap *sheetsSnapshot) {
+	s.Range = snap.Range
+	s.values = snap.Values
+	s.formulas = snap.Formulas
+	s.startRow, s.startCol = 1, 1
+	sheetName, part, err := splitA1Sheet(snap.Range)
+	if err != nil {
+		return
+	}
+	s.sheet = sheetName
+	start, _, _ := strings.Cut(strings.ReplaceAll(part, "$", ""), ":")
+	if col, row, err := parseA1Bound(start); err == nil {
+		s.startCol = col
+		if row > 0 {
+			s.startRow = row
+		}
+	}
+}
+
+func (s *sheetsDiffSide) cellAddr(r, col int) string {
+	ref := fmt.Sprintf("%s%d", colIndexToLetters(s.startCol+col), s.startRow+r)
+	if s.sheet == "" {
+		return ref
+	}
+	return quoteSheetName(s.sheet) + "!" + ref
+}
+
+func sheetsGridCell(grid [][]string, r, col int) string {
+	if r < len(grid) && col < len(grid[r]) {
+		return grid[r][col]
+	}
+	return ""
+}
+
+// diffSheetsSides compares both grids cell by cell, relative to each side's
+// top-left corner.
+func diffSheetsSides(a, b *sheetsDiffSide, withFormulas bool) []sheetsCellChange {
+	rows := max(len(a.values), len(b.values))
+	if withFormulas {
+		rows = max(rows, len(a.formulas), len(b.formulas))
+	}
+	changes := []sheetsCellChange{}
+	for r := 0; r < rows; r++ {
+		cols := max(len(sheetsGridRow(a.values, r)), len(sheetsGridRow(b.values, r)))
+		if withFormulas {
+			cols = max(cols, len(sheetsGridRow(a.formulas, r)), len(sheetsGridRow(b.formulas, r)))
+		}
+		for col := 0; col < cols; col++ {
+			before, after := sheetsGridCell(a.values, r, col), sheetsGridCell(b.values, r, col)
+			var beforeF, afterF string
+			if withFormulas {
+				beforeF = sheetsFormulaOnly(sheetsGridCell(a.formulas, r, col))
+				afterF = sheetsFormulaOnly(sheetsGridCell(b.formulas, r, col))
+			}
+			if before == after && beforeF == afterF {
+				continue
+			}
+			ch := sheetsCellChange{
+				Cell:          a.cellAddr(r, col),
+				Change:        "changed",
+				Before:        before,
+				After:         after,
+				BeforeFormula: beforeF,
+				AfterFormula:  afterF,
+			}
+			if cellB := b.cellAddr(r, col); cellB != ch.Cell {
+				ch.CellB = cellB
+			}
+			switch {
+			case before == "" && beforeF == "":
+				ch.Change = "added"
+			case after == "" && afterF == "":
+				ch.Change = "removed"
+			}
+			changes = append(changes, ch)
+		}
+	}
+	return changes
+}
+
+func sheetsGridRow(grid [][]string, r int) []string {
+	if r < len(grid) {
+		return grid[r]
+	}
+	return nil
+}
+
+func sheetsFormulaOnly(v string) string {
+	if strings.HasPrefix(v, "=") {
+		return v
+	}
+	return ""
+}
+
+func describeSheetsDiffCell(value, formula string) string {
+	if formula == "" {
+		return orDash(value)
+	}
+	return fmt.Sprintf("%s [%s]", orDash(value), formula)
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+func newSheetsDiffTestService(t *testing.T) {
+	t.Helper()
+	books := map[string]map[string]any{
+		"prod": {
+			"range":    "Pricing!A1:C3",
+			"values":   []any{[]any{"sku", "price", "total"}, []any{"a", "10", "20"}, []any{"b", "5", "10"}},
+			"formulas": []any{[]any{"sku", "price", "total"}, []any{"a", "10", "=B2*2"}, []any{"b", "5", "=B3*2"}},
+		},
+		"draft": {
+			"range":    "Pricing!A1:C4",
+			"values":   []any{[]any{"sku", "price", "total"}, []any{"a", "12", "24"}, []any{"b", "5", "10"}, []any{"c", "7"}},
+			"formulas": []any{[]any{"sku", "price", "total"}, []any{"a", "12", "=B2*2"}, []any{"b", "5", "=B3+B3"}, []any{"c", "7"}},
+		},
+	}
+
+	stubGoogleService(t, &newSheetsService, sheets.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		parts := strings.Split(strings.TrimPrefix(path, "/spreadsheets/"), "/")
+		book, ok := books[parts[0]]
+		if r.Method != http.MethodGet || !ok || len(parts) < 2 || parts[1] != "values" {
+			http.NotFound(w, r)
+			return
+		}
+		values := book["values"]
+		if r.URL.Query().Get("valueRenderOption") == "FORMULA" {
+			values = book["formulas"]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"range": book["range"], "values": values})
+	}))
+}
+
+func TestSheetsSnapshotAndDiff(t *testing.T) {
+	newSheetsDiffTestService(t)
+	ctx, _ := driveTrashTestContext(t, true)
+	flags := &RootFlags{Account: "a@b.com"}
+
+	snapPath := filepath.Join(t.TempDir(), "prod.json")
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &SheetsSnapshotCmd{}, []string{"prod", "Pricing", "--out", snapPath}, ctx, flags); err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+	})
+	data, err := os.ReadFile(snapPath)
+	if err != nil {
+		t.Fatalf("read snapshot: %v", err)
+	}
+	var snap sheetsSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		t.Fatalf("snapshot json: %v", err)
+	}
+	if snap.Range != "Pricing!A1:C3" || snap.Formulas[1][2] != "=B2*2" || snap.Values[2][1] != "5" {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+
+	var diffErr error
+	out := captureStdout(t, func() {
+		diffErr = runKong(t, &SheetsDiffCmd{}, []string{snapPath, "draft", "--formulas", "--exit-code"}, ctx, flags)
+	})
+	var ee *ExitError
+	if !errors.As(diffErr, &ee) || ee.Code != 1 {
+		t.Fatalf("expected exit code 1, got %v", diffErr)
+	}
+	var parsed struct {
+		B       sheetsDiffSide     `json:"b"`
+		Changes []sheetsCellChange `json:"changes"`
+		Summary map[string]int     `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.B.Range != "Pricing!A1:C4" {
+		t.Fatalf("expected draft to borrow the snapshot range, got %+v", parsed.B)
+	}
+	got := map[string]sheetsCellChange{}
+	for _, ch := range parsed.Changes {
+		got[ch.Cell] = ch
+	}
+	if ch := got["Pricing!B2"]; ch.Change != "changed" || ch.Before != "10" || ch.After != "12" {
+		t.Fatalf("unexpected B2: %+v", ch)
+	}
+	if ch := got["Pricing!C3"]; ch.Change != "changed" || ch.Before != ch.After || ch.AfterFormula != "=B3+B3" {
+		t.Fatalf("expected formula-only change on C3: %+v", ch)
+	}
+	if got["Pricing!A4"].Change != "added" || got["Pricing!B4"].Change != "added" {
+		t.Fatalf("expected added row 4: %+v", parsed.Changes)
+	}
+	if parsed.Summary["changed"] != 3 || parsed.Summary["added"] != 2 {
+		t.Fatalf("unexpected summary: %v (%+v)", parsed.Summary, parsed.Changes)
+	}
+
+	// Without --formulas the value-identical C3 is not reported.
+	out = captureStdout(t, func() {
+		if err := runKong(t, &SheetsDiffCmd{}, []string{"prod!Pricing", "draft"}, ctx, flags); err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+	})
+	if strings.Contains(out, "Pricing!C3") || !strings.Contains(out, `"changed": 2`) {
+		t.Fatalf("unexpected value-only diff: %s", out)
+	}
+
+	if err := runKong(t, &SheetsDiffCmd{}, []string{"prod", "draft"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "provide a range") {
+		t.Fatalf("expected range error, got %v", err)
+	}
+	if err := runKong(t, &SheetsDiffCmd{}, []string{snapPath + "!Other!A1:B2", "draft"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "covers Pricing!A1:C3") {
+		t.Fatalf("expected snapshot range error, got %v", err)
+	}
+}
+
+func TestSplitSheetsDiffSpec(t *testing.T) {
+	for spec, want := range map[string][2]string{
+		"abc":                 {"abc", ""},
+		"abc!Sheet1!A1:B2":    {"abc", "Sheet1!A1:B2"},
+		"abc!'My Tab'!A:C":    {"abc", "'My Tab'!A:C"},
+		" snap.json ":         {"snap.json", ""},
+		`abc\!Pricing\!A1:B2`: {"abc", "Pricing!A1:B2"},
+	} {
+		source, rng := splitSheetsDiffSpec(spec)
+		if source != want[0] || rng != want[1] {
+			t.Fatalf("%q: got (%q, %q), want %v", spec, source, rng, want)
+		}
+	}
+}
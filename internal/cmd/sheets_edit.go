@@ -0,0 +1,558 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	sheetsValueInputRaw         = "RAW"
+	sheetsValueInputUserEntered = "USER_ENTERED"
+)
+
+type SheetsEditCmd struct {
+	Values SheetsEditValuesCmd `cmd:"" name:"values" help:"Write values to a range (values.batchUpdate)"`
+	Append SheetsEditAppendCmd `cmd:"" name:"append" help:"Append rows after a table (values.append)"`
+	Clear  SheetsEditClearCmd  `cmd:"" name:"clear" help:"Clear values in one or more ranges (values.batchClear)"`
+	Batch  SheetsEditBatchCmd  `cmd:"" name:"batch" help:"Apply raw spreadsheets.batchUpdate requests from JSON"`
+}
+
+// SheetsEditSafetyFlags mirrors the docs edit contract: every edit can be
+// previewed, validated offline, persisted as a normalized request file and
+// replayed from that file.
+type SheetsEditSafetyFlags struct {
+	DryRun            bool   `name:"dry-run" help:"Build request and print it without executing API call"`
+	ValidateOnly      bool   `name:"validate-only" help:"Validate request payload locally without executing API call"`
+	Pretty            bool   `name:"pretty" help:"Include normalized pretty-printed request JSON in output"`
+	OutputRequestFile string `name:"output-request-file" help:"Write normalized request JSON to this file (use '-' for stdout)"`
+	ExecuteFromFile   string `name:"execute-from-file" help:"Execute request JSON from this file ('-' for stdin) instead of building it from arguments"`
+}
+
+func newSheetsEditError(op, spreadsheetID, code, msg string, cause error) *editError {
+	return newEditError("spreadsheet_id", op, spreadsheetID, code, msg, cause)
+}
+
+func sheetsEditUsageError(op, spreadsheetID, code, msg string) error {
+	return newSheetsEditError(op, spreadsheetID, code, msg, usage(msg))
+}
+
+func sheetsEditAPIError(op, spreadsheetID, msg string, err error) error {
+	var apiErr *gapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return newSheetsEditError(op, spreadsheetID, "spreadsheet_not_found", fmt.Sprintf("spreadsheet not found (id=%s)", spreadsheetID), err)
+	}
+	return newSheetsEditError(op, spreadsheetID, "api_error", msg, err)
+}
+
+// sheetsEditPlan is a fully built (and locally validated) request plus the
+// call that executes it. runSheetsEdit drives the shared safety flow.
+type sheetsEditPlan struct {
+	op            string
+	spreadsheetID string
+	request       any
+	operations    int
+	requestKinds  []string
+	summary       map[string]any
+	execute       func(ctx context.Context, svc *sheets.Service) (map[string]any, error)
+}
+
+func runSheetsEdit(ctx context.Context, flags *RootFlags, safety SheetsEditSafetyFlags, plan sheetsEditPlan) error {
+	u := ui.FromContext(ctx)
+	op, id := plan.op, plan.spreadsheetID
+
+	requestHash, err := editRequestHash(plan.request)
+	if err != nil {
+		return newSheetsEditError(op, id, "invalid_request", "failed to hash normalized request", err)
+	}
+	normalizedForJSON := ""
+	if strings.TrimSpace(safety.OutputRequestFile) == "-" && outfmt.IsJSON(ctx) {
+		norm, normErr := editNormalizedRequestString(plan.request)
+		if normErr != nil {
+			return newSheetsEditError(op, id, "invalid_request", "failed to normalize request", normErr)
+		}
+		normalizedForJSON = norm
+	} else if writeErr := editMaybeWriteNormalizedRequest(safety.OutputRequestFile, plan.request); writeErr != nil {
+		return newSheetsEditError(op, id, "output_write_failed", "write normalized request failed", writeErr)
+	}
+
+	base := map[string]any{
+		"spreadsheetId": id,
+		"operation":     op,
+		"operations":    plan.operations,
+		"requestKinds":  plan.requestKinds,
+		"requestHash":   requestHash,
+	}
+	for k, v := range plan.summary {
+		base[k] = v
+	}
+	if normalizedForJSON != "" {
+		base["normalizedRequest"] = normalizedForJSON
+	}
+
+	if safety.ValidateOnly || safety.DryRun {
+		if safety.ValidateOnly {
+			base["validateOnly"] = true
+			base["valid"] = true
+		} else {
+			base["dryRun"] = true
+			base["request"] = plan.request
+		}
+		if safety.Pretty {
+			if pretty, prettyErr := json.MarshalIndent(plan.request, "", "  "); prettyErr == nil {
+				base["prettyRequest"] = string(pretty)
+			}
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, base)
+		}
+		if safety.ValidateOnly {
+			u.Out().Printf("validate-only\ttrue")
+			u.Out().Printf("valid\ttrue")
+		} else {
+			u.Out().Printf("dry-run\ttrue")
+		}
+		u.Out().Printf("id\t%s", id)
+		u.Out().Printf("operations\t%d", plan.operations)
+		u.Out().Printf("request-hash\t%s", requestHash)
+		if safety.Pretty {
+			if pretty, prettyErr := json.MarshalIndent(plan.request, "", "  "); prettyErr == nil {
+				u.Out().Printf("pretty-request\t%s", string(pretty))
+			}
+		} else if safety.DryRun {
+			if raw, rawErr := json.Marshal(plan.request); rawErr == nil {
+				u.Out().Printf("request\t%s", string(raw))
+			}
+		}
+		return nil
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return newSheetsEditError(op, id, "service_init_failed", "create sheets service failed", err)
+	}
+	result, err := plan.execute(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]any{
+		"spreadsheetId": id,
+		"operations":    plan.operations,
+		"requestHash":   requestHash,
+	}
+	for k, v := range result {
+		payload[k] = v
+	}
+	if normalizedForJSON != "" {
+		payload["normalizedRequest"] = normalizedForJSON
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, payload)
+	}
+	u.Out().Printf("id\t%s", id)
+	u.Out().Printf("operations\t%d", plan.operations)
+	for _, key := range []string{"updatedRange", "updatedCells", "clearedRanges", "replies"} {
+		if v, ok := result[key]; ok {
+			u.Out().Printf("%s\t%v", key, v)
+		}
+	}
+	return nil
+}
+
+type SheetsEditValuesCmd struct {
+	SpreadsheetID string                `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string                `arg:"" optional:"" name:"range" help:"Range (eg. Sheet1!A1:B2)"`
+	Values        []string              `arg:"" optional:"" name:"values" help:"Values (comma-separated rows, pipe-separated cells)"`
+	ValuesJSON    string                `name:"values-json" help:"Values as JSON 2D array"`
+	ValueInput    string                `name:"input" help:"Value input option: RAW or USER_ENTERED" default:"USER_ENTERED"`
+	Safety        SheetsEditSafetyFlags `embed:""`
+}
+
+func (c *SheetsEditValuesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "values"
+	id := strings.TrimSpace(c.SpreadsheetID)
+	if id == "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "empty spreadsheetId")
+	}
+
+	var req sheets.BatchUpdateValuesRequest
+	if from := strings.TrimSpace(c.Safety.ExecuteFromFile); from != "" {
+		if c.Range != "" || len(c.Values) > 0 || strings.TrimSpace(c.ValuesJSON) != "" {
+			return sheetsEditUsageError(op, id, "invalid_argument", "cannot combine --execute-from-file with range/values arguments")
+		}
+		if err := decodeSheetsEditRequest(op, id, from, &req); err != nil {
+			return err
+		}
+	} else {
+		rangeSpec := cleanRange(strings.TrimSpace(c.Range))
+		if rangeSpec == "" {
+			return sheetsEditUsageError(op, id, "invalid_argument", "empty range")
+		}
+		values, err := parseSheetsValuesInput(c.Values, c.ValuesJSON)
+		if err != nil {
+			return newSheetsEditError(op, id, "invalid_argument", err.Error(), err)
+		}
+		req = sheets.BatchUpdateValuesRequest{
+			ValueInputOption: strings.ToUpper(strings.TrimSpace(c.ValueInput)),
+			Data:             []*sheets.ValueRange{{Range: rangeSpec, Values: values}},
+		}
+	}
+	if err := validateSheetsValuesRequest(op, id, &req); err != nil {
+		return err
+	}
+
+	ranges := make([]string, 0, len(req.Data))
+	cells := 0
+	for _, d := range req.Data {
+		ranges = append(ranges, d.Range)
+		for _, row := range d.Values {
+			cells += len(row)
+		}
+	}
+	return runSheetsEdit(ctx, flags, c.Safety, sheetsEditPlan{
+		op:            op,
+		spreadsheetID: id,
+		request:       &req,
+		operations:    len(req.Data),
+		requestKinds:  []string{"values.batchUpdate"},
+		summary:       map[string]any{"ranges": ranges, "cells": cells},
+		execute: func(ctx context.Context, svc *sheets.Service) (map[string]any, error) {
+			resp, err := svc.Spreadsheets.Values.BatchUpdate(id, &req).Context(ctx).Do()
+			if err != nil {
+				return nil, sheetsEditAPIError(op, id, "values update failed", err)
+			}
+			updatedRange := ""
+			if len(resp.Responses) == 1 {
+				updatedRange = resp.Responses[0].UpdatedRange
+			}
+			return map[string]any{
+				"updatedRange":   updatedRange,
+				"updatedRows":    resp.TotalUpdatedRows,
+				"updatedColumns": resp.TotalUpdatedColumns,
+				"updatedCells":   resp.TotalUpdatedCells,
+			}, nil
+		},
+	})
+}
+
+// sheetsAppendRequest is the replayable form of a values.append call, which
+// carries its options as query parameters rather than in the body.
+type sheetsAppendRequest struct {
+	Range            string          `json:"range"`
+	ValueInputOption string          `json:"valueInputOption"`
+	InsertDataOption string          `json:"insertDataOption,omitempty"`
+	Values           [][]interface{} `json:"values"`
+}
+
+type SheetsEditAppendCmd struct {
+	SpreadsheetID string                `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string                `arg:"" optional:"" name:"range" help:"Range (eg. Sheet1!A:C)"`
+	Values        []string              `arg:"" optional:"" name:"values" help:"Values (comma-separated rows, pipe-separated cells)"`
+	ValuesJSON    string                `name:"values-json" help:"Values as JSON 2D array"`
+	ValueInput    string                `name:"input" help:"Value input option: RAW or USER_ENTERED" default:"USER_ENTERED"`
+	Insert        string                `name:"insert" help:"Insert data option: OVERWRITE or INSERT_ROWS"`
+	Safety        SheetsEditSafetyFlags `embed:""`
+}
+
+func (c *SheetsEditAppendCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "append"
+	id := strings.TrimSpace(c.SpreadsheetID)
+	if id == "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "empty spreadsheetId")
+	}
+
+	var req sheetsAppendRequest
+	if from := strings.TrimSpace(c.Safety.ExecuteFromFile); from != "" {
+		if c.Range != "" || len(c.Values) > 0 || strings.TrimSpace(c.ValuesJSON) != "" {
+			return sheetsEditUsageError(op, id, "invalid_argument", "cannot combine --execute-from-file with range/values arguments")
+		}
+		if err := decodeSheetsEditRequest(op, id, from, &req); err != nil {
+			return err
+		}
+	} else {
+		values, err := parseSheetsValuesInput(c.Values, c.ValuesJSON)
+		if err != nil {
+			return newSheetsEditError(op, id, "invalid_argument", err.Error(), err)
+		}
+		req = sheetsAppendRequest{
+			Range:            cleanRange(strings.TrimSpace(c.Range)),
+			ValueInputOption: strings.ToUpper(strings.TrimSpace(c.ValueInput)),
+			InsertDataOption: strings.ToUpper(strings.TrimSpace(c.Insert)),
+			Values:           values,
+		}
+	}
+	if strings.TrimSpace(req.Range) == "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "empty range")
+	}
+	if err := validateSheetsValueInput(op, id, req.ValueInputOption); err != nil {
+		return err
+	}
+	switch req.InsertDataOption {
+	case "", "OVERWRITE", "INSERT_ROWS":
+	default:
+		return sheetsEditUsageError(op, id, "invalid_argument", fmt.Sprintf("invalid insertDataOption %q (expected OVERWRITE or INSERT_ROWS)", req.InsertDataOption))
+	}
+	if len(req.Values) == 0 {
+		return sheetsEditUsageError(op, id, "invalid_request", "append request has no values")
+	}
+
+	return runSheetsEdit(ctx, flags, c.Safety, sheetsEditPlan{
+		op:            op,
+		spreadsheetID: id,
+		request:       &req,
+		operations:    1,
+		requestKinds:  []string{"values.append"},
+		summary:       map[string]any{"range": req.Range, "rows": len(req.Values)},
+		execute: func(ctx context.Context, svc *sheets.Service) (map[string]any, error) {
+			call := svc.Spreadsheets.Values.Append(id, req.Range, &sheets.ValueRange{Values: req.Values}).
+				ValueInputOption(req.ValueInputOption).
+				Context(ctx)
+			if req.InsertDataOption != "" {
+				call = call.InsertDataOption(req.InsertDataOption)
+			}
+			resp, err := call.Do()
+			if err != nil {
+				return nil, sheetsEditAPIError(op, id, "append failed", err)
+			}
+			out := map[string]any{"tableRange": resp.TableRange}
+			if resp.Updates != nil {
+				out["updatedRange"] = resp.Updates.UpdatedRange
+				out["updatedRows"] = resp.Updates.UpdatedRows
+				out["updatedColumns"] = resp.Updates.UpdatedColumns
+				out["updatedCells"] = resp.Updates.UpdatedCells
+			}
+			return out, nil
+		},
+	})
+}
+
+type SheetsEditClearCmd struct {
+	SpreadsheetID string                `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Ranges        []string              `arg:"" optional:"" name:"range" help:"Ranges to clear (eg. Sheet1!A2:D)"`
+	Safety        SheetsEditSafetyFlags `embed:""`
+}
+
+func (c *SheetsEditClearCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "clear"
+	id := strings.TrimSpace(c.SpreadsheetID)
+	if id == "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "empty spreadsheetId")
+	}
+
+	var req sheets.BatchClearValuesRequest
+	if from := strings.TrimSpace(c.Safety.ExecuteFromFile); from != "" {
+		if len(c.Ranges) > 0 {
+			return sheetsEditUsageError(op, id, "invalid_argument", "cannot combine --execute-from-file with range arguments")
+		}
+		if err := decodeSheetsEditRequest(op, id, from, &req); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range c.Ranges {
+			if r = cleanRange(strings.TrimSpace(r)); r != "" {
+				req.Ranges = append(req.Ranges, r)
+			}
+		}
+	}
+	if len(req.Ranges) == 0 {
+		return sheetsEditUsageError(op, id, "invalid_argument", "missing range")
+	}
+	for i, r := range req.Ranges {
+		if strings.TrimSpace(r) == "" {
+			e := newSheetsEditError(op, id, "invalid_request", fmt.Sprintf("ranges[%d] is empty", i), usage(fmt.Sprintf("ranges[%d] is empty", i)))
+			idx := i
+			e.RequestIndex = &idx
+			return e
+		}
+	}
+	if !c.Safety.DryRun && !c.Safety.ValidateOnly && (flags == nil || !flags.Force) {
+		return sheetsEditUsageError(op, id, "confirmation_required", "clear is destructive; rerun with --force or use --dry-run")
+	}
+
+	return runSheetsEdit(ctx, flags, c.Safety, sheetsEditPlan{
+		op:            op,
+		spreadsheetID: id,
+		request:       &req,
+		operations:    len(req.Ranges),
+		requestKinds:  []string{"values.batchClear"},
+		summary:       map[string]any{"ranges": req.Ranges},
+		execute: func(ctx context.Context, svc *sheets.Service) (map[string]any, error) {
+			resp, err := svc.Spreadsheets.Values.BatchClear(id, &req).Context(ctx).Do()
+			if err != nil {
+				return nil, sheetsEditAPIError(op, id, "clear failed", err)
+			}
+			return map[string]any{"clearedRanges": resp.ClearedRanges}, nil
+		},
+	})
+}
+
+type SheetsEditBatchCmd struct {
+	SpreadsheetID string                `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	RequestsFile  string                `name:"requests-file" help:"Path to spreadsheets.batchUpdate request JSON, or '-' for stdin" default:"-"`
+	Safety        SheetsEditSafetyFlags `embed:""`
+}
+
+func (c *SheetsEditBatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "batch"
+	id := strings.TrimSpace(c.SpreadsheetID)
+	if id == "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "empty spreadsheetId")
+	}
+	requestsFile := strings.TrimSpace(c.RequestsFile)
+	executeFromFile := strings.TrimSpace(c.Safety.ExecuteFromFile)
+	if executeFromFile != "" && requestsFile != "-" && requestsFile != "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "cannot combine --execute-from-file with --requests-file")
+	}
+	if executeFromFile != "" {
+		requestsFile = executeFromFile
+	}
+	if requestsFile == "" {
+		return sheetsEditUsageError(op, id, "invalid_argument", "empty requests-file")
+	}
+
+	var req sheets.BatchUpdateSpreadsheetRequest
+	if err := decodeSheetsEditRequest(op, id, requestsFile, &req); err != nil {
+		return err
+	}
+	if len(req.Requests) == 0 {
+		return sheetsEditUsageError(op, id, "invalid_argument", "batch request has no operations")
+	}
+	requestKinds := make([]string, 0, len(req.Requests))
+	for i, r := range req.Requests {
+		names := sheetsRequestOperationNames(r)
+		if len(names) != 1 {
+			msg := fmt.Sprintf("request[%d] must set exactly one operation field", i)
+			e := newSheetsEditError(op, id, "invalid_request", msg, usage(msg))
+			idx := i
+			e.RequestIndex = &idx
+			return e
+		}
+		requestKinds = append(requestKinds, names[0])
+	}
+
+	return runSheetsEdit(ctx, flags, c.Safety, sheetsEditPlan{
+		op:            op,
+		spreadsheetID: id,
+		request:       &req,
+		operations:    len(req.Requests),
+		requestKinds:  requestKinds,
+		execute: func(ctx context.Context, svc *sheets.Service) (map[string]any, error) {
+			resp, err := svc.Spreadsheets.BatchUpdate(id, &req).Context(ctx).Do()
+			if err != nil {
+				return nil, sheetsEditAPIError(op, id, "batch update failed", err)
+			}
+			return map[string]any{"replies": len(resp.Replies)}, nil
+		},
+	})
+}
+
+func decodeSheetsEditRequest(op, id, path string, dst any) error {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path) //nolint:gosec // user-provided path
+		if err != nil {
+			return newSheetsEditError(op, id, "input_open_failed", "open request file failed", err)
+		}
+		defer f.Close()
+		reader = f
+	}
+	if err := json.NewDecoder(reader).Decode(dst); err != nil {
+		return newSheetsEditError(op, id, "invalid_json", "decode request JSON failed", err)
+	}
+	return nil
+}
+
+func validateSheetsValueInput(op, id, option string) error {
+	switch option {
+	case sheetsValueInputRaw, sheetsValueInputUserEntered:
+		return nil
+	default:
+		return sheetsEditUsageError(op, id, "invalid_argument", fmt.Sprintf("invalid valueInputOption %q (expected RAW or USER_ENTERED)", option))
+	}
+}
+
+func validateSheetsValuesRequest(op, id string, req *sheets.BatchUpdateValuesRequest) error {
+	if err := validateSheetsValueInput(op, id, req.ValueInputOption); err != nil {
+		return err
+	}
+	if len(req.Data) == 0 {
+		return sheetsEditUsageError(op, id, "invalid_request", "values request has no data")
+	}
+	for i, d := range req.Data {
+		var msg string
+		switch {
+		case d == nil || strings.TrimSpace(d.Range) == "":
+			msg = fmt.Sprintf("data[%d] has no range", i)
+		case len(d.Values) == 0:
+			msg = fmt.Sprintf("data[%d] has no values", i)
+		default:
+			continue
+		}
+		e := newSheetsEditError(op, id, "invalid_request", msg, usage(msg))
+		idx := i
+		e.RequestIndex = &idx
+		return e
+	}
+	return nil
+}
+
+// parseSheetsValuesInput reads values from --values-json or from positional
+// arguments (comma-separated rows, pipe-separated cells).
+func parseSheetsValuesInput(args []string, valuesJSON string) ([][]interface{}, error) {
+	var values [][]interface{}
+	switch {
+	case strings.TrimSpace(valuesJSON) != "":
+		if err := json.Unmarshal([]byte(valuesJSON), &values); err != nil {
+			return nil, fmt.Errorf("invalid JSON values: %w", err)
+		}
+	case len(args) > 0:
+		rows := strings.Split(strings.Join(args, " "), ",")
+		for _, row := range rows {
+			cells := strings.Split(strings.TrimSpace(row), "|")
+			rowData := make([]interface{}, len(cells))
+			for i, cell := range cells {
+				rowData[i] = strings.TrimSpace(cell)
+			}
+			values = append(values, rowData)
+		}
+	default:
+		return nil, fmt.Errorf("provide values as args or via --values-json")
+	}
+	return values, nil
+}
+
+func sheetsRequestOperationNames(r *sheets.Request) []string {
+	if r == nil {
+		return nil
+	}
+	v := reflect.ValueOf(*r)
+	t := v.Type()
+	var names []string
+	for i := range t.NumField() {
+		name := t.Field(i).Name
+		if name == "ForceSendFields" || name == "NullFields" {
+			continue
+		}
+		if fv := v.Field(i); fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
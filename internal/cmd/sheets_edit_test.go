@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+type sheetsEditRecorder struct {
+	mu    sync.Mutex
+	calls []string
+	body  map[string]any
+	query map[string]string
+}
+
+func newSheetsEditTestService(t *testing.T, status int) *sheetsEditRecorder {
+	t.Helper()
+	rec := &sheetsEditRecorder{}
+
+	stubGoogleService(t, &newSheetsService, sheets.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		rec.calls = append(rec.calls, r.Method+" "+path)
+		rec.body = nil
+		_ = json.NewDecoder(r.Body).Decode(&rec.body)
+		rec.query = map[string]string{}
+		for k := range r.URL.Query() {
+			rec.query[k] = r.URL.Query().Get(k)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": status, "message": "nope", "errors": []map[string]any{{"reason": "notFound"}}}})
+			return
+		}
+		switch {
+		case strings.HasSuffix(path, "/values:batchUpdate"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"totalUpdatedCells": 4, "responses": []map[string]any{{"updatedRange": "Sheet1!A1:B2"}}})
+		case strings.HasSuffix(path, "/values:batchClear"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"clearedRanges": []string{"Sheet1!A2:D"}})
+		case strings.HasSuffix(path, ":append"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"updates": map[string]any{"updatedRange": "Sheet1!A5:B5", "updatedCells": 2}})
+		case strings.HasSuffix(path, ":batchUpdate"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"replies": []map[string]any{{}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return rec
+}
+
+func TestExecute_SheetsEditValues_JSON(t *testing.T) {
+	rec := newSheetsEditTestService(t, http.StatusOK)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "edit", "values", "s1", `Sheet1\!A1:B2`, "a|b,c|d", "--input", "raw"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if len(rec.calls) != 1 || rec.calls[0] != "POST /spreadsheets/s1/values:batchUpdate" {
+		t.Fatalf("unexpected calls: %v", rec.calls)
+	}
+	if rec.body["valueInputOption"] != "RAW" {
+		t.Fatalf("unexpected body: %v", rec.body)
+	}
+	data := rec.body["data"].([]any)[0].(map[string]any)
+	if data["range"] != "Sheet1!A1:B2" {
+		t.Fatalf("expected cleaned range, got %v", data["range"])
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed["updatedRange"] != "Sheet1!A1:B2" || parsed["updatedCells"] != float64(4) || parsed["requestHash"] == "" {
+		t.Fatalf("unexpected output: %v", parsed)
+	}
+}
+
+func TestExecute_SheetsEditValues_DryRunAndReplay(t *testing.T) {
+	rec := newSheetsEditTestService(t, http.StatusOK)
+	reqFile := filepath.Join(t.TempDir(), "req.json")
+
+	var dry map[string]any
+	out := captureStdout(t, func() {
+		args := []string{"--json", "sheets", "edit", "values", "s1", "Sheet1!A1", "--values-json", `[["x",1]]`, "--dry-run", "--output-request-file", reqFile}
+		if err := Execute(args); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &dry); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if dry["dryRun"] != true || dry["operations"] != float64(1) || dry["cells"] != float64(2) {
+		t.Fatalf("unexpected dry-run output: %v", dry)
+	}
+	if len(rec.calls) != 0 {
+		t.Fatalf("dry-run should not call the API: %v", rec.calls)
+	}
+	if _, err := os.Stat(reqFile); err != nil {
+		t.Fatalf("expected request file: %v", err)
+	}
+
+	var replay map[string]any
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "edit", "values", "s1", "--execute-from-file", reqFile}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &replay); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if replay["requestHash"] != dry["requestHash"] {
+		t.Fatalf("replayed request hash %v differs from dry-run %v", replay["requestHash"], dry["requestHash"])
+	}
+	if len(rec.calls) != 1 {
+		t.Fatalf("expected one API call on replay, got %v", rec.calls)
+	}
+}
+
+func TestExecute_SheetsEditAppend_ValidateOnly(t *testing.T) {
+	rec := newSheetsEditTestService(t, http.StatusOK)
+
+	out := captureStdout(t, func() {
+		args := []string{"--json", "sheets", "edit", "append", "s1", "Sheet1!A:B", "x|y", "--insert", "insert_rows", "--validate-only", "--pretty"}
+		if err := Execute(args); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed["valid"] != true || parsed["rows"] != float64(1) {
+		t.Fatalf("unexpected output: %v", parsed)
+	}
+	if pretty, _ := parsed["prettyRequest"].(string); !strings.Contains(pretty, `"insertDataOption": "INSERT_ROWS"`) {
+		t.Fatalf("unexpected pretty request: %v", parsed["prettyRequest"])
+	}
+	if len(rec.calls) != 0 {
+		t.Fatalf("validate-only should not call the API: %v", rec.calls)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "edit", "append", "s1", "Sheet1!A:B", "x|y"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if rec.query["valueInputOption"] != "USER_ENTERED" {
+		t.Fatalf("unexpected append query: %v", rec.query)
+	}
+}
+
+func TestExecute_SheetsEditClear_RequiresForce(t *testing.T) {
+	rec := newSheetsEditTestService(t, http.StatusOK)
+
+	err := Execute([]string{"--account", "a@b.com", "sheets", "edit", "clear", "s1", "Sheet1!A2:D"})
+	if err == nil || !strings.Contains(err.Error(), "destructive") {
+		t.Fatalf("expected destructive guard error, got %v", err)
+	}
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"--json", "--account", "a@b.com", "sheets", "edit", "clear", "s1", "Sheet1!A2:D"})
+	})
+	var se *editError
+	if !errors.As(err, &se) || se.ErrorCode != "confirmation_required" || rec.body != nil {
+		t.Fatalf("expected JSON mode to need --force too, got %v (body %v)", err, rec.body)
+	}
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "a@b.com", "sheets", "edit", "clear", "s1", "Sheet1!A2:D", "Sheet2!A:A"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if ranges := rec.body["ranges"].([]any); len(ranges) != 2 {
+		t.Fatalf("expected both ranges in batchClear, got %v", rec.body)
+	}
+}
+
+func TestExecute_SheetsEditBatch_InvalidRequest_JSONErrorEnvelope(t *testing.T) {
+	stderr := captureStderr(t, func() {
+		withStdin(t, `{"requests":[{"addSheet":{}},{"addSheet":{},"deleteSheet":{"sheetId":1}}]}`, func() {
+			if err := Execute([]string{"--json", "sheets", "edit", "batch", "s1"}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	})
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &parsed); err != nil {
+		t.Fatalf("parse stderr json: %v; stderr=%q", err, stderr)
+	}
+	errorObj := parsed["error"].(map[string]any)
+	if errorObj["error_code"] != "invalid_request" || errorObj["request_index"] != float64(1) {
+		t.Fatalf("unexpected error: %v", errorObj)
+	}
+	if errorObj["operation"] != "batch" || errorObj["spreadsheet_id"] != "s1" {
+		t.Fatalf("unexpected error context: %v", errorObj)
+	}
+}
+
+func TestExecute_SheetsEditBatch_ExecutesRawRequests(t *testing.T) {
+	rec := newSheetsEditTestService(t, http.StatusOK)
+
+	out := captureStdout(t, func() {
+		withStdin(t, `{"requests":[{"addSheet":{"properties":{"title":"Q4"}}}]}`, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "edit", "batch", "s1"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	if len(rec.calls) != 1 || rec.calls[0] != "POST /spreadsheets/s1:batchUpdate" {
+		t.Fatalf("unexpected calls: %v", rec.calls)
+	}
+	if !strings.Contains(out, `"replies": 1`) {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestExecute_SheetsEditValues_NotFound(t *testing.T) {
+	_ = newSheetsEditTestService(t, http.StatusNotFound)
+
+	stderr := captureStderr(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "edit", "values", "missing", "A1", "x"}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &parsed); err != nil {
+		t.Fatalf("parse stderr json: %v; stderr=%q", err, stderr)
+	}
+	errorObj := parsed["error"].(map[string]any)
+	if errorObj["error_code"] != "spreadsheet_not_found" || errorObj["http_status"] != float64(404) || errorObj["google_reason"] != "notFound" {
+		t.Fatalf("unexpected error: %v", errorObj)
+	}
+}
+
+func TestExecute_SheetsEditValues_Validation(t *testing.T) {
+	cases := map[string][]string{
+		"empty range":                 {"sheets", "edit", "values", "s1"},
+		"invalid valueInputOption":    {"sheets", "edit", "values", "s1", "A1", "x", "--input", "bogus"},
+		"cannot combine":              {"sheets", "edit", "values", "s1", "A1", "--execute-from-file", "x.json"},
+		"provide values":              {"sheets", "edit", "values", "s1", "A1"},
+		"insertDataOption":            {"sheets", "edit", "append", "s1", "A:A", "x", "--insert", "sideways"},
+		"missing range":               {"sheets", "edit", "clear", "s1", "--dry-run"},
+		"batch request has no operat": {"sheets", "edit", "batch", "s1", "--execute-from-file", writeTempJSON(t, `{"requests":[]}`)},
+	}
+	for want, args := range cases {
+		err := Execute(args)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("args %v: expected %q error, got %v", args, want, err)
+		}
+	}
+}
+
+func writeTempJSON(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "req.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsImportCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Sheet         string `arg:"" name:"sheet" help:"Sheet (tab) name"`
+	File          string `arg:"" name:"file" help:"CSV with a header row or JSON array of objects (- for stdin)"`
+	Format        string `name:"format" help:"Data format: auto|json|csv" default:"auto" enum:"auto,json,csv"`
+	Create        bool   `name:"create" help:"Create the tab if it does not exist"`
+	Mode          string `name:"mode" help:"append (below existing rows, matching the header) or replace (clear the tab first)" default:"append" enum:"append,replace"`
+	NoInfer       bool   `name:"no-infer" help:"Write every cell as literal text (RAW) instead of inferring column types"`
+}
+
+func (c *SheetsImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	spreadsheetID := strings.TrimSpace(c.SpreadsheetID)
+	sheetName := strings.TrimSpace(c.Sheet)
+	if spreadsheetID == "" {
+		return usage("empty spreadsheetId")
+	}
+	if sheetName == "" {
+		return usage("empty sheet")
+	}
+
+	records, err := readSheetsRecords(c.File, c.Format)
+	if err != nil {
+		return err
+	}
+	if len(records.Columns) == 0 {
+		return usage("no columns in data")
+	}
+
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	created := false
+	if _, ok := sheetIDs[sheetName]; !ok {
+		if !c.Create {
+			return usagef("sheet %q not found (use --create)", sheetName)
+		}
+		_, err = svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: sheetName}}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create sheet %q: %w", sheetName, err)
+		}
+		created = true
+	}
+
+	tab := quoteSheetName(sheetName)
+	columns := records.Columns
+	writeHeader := true
+	switch {
+	case created:
+	case c.Mode == "replace":
+		if _, err = svc.Spreadsheets.Values.Clear(spreadsheetID, tab, &sheets.ClearValuesRequest{}).Context(ctx).Do(); err != nil {
+			return err
+		}
+	default:
+		headerResp, err := svc.Spreadsheets.Values.Get(spreadsheetID, tab+"!1:1").Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+		if len(headerResp.Values) > 0 && len(headerResp.Values[0]) > 0 {
+			columns, err = matchSheetsImportHeader(headerResp.Values[0], records.Columns)
+			if err != nil {
+				return err
+			}
+			writeHeader = false
+		}
+	}
+
+	types := make(map[string]string, len(columns))
+	for _, col := range columns {
+		types[col] = "string"
+		if c.NoInfer {
+			continue
+		}
+		colValues := make([]string, 0, len(records.Rows))
+		for _, rec := range records.Rows {
+			colValues = append(colValues, rec[col])
+		}
+		types[col] = inferSheetsColumnType(colValues)
+	}
+
+	valueInput := sheetsValueInputUserEntered
+	if c.NoInfer {
+		valueInput = sheetsValueInputRaw
+	}
+	cellValue := func(v, kind string) any {
+		if c.NoInfer {
+			return v
+		}
+		return sheetsTypedCell(v, kind)
+	}
+
+	values := make([][]interface{}, 0, len(records.Rows)+1)
+	if writeHeader {
+		header := make([]interface{}, len(columns))
+		for i, col := range columns {
+			header[i] = cellValue(col, "string")
+		}
+		values = append(values, header)
+	}
+	for _, rec := range records.Rows {
+		row := make([]interface{}, len(columns))
+		for i, col := range columns {
+			row[i] = cellValue(rec[col], types[col])
+		}
+		values = append(values, row)
+	}
+
+	var updates *sheets.UpdateValuesResponse
+	if writeHeader {
+		updates, err = svc.Spreadsheets.Values.Update(spreadsheetID, tab+"!A1", &sheets.ValueRange{Values: values}).
+			ValueInputOption(valueInput).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+	} else if len(values) > 0 {
+		resp, err := svc.Spreadsheets.Values.Append(spreadsheetID, tab+"!A1", &sheets.ValueRange{Values: values}).
+			ValueInputOption(valueInput).
+			InsertDataOption("INSERT_ROWS").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return err
+		}
+		updates = resp.Updates
+	}
+	if updates == nil {
+		updates = &sheets.UpdateValuesResponse{}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"sheet":         sheetName,
+			"mode":          c.Mode,
+			"created":       created,
+			"columns":       columns,
+			"types":         types,
+			"rows":          len(records.Rows),
+			"updatedRange":  updates.UpdatedRange,
+			"updatedCells":  updates.UpdatedCells,
+		})
+	}
+
+	if created {
+		u.Out().Printf("created\t%s", sheetName)
+	}
+	u.Out().Printf("rows\t%d", len(records.Rows))
+	u.Out().Printf("range\t%s", updates.UpdatedRange)
+	for _, col := range columns {
+		u.Out().Printf("column\t%s\t%s", col, types[col])
+	}
+	return nil
+}
+
+// matchSheetsImportHeader returns the existing header as column order and
+// fails when the data has columns the sheet does not.
+func matchSheetsImportHeader(header []interface{}, dataColumns []string) ([]string, error) {
+	columns := make([]string, len(header))
+	known := make(map[string]bool, len(header))
+	for i, cell := range header {
+		columns[i] = strings.TrimSpace(fmt.Sprint(cell))
+		known[columns[i]] = true
+	}
+	for _, col := range dataColumns {
+		if !known[col] {
+			return nil, usagef("data column %q not found in header (%s); use --mode replace to rewrite the tab", col, strings.Join(columns, ", "))
+		}
+	}
+	return columns, nil
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+type sheetsImportRecorder struct {
+	calls  []string
+	bodies map[string]map[string]any
+	query  map[string]string
+}
+
+func newSheetsTableTestService(t *testing.T, header []any) *sheetsImportRecorder {
+	t.Helper()
+	rec := &sheetsImportRecorder{bodies: map[string]map[string]any{}, query: map[string]string{}}
+
+	stubGoogleService(t, &newSheetsService, sheets.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		call := r.Method + " " + path
+		rec.calls = append(rec.calls, call)
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		rec.bodies[call] = body
+		for k := range r.URL.Query() {
+			rec.query[k] = r.URL.Query().Get(k)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/spreadsheets/s1" && strings.Contains(r.URL.Query().Get("fields"), "numberFormat"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"sheets": []any{map[string]any{"data": []any{map[string]any{"rowData": []any{
+				map[string]any{"values": []any{map[string]any{}, map[string]any{}, map[string]any{}}},
+				map[string]any{"values": []any{map[string]any{}, map[string]any{"effectiveFormat": map[string]any{"numberFormat": map[string]any{"type": "DATE"}}}, map[string]any{}}},
+			}}}}}})
+		case r.Method == http.MethodGet && path == "/spreadsheets/s1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"sheets": []any{map[string]any{"properties": map[string]any{"sheetId": 1, "title": "People"}}}})
+		case r.Method == http.MethodGet && strings.HasSuffix(path, "!1:1"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"range": "People!1:1", "values": []any{header}})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/spreadsheets/s1/values/"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"range": "People!A1:C3", "values": []any{
+				[]any{"name", "joined", "score"},
+				[]any{"Ann", 45292, 9.5},
+				[]any{},
+			}})
+		case r.Method == http.MethodPost && path == "/spreadsheets/s1:batchUpdate":
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		case strings.HasSuffix(path, ":append"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"updates": map[string]any{"updatedRange": "People!A5:C6", "updatedCells": 6}})
+		case strings.HasSuffix(path, ":clear"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"clearedRange": "People!A1:Z1000"})
+		case r.Method == http.MethodPut:
+			_ = json.NewEncoder(w).Encode(map[string]any{"updatedRange": "Staff!A1:C3", "updatedCells": 9})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return rec
+}
+
+func TestSheetsGet_Records(t *testing.T) {
+	rec := newSheetsTableTestService(t, nil)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "get", "s1", "People!A1:C3", "--records"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if rec.query["valueRenderOption"] != "UNFORMATTED_VALUE" || rec.query["dateTimeRenderOption"] != "SERIAL_NUMBER" {
+		t.Fatalf("unexpected render options: %v", rec.query)
+	}
+	var parsed struct {
+		Columns []string         `json:"columns"`
+		Records []map[string]any `json:"records"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if strings.Join(parsed.Columns, ",") != "name,joined,score" || len(parsed.Records) != 1 {
+		t.Fatalf("unexpected output: %s", out)
+	}
+	got := parsed.Records[0]
+	if got["name"] != "Ann" || got["joined"] != "2024-01-01" || got["score"] != 9.5 {
+		t.Fatalf("unexpected record: %v", got)
+	}
+
+	if err := Execute([]string{"--account", "a@b.com", "sheets", "get", "s1", "A1", "--records", "--render", "FORMULA"}); err == nil || !strings.Contains(err.Error(), "UNFORMATTED_VALUE") {
+		t.Fatalf("expected render conflict error, got %v", err)
+	}
+}
+
+func TestSheetsImport_CreateTabWithTypes(t *testing.T) {
+	rec := newSheetsTableTestService(t, nil)
+	data := writeUpsertData(t, "staff.csv", "id,zip,active,start,name\n1,02139,true,2024-03-01,=cmd\n2,10001,FALSE,2024-03-02,007\n")
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "import", "s1", "Staff", data, "--create"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	if add := rec.bodies["POST /spreadsheets/s1:batchUpdate"]; !strings.Contains(mustJSON(t, add), `"title":"Staff"`) {
+		t.Fatalf("expected addSheet, got %v", add)
+	}
+	body := rec.bodies["PUT /spreadsheets/s1/values/Staff!A1"]
+	if body == nil || rec.query["valueInputOption"] != "USER_ENTERED" {
+		t.Fatalf("expected USER_ENTERED update, calls %v", rec.calls)
+	}
+	values := body["values"].([]any)
+	row := values[1].([]any)
+	if row[0] != float64(1) || row[1] != "'02139" || row[2] != true || row[3] != "2024-03-01" || row[4] != "'=cmd" {
+		t.Fatalf("unexpected typed row: %v", row)
+	}
+	if row := values[2].([]any); row[4] != "'007" {
+		t.Fatalf("expected literal prefix for numeric-looking text, got %v", row)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	types := parsed["types"].(map[string]any)
+	if parsed["created"] != true || types["id"] != "number" || types["zip"] != "string" || types["active"] != "boolean" || types["start"] != "date" {
+		t.Fatalf("unexpected output: %v", parsed)
+	}
+}
+
+func TestSheetsImport_AppendMatchesHeader(t *testing.T) {
+	rec := newSheetsTableTestService(t, []any{"email", "name", "team"})
+	data := writeUpsertData(t, "rows.json", `[{"name":"Cy","email":"c@x.com"},{"email":"d@x.com","team":"ops"}]`)
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+	if err := runKong(t, &SheetsImportCmd{}, []string{"s1", "People", data, "--no-infer"}, ctx, flags); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	body := rec.bodies["POST /spreadsheets/s1/values/People!A1:append"]
+	if body == nil || rec.query["valueInputOption"] != "RAW" {
+		t.Fatalf("expected RAW append, calls %v", rec.calls)
+	}
+	if got := mustJSON(t, body["values"]); got != `[["c@x.com","Cy",""],["d@x.com","","ops"]]` {
+		t.Fatalf("unexpected appended values: %s", got)
+	}
+	if !strings.Contains(textOut.String(), "range\tPeople!A5:C6") {
+		t.Fatalf("unexpected output: %q", textOut.String())
+	}
+
+	bad := writeUpsertData(t, "bad.json", `[{"phone":"1"}]`)
+	if err := runKong(t, &SheetsImportCmd{}, []string{"s1", "People", bad}, ctx, flags); err == nil || !strings.Contains(err.Error(), `data column "phone"`) {
+		t.Fatalf("expected header mismatch error, got %v", err)
+	}
+	if err := runKong(t, &SheetsImportCmd{}, []string{"s1", "Missing", data}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--create") {
+		t.Fatalf("expected missing sheet error, got %v", err)
+	}
+}
+
+func TestInferSheetsColumnType(t *testing.T) {
+	cases := map[string][]string{
+		"number":  {"1", "-2.5", "", "3e4"},
+		"string":  {"1", "01"},
+		"boolean": {"TRUE", "false"},
+		"date":    {"2024-01-02", "2024-02-03 10:00:00"},
+	}
+	for want, values := range cases {
+		if got := inferSheetsColumnType(values); got != want {
+			t.Fatalf("inferSheetsColumnType(%v) = %q, want %q", values, got, want)
+		}
+	}
+	if got, _ := formatSheetsSerial(45292.5, "DATE_TIME"); got != "2024-01-01T12:00:00" {
+		t.Fatalf("unexpected serial conversion: %q", got)
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(b)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsNamedRangesCmd struct {
+	List   SheetsNamedRangesListCmd   `cmd:"" name:"list" default:"withargs" help:"List named ranges"`
+	Create SheetsNamedRangesCreateCmd `cmd:"" name:"create" aliases:"add" help:"Create a named range"`
+	Delete SheetsNamedRangesDeleteCmd `cmd:"" name:"delete" aliases:"rm" help:"Delete a named range"`
+}
+
+type SheetsNamedRangesListCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+}
+
+func (c *SheetsNamedRangesListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	ranges, titles, err := fetchSheetsNamedRanges(ctx, svc, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		items := make([]map[string]any, 0, len(ranges))
+		for _, nr := range ranges {
+			items = append(items, map[string]any{
+				"namedRangeId": nr.NamedRangeId,
+				"name":         nr.Name,
+				"range":        formatGridRangeA1(titles[sheetsGridSheetID(nr.Range)], nr.Range),
+				"gridRange":    nr.Range,
+			})
+		}
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"namedRanges":   items,
+		})
+	}
+
+	if len(ranges) == 0 {
+		u.Err().Println("No named ranges")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "NAME\tRANGE\tID")
+	for _, nr := range ranges {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", nr.Name, formatGridRangeA1(titles[sheetsGridSheetID(nr.Range)], nr.Range), nr.NamedRangeId)
+	}
+	return nil
+}
+
+type SheetsNamedRangesCreateCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Name          string `arg:"" name:"name" help:"Range name (letters, digits and underscores; usable in get/update instead of A1)"`
+	Range         string `arg:"" name:"range" help:"Tab title or sheet-qualified A1 range (eg. Sheet1!A1:D20)"`
+}
+
+func (c *SheetsNamedRangesCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty name")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	grid, err := resolveSheetsTarget(ctx, svc, spreadsheetID, c.Range)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		AddNamedRange: &sheets.AddNamedRangeRequest{NamedRange: &sheets.NamedRange{Name: name, Range: grid}},
+	})
+	if err != nil {
+		return err
+	}
+	id := ""
+	if len(resp.Replies) > 0 && resp.Replies[0].AddNamedRange != nil && resp.Replies[0].AddNamedRange.NamedRange != nil {
+		id = resp.Replies[0].AddNamedRange.NamedRange.NamedRangeId
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"namedRangeId":  id,
+			"name":          name,
+			"range":         cleanRange(c.Range),
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Created named range %s", name)
+	u.Out().Printf("id\t%s", id)
+	u.Out().Printf("range\t%s", cleanRange(c.Range))
+	return nil
+}
+
+type SheetsNamedRangesDeleteCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Name          string `arg:"" name:"name" help:"Range name or named range ID"`
+}
+
+func (c *SheetsNamedRangesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	ref := strings.TrimSpace(c.Name)
+	if ref == "" {
+		return usage("empty name")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	ranges, _, err := fetchSheetsNamedRanges(ctx, svc, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	var target *sheets.NamedRange
+	for _, nr := range ranges {
+		if nr.Name == ref || nr.NamedRangeId == ref {
+			target = nr
+			break
+		}
+	}
+	if target == nil {
+		return usagef("named range %q not found", ref)
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete named range %s", target.Name)); err != nil {
+		return err
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		DeleteNamedRange: &sheets.DeleteNamedRangeRequest{NamedRangeId: target.NamedRangeId},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"deleted":       true,
+			"namedRangeId":  target.NamedRangeId,
+			"name":          target.Name,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Deleted named range %s", target.Name)
+	return nil
+}
+
+func fetchSheetsNamedRanges(ctx context.Context, svc *sheets.Service, spreadsheetID string) ([]*sheets.NamedRange, map[int64]string, error) {
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("namedRanges,sheets(properties(sheetId,title))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.NamedRanges, sheetsTitleMap(resp.Sheets), nil
+}
+
+func sheetsTitleMap(list []*sheets.Sheet) map[int64]string {
+	titles := make(map[int64]string, len(list))
+	for _, sheet := range list {
+		if sheet.Properties != nil {
+			titles[sheet.Properties.SheetId] = sheet.Properties.Title
+		}
+	}
+	return titles
+}
+
+func sheetsGridSheetID(g *sheets.GridRange) int64 {
+	if g == nil {
+		return 0
+	}
+	return g.SheetId
+}
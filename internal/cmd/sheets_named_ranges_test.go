@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// newSheetsNamedRangesTestService serves one named range, Totals
+// (Archive!B1:C10), and answers addNamedRange with id nr2.
+func newSheetsNamedRangesTestService(t *testing.T) *[]map[string]any {
+	t.Helper()
+	return newSheetsSpreadsheetTestService(t, map[string]any{
+		"namedRanges": []any{
+			map[string]any{"namedRangeId": "nr1", "name": "Totals", "range": map[string]any{"sheetId": 7, "endRowIndex": 10, "startColumnIndex": 1, "endColumnIndex": 3}},
+		},
+		"sheets": []any{
+			map[string]any{"properties": map[string]any{"sheetId": 0, "title": "Data", "index": 0, "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26, "frozenRowCount": 1}}},
+			map[string]any{"properties": map[string]any{"sheetId": 7, "title": "Archive", "index": 1, "hidden": true}},
+		},
+	}, map[string]any{
+		"addNamedRange": map[string]any{"namedRange": map[string]any{"namedRangeId": "nr2"}},
+	})
+}
+
+func TestSheetsNamedRanges_Commands(t *testing.T) {
+	batches := newSheetsNamedRangesTestService(t)
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsNamedRangesListCmd{}, []string{"s1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Totals") || !strings.Contains(out, "Archive!B1:C10") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsNamedRangesCreateCmd{}, []string{"s1", "Inputs", "Data!A2:B"}, ctx, flags); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	nr := lastSheetsRequest(t, batches)["addNamedRange"].(map[string]any)["namedRange"].(map[string]any)
+	rng := nr["range"].(map[string]any)
+	if nr["name"] != "Inputs" || rng["startRowIndex"] != float64(1) || rng["endColumnIndex"] != float64(2) || rng["endRowIndex"] != nil {
+		t.Fatalf("unexpected addNamedRange: %v", nr)
+	}
+	if !strings.Contains(textOut.String(), "id\tnr2") {
+		t.Fatalf("unexpected output: %q", textOut.String())
+	}
+
+	if err := runKong(t, &SheetsNamedRangesDeleteCmd{}, []string{"s1", "Totals"}, ctx, flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if del := lastSheetsRequest(t, batches)["deleteNamedRange"].(map[string]any); del["namedRangeId"] != "nr1" {
+		t.Fatalf("unexpected delete: %v", del)
+	}
+	if err := runKong(t, &SheetsNamedRangesDeleteCmd{}, []string{"s1", "Nope"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
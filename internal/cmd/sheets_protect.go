@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsProtectCmd struct {
+	List   SheetsProtectListCmd   `cmd:"" name:"list" default:"withargs" help:"List protected ranges"`
+	Add    SheetsProtectAddCmd    `cmd:"" name:"add" help:"Protect a tab or range"`
+	Remove SheetsProtectRemoveCmd `cmd:"" name:"remove" aliases:"rm" help:"Remove a protected range"`
+}
+
+type SheetsProtectListCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `name:"tab" help:"Only show protections on this tab"`
+}
+
+func (c *SheetsProtectListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets(properties(sheetId,title),protectedRanges)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	tab := strings.TrimSpace(c.Tab)
+	titles := sheetsTitleMap(resp.Sheets)
+	var ranges []*sheets.ProtectedRange
+	for _, sheet := range resp.Sheets {
+		if tab != "" && (sheet.Properties == nil || sheet.Properties.Title != tab) {
+			continue
+		}
+		ranges = append(ranges, sheet.ProtectedRanges...)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		items := make([]map[string]any, 0, len(ranges))
+		for _, pr := range ranges {
+			items = append(items, map[string]any{
+				"protectedRangeId": pr.ProtectedRangeId,
+				"range":            describeSheetsProtectedRange(titles, pr),
+				"description":      pr.Description,
+				"warningOnly":      pr.WarningOnly,
+				"editors":          pr.Editors,
+				"protectedRange":   pr,
+			})
+		}
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId":   spreadsheetID,
+			"protectedRanges": items,
+		})
+	}
+
+	if len(ranges) == 0 {
+		u.Err().Println("No protected ranges")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tRANGE\tMODE\tEDITORS\tDESCRIPTION")
+	for _, pr := range ranges {
+		mode := "locked"
+		if pr.WarningOnly {
+			mode = "warning"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", pr.ProtectedRangeId, describeSheetsProtectedRange(titles, pr), mode, formatSheetsEditors(pr.Editors), orDash(pr.Description))
+	}
+	return nil
+}
+
+type SheetsProtectAddCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string   `arg:"" name:"range" help:"Tab title (whole sheet) or sheet-qualified A1 range"`
+	Description   string   `name:"description" help:"Description shown to editors"`
+	Editors       []string `name:"editors" help:"Emails allowed to edit (comma-separated; you are always included)"`
+	Groups        []string `name:"groups" help:"Group emails allowed to edit (comma-separated)"`
+	DomainEdit    bool     `name:"domain-edit" help:"Let everyone in the domain edit"`
+	WarningOnly   bool     `name:"warning-only" help:"Only warn before edits instead of blocking them"`
+	Except        []string `name:"except" help:"With a whole-tab protection, A1 ranges inside the tab that stay editable"`
+}
+
+func (c *SheetsProtectAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	editors := &sheets.Editors{
+		Users:              cleanSheetsEmails(c.Editors),
+		Groups:             cleanSheetsEmails(c.Groups),
+		DomainUsersCanEdit: c.DomainEdit,
+	}
+	hasEditors := len(editors.Users) > 0 || len(editors.Groups) > 0 || editors.DomainUsersCanEdit
+	if c.WarningOnly && hasEditors {
+		return usage("--warning-only cannot be combined with --editors, --groups or --domain-edit")
+	}
+	isTab := !strings.Contains(cleanRange(c.Range), "!")
+	if len(c.Except) > 0 && !isTab {
+		return usage("--except requires a whole-tab protection")
+	}
+
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	grid, err := resolveSheetsTarget(ctx, svc, spreadsheetID, c.Range)
+	if err != nil {
+		return err
+	}
+
+	pr := &sheets.ProtectedRange{
+		Range:       grid,
+		Description: strings.TrimSpace(c.Description),
+		WarningOnly: c.WarningOnly,
+	}
+	for _, raw := range c.Except {
+		ex, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, raw)
+		if err != nil {
+			return err
+		}
+		if ex.SheetId != grid.SheetId {
+			return usagef("--except range %q is not on the protected tab", raw)
+		}
+		pr.UnprotectedRanges = append(pr.UnprotectedRanges, ex)
+	}
+	if hasEditors {
+		pr.Editors = editors
+	}
+
+	resp, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		AddProtectedRange: &sheets.AddProtectedRangeRequest{ProtectedRange: pr},
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Replies) > 0 && resp.Replies[0].AddProtectedRange != nil && resp.Replies[0].AddProtectedRange.ProtectedRange != nil {
+		pr = resp.Replies[0].AddProtectedRange.ProtectedRange
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId":  spreadsheetID,
+			"protectedRange": pr,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Protected %s", cleanRange(c.Range))
+	u.Out().Printf("id\t%d", pr.ProtectedRangeId)
+	u.Out().Printf("warning_only\t%t", pr.WarningOnly)
+	if pr.Editors != nil {
+		u.Out().Printf("editors\t%s", formatSheetsEditors(pr.Editors))
+	}
+	return nil
+}
+
+type SheetsProtectRemoveCmd struct {
+	SpreadsheetID    string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	ProtectedRangeID string `arg:"" name:"protectedRangeId" help:"Protected range ID (see protect list)"`
+}
+
+func (c *SheetsProtectRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.ProtectedRangeID), 10, 64)
+	if err != nil {
+		return usagef("invalid protectedRangeId %q", c.ProtectedRangeID)
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("remove protected range %d", id)); err != nil {
+		return err
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		DeleteProtectedRange: &sheets.DeleteProtectedRangeRequest{ProtectedRangeId: id, ForceSendFields: []string{"ProtectedRangeId"}},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId":    spreadsheetID,
+			"removed":          true,
+			"protectedRangeId": id,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Removed protected range %d", id)
+	return nil
+}
+
+func describeSheetsProtectedRange(titles map[int64]string, pr *sheets.ProtectedRange) string {
+	if pr.NamedRangeId != "" {
+		return "named:" + pr.NamedRangeId
+	}
+	return formatGridRangeA1(titles[sheetsGridSheetID(pr.Range)], pr.Range)
+}
+
+func formatSheetsEditors(e *sheets.Editors) string {
+	if e == nil {
+		return "-"
+	}
+	parts := append([]string{}, e.Users...)
+	parts = append(parts, e.Groups...)
+	if e.DomainUsersCanEdit {
+		parts = append(parts, "domain")
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, ",")
+}
+
+func cleanSheetsEmails(in []string) []string {
+	var out []string
+	for _, v := range in {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// newSheetsProtectTestService serves a whole-tab protection (id 42) on Data
+// and answers addProtectedRange with id 43.
+func newSheetsProtectTestService(t *testing.T) *[]map[string]any {
+	t.Helper()
+	return newSheetsSpreadsheetTestService(t, map[string]any{
+		"sheets": []any{
+			map[string]any{
+				"properties":      map[string]any{"sheetId": 0, "title": "Data", "index": 0, "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26, "frozenRowCount": 1}},
+				"protectedRanges": []any{map[string]any{"protectedRangeId": 42, "range": map[string]any{"sheetId": 0}, "description": "Locked", "editors": map[string]any{"users": []any{"a@b.com"}}}},
+			},
+			map[string]any{"properties": map[string]any{"sheetId": 7, "title": "Archive", "index": 1, "hidden": true}},
+		},
+	}, map[string]any{
+		"addProtectedRange": map[string]any{"protectedRange": map[string]any{"protectedRangeId": 43, "warningOnly": true}},
+	})
+}
+
+func TestSheetsProtect_Commands(t *testing.T) {
+	batches := newSheetsProtectTestService(t)
+	ctx, _ := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsProtectListCmd{}, []string{"s1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "42") || !strings.Contains(out, "a@b.com") || !strings.Contains(out, "Locked") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsProtectAddCmd{}, []string{"s1", "Data", "--editors", "x@y.com,z@y.com", "--except", "Data!B2:B", "--description", "Inputs only"}, ctx, flags); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	pr := lastSheetsRequest(t, batches)["addProtectedRange"].(map[string]any)["protectedRange"].(map[string]any)
+	if pr["range"].(map[string]any)["sheetId"] != float64(0) || len(pr["editors"].(map[string]any)["users"].([]any)) != 2 || len(pr["unprotectedRanges"].([]any)) != 1 {
+		t.Fatalf("unexpected protected range: %v", pr)
+	}
+
+	if err := runKong(t, &SheetsProtectAddCmd{}, []string{"s1", "Data!A1:A5", "--warning-only"}, ctx, flags); err != nil {
+		t.Fatalf("add warning: %v", err)
+	}
+	pr = lastSheetsRequest(t, batches)["addProtectedRange"].(map[string]any)["protectedRange"].(map[string]any)
+	if pr["warningOnly"] != true || pr["editors"] != nil {
+		t.Fatalf("unexpected warning-only range: %v", pr)
+	}
+
+	if err := runKong(t, &SheetsProtectRemoveCmd{}, []string{"s1", "42"}, ctx, flags); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if del := lastSheetsRequest(t, batches)["deleteProtectedRange"].(map[string]any); del["protectedRangeId"] != float64(42) {
+		t.Fatalf("unexpected remove: %v", del)
+	}
+
+	if err := runKong(t, &SheetsProtectAddCmd{}, []string{"s1", "Data", "--warning-only", "--editors", "x@y.com"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "--warning-only") {
+		t.Fatalf("expected warning-only conflict, got %v", err)
+	}
+	if err := runKong(t, &SheetsProtectAddCmd{}, []string{"s1", "Data!A1:B2", "--except", "Data!A1"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "whole-tab") {
+		t.Fatalf("expected --except error, got %v", err)
+	}
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sheetsRecords is a header-keyed table read from a CSV or JSON file.
+// Columns keeps the first-seen column order; missing cells are absent from
+// the row maps rather than empty.
+type sheetsRecords struct {
+	Columns []string
+	Rows    []map[string]string
+}
+
+// readSheetsRecords loads records from path ("-" for stdin). format is
+// auto|json|csv; auto picks by file extension and falls back to sniffing.
+func readSheetsRecords(path, format string) (*sheetsRecords, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // user-provided path
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read data: %w", err)
+	}
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" || format == "auto" {
+		format = detectSheetsRecordsFormat(path, data)
+	}
+	switch format {
+	case "json":
+		return parseSheetsRecordsJSON(data)
+	case "csv":
+		return parseSheetsRecordsCSV(data)
+	default:
+		return nil, usagef("invalid data format %q (expected json or csv)", format)
+	}
+}
+
+func detectSheetsRecordsFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".csv":
+		return "csv"
+	}
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return "json"
+	}
+	return "csv"
+}
+
+func parseSheetsRecordsCSV(data []byte) (*sheetsRecords, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, usage("CSV data has no header row")
+	}
+
+	out := &sheetsRecords{}
+	for i, name := range rows[0] {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if name == "" {
+			return nil, usagef("CSV header column %d is empty", i+1)
+		}
+		out.Columns = append(out.Columns, name)
+	}
+	for _, row := range rows[1:] {
+		if len(row) == 1 && strings.TrimSpace(row[0]) == "" {
+			continue
+		}
+		rec := make(map[string]string, len(out.Columns))
+		for i, v := range row {
+			if i < len(out.Columns) {
+				rec[out.Columns[i]] = v
+			}
+		}
+		out.Rows = append(out.Rows, rec)
+	}
+	return out, nil
+}
+
+// parseSheetsRecordsJSON accepts an array of flat objects. Scalars are
+// converted to their spreadsheet text form; nested values are kept as JSON.
+func parseSheetsRecordsJSON(data []byte) (*sheetsRecords, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse JSON (expected an array of objects): %w", err)
+	}
+
+	out := &sheetsRecords{}
+	seen := map[string]bool{}
+	for i, item := range raw {
+		keys, rec, err := decodeSheetsRecordObject(item)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+		for _, k := range keys {
+			if !seen[k] {
+				seen[k] = true
+				out.Columns = append(out.Columns, k)
+			}
+		}
+		out.Rows = append(out.Rows, rec)
+	}
+	return out, nil
+}
+
+func decodeSheetsRecordObject(raw json.RawMessage) ([]string, map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, fmt.Errorf("expected a JSON object")
+	}
+
+	var keys []string
+	rec := map[string]string{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := tok.(string)
+		key = strings.TrimSpace(key)
+
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if key == "" {
+			continue
+		}
+		if _, dup := rec[key]; !dup {
+			keys = append(keys, key)
+		}
+		rec[key] = sheetsCellText(value)
+	}
+	return keys, rec, nil
+}
+
+func sheetsCellText(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case json.Number:
+		return t.String()
+	case bool:
+		if t {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(b)
+	}
+}
+
+// sheetsSerialEpoch is day zero of the spreadsheet date serial system.
+var sheetsSerialEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+func sheetsSerialToTime(serial float64) time.Time {
+	return sheetsSerialEpoch.Add(time.Duration(math.Round(serial*86400)) * time.Second)
+}
+
+// formatSheetsSerial renders a date serial according to the cell's number
+// format type (DATE, DATE_TIME or TIME).
+func formatSheetsSerial(serial float64, formatType string) (string, bool) {
+	t := sheetsSerialToTime(serial)
+	switch formatType {
+	case "DATE":
+		return t.Format("2006-01-02"), true
+	case "DATE_TIME":
+		return t.Format("2006-01-02T15:04:05"), true
+	case "TIME":
+		return t.Format("15:04:05"), true
+	default:
+		return "", false
+	}
+}
+
+// sheetsValuesToRecords maps rows to objects keyed by the header row. Header
+// cells that are blank fall back to the column letter; fully empty rows are
+// skipped. formats, when non-nil, holds number format types aligned with
+// values and is used to turn date serials into ISO strings.
+func sheetsValuesToRecords(values [][]interface{}, formats [][]string, startCol int) ([]string, []map[string]any) {
+	if len(values) == 0 {
+		return []string{}, []map[string]any{}
+	}
+
+	columns := make([]string, len(values[0]))
+	for i, cell := range values[0] {
+		name := strings.TrimSpace(fmt.Sprint(cell))
+		if name == "" {
+			name = colIndexToLetters(startCol + i)
+		}
+		columns[i] = name
+	}
+
+	records := make([]map[string]any, 0, len(values)-1)
+	for r := 1; r < len(values); r++ {
+		row := values[r]
+		empty := true
+		rec := make(map[string]any, len(columns))
+		for i, name := range columns {
+			var v any
+			if i < len(row) {
+				v = row[i]
+			}
+			if s, ok := v.(string); ok && s == "" {
+				v = nil
+			}
+			if n, ok := v.(float64); ok && r < len(formats) && i < len(formats[r]) {
+				if s, ok := formatSheetsSerial(n, formats[r][i]); ok {
+					v = s
+				}
+			}
+			if v != nil {
+				empty = false
+			}
+			rec[name] = v
+		}
+		if !empty {
+			records = append(records, rec)
+		}
+	}
+	return columns, records
+}
+
+var (
+	sheetsNumberRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+	// sheetsNumericLikeRe matches text USER_ENTERED would parse as a number,
+	// currency or percentage.
+	sheetsNumericLikeRe = regexp.MustCompile(`^[-+]?\$?[0-9][0-9,]*(\.[0-9]+)?([eE][-+]?[0-9]+)?%?$`)
+	sheetsDateLayout    = []string{"2006-01-02", "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04:05Z07:00"}
+)
+
+// inferSheetsColumnType classifies a column as number, boolean, date or
+// string. Values with leading zeros stay strings so IDs and ZIP codes keep
+// their digits.
+func inferSheetsColumnType(values []string) string {
+	kinds := map[string]bool{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		switch {
+		case sheetsNumberRe.MatchString(v):
+			kinds["number"] = true
+		case strings.EqualFold(v, "true") || strings.EqualFold(v, "false"):
+			kinds["boolean"] = true
+		case parseSheetsDate(v) != "":
+			kinds["date"] = true
+		default:
+			return "string"
+		}
+	}
+	if len(kinds) != 1 {
+		return "string"
+	}
+	for k := range kinds {
+		return k
+	}
+	return "string"
+}
+
+func parseSheetsDate(v string) string {
+	for _, layout := range sheetsDateLayout {
+		t, err := time.Parse(layout, v)
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			return t.Format("2006-01-02")
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return ""
+}
+
+// sheetsTypedCell converts text to the value sent with USER_ENTERED input.
+// Strings that Sheets would reinterpret (numbers, dates, formulas) are
+// prefixed with an apostrophe so they stay literal.
+func sheetsTypedCell(v, kind string) any {
+	trimmed := strings.TrimSpace(v)
+	if trimmed == "" {
+		return ""
+	}
+	switch kind {
+	case "number":
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		return strings.EqualFold(trimmed, "true")
+	case "date":
+		if d := parseSheetsDate(trimmed); d != "" {
+			return d
+		}
+	}
+	if sheetsNeedsLiteralPrefix(v) {
+		return "'" + v
+	}
+	return v
+}
+
+func sheetsNeedsLiteralPrefix(v string) bool {
+	trimmed := strings.TrimSpace(v)
+	if trimmed == "" {
+		return false
+	}
+	switch trimmed[0] {
+	case '=', '+', '\'':
+		return true
+	}
+	if sheetsNumericLikeRe.MatchString(trimmed) {
+		return true
+	}
+	if strings.EqualFold(trimmed, "true") || strings.EqualFold(trimmed, "false") {
+		return true
+	}
+	return parseSheetsDate(trimmed) != ""
+}
@@ -0,0 +1,591 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	sheetsDimensionRows    = "ROWS"
+	sheetsDimensionColumns = "COLUMNS"
+)
+
+type SheetsRowsCmd struct {
+	Insert     SheetsRowsInsertCmd     `cmd:"" name:"insert" help:"Insert empty rows before a row span (eg. Sheet1!5:7 inserts 3 rows at 5)"`
+	Delete     SheetsRowsDeleteCmd     `cmd:"" name:"delete" aliases:"rm" help:"Delete rows (eg. Sheet1!5:7)"`
+	Resize     SheetsRowsResizeCmd     `cmd:"" name:"resize" help:"Set row height in pixels"`
+	AutoResize SheetsRowsAutoResizeCmd `cmd:"" name:"auto-resize" help:"Fit row height to content"`
+}
+
+type SheetsColsCmd struct {
+	Insert     SheetsColsInsertCmd     `cmd:"" name:"insert" help:"Insert empty columns before a column span (eg. Sheet1!B:C inserts 2 columns at B)"`
+	Delete     SheetsColsDeleteCmd     `cmd:"" name:"delete" aliases:"rm" help:"Delete columns (eg. Sheet1!B:D)"`
+	Resize     SheetsColsResizeCmd     `cmd:"" name:"resize" help:"Set column width in pixels"`
+	AutoResize SheetsColsAutoResizeCmd `cmd:"" name:"auto-resize" help:"Fit column width to content"`
+}
+
+type sheetsDimensionInsertCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Span          string `arg:"" name:"span" help:"Sheet-qualified span: rows (Sheet1!5:7) or columns (Sheet1!B:D)"`
+	InheritBefore bool   `name:"inherit-before" help:"Copy formatting from the row/column before instead of after"`
+}
+
+type sheetsDimensionDeleteCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Span          string `arg:"" name:"span" help:"Sheet-qualified span: rows (Sheet1!5:7) or columns (Sheet1!B:D)"`
+}
+
+type sheetsDimensionResizeCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Span          string `arg:"" name:"span" help:"Sheet-qualified span: rows (Sheet1!5:7) or columns (Sheet1!B:D)"`
+	Size          int64  `name:"size" required:"" help:"Height (rows) or width (columns) in pixels"`
+}
+
+type sheetsDimensionAutoResizeCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Span          string `arg:"" name:"span" help:"Sheet-qualified span: rows (Sheet1!5:7) or columns (Sheet1!B:D)"`
+}
+
+type (
+	SheetsRowsInsertCmd     sheetsDimensionInsertCmd
+	SheetsColsInsertCmd     sheetsDimensionInsertCmd
+	SheetsRowsDeleteCmd     sheetsDimensionDeleteCmd
+	SheetsColsDeleteCmd     sheetsDimensionDeleteCmd
+	SheetsRowsResizeCmd     sheetsDimensionResizeCmd
+	SheetsColsResizeCmd     sheetsDimensionResizeCmd
+	SheetsRowsAutoResizeCmd sheetsDimensionAutoResizeCmd
+	SheetsColsAutoResizeCmd sheetsDimensionAutoResizeCmd
+)
+
+func (c *SheetsRowsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionInsertCmd)(c).run(ctx, flags, sheetsDimensionRows)
+}
+
+func (c *SheetsColsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionInsertCmd)(c).run(ctx, flags, sheetsDimensionColumns)
+}
+
+func (c *SheetsRowsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionDeleteCmd)(c).run(ctx, flags, sheetsDimensionRows)
+}
+
+func (c *SheetsColsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionDeleteCmd)(c).run(ctx, flags, sheetsDimensionColumns)
+}
+
+func (c *SheetsRowsResizeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionResizeCmd)(c).run(ctx, flags, sheetsDimensionRows)
+}
+
+func (c *SheetsColsResizeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionResizeCmd)(c).run(ctx, flags, sheetsDimensionColumns)
+}
+
+func (c *SheetsRowsAutoResizeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionAutoResizeCmd)(c).run(ctx, flags, sheetsDimensionRows)
+}
+
+func (c *SheetsColsAutoResizeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return (*sheetsDimensionAutoResizeCmd)(c).run(ctx, flags, sheetsDimensionColumns)
+}
+
+// sheetsDimensionTarget is a resolved row or column span.
+type sheetsDimensionTarget struct {
+	SpreadsheetID string
+	Sheet         string
+	Dimension     string
+	Start, End    int
+	Range         *sheets.DimensionRange
+}
+
+func resolveSheetsDimension(ctx context.Context, flags *RootFlags, spreadsheetID, span, dimension string) (*sheets.Service, *sheetsDimensionTarget, error) {
+	sheetName, start, end, err := parseA1Span(span, dimension)
+	if err != nil {
+		return nil, nil, usage(err.Error())
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, spreadsheetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sheetID, ok := sheetIDs[sheetName]
+	if !ok {
+		return nil, nil, usagef("tab %q not found", sheetName)
+	}
+	return svc, &sheetsDimensionTarget{
+		SpreadsheetID: spreadsheetID,
+		Sheet:         sheetName,
+		Dimension:     dimension,
+		Start:         start,
+		End:           end,
+		Range: &sheets.DimensionRange{
+			SheetId:         sheetID,
+			Dimension:       dimension,
+			StartIndex:      int64(start - 1),
+			EndIndex:        int64(end),
+			ForceSendFields: []string{"SheetId", "StartIndex"},
+		},
+	}, nil
+}
+
+func (c *sheetsDimensionInsertCmd) run(ctx context.Context, flags *RootFlags, dimension string) error {
+	svc, target, err := resolveSheetsDimension(ctx, flags, c.SpreadsheetID, c.Span, dimension)
+	if err != nil {
+		return err
+	}
+	if c.InheritBefore && target.Start == 1 {
+		return usage("--inherit-before needs a row/column before the span")
+	}
+	_, err = sheetsBatchUpdate(ctx, svc, target.SpreadsheetID, &sheets.Request{
+		InsertDimension: &sheets.InsertDimensionRequest{Range: target.Range, InheritFromBefore: c.InheritBefore},
+	})
+	if err != nil {
+		return err
+	}
+	return writeSheetsDimension(ctx, "Inserted", target, nil)
+}
+
+func (c *sheetsDimensionDeleteCmd) run(ctx context.Context, flags *RootFlags, dimension string) error {
+	svc, target, err := resolveSheetsDimension(ctx, flags, c.SpreadsheetID, c.Span, dimension)
+	if err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete %s from %s", describeSheetsDimension(target), target.Sheet)); err != nil {
+		return err
+	}
+	_, err = sheetsBatchUpdate(ctx, svc, target.SpreadsheetID, &sheets.Request{
+		DeleteDimension: &sheets.DeleteDimensionRequest{Range: target.Range},
+	})
+	if err != nil {
+		return err
+	}
+	return writeSheetsDimension(ctx, "Deleted", target, nil)
+}
+
+func (c *sheetsDimensionResizeCmd) run(ctx context.Context, flags *RootFlags, dimension string) error {
+	if c.Size <= 0 {
+		return usage("--size must be > 0")
+	}
+	svc, target, err := resolveSheetsDimension(ctx, flags, c.SpreadsheetID, c.Span, dimension)
+	if err != nil {
+		return err
+	}
+	_, err = sheetsBatchUpdate(ctx, svc, target.SpreadsheetID, &sheets.Request{
+		UpdateDimensionProperties: &sheets.UpdateDimensionPropertiesRequest{
+			Range:      target.Range,
+			Properties: &sheets.DimensionProperties{PixelSize: c.Size},
+			Fields:     "pixelSize",
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return writeSheetsDimension(ctx, "Resized", target, map[string]any{"pixelSize": c.Size})
+}
+
+func (c *sheetsDimensionAutoResizeCmd) run(ctx context.Context, flags *RootFlags, dimension string) error {
+	svc, target, err := resolveSheetsDimension(ctx, flags, c.SpreadsheetID, c.Span, dimension)
+	if err != nil {
+		return err
+	}
+	_, err = sheetsBatchUpdate(ctx, svc, target.SpreadsheetID, &sheets.Request{
+		AutoResizeDimensions: &sheets.AutoResizeDimensionsRequest{Dimensions: target.Range},
+	})
+	if err != nil {
+		return err
+	}
+	return writeSheetsDimension(ctx, "Auto-resized", target, nil)
+}
+
+func describeSheetsDimension(t *sheetsDimensionTarget) string {
+	n := t.End - t.Start + 1
+	if t.Dimension == sheetsDimensionRows {
+		if n == 1 {
+			return fmt.Sprintf("row %d", t.Start)
+		}
+		return fmt.Sprintf("%d rows (%d-%d)", n, t.Start, t.End)
+	}
+	if n == 1 {
+		return "column " + colIndexToLetters(t.Start)
+	}
+	return fmt.Sprintf("%d columns (%s-%s)", n, colIndexToLetters(t.Start), colIndexToLetters(t.End))
+}
+
+func writeSheetsDimension(ctx context.Context, verb string, t *sheetsDimensionTarget, extra map[string]any) error {
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{
+			"spreadsheetId": t.SpreadsheetID,
+			"sheet":         t.Sheet,
+			"dimension":     t.Dimension,
+			"start":         t.Start,
+			"end":           t.End,
+		}
+		for k, v := range extra {
+			out[k] = v
+		}
+		return outfmt.WriteJSON(os.Stdout, out)
+	}
+	ui.FromContext(ctx).Out().Printf("%s %s in %s", verb, describeSheetsDimension(t), t.Sheet)
+	return nil
+}
+
+type SheetsFreezeCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+	Rows          *int64 `name:"rows" help:"Number of frozen rows (0 to unfreeze)"`
+	Cols          *int64 `name:"cols" help:"Number of frozen columns (0 to unfreeze)"`
+}
+
+func (c *SheetsFreezeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Rows == nil && c.Cols == nil {
+		return usage("provide --rows and/or --cols")
+	}
+	if (c.Rows != nil && *c.Rows < 0) || (c.Cols != nil && *c.Cols < 0) {
+		return usage("--rows and --cols must be >= 0")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	tab, err := resolveSheetTab(ctx, svc, spreadsheetID, c.Tab)
+	if err != nil {
+		return err
+	}
+
+	grid := &sheets.GridProperties{}
+	var fields []string
+	if c.Rows != nil {
+		grid.FrozenRowCount = *c.Rows
+		grid.ForceSendFields = append(grid.ForceSendFields, "FrozenRowCount")
+		fields = append(fields, "gridProperties.frozenRowCount")
+	}
+	if c.Cols != nil {
+		grid.FrozenColumnCount = *c.Cols
+		grid.ForceSendFields = append(grid.ForceSendFields, "FrozenColumnCount")
+		fields = append(fields, "gridProperties.frozenColumnCount")
+	}
+	_, err = sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{
+			Properties: &sheets.SheetProperties{SheetId: tab.SheetId, GridProperties: grid, ForceSendFields: []string{"SheetId"}},
+			Fields:     strings.Join(fields, ","),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{"spreadsheetId": spreadsheetID, "sheet": tab.Title}
+		if c.Rows != nil {
+			out["frozenRows"] = *c.Rows
+		}
+		if c.Cols != nil {
+			out["frozenCols"] = *c.Cols
+		}
+		return outfmt.WriteJSON(os.Stdout, out)
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Froze %s", tab.Title)
+	if c.Rows != nil {
+		u.Out().Printf("rows\t%d", *c.Rows)
+	}
+	if c.Cols != nil {
+		u.Out().Printf("cols\t%d", *c.Cols)
+	}
+	return nil
+}
+
+type SheetsSortCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string   `arg:"" name:"range" help:"Range to sort (eg. Sheet1!A1:D100 or Sheet1!A:D)"`
+	By            []string `name:"by" required:"" help:"Sort column(s) as letter[:asc|desc], first wins (eg. C:desc,A)"`
+	Header        bool     `name:"header" help:"Leave the first row of the range in place"`
+}
+
+func (c *SheetsSortCmd) Run(ctx context.Context, flags *RootFlags) error {
+	specs, err := parseSheetsSortSpecs(c.By)
+	if err != nil {
+		return err
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	grid, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, c.Range)
+	if err != nil {
+		return err
+	}
+	if c.Header {
+		grid.StartRowIndex++
+	}
+	if err := checkSheetsSortColumns(grid, specs); err != nil {
+		return err
+	}
+
+	_, err = sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		SortRange: &sheets.SortRangeRequest{Range: grid, SortSpecs: specs},
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         cleanRange(c.Range),
+			"sortSpecs":     specs,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Sorted %s by %s", cleanRange(c.Range), strings.Join(c.By, ","))
+	return nil
+}
+
+type SheetsFilterCmd struct {
+	Set   SheetsFilterSetCmd   `cmd:"" name:"set" help:"Set the basic filter on a range"`
+	Clear SheetsFilterClearCmd `cmd:"" name:"clear" help:"Remove the basic filter from a tab"`
+}
+
+type SheetsFilterSetCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string   `arg:"" name:"range" help:"Filter range including the header row (eg. Sheet1!A1:F)"`
+	Hide          []string `name:"hide" sep:"none" help:"Hide rows whose column has one of these values: COL=v1|v2 (repeatable)"`
+	Sort          []string `name:"sort" help:"Sort column(s) as letter[:asc|desc]"`
+}
+
+func (c *SheetsFilterSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	specs, err := parseSheetsSortSpecs(c.Sort)
+	if err != nil {
+		return err
+	}
+	filter := &sheets.BasicFilter{SortSpecs: specs}
+	for _, raw := range c.Hide {
+		col, values, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(col) == "" {
+			return usagef("invalid --hide %q (expected COL=v1|v2)", raw)
+		}
+		idx, err := colLettersToIndex(col)
+		if err != nil {
+			return usagef("invalid --hide column %q", col)
+		}
+		filter.FilterSpecs = append(filter.FilterSpecs, &sheets.FilterSpec{
+			ColumnIndex:     int64(idx - 1),
+			FilterCriteria:  &sheets.FilterCriteria{HiddenValues: strings.Split(values, "|")},
+			ForceSendFields: []string{"ColumnIndex"},
+		})
+	}
+
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	grid, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, c.Range)
+	if err != nil {
+		return err
+	}
+	if err := checkSheetsSortColumns(grid, specs); err != nil {
+		return err
+	}
+	filter.Range = grid
+
+	_, err = sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		SetBasicFilter: &sheets.SetBasicFilterRequest{Filter: filter},
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         cleanRange(c.Range),
+			"filter":        filter,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Filter set on %s", cleanRange(c.Range))
+	return nil
+}
+
+type SheetsFilterClearCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+}
+
+func (c *SheetsFilterClearCmd) Run(ctx context.Context, flags *RootFlags) error {
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	tab, err := resolveSheetTab(ctx, svc, spreadsheetID, c.Tab)
+	if err != nil {
+		return err
+	}
+	_, err = sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		ClearBasicFilter: &sheets.ClearBasicFilterRequest{SheetId: tab.SheetId, ForceSendFields: []string{"SheetId"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"sheet":         tab.Title,
+			"cleared":       true,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Filter cleared on %s", tab.Title)
+	return nil
+}
+
+func parseSheetsSortSpecs(raw []string) ([]*sheets.SortSpec, error) {
+	specs := make([]*sheets.SortSpec, 0, len(raw))
+	for _, item := range raw {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		col, order, _ := strings.Cut(item, ":")
+		idx, err := colLettersToIndex(col)
+		if err != nil {
+			return nil, usagef("invalid sort column %q", item)
+		}
+		spec := &sheets.SortSpec{DimensionIndex: int64(idx - 1), SortOrder: "ASCENDING", ForceSendFields: []string{"DimensionIndex"}}
+		switch strings.ToLower(strings.TrimSpace(order)) {
+		case "", "asc", "ascending":
+		case "desc", "descending":
+			spec.SortOrder = "DESCENDING"
+		default:
+			return nil, usagef("invalid sort order %q (expected asc or desc)", order)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func checkSheetsSortColumns(grid *sheets.GridRange, specs []*sheets.SortSpec) error {
+	for _, spec := range specs {
+		if spec.DimensionIndex < grid.StartColumnIndex || (grid.EndColumnIndex > 0 && spec.DimensionIndex >= grid.EndColumnIndex) {
+			return usagef("sort column %s is outside the range", colIndexToLetters(int(spec.DimensionIndex)+1))
+		}
+	}
+	return nil
+}
+
+// resolveSheetsGridRange converts a sheet-qualified A1 range to a GridRange.
+// Open-ended column ranges (Sheet1!A:D, Sheet1!A2:D) leave the row end unset.
+func resolveSheetsGridRange(ctx context.Context, svc *sheets.Service, spreadsheetID, a1 string) (*sheets.GridRange, error) {
+	raw := cleanRange(strings.TrimSpace(a1))
+	sheetName, part, err := splitA1Sheet(raw)
+	if err != nil {
+		return nil, usage(err.Error())
+	}
+	if sheetName == "" {
+		return nil, usagef("range %q must include a sheet name", raw)
+	}
+
+	parts := strings.Split(strings.ReplaceAll(part, "$", ""), ":")
+	if len(parts) > 2 {
+		return nil, usagef("invalid range %q", raw)
+	}
+	startCol, startRow, err := parseA1Bound(parts[0])
+	if err != nil {
+		return nil, usage(err.Error())
+	}
+	endCol, endRow := startCol, startRow
+	if len(parts) == 2 {
+		if endCol, endRow, err = parseA1Bound(parts[1]); err != nil {
+			return nil, usage(err.Error())
+		}
+	}
+	if endCol < startCol {
+		startCol, endCol = endCol, startCol
+	}
+	if startRow > 0 && endRow > 0 && endRow < startRow {
+		startRow, endRow = endRow, startRow
+	}
+
+	sheetIDs, err := fetchSheetIDMap(ctx, svc, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	sheetID, ok := sheetIDs[sheetName]
+	if !ok {
+		return nil, usagef("tab %q not found", sheetName)
+	}
+
+	grid := &sheets.GridRange{
+		SheetId:          sheetID,
+		StartColumnIndex: int64(startCol - 1),
+		EndColumnIndex:   int64(endCol),
+		ForceSendFields:  []string{"SheetId"},
+	}
+	if startRow > 0 {
+		grid.StartRowIndex = int64(startRow - 1)
+	}
+	if endRow > 0 {
+		grid.EndRowIndex = int64(endRow)
+	}
+	return grid, nil
+}
+
+// parseA1Bound parses a cell (B2) or bare column (B) reference; row is 0
+// for a bare column.
+func parseA1Bound(ref string) (int, int, error) {
+	ref = strings.TrimSpace(ref)
+	if a1ColRe.MatchString(ref) {
+		col, err := colLettersToIndex(ref)
+		return col, 0, err
+	}
+	return parseA1Cell(ref)
+}
+
+// resolveSheetsTarget accepts either a bare tab title (whole sheet) or a
+// sheet-qualified A1 range.
+func resolveSheetsTarget(ctx context.Context, svc *sheets.Service, spreadsheetID, ref string) (*sheets.GridRange, error) {
+	ref = cleanRange(strings.TrimSpace(ref))
+	if !strings.Contains(ref, "!") {
+		tab, err := resolveSheetTab(ctx, svc, spreadsheetID, ref)
+		if err != nil {
+			return nil, err
+		}
+		return &sheets.GridRange{SheetId: tab.SheetId, ForceSendFields: []string{"SheetId"}}, nil
+	}
+	return resolveSheetsGridRange(ctx, svc, spreadsheetID, ref)
+}
+
+// formatGridRangeA1 renders a GridRange as A1 notation. Unset end indexes
+// mean the range is open in that direction.
+func formatGridRangeA1(title string, g *sheets.GridRange) string {
+	if g == nil {
+		return ""
+	}
+	sheet := quoteSheetName(title)
+	hasCols := g.EndColumnIndex > 0
+	hasRows := g.EndRowIndex > 0
+	startCol := colIndexToLetters(int(g.StartColumnIndex) + 1)
+
+	var ref string
+	switch {
+	case hasCols && hasRows:
+		ref = fmt.Sprintf("%s%d:%s%d", startCol, g.StartRowIndex+1, colIndexToLetters(int(g.EndColumnIndex)), g.EndRowIndex)
+	case hasCols && g.StartRowIndex > 0:
+		ref = fmt.Sprintf("%s%d:%s", startCol, g.StartRowIndex+1, colIndexToLetters(int(g.EndColumnIndex)))
+	case hasCols:
+		ref = fmt.Sprintf("%s:%s", startCol, colIndexToLetters(int(g.EndColumnIndex)))
+	case hasRows:
+		ref = fmt.Sprintf("%d:%d", g.StartRowIndex+1, g.EndRowIndex)
+	default:
+		return sheet
+	}
+	return sheet + "!" + ref
+}
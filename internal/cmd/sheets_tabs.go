@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsTabsCmd struct {
+	List      SheetsTabsListCmd      `cmd:"" name:"list" default:"withargs" help:"List tabs"`
+	Add       SheetsTabsAddCmd       `cmd:"" name:"add" help:"Add a tab"`
+	Rename    SheetsTabsRenameCmd    `cmd:"" name:"rename" help:"Rename a tab"`
+	Delete    SheetsTabsDeleteCmd    `cmd:"" name:"delete" aliases:"rm" help:"Delete a tab"`
+	Duplicate SheetsTabsDuplicateCmd `cmd:"" name:"duplicate" aliases:"dup" help:"Duplicate a tab"`
+	Move      SheetsTabsMoveCmd      `cmd:"" name:"move" help:"Move a tab to a new position"`
+	Hide      SheetsTabsHideCmd      `cmd:"" name:"hide" help:"Hide a tab"`
+	Unhide    SheetsTabsUnhideCmd    `cmd:"" name:"unhide" help:"Show a hidden tab"`
+}
+
+type SheetsTabsListCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+}
+
+func (c *SheetsTabsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	props, err := fetchSheetProperties(ctx, svc, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"tabs":          props,
+		})
+	}
+
+	if len(props) == 0 {
+		u.Err().Println("No tabs")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tINDEX\tTITLE\tROWS\tCOLS\tFROZEN\tHIDDEN")
+	for _, p := range props {
+		var rows, cols, frozenRows, frozenCols int64
+		if g := p.GridProperties; g != nil {
+			rows, cols, frozenRows, frozenCols = g.RowCount, g.ColumnCount, g.FrozenRowCount, g.FrozenColumnCount
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%d\t%d/%d\t%t\n", p.SheetId, p.Index, p.Title, rows, cols, frozenRows, frozenCols, p.Hidden)
+	}
+	return nil
+}
+
+type SheetsTabsAddCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Title         string `arg:"" name:"title" help:"Tab title"`
+	Index         *int64 `name:"index" help:"Zero-based position (default: last)"`
+	Rows          int64  `name:"rows" help:"Initial row count"`
+	Cols          int64  `name:"cols" help:"Initial column count"`
+	Hidden        bool   `name:"hidden" help:"Create the tab hidden"`
+}
+
+func (c *SheetsTabsAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		return usage("empty title")
+	}
+	if c.Rows < 0 || c.Cols < 0 {
+		return usage("--rows and --cols must be positive")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	props := &sheets.SheetProperties{Title: title, Hidden: c.Hidden}
+	if c.Index != nil {
+		props.Index = *c.Index
+		props.ForceSendFields = []string{"Index"}
+	}
+	if c.Rows > 0 || c.Cols > 0 {
+		props.GridProperties = &sheets.GridProperties{RowCount: c.Rows, ColumnCount: c.Cols}
+	}
+
+	resp, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{AddSheet: &sheets.AddSheetRequest{Properties: props}})
+	if err != nil {
+		return err
+	}
+	if len(resp.Replies) > 0 && resp.Replies[0].AddSheet != nil && resp.Replies[0].AddSheet.Properties != nil {
+		props = resp.Replies[0].AddSheet.Properties
+	}
+	return writeSheetsTab(ctx, "Added", spreadsheetID, props)
+}
+
+type SheetsTabsRenameCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+	Title         string `arg:"" name:"title" help:"New title"`
+}
+
+func (c *SheetsTabsRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		return usage("empty title")
+	}
+	return updateSheetsTab(ctx, flags, c.SpreadsheetID, c.Tab, "Renamed", "title", func(p *sheets.SheetProperties) {
+		p.Title = title
+	})
+}
+
+type SheetsTabsMoveCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+	Index         int64  `name:"index" required:"" help:"Zero-based target position"`
+}
+
+func (c *SheetsTabsMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Index < 0 {
+		return usage("--index must be >= 0")
+	}
+	return updateSheetsTab(ctx, flags, c.SpreadsheetID, c.Tab, "Moved", "index", func(p *sheets.SheetProperties) {
+		p.Index = c.Index
+		p.ForceSendFields = append(p.ForceSendFields, "Index")
+	})
+}
+
+type SheetsTabsHideCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+}
+
+func (c *SheetsTabsHideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return updateSheetsTab(ctx, flags, c.SpreadsheetID, c.Tab, "Hid", "hidden", func(p *sheets.SheetProperties) {
+		p.Hidden = true
+	})
+}
+
+type SheetsTabsUnhideCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+}
+
+func (c *SheetsTabsUnhideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return updateSheetsTab(ctx, flags, c.SpreadsheetID, c.Tab, "Unhid", "hidden", func(p *sheets.SheetProperties) {
+		p.Hidden = false
+		p.ForceSendFields = append(p.ForceSendFields, "Hidden")
+	})
+}
+
+type SheetsTabsDeleteCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+}
+
+func (c *SheetsTabsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	tab, err := resolveSheetTab(ctx, svc, spreadsheetID, c.Tab)
+	if err != nil {
+		return err
+	}
+	if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete tab %q", tab.Title)); err != nil {
+		return err
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{DeleteSheet: &sheets.DeleteSheetRequest{SheetId: tab.SheetId}}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"deleted":       true,
+			"sheetId":       tab.SheetId,
+			"title":         tab.Title,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Deleted tab %s", tab.Title)
+	return nil
+}
+
+type SheetsTabsDuplicateCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Tab           string `arg:"" name:"tab" help:"Tab title or sheet ID"`
+	Title         string `name:"title" help:"Title for the copy (default: Sheets picks \"Copy of ...\")"`
+	Index         *int64 `name:"index" help:"Zero-based position for the copy (default: after the source)"`
+}
+
+func (c *SheetsTabsDuplicateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	tab, err := resolveSheetTab(ctx, svc, spreadsheetID, c.Tab)
+	if err != nil {
+		return err
+	}
+
+	req := &sheets.DuplicateSheetRequest{
+		SourceSheetId:    tab.SheetId,
+		NewSheetName:     strings.TrimSpace(c.Title),
+		InsertSheetIndex: tab.Index + 1,
+		ForceSendFields:  []string{"SourceSheetId"},
+	}
+	if c.Index != nil {
+		req.InsertSheetIndex = *c.Index
+		req.ForceSendFields = append(req.ForceSendFields, "InsertSheetIndex")
+	}
+
+	resp, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{DuplicateSheet: req})
+	if err != nil {
+		return err
+	}
+	props := &sheets.SheetProperties{Title: req.NewSheetName}
+	if len(resp.Replies) > 0 && resp.Replies[0].DuplicateSheet != nil && resp.Replies[0].DuplicateSheet.Properties != nil {
+		props = resp.Replies[0].DuplicateSheet.Properties
+	}
+	return writeSheetsTab(ctx, "Duplicated", spreadsheetID, props)
+}
+
+func updateSheetsTab(ctx context.Context, flags *RootFlags, spreadsheetID, tabRef, verb, fields string, apply func(*sheets.SheetProperties)) error {
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	tab, err := resolveSheetTab(ctx, svc, spreadsheetID, tabRef)
+	if err != nil {
+		return err
+	}
+
+	props := &sheets.SheetProperties{SheetId: tab.SheetId, ForceSendFields: []string{"SheetId"}}
+	apply(props)
+	_, err = sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		UpdateSheetProperties: &sheets.UpdateSheetPropertiesRequest{Properties: props, Fields: fields},
+	})
+	if err != nil {
+		return err
+	}
+
+	updated := *tab
+	switch fields {
+	case "title":
+		updated.Title = props.Title
+	case "index":
+		updated.Index = props.Index
+	case "hidden":
+		updated.Hidden = props.Hidden
+	}
+	return writeSheetsTab(ctx, verb, spreadsheetID, &updated)
+}
+
+func writeSheetsTab(ctx context.Context, verb, spreadsheetID string, props *sheets.SheetProperties) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"tab":           props,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("%s tab %s", verb, props.Title)
+	u.Out().Printf("id\t%d", props.SheetId)
+	u.Out().Printf("index\t%d", props.Index)
+	u.Out().Printf("hidden\t%t", props.Hidden)
+	return nil
+}
+
+// sheetsServiceFor validates the spreadsheet ID and account and returns a
+// Sheets client.
+func sheetsServiceFor(ctx context.Context, flags *RootFlags, spreadsheetID string) (string, *sheets.Service, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return "", nil, err
+	}
+	spreadsheetID = strings.TrimSpace(spreadsheetID)
+	if spreadsheetID == "" {
+		return "", nil, usage("empty spreadsheetId")
+	}
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return "", nil, err
+	}
+	return spreadsheetID, svc, nil
+}
+
+func sheetsBatchUpdate(ctx context.Context, svc *sheets.Service, spreadsheetID string, reqs ...*sheets.Request) (*sheets.BatchUpdateSpreadsheetResponse, error) {
+	return svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{Requests: reqs}).
+		Context(ctx).
+		Do()
+}
+
+func fetchSheetProperties(ctx context.Context, svc *sheets.Service, spreadsheetID string) ([]*sheets.SheetProperties, error) {
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Fields("sheets(properties)").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+	props := make([]*sheets.SheetProperties, 0, len(resp.Sheets))
+	for _, sheet := range resp.Sheets {
+		if sheet.Properties != nil {
+			props = append(props, sheet.Properties)
+		}
+	}
+	return props, nil
+}
+
+// resolveSheetTab finds a tab by exact title, falling back to a numeric
+// sheet ID.
+func resolveSheetTab(ctx context.Context, svc *sheets.Service, spreadsheetID, tab string) (*sheets.SheetProperties, error) {
+	tab = strings.TrimSpace(tab)
+	if tab == "" {
+		return nil, usage("empty tab")
+	}
+	props, err := fetchSheetProperties(ctx, svc, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range props {
+		if p.Title == tab {
+			return p, nil
+		}
+	}
+	if id, convErr := strconv.ParseInt(tab, 10, 64); convErr == nil {
+		for _, p := range props {
+			if p.SheetId == id {
+				return p, nil
+			}
+		}
+	}
+	return nil, usagef("tab %q not found", tab)
+}
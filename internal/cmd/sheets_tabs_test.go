@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// newSheetsBatchTestService serves tab metadata for "Data" (id 0) and
+// "Archive" (id 7) and records every spreadsheets.batchUpdate body.
+func newSheetsBatchTestService(t *testing.T) *[]map[string]any {
+	t.Helper()
+	var batches []map[string]any
+
+	stubGoogleService(t, &newSheetsService, sheets.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/spreadsheets/s1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"sheets": []any{
+				map[string]any{"properties": map[string]any{"sheetId": 0, "title": "Data", "index": 0, "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26, "frozenRowCount": 1}}},
+				map[string]any{"properties": map[string]any{"sheetId": 7, "title": "Archive", "index": 1, "hidden": true}},
+			}})
+		case r.Method == http.MethodPost && path == "/spreadsheets/s1:batchUpdate":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"replies": []any{map[string]any{
+				"addSheet":       map[string]any{"properties": map[string]any{"sheetId": 9, "title": "Q4", "index": 2}},
+				"duplicateSheet": map[string]any{"properties": map[string]any{"sheetId": 11, "title": "Copy of Data", "index": 1}},
+			}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return &batches
+}
+
+func lastSheetsRequest(t *testing.T, batches *[]map[string]any) map[string]any {
+	t.Helper()
+	if len(*batches) == 0 {
+		t.Fatal("expected a batchUpdate call")
+	}
+	reqs := (*batches)[len(*batches)-1]["requests"].([]any)
+	if len(reqs) != 1 {
+		t.Fatalf("expected one request, got %v", reqs)
+	}
+	return reqs[0].(map[string]any)
+}
+
+func TestSheetsTabs_Commands(t *testing.T) {
+	batches := newSheetsBatchTestService(t)
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsTabsListCmd{}, []string{"s1"}, ctx, flags); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Archive") || !strings.Contains(out, "1/0") {
+		t.Fatalf("unexpected list output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsTabsAddCmd{}, []string{"s1", "Q4", "--index", "0"}, ctx, flags); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	add := lastSheetsRequest(t, batches)["addSheet"].(map[string]any)["properties"].(map[string]any)
+	if add["title"] != "Q4" || add["index"] != float64(0) {
+		t.Fatalf("unexpected addSheet: %v", add)
+	}
+
+	if err := runKong(t, &SheetsTabsRenameCmd{}, []string{"s1", "7", "Old"}, ctx, flags); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	upd := lastSheetsRequest(t, batches)["updateSheetProperties"].(map[string]any)
+	if upd["fields"] != "title" || upd["properties"].(map[string]any)["sheetId"] != float64(7) {
+		t.Fatalf("unexpected rename: %v", upd)
+	}
+
+	if err := runKong(t, &SheetsTabsMoveCmd{}, []string{"s1", "Archive", "--index", "0"}, ctx, flags); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	upd = lastSheetsRequest(t, batches)["updateSheetProperties"].(map[string]any)
+	if upd["fields"] != "index" || upd["properties"].(map[string]any)["index"] != float64(0) {
+		t.Fatalf("expected explicit index 0, got %v", upd)
+	}
+
+	if err := runKong(t, &SheetsTabsUnhideCmd{}, []string{"s1", "Archive"}, ctx, flags); err != nil {
+		t.Fatalf("unhide: %v", err)
+	}
+	if props := lastSheetsRequest(t, batches)["updateSheetProperties"].(map[string]any)["properties"].(map[string]any); props["hidden"] != false {
+		t.Fatalf("expected explicit hidden=false, got %v", props)
+	}
+
+	if err := runKong(t, &SheetsTabsDuplicateCmd{}, []string{"s1", "Data"}, ctx, flags); err != nil {
+		t.Fatalf("duplicate: %v", err)
+	}
+	dup := lastSheetsRequest(t, batches)["duplicateSheet"].(map[string]any)
+	if dup["sourceSheetId"] != float64(0) || dup["insertSheetIndex"] != float64(1) {
+		t.Fatalf("unexpected duplicate: %v", dup)
+	}
+
+	if err := runKong(t, &SheetsTabsDeleteCmd{}, []string{"s1", "Archive"}, ctx, flags); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if del := lastSheetsRequest(t, batches)["deleteSheet"].(map[string]any); del["sheetId"] != float64(7) {
+		t.Fatalf("unexpected delete: %v", del)
+	}
+
+	if !strings.Contains(textOut.String(), "Duplicated tab Copy of Data") {
+		t.Fatalf("unexpected output: %q", textOut.String())
+	}
+	if err := runKong(t, &SheetsTabsHideCmd{}, []string{"s1", "Nope"}, ctx, flags); err == nil || !strings.Contains(err.Error(), `tab "Nope" not found`) {
+		t.Fatalf("expected missing tab error, got %v", err)
+	}
+}
+
+func TestSheetsStructure_Commands(t *testing.T) {
+	batches := newSheetsBatchTestService(t)
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	if err := runKong(t, &SheetsRowsInsertCmd{}, []string{"s1", "Data!5:7", "--inherit-before"}, ctx, flags); err != nil {
+		t.Fatalf("rows insert: %v", err)
+	}
+	ins := lastSheetsRequest(t, batches)["insertDimension"].(map[string]any)
+	rng := ins["range"].(map[string]any)
+	if rng["dimension"] != "ROWS" || rng["startIndex"] != float64(4) || rng["endIndex"] != float64(7) || ins["inheritFromBefore"] != true {
+		t.Fatalf("unexpected insert: %v", ins)
+	}
+
+	if err := runKong(t, &SheetsColsDeleteCmd{}, []string{"s1", "Archive!B:D"}, ctx, flags); err != nil {
+		t.Fatalf("cols delete: %v", err)
+	}
+	rng = lastSheetsRequest(t, batches)["deleteDimension"].(map[string]any)["range"].(map[string]any)
+	if rng["dimension"] != "COLUMNS" || rng["sheetId"] != float64(7) || rng["startIndex"] != float64(1) || rng["endIndex"] != float64(4) {
+		t.Fatalf("unexpected delete: %v", rng)
+	}
+
+	if err := runKong(t, &SheetsColsResizeCmd{}, []string{"s1", "Data!A", "--size", "180"}, ctx, flags); err != nil {
+		t.Fatalf("cols resize: %v", err)
+	}
+	resize := lastSheetsRequest(t, batches)["updateDimensionProperties"].(map[string]any)
+	if resize["fields"] != "pixelSize" || resize["properties"].(map[string]any)["pixelSize"] != float64(180) {
+		t.Fatalf("unexpected resize: %v", resize)
+	}
+
+	if err := runKong(t, &SheetsFreezeCmd{}, []string{"s1", "Data", "--rows", "1", "--cols", "0"}, ctx, flags); err != nil {
+		t.Fatalf("freeze: %v", err)
+	}
+	freeze := lastSheetsRequest(t, batches)["updateSheetProperties"].(map[string]any)
+	grid := freeze["properties"].(map[string]any)["gridProperties"].(map[string]any)
+	if freeze["fields"] != "gridProperties.frozenRowCount,gridProperties.frozenColumnCount" || grid["frozenColumnCount"] != float64(0) {
+		t.Fatalf("unexpected freeze: %v", freeze)
+	}
+
+	if err := runKong(t, &SheetsSortCmd{}, []string{"s1", "Data!A:D", "--by", "C:desc,A", "--header"}, ctx, flags); err != nil {
+		t.Fatalf("sort: %v", err)
+	}
+	sortReq := lastSheetsRequest(t, batches)["sortRange"].(map[string]any)
+	sortRange := sortReq["range"].(map[string]any)
+	specs := sortReq["sortSpecs"].([]any)
+	if sortRange["startRowIndex"] != float64(1) || sortRange["endColumnIndex"] != float64(4) || sortRange["endRowIndex"] != nil {
+		t.Fatalf("unexpected sort range: %v", sortRange)
+	}
+	if specs[0].(map[string]any)["sortOrder"] != "DESCENDING" || specs[1].(map[string]any)["dimensionIndex"] != float64(0) {
+		t.Fatalf("unexpected sort specs: %v", specs)
+	}
+
+	if err := runKong(t, &SheetsFilterSetCmd{}, []string{"s1", "Data!A1:F", "--hide", "C=Done|Archived"}, ctx, flags); err != nil {
+		t.Fatalf("filter set: %v", err)
+	}
+	filter := lastSheetsRequest(t, batches)["setBasicFilter"].(map[string]any)["filter"].(map[string]any)
+	spec := filter["filterSpecs"].([]any)[0].(map[string]any)
+	if spec["columnIndex"] != float64(2) || len(spec["filterCriteria"].(map[string]any)["hiddenValues"].([]any)) != 2 {
+		t.Fatalf("unexpected filter: %v", filter)
+	}
+
+	if err := runKong(t, &SheetsFilterClearCmd{}, []string{"s1", "Data"}, ctx, flags); err != nil {
+		t.Fatalf("filter clear: %v", err)
+	}
+	if clr := lastSheetsRequest(t, batches)["clearBasicFilter"].(map[string]any); clr["sheetId"] != float64(0) {
+		t.Fatalf("expected explicit sheetId 0, got %v", clr)
+	}
+
+	if out := textOut.String(); !strings.Contains(out, "Inserted 3 rows (5-7) in Data") || !strings.Contains(out, "Deleted 3 columns (B-D) in Archive") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	if err := runKong(t, &SheetsSortCmd{}, []string{"s1", "Data!A:B", "--by", "D"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "outside the range") {
+		t.Fatalf("expected sort column error, got %v", err)
+	}
+	if err := runKong(t, &SheetsRowsDeleteCmd{}, []string{"s1", "5:7"}, ctx, flags); err == nil || !strings.Contains(err.Error(), "sheet name") {
+		t.Fatalf("expected sheet name error, got %v", err)
+	}
+}
+
+func TestParseA1Span(t *testing.T) {
+	sheet, start, end, err := parseA1Span(`'My Tab'\!7:5`, "ROWS")
+	if err != nil || sheet != "My Tab" || start != 5 || end != 7 {
+		t.Fatalf("rows: %q %d %d %v", sheet, start, end, err)
+	}
+	sheet, start, end, err = parseA1Span("Data!AA", "COLUMNS")
+	if err != nil || sheet != "Data" || start != 27 || end != 27 {
+		t.Fatalf("cols: %q %d %d %v", sheet, start, end, err)
+	}
+	if _, _, _, err := parseA1Span("Data!B:C", "ROWS"); err == nil {
+		t.Fatal("expected error for column span as rows")
+	}
+}
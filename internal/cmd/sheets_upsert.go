@@ -0,0 +1,633 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SheetsUpsertCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string   `arg:"" name:"range" help:"Table range starting at the header row (eg. Roster or Roster!A1:F)"`
+	Key           []string `name:"key" required:"" help:"Header name(s) of the key column(s) used to match rows (comma-separated)"`
+	Data          string   `name:"data" required:"" help:"Records file: JSON array of objects or CSV with a header row (- for stdin)"`
+	Format        string   `name:"format" help:"Data format: auto|json|csv" default:"auto" enum:"auto,json,csv"`
+	DeleteMissing bool     `name:"delete-missing" help:"Delete the sheet rows whose key is not in the data (whole rows; rows below move up). Writes and deletes go in one atomic batchUpdate; USER_ENTERED then only recognizes formulas, numbers and TRUE/FALSE"`
+	ValueInput    string   `name:"input" help:"Value input option: RAW or USER_ENTERED" default:"USER_ENTERED"`
+	DryRun        bool     `name:"dry-run" help:"Show the per-row diff without writing"`
+}
+
+type sheetsUpsertChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type sheetsUpsertRow struct {
+	Action  string                        `json:"action"`
+	Row     int                           `json:"row"`
+	Key     map[string]string             `json:"key"`
+	Changes map[string]sheetsUpsertChange `json:"changes,omitempty"`
+	Values  map[string]string             `json:"values,omitempty"`
+}
+
+// sheetsUpsertTable is the existing table as read from the sheet. Row i of
+// Rows lives on sheet row StartRow+1+i (StartRow holds the header).
+type sheetsUpsertTable struct {
+	SheetName string
+	StartRow  int
+	StartCol  int
+	Header    []string
+	Rows      [][]string
+}
+
+func (c *SheetsUpsertCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	spreadsheetID := strings.TrimSpace(c.SpreadsheetID)
+	rangeSpec := cleanRange(strings.TrimSpace(c.Range))
+	if spreadsheetID == "" {
+		return usage("empty spreadsheetId")
+	}
+	if rangeSpec == "" {
+		return usage("empty range")
+	}
+	keys := make([]string, 0, len(c.Key))
+	for _, k := range c.Key {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return usage("missing --key")
+	}
+	valueInput := strings.ToUpper(strings.TrimSpace(c.ValueInput))
+	if valueInput != sheetsValueInputRaw && valueInput != sheetsValueInputUserEntered {
+		return usagef("invalid --input %q (expected RAW or USER_ENTERED)", c.ValueInput)
+	}
+
+	records, err := readSheetsRecords(c.Data, c.Format)
+	if err != nil {
+		return err
+	}
+
+	svc, err := newSheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	// Compare against the underlying values, not the display formatting, so
+	// "1,000" or "50%" on the sheet does not count as a change of 1000/0.5.
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, rangeSpec).
+		ValueRenderOption("UNFORMATTED_VALUE").
+		DateTimeRenderOption("FORMATTED_STRING").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+	table, err := newSheetsUpsertTable(rangeSpec, resp)
+	if err != nil {
+		return err
+	}
+
+	writeHeader := false
+	if len(table.Header) == 0 {
+		if len(table.Rows) > 0 {
+			return fmt.Errorf("header row %d is empty", table.StartRow)
+		}
+		table.Header = append([]string(nil), records.Columns...)
+		writeHeader = true
+	}
+
+	plan, err := planSheetsUpsert(table, keys, records, valueInput, c.DeleteMissing)
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int{"update": 0, "append": 0, "delete": 0}
+	for _, r := range plan.Rows {
+		counts[r.Action]++
+	}
+
+	var updatedCells int64
+	if !c.DryRun && (writeHeader || len(plan.Rows) > 0) {
+		blocks := buildSheetsUpsertWrites(table, plan, writeHeader)
+		if counts["delete"] == 0 {
+			if len(blocks) > 0 {
+				updateResp, err := svc.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+					ValueInputOption: valueInput,
+					Data:             sheetsUpsertValueRanges(table.SheetName, blocks),
+				}).Context(ctx).Do()
+				if err != nil {
+					return err
+				}
+				updatedCells = updateResp.TotalUpdatedCells
+			}
+		} else {
+			if err := confirmDestructive(ctx, flags, fmt.Sprintf("delete %d sheet rows from %s", counts["delete"], rangeSpec)); err != nil {
+				return err
+			}
+			props, err := fetchSheetProperties(ctx, svc, spreadsheetID)
+			if err != nil {
+				return err
+			}
+			var tab *sheets.SheetProperties
+			for _, p := range props {
+				if p.Title == table.SheetName {
+					tab = p
+				}
+			}
+			if tab == nil {
+				return fmt.Errorf("tab %q not found", table.SheetName)
+			}
+			// One batchUpdate, so the writes and row deletions apply together or
+			// not at all. Values go first at their current positions; deleting
+			// rows afterwards moves everything below up (appended rows included)
+			// and lets Sheets adjust formula references the same way a manual
+			// row delete would.
+			reqs := sheetsUpsertGridRequests(tab, blocks, valueInput)
+			reqs = append(reqs, buildSheetsUpsertDeletes(table, plan, tab.SheetId)...)
+			if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, reqs...); err != nil {
+				return err
+			}
+			for _, b := range blocks {
+				for _, row := range b.Values {
+					updatedCells += int64(len(row))
+				}
+			}
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		rows := plan.Rows
+		if rows == nil {
+			rows = []sheetsUpsertRow{}
+		}
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         resp.Range,
+			"keys":          keys,
+			"dryRun":        c.DryRun,
+			"updated":       counts["update"],
+			"appended":      counts["append"],
+			"deleted":       counts["delete"],
+			"unchanged":     plan.Unchanged,
+			"updatedCells":  updatedCells,
+			"rows":          rows,
+		})
+	}
+
+	if c.DryRun {
+		u.Out().Printf("dry-run\ttrue")
+	}
+	u.Out().Printf("updated\t%d", counts["update"])
+	u.Out().Printf("appended\t%d", counts["append"])
+	u.Out().Printf("deleted\t%d", counts["delete"])
+	u.Out().Printf("unchanged\t%d", plan.Unchanged)
+	for _, r := range plan.Rows {
+		u.Out().Printf("%s\t%d\t%s\t%s", r.Action, r.Row, formatSheetsUpsertKey(keys, r.Key), formatSheetsUpsertChanges(r))
+	}
+	return nil
+}
+
+func newSheetsUpsertTable(rangeSpec string, resp *sheets.ValueRange) (*sheetsUpsertTable, error) {
+	bounds := strings.TrimSpace(resp.Range)
+	if bounds == "" {
+		bounds = rangeSpec
+	}
+	r, err := parseA1Range(bounds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot locate table from range %q: %w", bounds, err)
+	}
+
+	values := sheetsValuesToStrings(resp.Values)
+	table := &sheetsUpsertTable{SheetName: r.SheetName, StartRow: r.StartRow, StartCol: r.StartCol}
+	if len(values) == 0 {
+		return table, nil
+	}
+	for _, name := range values[0] {
+		table.Header = append(table.Header, strings.TrimSpace(name))
+	}
+	for len(table.Header) > 0 && table.Header[len(table.Header)-1] == "" {
+		table.Header = table.Header[:len(table.Header)-1]
+	}
+	table.Rows = values[1:]
+	return table, nil
+}
+
+func sheetsValuesToStrings(values [][]interface{}) [][]string {
+	out := make([][]string, len(values))
+	for i, row := range values {
+		out[i] = make([]string, len(row))
+		for j, cell := range row {
+			switch v := cell.(type) {
+			case float64:
+				out[i][j] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				out[i][j] = strings.ToUpper(strconv.FormatBool(v))
+			default:
+				out[i][j] = fmt.Sprint(cell)
+			}
+		}
+	}
+	return out
+}
+
+var sheetsUpsertNumberRE = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+// normalizeSheetsUpsertValue maps an input value to the form an unformatted
+// read returns for it, so it can be compared with the sheet. USER_ENTERED input is
+// parsed by Sheets (numbers, percentages, booleans, a leading ' for text);
+// RAW input is stored verbatim.
+func normalizeSheetsUpsertValue(v, valueInput string) string {
+	if valueInput == sheetsValueInputRaw {
+		return v
+	}
+	s := strings.TrimSpace(v)
+	if strings.HasPrefix(s, "'") {
+		return s[1:]
+	}
+	if upper := strings.ToUpper(s); upper == "TRUE" || upper == "FALSE" {
+		return upper
+	}
+	num := strings.ReplaceAll(strings.TrimPrefix(s, "$"), ",", "")
+	scale := 1.0
+	if strings.HasSuffix(num, "%") {
+		num = strings.TrimSuffix(num, "%")
+		scale = 100
+	}
+	if sheetsUpsertNumberRE.MatchString(num) {
+		if f, err := strconv.ParseFloat(num, 64); err == nil {
+			return strconv.FormatFloat(f/scale, 'f', -1, 64)
+		}
+	}
+	return s
+}
+
+type sheetsUpsertPlan struct {
+	Rows      []sheetsUpsertRow
+	Unchanged int
+	// Updates holds the cells to write for updated rows, keyed by the index
+	// into table.Rows and then by column offset; Appended holds new rows in
+	// header order.
+	Updates  map[int]map[int]string
+	Appended [][]string
+	Deleted  map[int]bool
+}
+
+func planSheetsUpsert(table *sheetsUpsertTable, keys []string, records *sheetsRecords, valueInput string, deleteMissing bool) (*sheetsUpsertPlan, error) {
+	colIndex := make(map[string]int, len(table.Header))
+	for i, name := range table.Header {
+		if name == "" {
+			continue
+		}
+		if _, dup := colIndex[name]; dup {
+			return nil, fmt.Errorf("duplicate header column %q", name)
+		}
+		colIndex[name] = i
+	}
+	for _, k := range keys {
+		if _, ok := colIndex[k]; !ok {
+			return nil, usagef("key column %q not found in header (%s)", k, strings.Join(table.Header, ", "))
+		}
+	}
+	for _, col := range records.Columns {
+		if _, ok := colIndex[col]; !ok {
+			return nil, usagef("data column %q not found in header (%s)", col, strings.Join(table.Header, ", "))
+		}
+	}
+
+	width := len(table.Header)
+	cell := func(row []string, i int) string {
+		if i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+	// Sheet cells are already canonical (sheetsValuesToStrings over an
+	// unformatted read); input is brought into the same form before comparing.
+	norm := func(v string) string { return normalizeSheetsUpsertValue(v, valueInput) }
+	keyOf := func(get func(string) string) (string, map[string]string, bool) {
+		parts := make([]string, len(keys))
+		display := make(map[string]string, len(keys))
+		blank := true
+		for i, k := range keys {
+			v := strings.TrimSpace(get(k))
+			parts[i] = v
+			display[k] = v
+			if v != "" {
+				blank = false
+			}
+		}
+		return strings.Join(parts, "\x1f"), display, blank
+	}
+	sheetRow := func(i int) int { return table.StartRow + 1 + i }
+
+	existing := make(map[string]int, len(table.Rows))
+	for i, row := range table.Rows {
+		k, _, blank := keyOf(func(col string) string { return cell(row, colIndex[col]) })
+		if blank {
+			continue
+		}
+		if prev, dup := existing[k]; dup {
+			return nil, fmt.Errorf("duplicate key %s in sheet rows %d and %d", strings.ReplaceAll(k, "\x1f", "/"), sheetRow(prev), sheetRow(i))
+		}
+		existing[k] = i
+	}
+
+	plan := &sheetsUpsertPlan{Updates: map[int]map[int]string{}, Deleted: map[int]bool{}}
+	matched := make(map[int]bool, len(records.Rows))
+	seen := make(map[string]int, len(records.Rows))
+	nextRow := table.StartRow + 1 + len(table.Rows)
+	for ri, rec := range records.Rows {
+		k, display, _ := keyOf(func(col string) string { return norm(rec[col]) })
+		for _, col := range keys {
+			if display[col] == "" {
+				return nil, usagef("record %d: empty key column %q", ri, col)
+			}
+		}
+		if prev, dup := seen[k]; dup {
+			return nil, usagef("records %d and %d share key %s", prev, ri, strings.ReplaceAll(k, "\x1f", "/"))
+		}
+		seen[k] = ri
+
+		idx, ok := existing[k]
+		if !ok {
+			values := make([]string, width)
+			shown := map[string]string{}
+			for col, v := range rec {
+				values[colIndex[col]] = v
+				shown[col] = v
+			}
+			plan.Appended = append(plan.Appended, values)
+			plan.Rows = append(plan.Rows, sheetsUpsertRow{Action: "append", Row: nextRow, Key: display, Values: shown})
+			nextRow++
+			continue
+		}
+
+		matched[idx] = true
+		old := table.Rows[idx]
+		cells := map[int]string{}
+		changes := map[string]sheetsUpsertChange{}
+		for col, v := range rec {
+			i := colIndex[col]
+			if cell(old, i) != norm(v) {
+				changes[col] = sheetsUpsertChange{Old: cell(old, i), New: v}
+				cells[i] = v
+			}
+		}
+		if len(changes) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Updates[idx] = cells
+		plan.Rows = append(plan.Rows, sheetsUpsertRow{Action: "update", Row: sheetRow(idx), Key: display, Changes: changes})
+	}
+
+	if deleteMissing {
+		for i, row := range table.Rows {
+			if matched[i] {
+				continue
+			}
+			_, display, blank := keyOf(func(col string) string { return cell(row, colIndex[col]) })
+			if blank {
+				continue
+			}
+			values := map[string]string{}
+			for col, ci := range colIndex {
+				if v := cell(row, ci); v != "" {
+					values[col] = v
+				}
+			}
+			plan.Deleted[i] = true
+			plan.Rows = append(plan.Rows, sheetsUpsertRow{Action: "delete", Row: sheetRow(i), Key: display, Values: values})
+		}
+		if len(plan.Deleted) > 0 {
+			// Appended rows land right after the compacted table.
+			next := table.StartRow + 1 + len(table.Rows) - len(plan.Deleted)
+			for i := range plan.Rows {
+				if plan.Rows[i].Action == "append" {
+					plan.Rows[i].Row = next
+					next++
+				}
+			}
+		}
+	}
+	return plan, nil
+}
+
+// sheetsUpsertBlock is a rectangle of values whose top-left cell is at the
+// 1-based Row and Col.
+type sheetsUpsertBlock struct {
+	Row, Col int
+	Values   [][]string
+}
+
+// buildSheetsUpsertWrites turns a plan into the blocks to write: changed cells
+// and appended rows, addressed as the table stands before any deletion.
+func buildSheetsUpsertWrites(table *sheetsUpsertTable, plan *sheetsUpsertPlan, writeHeader bool) []sheetsUpsertBlock {
+	width := len(table.Header)
+	toRow := func(values []string) []string {
+		out := make([]string, width)
+		copy(out, values)
+		return out
+	}
+
+	var blocks []sheetsUpsertBlock
+	if writeHeader {
+		blocks = append(blocks, sheetsUpsertBlock{Row: table.StartRow, Col: table.StartCol, Values: [][]string{toRow(table.Header)}})
+	}
+	firstDataRow := table.StartRow + 1
+
+	indexes := make([]int, 0, len(plan.Updates))
+	for idx := range plan.Updates {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+	for _, idx := range indexes {
+		cells := plan.Updates[idx]
+		for i := 0; i < width; i++ {
+			v, ok := cells[i]
+			if !ok {
+				continue
+			}
+			blocks = append(blocks, sheetsUpsertBlock{Row: firstDataRow + idx, Col: table.StartCol + i, Values: [][]string{{v}}})
+		}
+	}
+	if len(plan.Appended) > 0 {
+		values := make([][]string, len(plan.Appended))
+		for i, row := range plan.Appended {
+			values[i] = toRow(row)
+		}
+		blocks = append(blocks, sheetsUpsertBlock{Row: firstDataRow + len(table.Rows), Col: table.StartCol, Values: values})
+	}
+	return blocks
+}
+
+func sheetsUpsertValueRanges(sheetName string, blocks []sheetsUpsertBlock) []*sheets.ValueRange {
+	data := make([]*sheets.ValueRange, 0, len(blocks))
+	for _, b := range blocks {
+		values := make([][]interface{}, len(b.Values))
+		width := 0
+		for i, row := range b.Values {
+			values[i] = make([]interface{}, len(row))
+			for j, v := range row {
+				values[i][j] = v
+			}
+			width = max(width, len(row))
+		}
+		data = append(data, &sheets.ValueRange{
+			Range:  formatA1Range(sheetName, b.Row, b.Col, b.Row+len(b.Values)-1, b.Col+width-1),
+			Values: values,
+		})
+	}
+	return data
+}
+
+// sheetsUpsertGridRequests turns the writes into updateCells requests, first
+// growing the grid when appended rows (or a new header) reach past it, as
+// values.batchUpdate would.
+func sheetsUpsertGridRequests(tab *sheets.SheetProperties, blocks []sheetsUpsertBlock, valueInput string) []*sheets.Request {
+	var rows, cols int64
+	if g := tab.GridProperties; g != nil {
+		rows, cols = g.RowCount, g.ColumnCount
+	}
+	var needRows, needCols int64
+	for _, b := range blocks {
+		needRows = max(needRows, int64(b.Row+len(b.Values)-1))
+		for _, row := range b.Values {
+			needCols = max(needCols, int64(b.Col+len(row)-1))
+		}
+	}
+
+	var reqs []*sheets.Request
+	if needRows > rows {
+		reqs = append(reqs, &sheets.Request{AppendDimension: &sheets.AppendDimensionRequest{
+			SheetId: tab.SheetId, Dimension: "ROWS", Length: needRows - rows, ForceSendFields: []string{"SheetId"},
+		}})
+	}
+	if needCols > cols {
+		reqs = append(reqs, &sheets.Request{AppendDimension: &sheets.AppendDimensionRequest{
+			SheetId: tab.SheetId, Dimension: "COLUMNS", Length: needCols - cols, ForceSendFields: []string{"SheetId"},
+		}})
+	}
+	for _, b := range blocks {
+		rowData := make([]*sheets.RowData, len(b.Values))
+		for i, row := range b.Values {
+			cells := make([]*sheets.CellData, len(row))
+			for j, v := range row {
+				cells[j] = &sheets.CellData{UserEnteredValue: sheetsUpsertExtendedValue(v, valueInput)}
+			}
+			rowData[i] = &sheets.RowData{Values: cells}
+		}
+		reqs = append(reqs, &sheets.Request{UpdateCells: &sheets.UpdateCellsRequest{
+			Start: &sheets.GridCoordinate{
+				SheetId:         tab.SheetId,
+				RowIndex:        int64(b.Row - 1),
+				ColumnIndex:     int64(b.Col - 1),
+				ForceSendFields: []string{"SheetId", "RowIndex", "ColumnIndex"},
+			},
+			Rows:   rowData,
+			Fields: "userEnteredValue",
+		}})
+	}
+	return reqs
+}
+
+// sheetsUpsertExtendedValue types a cell for updateCells. RAW keeps text;
+// USER_ENTERED recognizes formulas, booleans and plain numbers, and a leading
+// apostrophe forces text. Other user-entered formats (dates, currency,
+// percentages) are stored as text. An empty value clears the cell.
+func sheetsUpsertExtendedValue(v, valueInput string) *sheets.ExtendedValue {
+	if v == "" {
+		return nil
+	}
+	if strings.EqualFold(valueInput, "RAW") {
+		return &sheets.ExtendedValue{StringValue: &v}
+	}
+	switch upper := strings.ToUpper(strings.TrimSpace(v)); {
+	case strings.HasPrefix(v, "="):
+		return &sheets.ExtendedValue{FormulaValue: &v}
+	case strings.HasPrefix(v, "'"):
+		text := v[1:]
+		return &sheets.ExtendedValue{StringValue: &text}
+	case upper == "TRUE" || upper == "FALSE":
+		b := upper == "TRUE"
+		return &sheets.ExtendedValue{BoolValue: &b}
+	}
+	if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return &sheets.ExtendedValue{NumberValue: &f}
+	}
+	return &sheets.ExtendedValue{StringValue: &v}
+}
+
+// buildSheetsUpsertDeletes removes deleted rows with deleteDimension, one
+// request per contiguous run, bottom-up so earlier requests do not shift the
+// indexes of later ones.
+func buildSheetsUpsertDeletes(table *sheetsUpsertTable, plan *sheetsUpsertPlan, sheetID int64) []*sheets.Request {
+	indexes := make([]int, 0, len(plan.Deleted))
+	for idx := range plan.Deleted {
+		indexes = append(indexes, idx)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(indexes)))
+
+	var reqs []*sheets.Request
+	for i := 0; i < len(indexes); {
+		end := indexes[i]
+		start := end
+		i++
+		for i < len(indexes) && indexes[i] == start-1 {
+			start = indexes[i]
+			i++
+		}
+		// Sheet row of table.Rows[idx] is StartRow+1+idx; the grid is 0-based.
+		reqs = append(reqs, &sheets.Request{
+			DeleteDimension: &sheets.DeleteDimensionRequest{Range: &sheets.DimensionRange{
+				SheetId:         sheetID,
+				Dimension:       "ROWS",
+				StartIndex:      int64(table.StartRow + start),
+				EndIndex:        int64(table.StartRow + end + 1),
+				ForceSendFields: []string{"SheetId", "StartIndex"},
+			}},
+		})
+	}
+	return reqs
+}
+
+func formatSheetsUpsertKey(keys []string, key map[string]string) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + key[k]
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatSheetsUpsertChanges(r sheetsUpsertRow) string {
+	if r.Action != "update" {
+		return ""
+	}
+	cols := make([]string, 0, len(r.Changes))
+	for col := range r.Changes {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	parts := make([]string, len(cols))
+	for i, col := range cols {
+		parts[i] = fmt.Sprintf("%s: %q -> %q", col, r.Changes[col].Old, r.Changes[col].New)
+	}
+	return strings.Join(parts, "; ")
+}
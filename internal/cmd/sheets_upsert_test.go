@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/sheets/v4"
+)
+
+// newSheetsUpsertTestService serves values (as an UNFORMATTED_VALUE read) for
+// the Roster tab (sheetId 7) and records values.batchUpdate bodies and the
+// requests of each spreadsheets.batchUpdate.
+func newSheetsUpsertTestService(t *testing.T, values [][]any) (*[]map[string]any, *[][]map[string]any) {
+	t.Helper()
+	var writes []map[string]any
+	var structural [][]map[string]any
+
+	stubGoogleService(t, &newSheetsService, sheets.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "/spreadsheets/s1/values/"):
+			if got := r.URL.Query().Get("valueRenderOption"); got != "UNFORMATTED_VALUE" {
+				t.Errorf("expected an unformatted read, got valueRenderOption=%q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"range": "Roster!A1:C1000", "values": values})
+		case r.Method == http.MethodGet && path == "/spreadsheets/s1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"sheets": []map[string]any{
+				{"properties": map[string]any{"sheetId": 7, "title": "Roster", "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26}}},
+			}})
+		case r.Method == http.MethodPost && path == "/spreadsheets/s1/values:batchUpdate":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writes = append(writes, body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"totalUpdatedCells": 7})
+		case r.Method == http.MethodPost && path == "/spreadsheets/s1:batchUpdate":
+			var body struct {
+				Requests []map[string]any `json:"requests"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			structural = append(structural, body.Requests)
+			_ = json.NewEncoder(w).Encode(map[string]any{"spreadsheetId": "s1"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return &writes, &structural
+}
+
+func writeUpsertData(t *testing.T, name, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func sheetsUpsertRanges(body map[string]any) []string {
+	var out []string
+	for _, d := range body["data"].([]any) {
+		out = append(out, d.(map[string]any)["range"].(string))
+	}
+	return out
+}
+
+func TestSheetsUpsert_UpdatesChangedCellsAndAppends(t *testing.T) {
+	writes, _ := newSheetsUpsertTestService(t, [][]any{
+		{"email", "name", "team"},
+		{"a@x.com", "Ann", "ops"},
+		{"b@x.com", "Bob", "eng"},
+	})
+	data := writeUpsertData(t, "rows.json", `[
+		{"email":"b@x.com","team":"sales"},
+		{"email":"a@x.com","name":"Ann"},
+		{"email":"c@x.com","name":"Cy","team":"eng"}
+	]`)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "upsert", "s1", "Roster", "--key", "email", "--data", data}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	if len(*writes) != 1 {
+		t.Fatalf("expected a single batchUpdate, got %d", len(*writes))
+	}
+	ranges := sheetsUpsertRanges((*writes)[0])
+	if strings.Join(ranges, " ") != "Roster!C3:C3 Roster!A4:C4" {
+		t.Fatalf("unexpected ranges: %v", ranges)
+	}
+
+	var parsed struct {
+		Updated   int               `json:"updated"`
+		Appended  int               `json:"appended"`
+		Unchanged int               `json:"unchanged"`
+		Rows      []sheetsUpsertRow `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Updated != 1 || parsed.Appended != 1 || parsed.Unchanged != 1 {
+		t.Fatalf("unexpected counts: %+v", parsed)
+	}
+	if got := parsed.Rows[0]; got.Row != 3 || got.Changes["team"].Old != "eng" || got.Changes["team"].New != "sales" {
+		t.Fatalf("unexpected update diff: %+v", got)
+	}
+	if got := parsed.Rows[1]; got.Action != "append" || got.Row != 4 || got.Values["name"] != "Cy" {
+		t.Fatalf("unexpected append diff: %+v", got)
+	}
+}
+
+func TestSheetsUpsert_DeleteMissingDeletesRows(t *testing.T) {
+	writes, structural := newSheetsUpsertTestService(t, [][]any{
+		{"email", "name", "score"},
+		{"a@x.com", "Ann", 2},
+		{"b@x.com", "Bob", 4},
+		{"c@x.com", "Cy", 6},
+		{"d@x.com", "Di", 8},
+		{"e@x.com", "Ed", 10},
+	})
+	data := writeUpsertData(t, "rows.csv", "email,name\nc@x.com,Cyrus\na@x.com,Ann\nf@x.com,Flo\n")
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--force", "--account", "a@b.com", "sheets", "upsert", "s1", "Roster", "--key", "email", "--data", data, "--delete-missing"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	// Writes and deletes go out together in one batchUpdate.
+	if len(*writes) != 0 || len(*structural) != 1 {
+		t.Fatalf("expected a single spreadsheets batchUpdate, got values=%v structural=%v", *writes, *structural)
+	}
+	reqs := (*structural)[0]
+	if len(reqs) != 4 {
+		t.Fatalf("expected two updateCells and two deleteDimension requests, got %v", reqs)
+	}
+
+	// Formula cells are never rewritten: only the changed cell and the new
+	// row are written, at their pre-delete positions.
+	for i, want := range []struct {
+		row, col float64
+		first    string
+		cells    int
+	}{{3, 1, "Cyrus", 1}, {6, 0, "f@x.com", 3}} {
+		u := reqs[i]["updateCells"].(map[string]any)
+		start := u["start"].(map[string]any)
+		if start["sheetId"] != float64(7) || start["rowIndex"] != want.row || start["columnIndex"] != want.col || u["fields"] != "userEnteredValue" {
+			t.Fatalf("request %d: unexpected updateCells %v", i, u)
+		}
+		cells := u["rows"].([]any)[0].(map[string]any)["values"].([]any)
+		first := cells[0].(map[string]any)["userEnteredValue"].(map[string]any)["stringValue"]
+		if len(cells) != want.cells || first != want.first {
+			t.Fatalf("request %d: unexpected cells %v", i, cells)
+		}
+	}
+
+	// b (row 3) and d, e (rows 5-6) go, bottom-up, as whole rows.
+	for i, want := range [][2]float64{{4, 6}, {2, 3}} {
+		r := reqs[2+i]["deleteDimension"].(map[string]any)["range"].(map[string]any)
+		if r["sheetId"] != float64(7) || r["dimension"] != "ROWS" || r["startIndex"] != want[0] || r["endIndex"] != want[1] {
+			t.Fatalf("request %d: unexpected range %v", i, r)
+		}
+	}
+
+	var parsed struct {
+		Deleted int               `json:"deleted"`
+		Rows    []sheetsUpsertRow `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Deleted != 3 {
+		t.Fatalf("unexpected counts: %+v", parsed)
+	}
+	for _, r := range parsed.Rows {
+		if r.Action == "append" && r.Row != 4 {
+			t.Fatalf("appended row should land after the compacted table, got %+v", r)
+		}
+	}
+}
+
+func TestSheetsUpsert_ComparesUnformattedValues(t *testing.T) {
+	writes, _ := newSheetsUpsertTestService(t, [][]any{
+		{"id", "amount", "share", "active", "code"},
+		{1, 1000, 0.5, true, "007"},
+		{2, 3.25, 0.1, false, "x"},
+	})
+	data := writeUpsertData(t, "rows.csv", "id,amount,share,active,code\n1,\"1,000\",50%,true,'007\n2.0,3.250,10%,FALSE,y\n")
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "sheets", "upsert", "s1", "Roster", "--key", "id", "--data", data}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	var parsed struct {
+		Updated   int               `json:"updated"`
+		Unchanged int               `json:"unchanged"`
+		Rows      []sheetsUpsertRow `json:"rows"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if parsed.Unchanged != 1 || parsed.Updated != 1 || len(parsed.Rows[0].Changes) != 1 || parsed.Rows[0].Changes["code"].New != "y" {
+		t.Fatalf("expected only code of id 2 to change, got %+v", parsed)
+	}
+	if ranges := sheetsUpsertRanges((*writes)[0]); strings.Join(ranges, " ") != "Roster!E3:E3" {
+		t.Fatalf("unexpected ranges: %v", ranges)
+	}
+}
+
+func TestSheetsUpsertExtendedValue(t *testing.T) {
+	if v := sheetsUpsertExtendedValue("", "USER_ENTERED"); v != nil {
+		t.Fatalf("empty value should clear the cell, got %+v", v)
+	}
+	if v := sheetsUpsertExtendedValue("=A1*2", "USER_ENTERED"); v.FormulaValue == nil || *v.FormulaValue != "=A1*2" {
+		t.Fatalf("formula: %+v", v)
+	}
+	if v := sheetsUpsertExtendedValue("42.5", "USER_ENTERED"); v.NumberValue == nil || *v.NumberValue != 42.5 {
+		t.Fatalf("number: %+v", v)
+	}
+	if v := sheetsUpsertExtendedValue("true", "USER_ENTERED"); v.BoolValue == nil || !*v.BoolValue {
+		t.Fatalf("bool: %+v", v)
+	}
+	if v := sheetsUpsertExtendedValue("'007", "USER_ENTERED"); v.StringValue == nil || *v.StringValue != "007" {
+		t.Fatalf("quoted text: %+v", v)
+	}
+	if v := sheetsUpsertExtendedValue("42", "RAW"); v.StringValue == nil || *v.StringValue != "42" {
+		t.Fatalf("raw: %+v", v)
+	}
+}
+
+func TestNormalizeSheetsUpsertValue(t *testing.T) {
+	cases := map[string]string{
+		"1,000":  "1000",
+		"$12.50": "12.5",
+		"50%":    "0.5",
+		"true":   "TRUE",
+		"'007":   "007",
+		"007":    "7",
+		"1e3":    "1000",
+		"Inf":    "Inf",
+		" a ":    "a",
+	}
+	for in, want := range cases {
+		if got := normalizeSheetsUpsertValue(in, sheetsValueInputUserEntered); got != want {
+			t.Fatalf("%q: got %q, want %q", in, got, want)
+		}
+	}
+	if got := normalizeSheetsUpsertValue("007", sheetsValueInputRaw); got != "007" {
+		t.Fatalf("RAW must be kept verbatim, got %q", got)
+	}
+}
+
+func TestSheetsUpsert_DryRunAndValidation(t *testing.T) {
+	writes, _ := newSheetsUpsertTestService(t, [][]any{
+		{"email", "name"},
+		{"a@x.com", "Ann"},
+	})
+
+	ctx, textOut := driveTrashTestContext(t, false)
+	flags := &RootFlags{Account: "a@b.com"}
+	data := writeUpsertData(t, "rows.json", `[{"email":"a@x.com","name":"Anna"}]`)
+	if err := runKong(t, &SheetsUpsertCmd{}, []string{"s1", "Roster", "--key", "email", "--data", data, "--dry-run"}, ctx, flags); err != nil {
+		t.Fatalf("dry-run: %v", err)
+	}
+	if len(*writes) != 0 {
+		t.Fatalf("dry-run should not write: %v", *writes)
+	}
+	if out := textOut.String(); !strings.Contains(out, "update\t2\temail=a@x.com\tname: \"Ann\" -> \"Anna\"") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	cases := map[string]string{
+		`data column "phone"`:    `[{"email":"a@x.com","phone":"1"}]`,
+		`empty key column`:       `[{"name":"x"}]`,
+		`share key a@x.com`:      `[{"email":"a@x.com"},{"email":"a@x.com"}]`,
+		`key column "id" not fo`: "",
+	}
+	for want, body := range cases {
+		args := []string{"s1", "Roster", "--key", "email", "--data", writeUpsertData(t, "rows.json", body)}
+		if body == "" {
+			args = []string{"s1", "Roster", "--key", "id", "--data", data}
+		}
+		if err := runKong(t, &SheetsUpsertCmd{}, args, ctx, flags); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q error, got %v", want, err)
+		}
+	}
+}
+
+func TestParseSheetsRecordsJSON_KeepsColumnOrder(t *testing.T) {
+	recs, err := parseSheetsRecordsJSON([]byte(`[{"z":1,"a":true,"m":null},{"b":{"x":1}}]`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if strings.Join(recs.Columns, ",") != "z,a,m,b" {
+		t.Fatalf("unexpected columns: %v", recs.Columns)
+	}
+	if recs.Rows[0]["z"] != "1" || recs.Rows[0]["a"] != "TRUE" || recs.Rows[0]["m"] != "" || recs.Rows[1]["b"] != `{"x":1}` {
+		t.Fatalf("unexpected rows: %v", recs.Rows)
+	}
+}
@@ -3,11 +3,314 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
 )
 
+type SheetsValidationCmd struct {
+	Set   SheetsValidationSetCmd   `cmd:"" name:"set" help:"Set a data validation rule on a range"`
+	Clear SheetsValidationClearCmd `cmd:"" name:"clear" help:"Remove data validation from a range"`
+	Get   SheetsValidationGetCmd   `cmd:"" name:"get" help:"Show data validation rules in a range"`
+}
+
+type SheetsValidationSetCmd struct {
+	SpreadsheetID string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string   `arg:"" name:"range" help:"Sheet-qualified A1 range (eg. Sheet1!C2:C)"`
+	Type          string   `name:"type" required:"" help:"Rule type: list|range|number|date|checkbox" enum:"list,range,number,date,checkbox"`
+	Values        []string `name:"values" help:"list: allowed values; number/date: operand(s); checkbox: checked,unchecked values"`
+	Source        string   `name:"source" help:"With --type range: A1 range holding the allowed values (eg. Lists!A2:A)"`
+	Op            string   `name:"op" help:"number: between|not-between|eq|ne|gt|gte|lt|lte; date: between|not-between|on|before|after|on-or-before|on-or-after|valid"`
+	Reject        bool     `name:"reject" help:"Reject invalid input instead of showing a warning"`
+	HelpText      string   `name:"help-text" help:"Input help shown when a cell is selected"`
+	NoDropdown    bool     `name:"no-dropdown" help:"With list/range, hide the in-cell dropdown"`
+}
+
+var (
+	sheetsNumberConditions = map[string]string{
+		"between":     "NUMBER_BETWEEN",
+		"not-between": "NUMBER_NOT_BETWEEN",
+		"eq":          "NUMBER_EQ",
+		"ne":          "NUMBER_NOT_EQ",
+		"gt":          "NUMBER_GREATER",
+		"gte":         "NUMBER_GREATER_THAN_EQ",
+		"lt":          "NUMBER_LESS",
+		"lte":         "NUMBER_LESS_THAN_EQ",
+	}
+	sheetsDateConditions = map[string]string{
+		"between":      "DATE_BETWEEN",
+		"not-between":  "DATE_NOT_BETWEEN",
+		"on":           "DATE_EQ",
+		"before":       "DATE_BEFORE",
+		"after":        "DATE_AFTER",
+		"on-or-before": "DATE_ON_OR_BEFORE",
+		"on-or-after":  "DATE_ON_OR_AFTER",
+		"valid":        "DATE_IS_VALID",
+	}
+)
+
+func (c *SheetsValidationSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	rule, err := c.rule()
+	if err != nil {
+		return err
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	grid, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, c.Range)
+	if err != nil {
+		return err
+	}
+
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		SetDataValidation: &sheets.SetDataValidationRequest{Range: grid, Rule: rule},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         cleanRange(c.Range),
+			"rule":          rule,
+		})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Printf("Validation set on %s", cleanRange(c.Range))
+	u.Out().Printf("condition\t%s", describeSheetsCondition(rule.Condition))
+	u.Out().Printf("strict\t%t", rule.Strict)
+	return nil
+}
+
+func (c *SheetsValidationSetCmd) rule() (*sheets.DataValidationRule, error) {
+	var values []string
+	for _, v := range c.Values {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	op := strings.ToLower(strings.TrimSpace(c.Op))
+	if op != "" && c.Type != "number" && c.Type != "date" {
+		return nil, usage("--op only applies to --type number or date")
+	}
+	if c.Source != "" && c.Type != "range" {
+		return nil, usage("--source only applies to --type range")
+	}
+
+	cond := &sheets.BooleanCondition{}
+	switch c.Type {
+	case "list":
+		if len(values) == 0 {
+			return nil, usage("--type list requires --values")
+		}
+		cond.Type = "ONE_OF_LIST"
+	case "range":
+		source := cleanRange(strings.TrimSpace(c.Source))
+		if source == "" {
+			return nil, usage("--type range requires --source")
+		}
+		if len(values) > 0 {
+			return nil, usage("--type range takes --source, not --values")
+		}
+		cond.Type = "ONE_OF_RANGE"
+		values = []string{"=" + strings.TrimPrefix(source, "=")}
+	case "number":
+		if op == "" {
+			op = "between"
+		}
+		cond.Type = sheetsNumberConditions[op]
+		if cond.Type == "" {
+			return nil, usagef("invalid --op %q for number", op)
+		}
+		for _, v := range values {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, usagef("invalid number %q", v)
+			}
+		}
+	case "date":
+		if op == "" {
+			op = "between"
+		}
+		cond.Type = sheetsDateConditions[op]
+		if cond.Type == "" {
+			return nil, usagef("invalid --op %q for date", op)
+		}
+		for _, v := range values {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return nil, usagef("invalid date %q (expected YYYY-MM-DD)", v)
+			}
+		}
+	case "checkbox":
+		if len(values) != 0 && len(values) != 2 {
+			return nil, usage("--type checkbox takes no --values or exactly two (checked,unchecked)")
+		}
+		cond.Type = "BOOLEAN"
+	}
+
+	if c.Type == "number" || c.Type == "date" {
+		want := 1
+		switch op {
+		case "between", "not-between":
+			want = 2
+		case "valid":
+			want = 0
+		}
+		if len(values) != want {
+			return nil, usagef("--op %s needs %d value(s) in --values, got %d", op, want, len(values))
+		}
+	}
+
+	for _, v := range values {
+		cond.Values = append(cond.Values, &sheets.ConditionValue{UserEnteredValue: v})
+	}
+	rule := &sheets.DataValidationRule{
+		Condition:    cond,
+		Strict:       c.Reject,
+		InputMessage: strings.TrimSpace(c.HelpText),
+	}
+	if c.Type == "list" || c.Type == "range" {
+		rule.ShowCustomUi = !c.NoDropdown
+	} else if c.NoDropdown {
+		return nil, usage("--no-dropdown only applies to --type list or range")
+	}
+	return rule, nil
+}
+
+type SheetsValidationClearCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string `arg:"" name:"range" help:"Sheet-qualified A1 range"`
+}
+
+func (c *SheetsValidationClearCmd) Run(ctx context.Context, flags *RootFlags) error {
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+	grid, err := resolveSheetsGridRange(ctx, svc, spreadsheetID, c.Range)
+	if err != nil {
+		return err
+	}
+
+	// A SetDataValidation request without a rule clears validation.
+	if _, err := sheetsBatchUpdate(ctx, svc, spreadsheetID, &sheets.Request{
+		SetDataValidation: &sheets.SetDataValidationRequest{Range: grid},
+	}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         cleanRange(c.Range),
+			"cleared":       true,
+		})
+	}
+	ui.FromContext(ctx).Out().Printf("Validation cleared on %s", cleanRange(c.Range))
+	return nil
+}
+
+type SheetsValidationGetCmd struct {
+	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
+	Range         string `arg:"" name:"range" help:"Range (eg. Sheet1!A1:D20)"`
+}
+
+func (c *SheetsValidationGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	rangeSpec := cleanRange(strings.TrimSpace(c.Range))
+	if rangeSpec == "" {
+		return usage("empty range")
+	}
+	spreadsheetID, svc, err := sheetsServiceFor(ctx, flags, c.SpreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spreadsheets.Get(spreadsheetID).
+		Ranges(rangeSpec).
+		Fields("sheets(properties(title),data(startRow,startColumn,rowData(values(dataValidation))))").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	type cellRule struct {
+		Cell string                     `json:"cell"`
+		Rule *sheets.DataValidationRule `json:"rule"`
+	}
+	var cells []cellRule
+	for _, sheet := range resp.Sheets {
+		title := ""
+		if sheet.Properties != nil {
+			title = sheet.Properties.Title
+		}
+		for _, data := range sheet.Data {
+			for r, row := range data.RowData {
+				for col, cell := range row.Values {
+					if cell == nil || cell.DataValidation == nil {
+						continue
+					}
+					rowNum := int(data.StartRow) + r + 1
+					colNum := int(data.StartColumn) + col + 1
+					cells = append(cells, cellRule{
+						Cell: fmt.Sprintf("%s!%s%d", quoteSheetName(title), colIndexToLetters(colNum), rowNum),
+						Rule: cell.DataValidation,
+					})
+				}
+			}
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if cells == nil {
+			cells = []cellRule{}
+		}
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"spreadsheetId": spreadsheetID,
+			"range":         rangeSpec,
+			"cells":         cells,
+		})
+	}
+
+	if len(cells) == 0 {
+		u.Err().Println("No data validation")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "CELL\tCONDITION\tSTRICT\tDROPDOWN")
+	for _, cr := range cells {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%t\n", cr.Cell, describeSheetsCondition(cr.Rule.Condition), cr.Rule.Strict, cr.Rule.ShowCustomUi)
+	}
+	return nil
+}
+
+func describeSheetsCondition(cond *sheets.BooleanCondition) string {
+	if cond == nil {
+		return "-"
+	}
+	values := make([]string, 0, len(cond.Values))
+	for _, v := range cond.Values {
+		if v.RelativeDate != "" {
+			values = append(values, v.RelativeDate)
+		} else {
+			values = append(values, v.UserEnteredValue)
+		}
+	}
+	if len(values) == 0 {
+		return cond.Type
+	}
+	return cond.Type + " " + strings.Join(values, ",")
+}
+
 func copyDataValidation(ctx context.Context, svc *sheets.Service, spreadsheetID, sourceA1, destA1 string) error {
 	sourceRange, err := parseSheetRange(sourceA1, "copy-validation-from")
 	if err != nil {
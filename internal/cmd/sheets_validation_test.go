@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// newSheetsDataValidationTestService serves a dropdown rule on Data!C2.
+func newSheetsDataValidationTestService(t *testing.T) *[]map[string]any {
+	t.Helper()
+	return newSheetsSpreadsheetTestService(t, map[string]any{
+		"sheets": []any{
+			map[string]any{
+				"properties": map[string]any{"sheetId": 0, "title": "Data", "index": 0, "gridProperties": map[string]any{"rowCount": 1000, "columnCount": 26, "frozenRowCount": 1}},
+				"data": []any{map[string]any{"startRow": 1, "startColumn": 2, "rowData": []any{map[string]any{"values": []any{
+					map[string]any{"dataValidation": map[string]any{"condition": map[string]any{"type": "ONE_OF_LIST", "values": []any{map[string]any{"userEnteredValue": "open"}, map[string]any{"userEnteredValue": "done"}}}, "strict": true, "showCustomUi": true}},
+				}}}}},
+			},
+			map[string]any{"properties": map[string]any{"sheetId": 7, "title": "Archive", "index": 1, "hidden": true}},
+		},
+	}, map[string]any{})
+}
+
+func TestSheetsValidation_Commands(t *testing.T) {
+	batches := newSheetsDataValidationTestService(t)
+	ctx, _ := driveTrashTestContext(t, true)
+	flags := &RootFlags{Account: "a@b.com"}
+
+	cases := []struct {
+		args     []string
+		condType string
+		values   []string
+		dropdown bool
+	}{
+		{[]string{"--type", "list", "--values", "open,done", "--reject"}, "ONE_OF_LIST", []string{"open", "done"}, true},
+		{[]string{"--type", "range", "--source", `Lists\!A2:A`}, "ONE_OF_RANGE", []string{"=Lists!A2:A"}, true},
+		{[]string{"--type", "number", "--op", "gte", "--values", "0"}, "NUMBER_GREATER_THAN_EQ", []string{"0"}, false},
+		{[]string{"--type", "date", "--values", "2026-01-01,2026-12-31"}, "DATE_BETWEEN", []string{"2026-01-01", "2026-12-31"}, false},
+		{[]string{"--type", "checkbox"}, "BOOLEAN", nil, false},
+	}
+	for _, tc := range cases {
+		_ = captureStdout(t, func() {
+			if err := runKong(t, &SheetsValidationSetCmd{}, append([]string{"s1", "Data!C2:C"}, tc.args...), ctx, flags); err != nil {
+				t.Fatalf("set %v: %v", tc.args, err)
+			}
+		})
+		rule := lastSheetsRequest(t, batches)["setDataValidation"].(map[string]any)["rule"].(map[string]any)
+		cond := rule["condition"].(map[string]any)
+		if cond["type"] != tc.condType {
+			t.Fatalf("%v: unexpected condition %v", tc.args, cond)
+		}
+		got, _ := cond["values"].([]any)
+		if len(got) != len(tc.values) {
+			t.Fatalf("%v: unexpected values %v", tc.args, got)
+		}
+		for i, v := range got {
+			if v.(map[string]any)["userEnteredValue"] != tc.values[i] {
+				t.Fatalf("%v: value %d = %v", tc.args, i, v)
+			}
+		}
+		if (rule["showCustomUi"] == true) != tc.dropdown {
+			t.Fatalf("%v: unexpected dropdown %v", tc.args, rule)
+		}
+	}
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &SheetsValidationClearCmd{}, []string{"s1", "Data!C2:C"}, ctx, flags); err != nil {
+			t.Fatalf("clear: %v", err)
+		}
+	})
+	if req := lastSheetsRequest(t, batches)["setDataValidation"].(map[string]any); req["rule"] != nil {
+		t.Fatalf("expected rule-less request to clear, got %v", req)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &SheetsValidationGetCmd{}, []string{"s1", "Data!A1:D5"}, ctx, flags); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+	})
+	var parsed struct {
+		Cells []struct {
+			Cell string `json:"cell"`
+		} `json:"cells"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Cells) != 1 || parsed.Cells[0].Cell != "Data!C2" {
+		t.Fatalf("unexpected cells: %s", out)
+	}
+
+	for want, args := range map[string][]string{
+		"requires --values":  {"--type", "list"},
+		"needs 2 value(s)":   {"--type", "number", "--values", "1"},
+		"invalid date":       {"--type", "date", "--op", "before", "--values", "tomorrow"},
+		"only applies to":    {"--type", "checkbox", "--op", "gt"},
+		"exactly two":        {"--type", "checkbox", "--values", "yes"},
+		`invalid --op "foo"`: {"--type", "number", "--op", "foo", "--values", "1"},
+	} {
+		if err := runKong(t, &SheetsValidationSetCmd{}, append([]string{"s1", "Data!C2:C"}, args...), ctx, flags); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("%v: expected %q error, got %v", args, want, err)
+		}
+	}
+}
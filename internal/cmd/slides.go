@@ -13,10 +13,14 @@ import (
 )
 
 type SlidesCmd struct {
-	Export SlidesExportCmd `cmd:"" name:"export" help:"Export a Google Slides deck (pdf|pptx)"`
-	Info   SlidesInfoCmd   `cmd:"" name:"info" help:"Get Google Slides presentation metadata"`
-	Create SlidesCreateCmd `cmd:"" name:"create" help:"Create a Google Slides presentation"`
-	Copy   SlidesCopyCmd   `cmd:"" name:"copy" help:"Copy a Google Slides presentation"`
+	Export     SlidesExportCmd     `cmd:"" name:"export" help:"Export a Google Slides deck (pdf|pptx)"`
+	Info       SlidesInfoCmd       `cmd:"" name:"info" help:"Get Google Slides presentation metadata"`
+	Create     SlidesCreateCmd     `cmd:"" name:"create" help:"Create a Google Slides presentation"`
+	Copy       SlidesCopyCmd       `cmd:"" name:"copy" help:"Copy a Google Slides presentation"`
+	Generate   SlidesGenerateCmd   `cmd:"" name:"generate" help:"Generate decks or slides from a template and JSON/CSV data"`
+	Cat        SlidesCatCmd        `cmd:"" name:"cat" help:"Print slide titles, body text and speaker notes"`
+	Thumbnails SlidesThumbnailsCmd `cmd:"" name:"thumbnails" help:"Download PNG thumbnails of slides"`
+	Edit       SlidesEditCmd       `cmd:"" name:"edit" help:"Edit Google Slides content"`
 }
 
 type SlidesExportCmd struct {
@@ -108,10 +112,12 @@ type SlidesCopyCmd struct {
 	Parent         string `name:"parent" help:"Destination folder ID"`
 }
 
+var slidesCopyOptions = copyViaDriveOptions{
+	ArgName:      "presentationId",
+	ExpectedMime: "application/vnd.google-apps.presentation",
+	KindLabel:    "Google Slides presentation",
+}
+
 func (c *SlidesCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
-	return copyViaDrive(ctx, flags, copyViaDriveOptions{
-		ArgName:      "presentationId",
-		ExpectedMime: "application/vnd.google-apps.presentation",
-		KindLabel:    "Google Slides presentation",
-	}, c.PresentationID, c.Title, c.Parent)
+	return copyViaDrive(ctx, flags, slidesCopyOptions, c.PresentationID, c.Title, c.Parent)
 }
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SlidesCatCmd struct {
+	PresentationID string   `arg:"" name:"presentationId" help:"Presentation ID"`
+	Format         string   `name:"format" help:"Output format: text|markdown|json" enum:"text,markdown,json" default:"text"`
+	NoNotes        bool     `name:"no-notes" help:"Omit speaker notes"`
+	Slides         []string `name:"slide" help:"Only these slides (object IDs or 1-based numbers)"`
+}
+
+type slidesOutlineSlide struct {
+	Number   int    `json:"number"`
+	ObjectID string `json:"objectId"`
+	Title    string `json:"title,omitempty"`
+	Body     string `json:"body,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+func (c *SlidesCatCmd) Run(ctx context.Context, flags *RootFlags) error {
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return usage("empty presentationId")
+	}
+	pres, err := fetchSlidesPresentation(ctx, flags, id)
+	if err != nil {
+		return err
+	}
+	pages, err := selectSlidesPages(pres, c.Slides)
+	if err != nil {
+		return err
+	}
+
+	outline := make([]slidesOutlineSlide, 0, len(pages))
+	for _, p := range pages {
+		s := slidesOutline(p.number, p.page)
+		if c.NoNotes {
+			s.Notes = ""
+		}
+		outline = append(outline, s)
+	}
+
+	if outfmt.IsJSON(ctx) || c.Format == "json" {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"presentationId": pres.PresentationId,
+			"title":          pres.Title,
+			"slides":         outline,
+		})
+	}
+
+	var b strings.Builder
+	for i, s := range outline {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if c.Format == "markdown" {
+			title := s.Title
+			if title == "" {
+				title = "(untitled)"
+			}
+			fmt.Fprintf(&b, "## %d. %s\n", s.Number, title)
+			if s.Body != "" {
+				fmt.Fprintf(&b, "\n%s\n", s.Body)
+			}
+			if s.Notes != "" {
+				fmt.Fprintf(&b, "\n> %s\n", strings.ReplaceAll(s.Notes, "\n", "\n> "))
+			}
+			continue
+		}
+		fmt.Fprintf(&b, "--- Slide %d: %s\n", s.Number, s.Title)
+		if s.Body != "" {
+			fmt.Fprintf(&b, "%s\n", s.Body)
+		}
+		if s.Notes != "" {
+			fmt.Fprintf(&b, "Notes: %s\n", s.Notes)
+		}
+	}
+	_, err = io.WriteString(os.Stdout, b.String())
+	return err
+}
+
+type SlidesThumbnailsCmd struct {
+	PresentationID string   `arg:"" name:"presentationId" help:"Presentation ID"`
+	Out            string   `name:"out" help:"Directory to write PNG thumbnails to" default:"."`
+	Size           string   `name:"size" help:"Thumbnail size: small|medium|large" enum:"small,medium,large" default:"large"`
+	Slides         []string `name:"slide" help:"Only these slides (object IDs or 1-based numbers)"`
+}
+
+type slidesThumbnail struct {
+	Number   int    `json:"number"`
+	ObjectID string `json:"objectId"`
+	Path     string `json:"path"`
+	Width    int64  `json:"width"`
+	Height   int64  `json:"height"`
+}
+
+func (c *SlidesThumbnailsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return usage("empty presentationId")
+	}
+	dir, err := config.ExpandPath(strings.TrimSpace(c.Out))
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+	pres, err := getSlidesPresentation(ctx, svc, id)
+	if err != nil {
+		return err
+	}
+	pages, err := selectSlidesPages(pres, c.Slides)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	thumbs := make([]slidesThumbnail, 0, len(pages))
+	for _, p := range pages {
+		thumb, err := svc.Presentations.Pages.GetThumbnail(id, p.page.ObjectId).
+			ThumbnailPropertiesThumbnailSize(strings.ToUpper(c.Size)).
+			ThumbnailPropertiesMimeType("PNG").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("slide %d: get thumbnail: %w", p.number, err)
+		}
+		path := filepath.Join(dir, fmt.Sprintf("slide-%03d.png", p.number))
+		if err := downloadSlidesThumbnail(ctx, thumb.ContentUrl, path); err != nil {
+			return fmt.Errorf("slide %d: %w", p.number, err)
+		}
+		thumbs = append(thumbs, slidesThumbnail{Number: p.number, ObjectID: p.page.ObjectId, Path: path, Width: thumb.Width, Height: thumb.Height})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"presentationId": id,
+			"thumbnails":     thumbs,
+		})
+	}
+	if len(thumbs) == 0 {
+		u.Err().Println("No slides")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SLIDE\tOBJECT_ID\tSIZE\tPATH")
+	for _, t := range thumbs {
+		fmt.Fprintf(w, "%d\t%s\t%dx%d\t%s\n", t.Number, t.ObjectID, t.Width, t.Height, t.Path)
+	}
+	return nil
+}
+
+// downloadSlidesThumbnail fetches a thumbnail's short-lived content URL,
+// which needs no authorization.
+func downloadSlidesThumbnail(ctx context.Context, url, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("download thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download thumbnail: HTTP %d", resp.StatusCode)
+	}
+
+	f, err := os.Create(path) //nolint:gosec // user-provided output directory
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("write thumbnail: %w", err)
+	}
+	return nil
+}
+
+func fetchSlidesPresentation(ctx context.Context, flags *RootFlags, id string) (*slides.Presentation, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return getSlidesPresentation(ctx, svc, id)
+}
+
+func getSlidesPresentation(ctx context.Context, svc *slides.Service, id string) (*slides.Presentation, error) {
+	pres, err := svc.Presentations.Get(id).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return nil, fmt.Errorf("presentation not found or not a Google Slides presentation (id=%s)", id)
+		}
+		return nil, err
+	}
+	return pres, nil
+}
+
+type slidesNumberedPage struct {
+	number int
+	page   *slides.Page
+}
+
+// selectSlidesPages returns the slides named by refs (object IDs or
+// 1-based numbers) in deck order, or every slide when refs is empty.
+func selectSlidesPages(pres *slides.Presentation, refs []string) ([]slidesNumberedPage, error) {
+	want := map[string]bool{}
+	if len(refs) > 0 {
+		ids, err := resolveSlideRefs("select", pres.PresentationId, pres, refs)
+		if err != nil {
+			return nil, usage(err.Error())
+		}
+		for _, id := range ids {
+			want[id] = true
+		}
+	}
+	var out []slidesNumberedPage
+	for i, s := range pres.Slides {
+		if len(want) == 0 || want[s.ObjectId] {
+			out = append(out, slidesNumberedPage{number: i + 1, page: s})
+		}
+	}
+	return out, nil
+}
+
+// slidesOutline splits a slide into its title placeholder, the remaining
+// text (bullets as Markdown list items, tables as pipe-separated rows) and
+// the speaker notes.
+func slidesOutline(number int, page *slides.Page) slidesOutlineSlide {
+	s := slidesOutlineSlide{Number: number, ObjectID: page.ObjectId}
+	var body []string
+	var walk func(elements []*slides.PageElement)
+	walk = func(elements []*slides.PageElement) {
+		for _, el := range elements {
+			switch {
+			case el == nil:
+			case el.Shape != nil:
+				text := slidesParagraphsText(el.Shape.Text)
+				if text == "" {
+					continue
+				}
+				if ph := el.Shape.Placeholder; ph != nil && (ph.Type == "TITLE" || ph.Type == "CENTERED_TITLE") && s.Title == "" {
+					s.Title = strings.Join(strings.Fields(text), " ")
+					continue
+				}
+				body = append(body, text)
+			case el.Table != nil:
+				var rows []string
+				for _, row := range el.Table.TableRows {
+					cells := make([]string, 0, len(row.TableCells))
+					for _, cell := range row.TableCells {
+						cells = append(cells, strings.Join(strings.Fields(slidesParagraphsText(cell.Text)), " "))
+					}
+					rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+				}
+				body = append(body, strings.Join(rows, "\n"))
+			case el.ElementGroup != nil:
+				walk(el.ElementGroup.Children)
+			}
+		}
+	}
+	walk(page.PageElements)
+	s.Body = strings.Join(body, "\n\n")
+
+	if page.SlideProperties != nil && page.SlideProperties.NotesPage != nil {
+		notes := page.SlideProperties.NotesPage
+		speakerID := ""
+		if notes.NotesProperties != nil {
+			speakerID = notes.NotesProperties.SpeakerNotesObjectId
+		}
+		for _, el := range notes.PageElements {
+			if el != nil && el.Shape != nil && el.ObjectId == speakerID {
+				s.Notes = slidesParagraphsText(el.Shape.Text)
+			}
+		}
+	}
+	return s
+}
+
+// slidesParagraphsText renders a shape's text one paragraph per line,
+// prefixing bulleted paragraphs with "- " indented by nesting level.
+func slidesParagraphsText(t *slides.TextContent) string {
+	if t == nil {
+		return ""
+	}
+	var lines []string
+	var cur strings.Builder
+	prefix := ""
+	flush := func() {
+		if line := strings.TrimSpace(cur.String()); line != "" {
+			lines = append(lines, prefix+line)
+		}
+		cur.Reset()
+	}
+	for _, te := range t.TextElements {
+		switch {
+		case te.ParagraphMarker != nil:
+			flush()
+			prefix = ""
+			if b := te.ParagraphMarker.Bullet; b != nil {
+				prefix = strings.Repeat("  ", int(b.NestingLevel)) + "- "
+			}
+		case te.TextRun != nil:
+			// Vertical tabs are soft line breaks inside a paragraph.
+			cur.WriteString(strings.ReplaceAll(te.TextRun.Content, "\v", " "))
+		}
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/slides/v1"
+)
+
+func newSlidesContentTestService(t *testing.T) {
+	t.Helper()
+	origNew := newSlidesService
+	t.Cleanup(func() { newSlidesService = origNew })
+
+	run := func(text string) map[string]any { return map[string]any{"textRun": map[string]any{"content": text}} }
+	para := func(level int) map[string]any {
+		if level < 0 {
+			return map[string]any{"paragraphMarker": map[string]any{}}
+		}
+		return map[string]any{"paragraphMarker": map[string]any{"bullet": map[string]any{"nestingLevel": level}}}
+	}
+	pres := map[string]any{
+		"presentationId": "p1",
+		"title":          "Weekly",
+		"slides": []any{
+			map[string]any{
+				"objectId": "s1",
+				"pageElements": []any{
+					map[string]any{"objectId": "b1", "shape": map[string]any{"text": map[string]any{"textElements": []any{
+						para(0), run("Shipped search\n"), para(1), run("API v2\n"), para(-1), run("Next: billing\n"),
+					}}}},
+					map[string]any{"objectId": "t1", "shape": map[string]any{"placeholder": map[string]any{"type": "TITLE"}, "text": map[string]any{"textElements": []any{
+						para(-1), run("Status\vupdate\n"),
+					}}}},
+					map[string]any{"objectId": "tb", "table": map[string]any{"tableRows": []any{
+						map[string]any{"tableCells": []any{
+							map[string]any{"text": map[string]any{"textElements": []any{run("Team\n")}}},
+							map[string]any{"text": map[string]any{"textElements": []any{run("Owner\n")}}},
+						}},
+					}}},
+				},
+				"slideProperties": map[string]any{"notesPage": map[string]any{
+					"notesProperties": map[string]any{"speakerNotesObjectId": "n1"},
+					"pageElements": []any{
+						map[string]any{"objectId": "n0", "shape": map[string]any{"text": map[string]any{"textElements": []any{run("slide image\n")}}}},
+						map[string]any{"objectId": "n1", "shape": map[string]any{"text": map[string]any{"textElements": []any{run("Mention the outage\n")}}}},
+					},
+				}},
+			},
+			map[string]any{"objectId": "s2"},
+		},
+	}
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/presentations/p1":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(pres)
+		case strings.HasSuffix(r.URL.Path, "/thumbnail"):
+			if r.URL.Query().Get("thumbnailProperties.thumbnailSize") != "SMALL" {
+				t.Errorf("unexpected thumbnail query: %s", r.URL.RawQuery)
+			}
+			page := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/presentations/p1/pages/"), "/thumbnail")
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"contentUrl": srv.URL + "/png/" + page, "width": 200, "height": 112})
+		case strings.HasPrefix(r.URL.Path, "/png/"):
+			_, _ = w.Write([]byte("PNG:" + strings.TrimPrefix(r.URL.Path, "/png/")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc, err := slides.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	newSlidesService = func(context.Context, string) (*slides.Service, error) { return svc, nil }
+}
+
+func TestExecute_SlidesCat(t *testing.T) {
+	newSlidesContentTestService(t)
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "slides", "cat", "p1"}); err != nil {
+			t.Fatalf("cat: %v", err)
+		}
+	})
+	var parsed struct {
+		Slides []slidesOutlineSlide `json:"slides"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	first := parsed.Slides[0]
+	if len(parsed.Slides) != 2 || first.Title != "Status update" || first.Notes != "Mention the outage" {
+		t.Fatalf("unexpected outline: %+v", parsed.Slides)
+	}
+	if first.Body != "- Shipped search\n  - API v2\nNext: billing\n\n| Team | Owner |" {
+		t.Fatalf("unexpected body: %q", first.Body)
+	}
+
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--account", "a@b.com", "slides", "cat", "p1", "--format", "markdown", "--no-notes", "--slide", "1"}); err != nil {
+			t.Fatalf("cat markdown: %v", err)
+		}
+	})
+	if !strings.HasPrefix(out, "## 1. Status update\n\n- Shipped search\n") || strings.Contains(out, "outage") || strings.Contains(out, "## 2.") {
+		t.Fatalf("unexpected markdown: %q", out)
+	}
+}
+
+func TestExecute_SlidesThumbnails(t *testing.T) {
+	newSlidesContentTestService(t)
+	dir := filepath.Join(t.TempDir(), "thumbs")
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "slides", "thumbnails", "p1", "--out", dir, "--size", "small"}); err != nil {
+			t.Fatalf("thumbnails: %v", err)
+		}
+	})
+	var parsed struct {
+		Thumbnails []slidesThumbnail `json:"thumbnails"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if len(parsed.Thumbnails) != 2 || parsed.Thumbnails[1].Width != 200 {
+		t.Fatalf("unexpected thumbnails: %+v", parsed.Thumbnails)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "slide-002.png"))
+	if err != nil || string(data) != "PNG:s2" {
+		t.Fatalf("unexpected thumbnail file: %q %v", data, err)
+	}
+}
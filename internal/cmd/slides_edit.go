@@ -0,0 +1,562 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	gapi "google.golang.org/api/googleapi"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var newSlidesService = googleapi.NewSlides
+
+type SlidesEditCmd struct {
+	ReplaceText    SlidesReplaceTextCmd    `cmd:"" name:"replace-text" help:"Replace text throughout a deck or on selected slides"`
+	ReplaceImage   SlidesReplaceImageCmd   `cmd:"" name:"replace-image" help:"Replace shapes containing text with an image"`
+	AddSlide       SlidesAddSlideCmd       `cmd:"" name:"add-slide" help:"Add a slide from a predefined or named layout"`
+	DeleteSlide    SlidesDeleteSlideCmd    `cmd:"" name:"delete-slide" help:"Delete slides"`
+	DuplicateSlide SlidesDuplicateSlideCmd `cmd:"" name:"duplicate-slide" help:"Duplicate a slide"`
+	Batch          SlidesBatchCmd          `cmd:"" name:"batch" help:"Apply raw presentations.batchUpdate requests from JSON"`
+}
+
+// SlidesEditSafetyFlags follows the docs and sheets edit contract: every
+// edit can be previewed, validated offline, persisted as a normalized
+// request file and replayed from that file.
+type SlidesEditSafetyFlags struct {
+	DryRun            bool   `name:"dry-run" help:"Build request and print it without executing API call"`
+	ValidateOnly      bool   `name:"validate-only" help:"Validate request payload locally without executing API call"`
+	Pretty            bool   `name:"pretty" help:"Include normalized pretty-printed request JSON in output"`
+	OutputRequestFile string `name:"output-request-file" help:"Write normalized request JSON to this file (use '-' for stdout)"`
+	ExecuteFromFile   string `name:"execute-from-file" help:"Execute request JSON from this file ('-' for stdin) instead of building it from arguments"`
+	RequireRevision   string `name:"require-revision" help:"Require this presentation revision ID for update (optimistic concurrency guard)"`
+}
+
+func newSlidesEditError(op, presentationID, code, msg string, cause error) *editError {
+	return newEditError("presentation_id", op, presentationID, code, msg, cause)
+}
+
+func slidesEditUsageError(op, presentationID, code, msg string) error {
+	return newSlidesEditError(op, presentationID, code, msg, usage(msg))
+}
+
+func slidesEditAPIError(op, presentationID, msg string, err error) error {
+	var apiErr *gapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return newSlidesEditError(op, presentationID, "presentation_not_found", fmt.Sprintf("presentation not found (id=%s)", presentationID), err)
+	}
+	return newSlidesEditError(op, presentationID, "api_error", msg, err)
+}
+
+// slidesEditBuilder builds the requests for one edit from command
+// arguments. It is skipped when --execute-from-file replays a saved request.
+type slidesEditBuilder func() ([]*slides.Request, map[string]any, error)
+
+// runSlidesEdit builds (or loads) the request, validates it and drives the
+// shared dry-run / validate-only / execute flow.
+func runSlidesEdit(ctx context.Context, flags *RootFlags, op, id string, safety SlidesEditSafetyFlags, build slidesEditBuilder) error {
+	u := ui.FromContext(ctx)
+
+	req := &slides.BatchUpdatePresentationRequest{}
+	var summary map[string]any
+	if from := strings.TrimSpace(safety.ExecuteFromFile); from != "" {
+		if err := decodeSlidesEditRequest(op, id, from, req); err != nil {
+			return err
+		}
+	} else {
+		reqs, s, err := build()
+		if err != nil {
+			return err
+		}
+		req.Requests, summary = reqs, s
+	}
+	if len(req.Requests) == 0 {
+		return slidesEditUsageError(op, id, "invalid_argument", "request has no operations")
+	}
+	requestKinds := make([]string, 0, len(req.Requests))
+	for i, r := range req.Requests {
+		names := slidesRequestOperationNames(r)
+		if len(names) != 1 {
+			msg := fmt.Sprintf("request[%d] must set exactly one operation field", i)
+			e := newSlidesEditError(op, id, "invalid_request", msg, usage(msg))
+			idx := i
+			e.RequestIndex = &idx
+			return e
+		}
+		requestKinds = append(requestKinds, names[0])
+	}
+	if rev := strings.TrimSpace(safety.RequireRevision); rev != "" {
+		req.WriteControl = &slides.WriteControl{RequiredRevisionId: rev}
+	}
+
+	requestHash, err := editRequestHash(req)
+	if err != nil {
+		return newSlidesEditError(op, id, "invalid_request", "failed to hash normalized request", err)
+	}
+	normalizedForJSON := ""
+	if strings.TrimSpace(safety.OutputRequestFile) == "-" && outfmt.IsJSON(ctx) {
+		norm, normErr := editNormalizedRequestString(req)
+		if normErr != nil {
+			return newSlidesEditError(op, id, "invalid_request", "failed to normalize request", normErr)
+		}
+		normalizedForJSON = norm
+	} else if writeErr := editMaybeWriteNormalizedRequest(safety.OutputRequestFile, req); writeErr != nil {
+		return newSlidesEditError(op, id, "output_write_failed", "write normalized request failed", writeErr)
+	}
+
+	base := map[string]any{
+		"presentationId": id,
+		"operation":      op,
+		"operations":     len(req.Requests),
+		"requestKinds":   requestKinds,
+		"requestHash":    requestHash,
+	}
+	for k, v := range summary {
+		base[k] = v
+	}
+	if normalizedForJSON != "" {
+		base["normalizedRequest"] = normalizedForJSON
+	}
+
+	if safety.ValidateOnly || safety.DryRun {
+		if safety.ValidateOnly {
+			base["validateOnly"] = true
+			base["valid"] = true
+		} else {
+			base["dryRun"] = true
+			base["request"] = req
+		}
+		if safety.Pretty {
+			if pretty, prettyErr := json.MarshalIndent(req, "", "  "); prettyErr == nil {
+				base["prettyRequest"] = string(pretty)
+			}
+		}
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, base)
+		}
+		if safety.ValidateOnly {
+			u.Out().Printf("validate-only\ttrue")
+			u.Out().Printf("valid\ttrue")
+		} else {
+			u.Out().Printf("dry-run\ttrue")
+		}
+		u.Out().Printf("id\t%s", id)
+		u.Out().Printf("operations\t%d", len(req.Requests))
+		u.Out().Printf("request-hash\t%s", requestHash)
+		if safety.Pretty {
+			if pretty, prettyErr := json.MarshalIndent(req, "", "  "); prettyErr == nil {
+				u.Out().Printf("pretty-request\t%s", string(pretty))
+			}
+		} else if safety.DryRun {
+			if raw, rawErr := json.Marshal(req); rawErr == nil {
+				u.Out().Printf("request\t%s", string(raw))
+			}
+		}
+		return nil
+	}
+
+	svc, err := slidesEditService(ctx, flags, op, id)
+	if err != nil {
+		return err
+	}
+	resp, err := svc.Presentations.BatchUpdate(id, req).Context(ctx).Do()
+	if err != nil {
+		return slidesEditAPIError(op, id, op+" failed", err)
+	}
+
+	payload := map[string]any{
+		"presentationId": id,
+		"operations":     len(req.Requests),
+		"requestHash":    requestHash,
+		"replies":        len(resp.Replies),
+	}
+	var occurrences int64
+	var counted bool
+	var objectIDs []string
+	for _, r := range resp.Replies {
+		switch {
+		case r == nil:
+		case r.ReplaceAllText != nil:
+			occurrences += r.ReplaceAllText.OccurrencesChanged
+			counted = true
+		case r.ReplaceAllShapesWithImage != nil:
+			occurrences += r.ReplaceAllShapesWithImage.OccurrencesChanged
+			counted = true
+		case r.CreateSlide != nil:
+			objectIDs = append(objectIDs, r.CreateSlide.ObjectId)
+		case r.DuplicateObject != nil:
+			objectIDs = append(objectIDs, r.DuplicateObject.ObjectId)
+		}
+	}
+	if counted {
+		payload["occurrencesChanged"] = occurrences
+	}
+	if len(objectIDs) > 0 {
+		payload["objectIds"] = objectIDs
+	}
+	if normalizedForJSON != "" {
+		payload["normalizedRequest"] = normalizedForJSON
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, payload)
+	}
+	u.Out().Printf("id\t%s", id)
+	u.Out().Printf("operations\t%d", len(req.Requests))
+	if counted {
+		u.Out().Printf("replaced\t%d", occurrences)
+	}
+	for _, objectID := range objectIDs {
+		u.Out().Printf("object\t%s", objectID)
+	}
+	return nil
+}
+
+func slidesEditService(ctx context.Context, flags *RootFlags, op, id string) (*slides.Service, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return nil, newSlidesEditError(op, id, "service_init_failed", "create slides service failed", err)
+	}
+	return svc, nil
+}
+
+func slidesEditPresentation(ctx context.Context, flags *RootFlags, op, id string) (*slides.Presentation, error) {
+	svc, err := slidesEditService(ctx, flags, op, id)
+	if err != nil {
+		return nil, err
+	}
+	pres, err := svc.Presentations.Get(id).Context(ctx).Do()
+	if err != nil {
+		return nil, slidesEditAPIError(op, id, "fetch presentation failed", err)
+	}
+	return pres, nil
+}
+
+// resolveSlideRefs maps slide references (object IDs or 1-based positions)
+// to object IDs.
+func resolveSlideRefs(op, id string, pres *slides.Presentation, refs []string) ([]string, error) {
+	out := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		found := ""
+		if n, err := strconv.Atoi(ref); err == nil {
+			if n >= 1 && n <= len(pres.Slides) {
+				found = pres.Slides[n-1].ObjectId
+			}
+		} else {
+			for _, s := range pres.Slides {
+				if s.ObjectId == ref {
+					found = ref
+					break
+				}
+			}
+		}
+		if found == "" {
+			return nil, slidesEditUsageError(op, id, "slide_not_found", fmt.Sprintf("slide %q not found (deck has %d slides)", ref, len(pres.Slides)))
+		}
+		out = append(out, found)
+	}
+	return out, nil
+}
+
+type SlidesReplaceTextCmd struct {
+	PresentationID string                `arg:"" name:"presentationId" help:"Presentation ID"`
+	Find           string                `arg:"" optional:"" name:"find" help:"Text to find"`
+	Replace        string                `arg:"" optional:"" name:"replace" help:"Replacement text"`
+	MatchCase      bool                  `name:"match-case" help:"Case-sensitive matching"`
+	Slides         []string              `name:"slide" help:"Limit to these slides (object IDs or 1-based numbers)"`
+	Safety         SlidesEditSafetyFlags `embed:""`
+}
+
+func (c *SlidesReplaceTextCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "replace-text"
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty presentationId")
+	}
+	return runSlidesEdit(ctx, flags, op, id, c.Safety, func() ([]*slides.Request, map[string]any, error) {
+		find := strings.TrimSpace(c.Find)
+		if find == "" {
+			return nil, nil, slidesEditUsageError(op, id, "invalid_argument", "empty find")
+		}
+		pages, err := slidesPageFilter(ctx, flags, op, id, c.Slides)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*slides.Request{{ReplaceAllText: &slides.ReplaceAllTextRequest{
+			ContainsText:    &slides.SubstringMatchCriteria{Text: find, MatchCase: c.MatchCase},
+			ReplaceText:     c.Replace,
+			PageObjectIds:   pages,
+			ForceSendFields: []string{"ReplaceText"},
+		}}}, map[string]any{"find": find}, nil
+	})
+}
+
+type SlidesReplaceImageCmd struct {
+	PresentationID string                `arg:"" name:"presentationId" help:"Presentation ID"`
+	Find           string                `arg:"" optional:"" name:"find" help:"Text identifying the shapes to replace (eg. {{logo}})"`
+	ImageURL       string                `arg:"" optional:"" name:"imageUrl" help:"Publicly accessible image URL"`
+	Method         string                `name:"method" help:"Image fit: center-inside|center-crop" enum:"center-inside,center-crop" default:"center-inside"`
+	MatchCase      bool                  `name:"match-case" help:"Case-sensitive matching"`
+	Slides         []string              `name:"slide" help:"Limit to these slides (object IDs or 1-based numbers)"`
+	Safety         SlidesEditSafetyFlags `embed:""`
+}
+
+func (c *SlidesReplaceImageCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "replace-image"
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty presentationId")
+	}
+	return runSlidesEdit(ctx, flags, op, id, c.Safety, func() ([]*slides.Request, map[string]any, error) {
+		find := strings.TrimSpace(c.Find)
+		imageURL := strings.TrimSpace(c.ImageURL)
+		if find == "" || imageURL == "" {
+			return nil, nil, slidesEditUsageError(op, id, "invalid_argument", "find text and image URL are required")
+		}
+		pages, err := slidesPageFilter(ctx, flags, op, id, c.Slides)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*slides.Request{{ReplaceAllShapesWithImage: &slides.ReplaceAllShapesWithImageRequest{
+			ContainsText:       &slides.SubstringMatchCriteria{Text: find, MatchCase: c.MatchCase},
+			ImageUrl:           imageURL,
+			ImageReplaceMethod: strings.ToUpper(strings.ReplaceAll(c.Method, "-", "_")),
+			PageObjectIds:      pages,
+		}}}, map[string]any{"find": find, "imageUrl": imageURL}, nil
+	})
+}
+
+// slidesPageFilter resolves --slide references, fetching the deck only
+// when there is something to resolve.
+func slidesPageFilter(ctx context.Context, flags *RootFlags, op, id string, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	pres, err := slidesEditPresentation(ctx, flags, op, id)
+	if err != nil {
+		return nil, err
+	}
+	return resolveSlideRefs(op, id, pres, refs)
+}
+
+// slidesPredefinedLayouts are the layout names accepted by
+// SlideLayoutReference.PredefinedLayout.
+var slidesPredefinedLayouts = map[string]bool{
+	"BLANK":                         true,
+	"CAPTION_ONLY":                  true,
+	"TITLE":                         true,
+	"TITLE_AND_BODY":                true,
+	"TITLE_AND_TWO_COLUMNS":         true,
+	"TITLE_ONLY":                    true,
+	"SECTION_HEADER":                true,
+	"SECTION_TITLE_AND_DESCRIPTION": true,
+	"ONE_COLUMN_TEXT":               true,
+	"MAIN_POINT":                    true,
+	"BIG_NUMBER":                    true,
+}
+
+type SlidesAddSlideCmd struct {
+	PresentationID string                `arg:"" name:"presentationId" help:"Presentation ID"`
+	Layout         string                `name:"layout" help:"Predefined layout (eg. TITLE_AND_BODY, blank) or a layout name/ID from the deck's master" default:"BLANK"`
+	Index          int64                 `name:"index" help:"1-based position of the new slide (default: end of deck)"`
+	ObjectID       string                `name:"object-id" help:"Object ID for the new slide (default: generated)"`
+	Safety         SlidesEditSafetyFlags `embed:""`
+}
+
+func (c *SlidesAddSlideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "add-slide"
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty presentationId")
+	}
+	return runSlidesEdit(ctx, flags, op, id, c.Safety, func() ([]*slides.Request, map[string]any, error) {
+		if c.Index < 0 {
+			return nil, nil, slidesEditUsageError(op, id, "invalid_argument", "--index must be >= 1")
+		}
+		ref, err := c.layoutReference(ctx, flags, op, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		create := &slides.CreateSlideRequest{
+			ObjectId:             strings.TrimSpace(c.ObjectID),
+			SlideLayoutReference: ref,
+		}
+		if c.Index > 0 {
+			create.InsertionIndex = c.Index - 1
+			create.ForceSendFields = []string{"InsertionIndex"}
+		}
+		return []*slides.Request{{CreateSlide: create}}, map[string]any{"layout": c.Layout}, nil
+	})
+}
+
+func (c *SlidesAddSlideCmd) layoutReference(ctx context.Context, flags *RootFlags, op, id string) (*slides.LayoutReference, error) {
+	layout := strings.TrimSpace(c.Layout)
+	if layout == "" {
+		layout = "BLANK"
+	}
+	predefined := strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(layout))
+	if slidesPredefinedLayouts[predefined] {
+		return &slides.LayoutReference{PredefinedLayout: predefined}, nil
+	}
+	pres, err := slidesEditPresentation(ctx, flags, op, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range pres.Layouts {
+		if l.ObjectId == layout {
+			return &slides.LayoutReference{LayoutId: l.ObjectId}, nil
+		}
+		if p := l.LayoutProperties; p != nil && (strings.EqualFold(p.DisplayName, layout) || strings.EqualFold(p.Name, layout)) {
+			return &slides.LayoutReference{LayoutId: l.ObjectId}, nil
+		}
+	}
+	return nil, slidesEditUsageError(op, id, "layout_not_found", fmt.Sprintf("layout %q not found", layout))
+}
+
+type SlidesDeleteSlideCmd struct {
+	PresentationID string                `arg:"" name:"presentationId" help:"Presentation ID"`
+	Slides         []string              `arg:"" optional:"" name:"slide" help:"Slides to delete (object IDs or 1-based numbers)"`
+	Safety         SlidesEditSafetyFlags `embed:""`
+}
+
+func (c *SlidesDeleteSlideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "delete-slide"
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty presentationId")
+	}
+	if !c.Safety.DryRun && !c.Safety.ValidateOnly && (flags == nil || !flags.Force) {
+		return slidesEditUsageError(op, id, "confirmation_required", "delete-slide is destructive; rerun with --force or use --dry-run")
+	}
+	return runSlidesEdit(ctx, flags, op, id, c.Safety, func() ([]*slides.Request, map[string]any, error) {
+		if len(c.Slides) == 0 {
+			return nil, nil, slidesEditUsageError(op, id, "invalid_argument", "missing slide")
+		}
+		pres, err := slidesEditPresentation(ctx, flags, op, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids, err := resolveSlideRefs(op, id, pres, c.Slides)
+		if err != nil {
+			return nil, nil, err
+		}
+		reqs := make([]*slides.Request, 0, len(ids))
+		for _, objectID := range ids {
+			reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: objectID}})
+		}
+		return reqs, map[string]any{"slides": ids}, nil
+	})
+}
+
+type SlidesDuplicateSlideCmd struct {
+	PresentationID string                `arg:"" name:"presentationId" help:"Presentation ID"`
+	Slide          string                `arg:"" optional:"" name:"slide" help:"Slide to duplicate (object ID or 1-based number)"`
+	ObjectID       string                `name:"object-id" help:"Object ID for the copy (default: generated)"`
+	Safety         SlidesEditSafetyFlags `embed:""`
+}
+
+func (c *SlidesDuplicateSlideCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "duplicate-slide"
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty presentationId")
+	}
+	return runSlidesEdit(ctx, flags, op, id, c.Safety, func() ([]*slides.Request, map[string]any, error) {
+		if strings.TrimSpace(c.Slide) == "" {
+			return nil, nil, slidesEditUsageError(op, id, "invalid_argument", "missing slide")
+		}
+		pres, err := slidesEditPresentation(ctx, flags, op, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids, err := resolveSlideRefs(op, id, pres, []string{c.Slide})
+		if err != nil {
+			return nil, nil, err
+		}
+		dup := &slides.DuplicateObjectRequest{ObjectId: ids[0]}
+		if newID := strings.TrimSpace(c.ObjectID); newID != "" {
+			dup.ObjectIds = map[string]string{ids[0]: newID}
+		}
+		return []*slides.Request{{DuplicateObject: dup}}, map[string]any{"slide": ids[0]}, nil
+	})
+}
+
+type SlidesBatchCmd struct {
+	PresentationID string                `arg:"" name:"presentationId" help:"Presentation ID"`
+	RequestsFile   string                `name:"requests-file" help:"Path to presentations.batchUpdate request JSON, or '-' for stdin" default:"-"`
+	Safety         SlidesEditSafetyFlags `embed:""`
+}
+
+func (c *SlidesBatchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	const op = "batch"
+	id := strings.TrimSpace(c.PresentationID)
+	if id == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty presentationId")
+	}
+	requestsFile := strings.TrimSpace(c.RequestsFile)
+	if from := strings.TrimSpace(c.Safety.ExecuteFromFile); from != "" {
+		if requestsFile != "-" && requestsFile != "" {
+			return slidesEditUsageError(op, id, "invalid_argument", "cannot combine --execute-from-file with --requests-file")
+		}
+		requestsFile = from
+	}
+	if requestsFile == "" {
+		return slidesEditUsageError(op, id, "invalid_argument", "empty requests-file")
+	}
+	safety := c.Safety
+	safety.ExecuteFromFile = requestsFile
+	return runSlidesEdit(ctx, flags, op, id, safety, nil)
+}
+
+func decodeSlidesEditRequest(op, id, path string, dst *slides.BatchUpdatePresentationRequest) error {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path) //nolint:gosec // user-provided path
+		if err != nil {
+			return newSlidesEditError(op, id, "input_open_failed", "open request file failed", err)
+		}
+		defer f.Close()
+		reader = f
+	}
+	if err := json.NewDecoder(reader).Decode(dst); err != nil {
+		return newSlidesEditError(op, id, "invalid_json", "decode request JSON failed", err)
+	}
+	return nil
+}
+
+func slidesRequestOperationNames(r *slides.Request) []string {
+	if r == nil {
+		return nil
+	}
+	v := reflect.ValueOf(*r)
+	t := v.Type()
+	var names []string
+	for i := range t.NumField() {
+		name := t.Field(i).Name
+		if name == "ForceSendFields" || name == "NullFields" {
+			continue
+		}
+		if fv := v.Field(i); fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			names = append(names, name)
+		}
+	}
+	return names
+}
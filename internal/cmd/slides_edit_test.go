@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/slides/v1"
+)
+
+type slidesEditRecorder struct {
+	mu      sync.Mutex
+	calls   []string
+	batches map[string]slides.BatchUpdatePresentationRequest
+}
+
+// newSlidesEditTestService serves presentation "p1" with slides s1 (with a
+// {{name}} title and a {{image:logo}} shape) and s2, plus one custom layout.
+func newSlidesEditTestService(t *testing.T) *slidesEditRecorder {
+	t.Helper()
+	rec := &slidesEditRecorder{batches: map[string]slides.BatchUpdatePresentationRequest{}}
+
+	shape := func(id, text string) map[string]any {
+		return map[string]any{"objectId": id, "shape": map[string]any{"text": map[string]any{
+			"textElements": []any{map[string]any{"textRun": map[string]any{"content": text}}},
+		}}}
+	}
+	pres := map[string]any{
+		"presentationId": "p1",
+		"slides": []any{
+			map[string]any{"objectId": "s1", "pageElements": []any{shape("t1", "Status for {{name}}\n"), shape("i1", "{{image:logo}}\n")}},
+			map[string]any{"objectId": "s2", "pageElements": []any{shape("t2", "Owner: {{ owner }}\n")}},
+		},
+		"layouts": []any{
+			map[string]any{"objectId": "lay1", "layoutProperties": map[string]any{"name": "CUSTOM_1", "displayName": "Weekly status"}},
+		},
+	}
+
+	stubGoogleService(t, &newSlidesService, slides.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		rec.calls = append(rec.calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/presentations/"):
+			id := strings.TrimPrefix(r.URL.Path, "/v1/presentations/")
+			if id != "p1" && id != "tpl" {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "nope"}})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(pres)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/presentations/"), ":batchUpdate")
+			var req slides.BatchUpdatePresentationRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			rec.batches[id] = req
+			replies := make([]any, len(req.Requests))
+			for i, rq := range req.Requests {
+				switch {
+				case rq.ReplaceAllText != nil:
+					replies[i] = map[string]any{"replaceAllText": map[string]any{"occurrencesChanged": 2}}
+				case rq.DuplicateObject != nil:
+					replies[i] = map[string]any{"duplicateObject": map[string]any{"objectId": "dup1"}}
+				default:
+					replies[i] = map[string]any{}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"presentationId": id, "replies": replies})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return rec
+}
+
+func TestExecute_SlidesEdit_Commands(t *testing.T) {
+	rec := newSlidesEditTestService(t)
+	run := func(args ...string) map[string]any {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := Execute(append([]string{"--json", "--account", "a@b.com", "slides", "edit"}, args...)); err != nil {
+				t.Fatalf("%v: %v", args, err)
+			}
+		})
+		var parsed map[string]any
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json: %v\n%s", err, out)
+		}
+		return parsed
+	}
+	last := func() *slides.Request {
+		t.Helper()
+		return rec.batches["p1"].Requests[0]
+	}
+
+	out := run("replace-text", "p1", "Q3", "Q4", "--slide", "2")
+	if r := last().ReplaceAllText; r == nil || r.ReplaceText != "Q4" || strings.Join(r.PageObjectIds, ",") != "s2" {
+		t.Fatalf("unexpected replace-text request: %+v", last())
+	}
+	if out["occurrencesChanged"] != float64(2) {
+		t.Fatalf("unexpected output: %v", out)
+	}
+
+	run("replace-image", "p1", "{{logo}}", "https://x.test/logo.png", "--method", "center-crop")
+	if r := last().ReplaceAllShapesWithImage; r == nil || r.ImageReplaceMethod != "CENTER_CROP" || r.ContainsText.Text != "{{logo}}" {
+		t.Fatalf("unexpected replace-image request: %+v", last())
+	}
+
+	run("add-slide", "p1", "--layout", "title-and-body", "--index", "1")
+	if r := last().CreateSlide; r == nil || r.SlideLayoutReference.PredefinedLayout != "TITLE_AND_BODY" || r.InsertionIndex != 0 {
+		t.Fatalf("unexpected add-slide request: %+v", last())
+	}
+	run("add-slide", "p1", "--layout", "weekly status")
+	if r := last().CreateSlide; r == nil || r.SlideLayoutReference.LayoutId != "lay1" {
+		t.Fatalf("unexpected add-slide layout: %+v", last())
+	}
+
+	out = run("duplicate-slide", "p1", "1", "--object-id", "copy_1")
+	if r := last().DuplicateObject; r == nil || r.ObjectId != "s1" || r.ObjectIds["s1"] != "copy_1" {
+		t.Fatalf("unexpected duplicate request: %+v", last())
+	}
+	if ids, _ := out["objectIds"].([]any); len(ids) != 1 || ids[0] != "dup1" {
+		t.Fatalf("unexpected duplicate output: %v", out)
+	}
+
+	_ = captureStderr(t, func() {
+		err := Execute([]string{"--json", "--account", "a@b.com", "slides", "edit", "delete-slide", "p1", "s2"})
+		var se *editError
+		if !errors.As(err, &se) || se.ErrorCode != "confirmation_required" {
+			t.Fatalf("expected JSON-mode delete-slide to need --force, got %v", err)
+		}
+	})
+	if len(rec.batches["p1"].Requests) != 1 {
+		t.Fatalf("delete-slide without --force must not send requests: %+v", rec.batches["p1"].Requests)
+	}
+
+	run("delete-slide", "p1", "s2", "1", "--force")
+	reqs := rec.batches["p1"].Requests
+	if len(reqs) != 2 || reqs[0].DeleteObject.ObjectId != "s2" || reqs[1].DeleteObject.ObjectId != "s1" {
+		t.Fatalf("unexpected delete requests: %+v", reqs)
+	}
+}
+
+func TestExecute_SlidesEdit_DryRunAndReplay(t *testing.T) {
+	rec := newSlidesEditTestService(t)
+	reqFile := filepath.Join(t.TempDir(), "req.json")
+
+	var dry map[string]any
+	out := captureStdout(t, func() {
+		args := []string{"--json", "slides", "edit", "replace-text", "p1", "{{week}}", "42", "--dry-run", "--require-revision", "rev1", "--output-request-file", reqFile}
+		if err := Execute(args); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &dry); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if dry["dryRun"] != true || len(rec.calls) != 0 {
+		t.Fatalf("unexpected dry-run: %v calls=%v", dry, rec.calls)
+	}
+	if raw, _ := os.ReadFile(reqFile); !strings.Contains(string(raw), `"requiredRevisionId": "rev1"`) {
+		t.Fatalf("request file misses write control: %s", raw)
+	}
+
+	var replay map[string]any
+	out = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "slides", "edit", "batch", "p1", "--execute-from-file", reqFile}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &replay); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if replay["requestHash"] != dry["requestHash"] || len(rec.calls) != 1 {
+		t.Fatalf("replay hash %v vs %v, calls=%v", replay["requestHash"], dry["requestHash"], rec.calls)
+	}
+}
+
+func TestExecute_SlidesEdit_Errors(t *testing.T) {
+	_ = newSlidesEditTestService(t)
+
+	stderr := captureStderr(t, func() {
+		withStdin(t, `{"requests":[{"deleteObject":{"objectId":"s1"}},{}]}`, func() {
+			if err := Execute([]string{"--json", "slides", "edit", "batch", "p1", "--validate-only"}); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	})
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stderr)), &parsed); err != nil {
+		t.Fatalf("parse stderr json: %v; stderr=%q", err, stderr)
+	}
+	errorObj := parsed["error"].(map[string]any)
+	if errorObj["error_code"] != "invalid_request" || errorObj["request_index"] != float64(1) || errorObj["presentation_id"] != "p1" {
+		t.Fatalf("unexpected error: %v", errorObj)
+	}
+
+	for want, args := range map[string][]string{
+		"slide_not_found":        {"duplicate-slide", "p1", "9"},
+		"layout_not_found":       {"add-slide", "p1", "--layout", "nope"},
+		"confirmation_required":  {"delete-slide", "p1", "s1"},
+		"presentation_not_found": {"delete-slide", "missing", "1", "--dry-run"},
+	} {
+		err := Execute(append([]string{"--account", "a@b.com", "slides", "edit"}, args...))
+		var se *editError
+		if !errors.As(err, &se) || se.ErrorCode != want {
+			t.Fatalf("%v: expected %s, got %v", args, want, err)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type SlidesGenerateCmd struct {
+	Template    string `name:"template" required:"" help:"Template presentation ID"`
+	Data        string `name:"data" required:"" help:"Values as a JSON object, a JSON array or CSV ('-' for stdin); arrays and CSV produce one deck (or slide) per row"`
+	DataFormat  string `name:"data-format" help:"Data format: auto|json|csv" enum:"auto,json,csv" default:"auto"`
+	Title       string `name:"title" required:"" help:"Title of each generated deck; may contain {{placeholders}}"`
+	Parent      string `name:"parent" help:"Destination folder ID"`
+	LayoutSlide string `name:"layout-slide" help:"Build a single deck with one copy of this template slide (object ID or 1-based number) per row"`
+	Strict      bool   `name:"strict" help:"Fail before copying when the template uses placeholders missing from the data"`
+	DryRun      bool   `name:"dry-run" help:"Print the planned decks and requests without copying"`
+}
+
+type slidesGeneratePlan struct {
+	Title    string            `json:"title"`
+	Slides   int               `json:"slides,omitempty"`
+	Missing  []string          `json:"missing,omitempty"`
+	Requests []*slides.Request `json:"requests,omitempty"`
+}
+
+type slidesGenerated struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Link    string   `json:"link,omitempty"`
+	Slides  int      `json:"slides,omitempty"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+func (c *SlidesGenerateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	templateID := strings.TrimSpace(c.Template)
+	if templateID == "" {
+		return usage("empty --template")
+	}
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		return usage("empty --title")
+	}
+	records, err := readDocsTemplateData(c.Data, c.DataFormat)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return usage("data has no records")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	slidesSvc, err := newSlidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tpl, err := slidesSvc.Presentations.Get(templateID).Context(ctx).Do()
+	if err != nil {
+		if isDocsNotFound(err) {
+			return fmt.Errorf("template not found or not a Google Slides presentation (id=%s)", templateID)
+		}
+		return err
+	}
+
+	// Plan every deck up front so bad data fails before anything is copied.
+	var plans []slidesGeneratePlan
+	if layout := strings.TrimSpace(c.LayoutSlide); layout != "" {
+		plan, err := slidesPerRowPlan(tpl, layout, title, records, c.Strict)
+		if err != nil {
+			return err
+		}
+		plans = append(plans, plan)
+	} else {
+		text := slidesPresentationText(tpl)
+		for i, rec := range records {
+			plan := slidesGeneratePlan{Title: fillDocsTemplateText(title, rec)}
+			if len(records) > 1 && plan.Title == title {
+				plan.Title = fmt.Sprintf("%s (%d)", title, i+1)
+			}
+			plan.Requests, plan.Missing = slidesTemplateRequests(text, rec, nil)
+			if c.Strict && len(plan.Missing) > 0 {
+				return usagef("record %d: no value for %s", i+1, strings.Join(plan.Missing, ", "))
+			}
+			plans = append(plans, plan)
+		}
+	}
+
+	if c.DryRun {
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(os.Stdout, map[string]any{
+				"dryRun":        true,
+				"template":      templateID,
+				"presentations": plans,
+			})
+		}
+		w, flush := tableWriter(ctx)
+		defer flush()
+		fmt.Fprintln(w, "TITLE\tREQUESTS\tMISSING")
+		for _, p := range plans {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", p.Title, len(p.Requests), orDash(strings.Join(p.Missing, ",")))
+		}
+		return nil
+	}
+
+	driveSvc, err := newDriveService(ctx, account)
+	if err != nil {
+		return err
+	}
+	generated := make([]slidesGenerated, 0, len(plans))
+	for i, p := range plans {
+		created, err := copyDriveFile(ctx, driveSvc, slidesCopyOptions, templateID, p.Title, c.Parent)
+		if err != nil {
+			return fmt.Errorf("deck %d: copy template: %w (generated %d of %d)", i+1, err, len(generated), len(plans))
+		}
+		if len(p.Requests) > 0 {
+			req := &slides.BatchUpdatePresentationRequest{Requests: p.Requests}
+			if _, err := slidesSvc.Presentations.BatchUpdate(created.Id, req).Context(ctx).Do(); err != nil {
+				// The batch is atomic, so an unfilled copy is the only leftover.
+				if delErr := driveSvc.Files.Delete(created.Id).SupportsAllDrives(true).Context(ctx).Do(); delErr != nil {
+					return fmt.Errorf("deck %d: fill %s: %w (also failed to delete the copy: %v)", i+1, created.Id, err, delErr)
+				}
+				return fmt.Errorf("deck %d: fill template: %w (generated %d of %d)", i+1, err, len(generated), len(plans))
+			}
+		}
+		link := created.WebViewLink
+		if link == "" {
+			link = slidesWebViewLink(created.Id)
+		}
+		generated = append(generated, slidesGenerated{ID: created.Id, Title: created.Name, Link: link, Slides: p.Slides, Missing: p.Missing})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{
+			"template":      templateID,
+			"presentations": generated,
+		})
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTITLE\tLINK")
+	for _, g := range generated {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", g.ID, g.Title, g.Link)
+	}
+	for _, g := range generated {
+		if len(g.Missing) > 0 {
+			u.Err().Printf("warning: %s: no value for %s", g.ID, strings.Join(g.Missing, ", "))
+		}
+	}
+	return nil
+}
+
+// slidesPerRowPlan builds one deck in which the layout slide is duplicated
+// once per record and each copy is filled on its own page. Duplicates land
+// right after the original, so rows are added last-first to keep their
+// order; the original is removed at the end.
+func slidesPerRowPlan(tpl *slides.Presentation, layout, title string, records []map[string]any, strict bool) (slidesGeneratePlan, error) {
+	ids, err := resolveSlideRefs("generate", tpl.PresentationId, tpl, []string{layout})
+	if err != nil {
+		return slidesGeneratePlan{}, err
+	}
+	layoutID := ids[0]
+	var text strings.Builder
+	for _, s := range tpl.Slides {
+		if s.ObjectId == layoutID {
+			slidesElementsText(s.PageElements, &text)
+		}
+	}
+
+	plan := slidesGeneratePlan{Title: title, Slides: len(records)}
+	missing := map[string]bool{}
+	perRow := make([][]*slides.Request, len(records))
+	for i, rec := range records {
+		pageID := fmt.Sprintf("gog_row_%d", i+1)
+		reqs, miss := slidesTemplateRequests(text.String(), rec, []string{pageID})
+		if strict && len(miss) > 0 {
+			return slidesGeneratePlan{}, usagef("record %d: no value for %s", i+1, strings.Join(miss, ", "))
+		}
+		for _, m := range miss {
+			missing[m] = true
+		}
+		perRow[i] = append([]*slides.Request{{DuplicateObject: &slides.DuplicateObjectRequest{
+			ObjectId:  layoutID,
+			ObjectIds: map[string]string{layoutID: pageID},
+		}}}, reqs...)
+	}
+	for i := len(perRow) - 1; i >= 0; i-- {
+		plan.Requests = append(plan.Requests, perRow[i]...)
+	}
+	plan.Requests = append(plan.Requests, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: layoutID}})
+	for m := range missing {
+		plan.Missing = append(plan.Missing, m)
+	}
+	sort.Strings(plan.Missing)
+	return plan, nil
+}
+
+// slidesTemplateRequests fills the placeholders found in text with rec.
+// {{image:key}} replaces the whole shape with the image at the value's URL
+// (a string or {"url": ...}); everything else is replaceAllText. A non-nil
+// pages scopes the requests to those slides.
+func slidesTemplateRequests(text string, rec map[string]any, pages []string) ([]*slides.Request, []string) {
+	raws := map[string]bool{}
+	for _, m := range docsPlaceholderRe.FindAllString(text, -1) {
+		raws[m] = true
+	}
+	keys := make([]string, 0, len(raws))
+	for raw := range raws {
+		keys = append(keys, raw)
+	}
+	sort.Strings(keys)
+
+	var reqs []*slides.Request
+	var missing []string
+	for _, raw := range keys {
+		name := docsPlaceholderRe.FindStringSubmatch(raw)[1]
+		if key, ok := strings.CutPrefix(name, "image:"); ok {
+			v, _ := lookupDocsTemplateValue(rec, strings.TrimSpace(key))
+			var url string
+			switch t := v.(type) {
+			case string:
+				url = t
+			case map[string]any:
+				url, _ = t["url"].(string)
+			}
+			if strings.TrimSpace(url) == "" {
+				missing = append(missing, name)
+				continue
+			}
+			reqs = append(reqs, &slides.Request{ReplaceAllShapesWithImage: &slides.ReplaceAllShapesWithImageRequest{
+				ContainsText:       &slides.SubstringMatchCriteria{Text: raw, MatchCase: true},
+				ImageUrl:           url,
+				ImageReplaceMethod: "CENTER_INSIDE",
+				PageObjectIds:      pages,
+			}})
+			continue
+		}
+		v, ok := lookupDocsTemplateValue(rec, name)
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		value, ok := docsTemplateText(v)
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		reqs = append(reqs, &slides.Request{ReplaceAllText: &slides.ReplaceAllTextRequest{
+			ContainsText:  &slides.SubstringMatchCriteria{Text: raw, MatchCase: true},
+			ReplaceText:   value,
+			PageObjectIds: pages,
+			// An empty value must still be sent to clear the placeholder.
+			ForceSendFields: []string{"ReplaceText"},
+		}})
+	}
+	return reqs, missing
+}
+
+// slidesPresentationText returns the text of every slide and its speaker
+// notes, one shape per line.
+func slidesPresentationText(p *slides.Presentation) string {
+	var b strings.Builder
+	for _, s := range p.Slides {
+		slidesElementsText(s.PageElements, &b)
+		if s.SlideProperties != nil && s.SlideProperties.NotesPage != nil {
+			slidesElementsText(s.SlideProperties.NotesPage.PageElements, &b)
+		}
+	}
+	return b.String()
+}
+
+func slidesElementsText(elements []*slides.PageElement, b *strings.Builder) {
+	for _, el := range elements {
+		switch {
+		case el == nil:
+		case el.Shape != nil:
+			slidesTextContent(el.Shape.Text, b)
+		case el.Table != nil:
+			for _, row := range el.Table.TableRows {
+				for _, cell := range row.TableCells {
+					slidesTextContent(cell.Text, b)
+				}
+			}
+		case el.ElementGroup != nil:
+			slidesElementsText(el.ElementGroup.Children, b)
+		}
+	}
+}
+
+func slidesTextContent(t *slides.TextContent, b *strings.Builder) {
+	if t == nil {
+		return
+	}
+	for _, te := range t.TextElements {
+		if te.TextRun != nil {
+			b.WriteString(te.TextRun.Content)
+		}
+	}
+	b.WriteString("\n")
+}
+
+func slidesWebViewLink(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	return "https://docs.google.com/presentation/d/" + id + "/edit"
+}
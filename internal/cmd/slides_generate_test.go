@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
+)
+
+// slidesGenerateDrive serves template "tpl" and records copies and deletes.
+type slidesGenerateDrive struct {
+	copies  []string
+	deleted []string
+}
+
+func (d *slidesGenerateDrive) install(t *testing.T) {
+	t.Helper()
+	stubGoogleService(t, &newDriveService, drive.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path == "/files/tpl" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "tpl", "mimeType": "application/vnd.google-apps.presentation"})
+		case path == "/files/tpl/copy" && r.Method == http.MethodPost:
+			var f drive.File
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			d.copies = append(d.copies, f.Name)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "deck" + string(rune('0'+len(d.copies))), "name": f.Name})
+		case strings.HasPrefix(path, "/files/deck") && r.Method == http.MethodDelete:
+			d.deleted = append(d.deleted, strings.TrimPrefix(path, "/files/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func writeSlidesGenerateData(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rows.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestSlidesGenerate_PerDeckAndPerSlide(t *testing.T) {
+	rec := newSlidesEditTestService(t)
+	d := &slidesGenerateDrive{}
+	d.install(t)
+	data := writeSlidesGenerateData(t, `[{"name":"Ada","owner":"ops","logo":"https://x.test/a.png"},{"name":"Grace"}]`)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "slides", "generate", "--template", "tpl", "--data", data, "--title", "Status - {{name}}"}); err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+	})
+	if strings.Join(d.copies, "|") != "Status - Ada|Status - Grace" {
+		t.Fatalf("unexpected copies: %v", d.copies)
+	}
+	var kinds []string
+	for _, r := range rec.batches["deck1"].Requests {
+		switch {
+		case r.ReplaceAllShapesWithImage != nil:
+			kinds = append(kinds, "img:"+r.ReplaceAllShapesWithImage.ImageUrl)
+		case r.ReplaceAllText != nil:
+			kinds = append(kinds, r.ReplaceAllText.ContainsText.Text+"="+r.ReplaceAllText.ReplaceText)
+		}
+	}
+	if strings.Join(kinds, ",") != "{{ owner }}=ops,img:https://x.test/a.png,{{name}}=Ada" {
+		t.Fatalf("unexpected deck requests: %v", kinds)
+	}
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "slides", "generate", "--template", "tpl", "--data", data, "--title", "Weekly", "--layout-slide", "2"}); err != nil {
+			t.Fatalf("generate per slide: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"slides": 2`) || !strings.Contains(out, `"owner"`) {
+		t.Fatalf("unexpected per-slide output: %s", out)
+	}
+	var steps []string
+	for _, r := range rec.batches["deck3"].Requests {
+		switch {
+		case r.DuplicateObject != nil:
+			steps = append(steps, "dup:"+r.DuplicateObject.ObjectIds["s2"])
+		case r.ReplaceAllText != nil:
+			steps = append(steps, r.ReplaceAllText.PageObjectIds[0]+":"+r.ReplaceAllText.ReplaceText)
+		case r.DeleteObject != nil:
+			steps = append(steps, "del:"+r.DeleteObject.ObjectId)
+		}
+	}
+	if strings.Join(steps, ",") != "dup:gog_row_2,dup:gog_row_1,gog_row_1:ops,del:s2" {
+		t.Fatalf("unexpected per-slide requests: %v", steps)
+	}
+}
+
+func TestSlidesGenerate_LayoutSlideKeepsRowOrder(t *testing.T) {
+	rec := newSlidesEditTestService(t)
+	d := &slidesGenerateDrive{}
+	d.install(t)
+	data := writeSlidesGenerateData(t, `[{"owner":"a"},{"owner":"b"},{"owner":"c"}]`)
+
+	_ = captureStdout(t, func() {
+		if err := Execute([]string{"--json", "--account", "a@b.com", "slides", "generate", "--template", "tpl", "--data", data, "--title", "Weekly", "--layout-slide", "s2"}); err != nil {
+			t.Fatalf("generate: %v", err)
+		}
+	})
+
+	// Replay the batch the way Slides applies it: a duplicate lands right
+	// after its source, so issuing rows last-first leaves them in row order.
+	order := []string{"s1", "s2"}
+	fills := map[string]string{}
+	for _, r := range rec.batches["deck1"].Requests {
+		switch {
+		case r.DuplicateObject != nil:
+			at := slices.Index(order, r.DuplicateObject.ObjectId)
+			order = slices.Insert(order, at+1, r.DuplicateObject.ObjectIds[r.DuplicateObject.ObjectId])
+		case r.ReplaceAllText != nil:
+			page := r.ReplaceAllText.PageObjectIds[0]
+			if !slices.Contains(order, page) {
+				t.Fatalf("fill for %s before its duplicate", page)
+			}
+			fills[page] = r.ReplaceAllText.ReplaceText
+		case r.DeleteObject != nil:
+			order = slices.DeleteFunc(order, func(id string) bool { return id == r.DeleteObject.ObjectId })
+		}
+	}
+	if strings.Join(order, ",") != "s1,gog_row_1,gog_row_2,gog_row_3" {
+		t.Fatalf("unexpected slide order: %v", order)
+	}
+	if fills["gog_row_1"] != "a" || fills["gog_row_2"] != "b" || fills["gog_row_3"] != "c" {
+		t.Fatalf("unexpected fills: %v", fills)
+	}
+}
+
+func TestSlidesGenerate_StrictFailsBeforeCopying(t *testing.T) {
+	rec := newSlidesEditTestService(t)
+	d := &slidesGenerateDrive{}
+	d.install(t)
+	data := writeSlidesGenerateData(t, `[{"name":"Ada","owner":"ops","logo":"https://x.test/a.png"},{"name":"Grace"}]`)
+
+	for _, extra := range [][]string{nil, {"--layout-slide", "2"}} {
+		args := append([]string{"--account", "a@b.com", "slides", "generate", "--template", "tpl", "--data", data, "--title", "{{name}}", "--strict"}, extra...)
+		err := Execute(args)
+		if err == nil || !strings.Contains(err.Error(), "record 2: no value for") {
+			t.Fatalf("%v: expected strict error for record 2, got %v", extra, err)
+		}
+	}
+	if len(d.copies) != 0 || len(rec.batches) != 0 {
+		t.Fatalf("strict failure must not copy or fill: copies=%v batches=%v", d.copies, rec.batches)
+	}
+}
+
+func TestSlidesGenerate_FillFailureDeletesCopy(t *testing.T) {
+	d := &slidesGenerateDrive{}
+	d.install(t)
+	stubGoogleService(t, &newSlidesService, slides.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/presentations/tpl":
+			_ = json.NewEncoder(w).Encode(map[string]any{"presentationId": "tpl", "slides": []any{
+				map[string]any{"objectId": "s1", "pageElements": []any{map[string]any{"objectId": "t1", "shape": map[string]any{"text": map[string]any{
+					"textElements": []any{map[string]any{"textRun": map[string]any{"content": "Hi {{name}}\n"}}},
+				}}}}},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 400, "message": "bad image"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	data := writeSlidesGenerateData(t, `[{"name":"Ada"},{"name":"Grace"}]`)
+	err := Execute([]string{"--account", "a@b.com", "slides", "generate", "--template", "tpl", "--data", data, "--title", "{{name}}"})
+	if err == nil || !strings.Contains(err.Error(), "deck 1: fill template") || !strings.Contains(err.Error(), "generated 0 of 2") {
+		t.Fatalf("expected fill error for deck 1, got %v", err)
+	}
+	if strings.Join(d.copies, ",") != "Ada" || strings.Join(d.deleted, ",") != "deck1" {
+		t.Fatalf("expected the unfilled copy to be deleted and no further decks: copies=%v deleted=%v", d.copies, d.deleted)
+	}
+}
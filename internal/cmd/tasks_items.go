@@ -154,7 +154,7 @@ type TasksAddCmd struct {
 	TasklistID  string `arg:"" name:"tasklistId" help:"Task list ID"`
 	Title       string `name:"title" help:"Task title (required)"`
 	Notes       string `name:"notes" help:"Task notes/description"`
-	Due         string `name:"due" help:"Due date (RFC3339 or YYYY-MM-DD; time may be ignored by Google Tasks)"`
+	Due         string `name:"due" help:"Due date (RFC3339, YYYY-MM-DD, or natural: tomorrow, next friday, +3d; time may be ignored by Google Tasks)"`
 	Parent      string `name:"parent" help:"Parent task ID (create as subtask)"`
 	Previous    string `name:"previous" help:"Previous sibling task ID (controls ordering)"`
 	Repeat      string `name:"repeat" help:"Repeat task: daily, weekly, monthly, yearly"`
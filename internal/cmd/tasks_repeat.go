@@ -55,7 +55,14 @@ func parseTaskDate(value string) (time.Time, bool, error) {
 	if t, err := time.ParseInLocation("2006-01-02 15:04", value, time.Local); err == nil {
 		return t, true, nil
 	}
-	return time.Time{}, false, fmt.Errorf("invalid date/time %q (expected RFC3339 or YYYY-MM-DD)", value)
+	if t, err := parseTimeExpr(value, time.Now(), time.Local); err == nil {
+		if t.Equal(startOfDay(t)) {
+			// "tomorrow", "next friday": keep the calendar day, not local midnight in UTC.
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), false, nil
+		}
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date/time %q (expected RFC3339, YYYY-MM-DD, or e.g. tomorrow, next friday 5pm, +3d)", value)
 }
 
 func expandRepeatSchedule(start time.Time, unit repeatUnit, count int, until *time.Time) []time.Time {
@@ -9,6 +9,7 @@ import (
 	"os"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"google.golang.org/api/option"
 	"google.golang.org/api/tasks/v1"
@@ -167,3 +168,21 @@ func TestTasksAddCmd_RepeatUntilDateOnlyWithTimeDue(t *testing.T) {
 		t.Fatalf("unexpected due schedule: %#v", gotDue)
 	}
 }
+
+func TestParseTaskDateNatural(t *testing.T) {
+	got, hasTime, err := parseTaskDate("tomorrow")
+	if err != nil || hasTime {
+		t.Fatalf("tomorrow: %v hasTime=%v", err, hasTime)
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	if got.Location() != time.UTC || got.Day() != tomorrow.Day() || got.Hour() != 0 {
+		t.Fatalf("expected tomorrow as a UTC date, got %v", got)
+	}
+
+	if _, hasTime, err = parseTaskDate("in 2 hours"); err != nil || !hasTime {
+		t.Fatalf("in 2 hours: %v hasTime=%v", err, hasTime)
+	}
+	if _, _, err = parseTaskDate("whenever"); err == nil {
+		t.Fatalf("expected error for invalid due")
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/alecthomas/kong"
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
 
 	"github.com/steipete/gogcli/internal/googleauth"
 )
@@ -170,3 +173,69 @@ func runKong(t *testing.T, cmd any, args []string, ctx context.Context, flags *R
 
 	return kctx.Run()
 }
+
+// recordedRequest is one API call captured by recordRequest.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+// recordRequest appends r, with its JSON body decoded, to reqs and returns it.
+func recordRequest(reqs *[]recordedRequest, r *http.Request) recordedRequest {
+	raw, _ := io.ReadAll(r.Body)
+	req := recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+	_ = json.Unmarshal(raw, &req.Body)
+	*reqs = append(*reqs, req)
+	return req
+}
+
+// stubGoogleService points *factory at a test server running handler until
+// the test ends. newService is the client package's NewService.
+func stubGoogleService[S any](
+	t *testing.T,
+	factory *func(context.Context, string) (*S, error),
+	newService func(context.Context, ...option.ClientOption) (*S, error),
+	handler http.Handler,
+) {
+	t.Helper()
+	orig := *factory
+	t.Cleanup(func() { *factory = orig })
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	svc, err := newService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	*factory = func(context.Context, string) (*S, error) { return svc, nil }
+}
+
+// newSheetsSpreadsheetTestService answers spreadsheets.get for "s1" with
+// spreadsheet and every spreadsheets.batchUpdate with a single reply, and
+// records the batchUpdate bodies.
+func newSheetsSpreadsheetTestService(t *testing.T, spreadsheet, reply map[string]any) *[]map[string]any {
+	t.Helper()
+	var batches []map[string]any
+	stubGoogleService(t, &newSheetsService, sheets.NewService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v4")
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && path == "/spreadsheets/s1":
+			_ = json.NewEncoder(w).Encode(spreadsheet)
+		case r.Method == http.MethodPost && path == "/spreadsheets/s1:batchUpdate":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			batches = append(batches, body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"replies": []any{reply}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return &batches
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -154,6 +156,8 @@ func ResolveTimeRangeWithDefaults(ctx context.Context, svc *calendar.Service, fl
 // - ISO 8601 with numeric timezone: 2026-01-05T14:00:00-0800 (no colon)
 // - Date only: 2026-01-05 (interpreted as start of day in user's timezone)
 // - Relative: today, tomorrow, monday, next tuesday
+// - Offsets from now: +1d, -30m, in 2 hours, 3 days ago
+// - A day with a clock time: tomorrow 3pm, next tuesday 15:30, 2026-01-05 9am, noon
 func parseTimeExpr(expr string, now time.Time, loc *time.Location) (time.Time, error) {
 	expr = strings.TrimSpace(expr)
 
@@ -201,7 +205,119 @@ func parseTimeExpr(expr string, now time.Time, loc *time.Location) (time.Time, e
 		return t, nil
 	}
 
-	return time.Time{}, fmt.Errorf("cannot parse %q as time (try: 2026-01-05, today, tomorrow, monday)", expr)
+	if t, ok := parseTimeOffset(exprLower, now); ok {
+		return t, nil
+	}
+	if t, ok := parseDayWithClock(exprLower, now, loc); ok {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse %q as time (try: 2026-01-05, today, tomorrow 3pm, next tuesday 10:00, in 2 hours, +1d)", expr)
+}
+
+var (
+	timeOffsetSignedRe = regexp.MustCompile(`^([+-])\s*(\d+)\s*([a-z]+)$`)
+	timeOffsetInRe     = regexp.MustCompile(`^in\s+(\d+|an?)\s+([a-z]+)$`)
+	timeOffsetAgoRe    = regexp.MustCompile(`^(\d+|an?)\s+([a-z]+)\s+ago$`)
+	timeClockRe        = regexp.MustCompile(`^(?:(.*?)\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+// parseTimeOffset parses offsets from now: "+1d", "-30m", "in 2 hours",
+// "an hour ago". Days and weeks keep the wall-clock time across DST changes.
+func parseTimeOffset(expr string, now time.Time) (time.Time, bool) {
+	var sign, count, unit string
+	switch {
+	case timeOffsetSignedRe.MatchString(expr):
+		m := timeOffsetSignedRe.FindStringSubmatch(expr)
+		sign, count, unit = m[1], m[2], m[3]
+	case timeOffsetInRe.MatchString(expr):
+		m := timeOffsetInRe.FindStringSubmatch(expr)
+		sign, count, unit = "+", m[1], m[2]
+	case timeOffsetAgoRe.MatchString(expr):
+		m := timeOffsetAgoRe.FindStringSubmatch(expr)
+		sign, count, unit = "-", m[1], m[2]
+	default:
+		return time.Time{}, false
+	}
+
+	n := 1
+	if count != "a" && count != "an" {
+		var err error
+		if n, err = strconv.Atoi(count); err != nil {
+			return time.Time{}, false
+		}
+	}
+	if sign == "-" {
+		n = -n
+	}
+
+	switch unit {
+	case "m", "min", "mins", "minute", "minutes":
+		return now.Add(time.Duration(n) * time.Minute), true
+	case "h", "hr", "hrs", "hour", "hours":
+		return now.Add(time.Duration(n) * time.Hour), true
+	case "d", "day", "days":
+		return now.AddDate(0, 0, n), true
+	case "w", "wk", "wks", "week", "weeks":
+		return now.AddDate(0, 0, 7*n), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// parseDayWithClock parses a clock time, optionally preceded by any day
+// expression parseTimeExpr understands: "3pm", "noon", "tomorrow 9:30am",
+// "next tuesday at 15:00". A bare hour needs am/pm so "monday 3" stays an
+// error rather than a guess.
+func parseDayWithClock(expr string, now time.Time, loc *time.Location) (time.Time, bool) {
+	var dayExpr string
+	var hour, minute int
+	switch {
+	case expr == "noon" || strings.HasSuffix(expr, " noon"):
+		dayExpr, hour = strings.TrimSuffix(expr, "noon"), 12
+	case expr == "midnight" || strings.HasSuffix(expr, " midnight"):
+		dayExpr = strings.TrimSuffix(expr, "midnight")
+	default:
+		m := timeClockRe.FindStringSubmatch(expr)
+		if m == nil || (m[3] == "" && m[4] == "") {
+			return time.Time{}, false
+		}
+		dayExpr = m[1]
+		hour, _ = strconv.Atoi(m[2])
+		minute, _ = strconv.Atoi(m[3])
+		if minute > 59 {
+			return time.Time{}, false
+		}
+		switch m[4] {
+		case "am", "pm":
+			if hour < 1 || hour > 12 {
+				return time.Time{}, false
+			}
+			hour %= 12
+			if m[4] == "pm" {
+				hour += 12
+			}
+		default:
+			if hour > 23 {
+				return time.Time{}, false
+			}
+		}
+	}
+
+	dayExpr = strings.TrimSpace(dayExpr)
+	if dayExpr == "at" {
+		dayExpr = ""
+	}
+	dayExpr = strings.TrimSpace(strings.TrimSuffix(dayExpr, " at"))
+
+	day := now
+	if dayExpr != "" {
+		var err error
+		if day, err = parseTimeExpr(dayExpr, now, loc); err != nil {
+			return time.Time{}, false
+		}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location()), true
 }
 
 // parseWeekday parses weekday expressions like "monday", "next tuesday"
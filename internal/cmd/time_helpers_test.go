@@ -94,6 +94,53 @@ func TestParseTimeExprMore(t *testing.T) {
 	}
 }
 
+func TestParseTimeExprNatural(t *testing.T) {
+	loc := time.FixedZone("Offset", 2*3600)
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, loc) // Friday
+
+	cases := map[string]time.Time{
+		"+1d":                time.Date(2025, 1, 11, 12, 0, 0, 0, loc),
+		"-30m":               time.Date(2025, 1, 10, 11, 30, 0, 0, loc),
+		"+2w":                time.Date(2025, 1, 24, 12, 0, 0, 0, loc),
+		"in 2 hours":         time.Date(2025, 1, 10, 14, 0, 0, 0, loc),
+		"in an hour":         time.Date(2025, 1, 10, 13, 0, 0, 0, loc),
+		"3 days ago":         time.Date(2025, 1, 7, 12, 0, 0, 0, loc),
+		"3pm":                time.Date(2025, 1, 10, 15, 0, 0, 0, loc),
+		"noon":               time.Date(2025, 1, 10, 12, 0, 0, 0, loc),
+		"tomorrow 9:30am":    time.Date(2025, 1, 11, 9, 30, 0, 0, loc),
+		"Next Tuesday 3PM":   time.Date(2025, 1, 14, 15, 0, 0, 0, loc),
+		"monday at 12am":     time.Date(2025, 1, 13, 0, 0, 0, 0, loc),
+		"2025-02-01 6pm":     time.Date(2025, 2, 1, 18, 0, 0, 0, loc),
+		"tomorrow at 12:30":  time.Date(2025, 1, 11, 12, 30, 0, 0, loc),
+		"yesterday midnight": time.Date(2025, 1, 9, 0, 0, 0, 0, loc),
+		"in 90 min":          time.Date(2025, 1, 10, 13, 30, 0, 0, loc),
+		"next tuesday 10:00": time.Date(2025, 1, 14, 10, 0, 0, 0, loc),
+		"a week ago":         time.Date(2025, 1, 3, 12, 0, 0, 0, loc),
+		"tomorrow noon":      time.Date(2025, 1, 11, 12, 0, 0, 0, loc),
+		"+ 45 minutes":       time.Date(2025, 1, 10, 12, 45, 0, 0, loc),
+		"-1w":                time.Date(2025, 1, 3, 12, 0, 0, 0, loc),
+		"next friday 1pm":    time.Date(2025, 1, 17, 13, 0, 0, 0, loc),
+		"at 7pm":             time.Date(2025, 1, 10, 19, 0, 0, 0, loc),
+		"2025-03-01 at 9am":  time.Date(2025, 3, 1, 9, 0, 0, 0, loc),
+		"tomorrow 3 pm":      time.Date(2025, 1, 11, 15, 0, 0, 0, loc),
+	}
+	for in, want := range cases {
+		got, err := parseTimeExpr(in, now, loc)
+		if err != nil {
+			t.Fatalf("parseTimeExpr(%q): %v", in, err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("parseTimeExpr(%q) = %v, want %v", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"monday 3", "13pm", "tomorrow 10:75", "in 2 fortnights", "+1y", "soonish 3pm"} {
+		if _, err := parseTimeExpr(in, now, loc); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
 func TestParseWeekday(t *testing.T) {
 	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
 	parsed, ok := parseWeekday("monday", now)
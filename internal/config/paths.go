@@ -241,6 +241,72 @@ func EnsureGmailWatchDir() (string, error) {
 	return dir, nil
 }
 
+func DriveChangesDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "drive-changes"), nil
+}
+
+func EnsureDriveChangesDir() (string, error) {
+	dir, err := DriveChangesDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure drive changes dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func CalendarSyncDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "calendar-sync"), nil
+}
+
+func EnsureCalendarSyncDir() (string, error) {
+	dir, err := CalendarSyncDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure calendar sync dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func CalendarBulkDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "calendar-bulk"), nil
+}
+
+func EnsureCalendarBulkDir() (string, error) {
+	dir, err := CalendarBulkDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure calendar bulk dir: %w", err)
+	}
+
+	return dir, nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 // This is needed because ~ is a shell feature and is not expanded when paths
 // are quoted (e.g., --out "~/Downloads/file.pdf").
@@ -53,6 +53,33 @@ func TestDerivedPaths(t *testing.T) {
 	if !strings.HasPrefix(downloadsDir, base) {
 		t.Fatalf("expected downloads dir under %q, got %q", base, downloadsDir)
 	}
+
+	changesDir, err := DriveChangesDir()
+	if err != nil {
+		t.Fatalf("DriveChangesDir: %v", err)
+	}
+
+	if !strings.HasPrefix(changesDir, base) {
+		t.Fatalf("expected drive changes dir under %q, got %q", base, changesDir)
+	}
+
+	syncDir, err := CalendarSyncDir()
+	if err != nil {
+		t.Fatalf("CalendarSyncDir: %v", err)
+	}
+
+	if !strings.HasPrefix(syncDir, base) {
+		t.Fatalf("expected calendar sync dir under %q, got %q", base, syncDir)
+	}
+
+	bulkDir, err := CalendarBulkDir()
+	if err != nil {
+		t.Fatalf("CalendarBulkDir: %v", err)
+	}
+
+	if !strings.HasPrefix(bulkDir, base) {
+		t.Fatalf("expected calendar bulk dir under %q, got %q", base, bulkDir)
+	}
 }
 
 func TestKeepServiceAccountLegacyPathMore(t *testing.T) {
@@ -0,0 +1,23 @@
+package googleapi
+
+import (
+	"context"
+	"fmt"
+
+	admin "google.golang.org/api/admin/directory/v1"
+
+	"github.com/steipete/gogcli/internal/googleauth"
+)
+
+// NewAdminDirectoryResources creates an Admin SDK Directory service for
+// reading calendar resources (meeting rooms). Listing resources needs a
+// Workspace account with the "Calendar resources: read" admin privilege.
+func NewAdminDirectoryResources(ctx context.Context, email string) (*admin.Service, error) {
+	if opts, err := optionsForAccount(ctx, googleauth.ServiceRooms, email); err != nil {
+		return nil, fmt.Errorf("admin directory options: %w", err)
+	} else if svc, err := admin.NewService(ctx, opts...); err != nil {
+		return nil, fmt.Errorf("create admin directory service: %w", err)
+	} else {
+		return svc, nil
+	}
+}
@@ -0,0 +1,23 @@
+package googleapi
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/drivelabels/v2"
+
+	"github.com/steipete/gogcli/internal/googleauth"
+)
+
+// NewDriveLabels creates a Drive Labels API service. Label definitions need
+// the opt-in drivelabels service; applying labels to files goes through the
+// Drive API instead.
+func NewDriveLabels(ctx context.Context, email string) (*drivelabels.Service, error) {
+	if opts, err := optionsForAccount(ctx, googleauth.ServiceDriveLabels, email); err != nil {
+		return nil, fmt.Errorf("drive labels options: %w", err)
+	} else if svc, err := drivelabels.NewService(ctx, opts...); err != nil {
+		return nil, fmt.Errorf("create drive labels service: %w", err)
+	} else {
+		return svc, nil
+	}
+}
@@ -10,18 +10,20 @@ import (
 type Service string
 
 const (
-	ServiceGmail     Service = "gmail"
-	ServiceCalendar  Service = "calendar"
-	ServiceChat      Service = "chat"
-	ServiceClassroom Service = "classroom"
-	ServiceDrive     Service = "drive"
-	ServiceDocs      Service = "docs"
-	ServiceContacts  Service = "contacts"
-	ServiceTasks     Service = "tasks"
-	ServicePeople    Service = "people"
-	ServiceSheets    Service = "sheets"
-	ServiceGroups    Service = "groups"
-	ServiceKeep      Service = "keep"
+	ServiceGmail       Service = "gmail"
+	ServiceCalendar    Service = "calendar"
+	ServiceChat        Service = "chat"
+	ServiceClassroom   Service = "classroom"
+	ServiceDrive       Service = "drive"
+	ServiceDocs        Service = "docs"
+	ServiceContacts    Service = "contacts"
+	ServiceTasks       Service = "tasks"
+	ServicePeople      Service = "people"
+	ServiceSheets      Service = "sheets"
+	ServiceGroups      Service = "groups"
+	ServiceDriveLabels Service = "drivelabels"
+	ServiceRooms       Service = "rooms"
+	ServiceKeep        Service = "keep"
 )
 
 const (
@@ -67,6 +69,8 @@ var serviceOrder = []Service{
 	ServiceSheets,
 	ServicePeople,
 	ServiceGroups,
+	ServiceDriveLabels,
+	ServiceRooms,
 	ServiceKeep,
 }
 
@@ -164,6 +168,18 @@ var serviceInfoByService = map[Service]serviceInfo{
 		apis:   []string{"Cloud Identity API"},
 		note:   "Workspace only",
 	},
+	ServiceDriveLabels: {
+		scopes: []string{"https://www.googleapis.com/auth/drive.labels.readonly"},
+		user:   false,
+		apis:   []string{"Drive Labels API"},
+		note:   "Label definitions for drive labels list/get",
+	},
+	ServiceRooms: {
+		scopes: []string{"https://www.googleapis.com/auth/admin.directory.resource.calendar.readonly"},
+		user:   false,
+		apis:   []string{"Admin SDK API"},
+		note:   "Workspace only; meeting rooms and resources",
+	},
 	ServiceKeep: {
 		scopes: []string{"https://www.googleapis.com/auth/keep.readonly"},
 		user:   false,
@@ -459,7 +475,7 @@ func scopesForServiceWithOptions(service Service, opts ScopeOptions) ([]string,
 		}
 
 		return []string{driveScopeValue(), sheetsScope}, nil
-	case ServiceGroups:
+	case ServiceGroups, ServiceDriveLabels, ServiceRooms:
 		return Scopes(service)
 	case ServiceKeep:
 		return Scopes(service)
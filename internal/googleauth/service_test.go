@@ -19,6 +19,8 @@ func TestParseService(t *testing.T) {
 		{"people", ServicePeople},
 		{"sheets", ServiceSheets},
 		{"groups", ServiceGroups},
+		{"drivelabels", ServiceDriveLabels},
+		{"rooms", ServiceRooms},
 		{"keep", ServiceKeep},
 	}
 	for _, tt := range tests {
@@ -62,7 +64,7 @@ func TestExtractCodeAndState_Errors(t *testing.T) {
 
 func TestAllServices(t *testing.T) {
 	svcs := AllServices()
-	if len(svcs) != 12 {
+	if len(svcs) != 14 {
 		t.Fatalf("unexpected: %v", svcs)
 	}
 	seen := make(map[Service]bool)
@@ -71,7 +73,7 @@ func TestAllServices(t *testing.T) {
 		seen[s] = true
 	}
 
-	for _, want := range []Service{ServiceGmail, ServiceCalendar, ServiceChat, ServiceClassroom, ServiceDrive, ServiceDocs, ServiceContacts, ServiceTasks, ServicePeople, ServiceSheets, ServiceGroups, ServiceKeep} {
+	for _, want := range []Service{ServiceGmail, ServiceCalendar, ServiceChat, ServiceClassroom, ServiceDrive, ServiceDocs, ServiceContacts, ServiceTasks, ServicePeople, ServiceSheets, ServiceGroups, ServiceDriveLabels, ServiceRooms, ServiceKeep} {
 		if !seen[want] {
 			t.Fatalf("missing %q", want)
 		}